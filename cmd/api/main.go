@@ -3,11 +3,14 @@ package main
 import (
 	"log/slog"
 	"os"
+	"path/filepath"
 	"topdoctors/internal/application"
 	"topdoctors/internal/infrastructure/config"
 	httpinfra "topdoctors/internal/infrastructure/http"
 	"topdoctors/internal/infrastructure/persistence"
+	"topdoctors/internal/infrastructure/schedule"
 	"topdoctors/internal/infrastructure/shared"
+	"topdoctors/internal/infrastructure/storage"
 	"topdoctors/pkg/logger"
 )
 
@@ -48,7 +51,9 @@ func main() {
 	// Initialize Repository (Infrastructure)
 	repo, err := persistence.NewGormRepository(
 		persistence.Config{
-			DSN: cfg.Database.DSN,
+			DSN:           cfg.Database.DSN,
+			MasterKey:     cfg.Encryption.MasterKey,
+			MasterKeyFile: cfg.Encryption.MasterKeyFile,
 		},
 	)
 	if err != nil {
@@ -57,18 +62,66 @@ func main() {
 	}
 	slog.Info("Connected to database successfully")
 
+	// Initialize Blob Storage (Infrastructure)
+	blobs, err := storage.NewFileStorage(cfg.Storage.Root)
+	if err != nil {
+		slog.Error("Failed to open attachment storage", "error", err, "root", cfg.Storage.Root)
+		os.Exit(1)
+	}
+
+	// Initialize Bulk Export Storage (Infrastructure)
+	exportFiles, err := storage.NewNDJSONStorage(filepath.Join(cfg.Storage.Root, "bulk-export"))
+	if err != nil {
+		slog.Error("Failed to open bulk export storage", "error", err, "root", cfg.Storage.Root)
+		os.Exit(1)
+	}
+
+	// Load Vaccination Schedule (Infrastructure)
+	vaccinationSchedule, err := schedule.LoadFileSchedule(cfg.Vaccination.Schedule)
+	if err != nil {
+		slog.Error("Failed to load vaccination schedule", "error", err, "path", cfg.Vaccination.Schedule)
+		os.Exit(1)
+	}
+
 	// Initialize Support (Infrastructure)
 	support := shared.NewSupport()
 
 	// Initialize Application Services (Application)
 	app := application.NewApplication(
-		repo,
-		repo,
+		application.Repositories{
+			User:        repo,
+			Patient:     repo,
+			CareTeam:    repo,
+			Consent:     repo,
+			Erasure:     repo,
+			Merge:       repo,
+			Contact:     repo,
+			Appointment: repo,
+			Calendar:    repo,
+			Observation: repo,
+			Lab:         repo,
+			Attachment:  repo,
+			Blobs:       blobs,
+			Vaccination: repo,
+			Schedule:    vaccinationSchedule,
+			Referral:    repo,
+			Encounter:   repo,
+			BulkExport:  repo,
+			ExportFiles: exportFiles,
+		},
 		support,
 		cfg,
 	)
 	slog.Info("Application services initialized")
 
+	// Bulk exports run inside this process, the ones a previous run left in
+	// progress will never complete
+	if interrupted, err := app.BulkExport().FailInterruptedExports(); err != nil {
+		slog.Error("Failed to close interrupted bulk exports", "error", err)
+	} else if interrupted > 0 {
+		slog.Warn("Interrupted bulk exports marked as failed", "jobs", interrupted)
+	}
+
 	// Initialize Handler (Adapter)
 	h := httpinfra.NewHttpHandler(app, cfg)
 
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+	"topdoctors/internal/application"
+	"topdoctors/internal/infrastructure/config"
+	"topdoctors/internal/infrastructure/persistence"
+	"topdoctors/internal/infrastructure/schedule"
+	"topdoctors/internal/infrastructure/shared"
+	"topdoctors/internal/infrastructure/storage"
+	"topdoctors/pkg/logger"
+)
+
+// manage runs one-off administrative tasks against the configured database.
+//
+// Usage:
+//
+//	go run ./cmd/manage -config=configs/config.dev.yml <command> [args]
+//
+// Commands:
+//
+//	set-role <username> <role>   Assign a role (practitioner, admin, integration) to a user
+//	set-specialty <username> [specialty] Assign a specialty (e.g. cardiology) to a practitioner, or clear it
+//	purge-erased                 Delete clinical records of erased patients past their retention period
+//	purge-exports                Delete bulk exports past their retention period, with their files
+//	rotate-keys [master-key-file] Re-encrypt patient data with a new data key, optionally re-wrapping keys with a new master key
+//	normalize-phones             Rewrite stored patient phones in E.164
+func main() {
+	// Load Config
+	cfg, errLoadCfg := config.LoadConfig()
+	if errLoadCfg != nil {
+		slog.Error("Failed to load configuration", "error", errLoadCfg)
+		os.Exit(1)
+	}
+
+	// Configure Logger
+	logger.SetConfig(logger.Config{
+		Level: &cfg.Logs.Level,
+	})
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	// Initialize Repository (Infrastructure)
+	repo, err := persistence.NewGormRepository(
+		persistence.Config{
+			DSN:           cfg.Database.DSN,
+			MasterKey:     cfg.Encryption.MasterKey,
+			MasterKeyFile: cfg.Encryption.MasterKeyFile,
+		},
+	)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer repo.Close()
+
+	blobs, err := storage.NewFileStorage(cfg.Storage.Root)
+	if err != nil {
+		slog.Error("Failed to open attachment storage", "error", err, "root", cfg.Storage.Root)
+		os.Exit(1)
+	}
+	exportFiles, err := storage.NewNDJSONStorage(filepath.Join(cfg.Storage.Root, "bulk-export"))
+	if err != nil {
+		slog.Error("Failed to open bulk export storage", "error", err, "root", cfg.Storage.Root)
+		os.Exit(1)
+	}
+	vaccinationSchedule, err := schedule.LoadFileSchedule(cfg.Vaccination.Schedule)
+	if err != nil {
+		slog.Error("Failed to load vaccination schedule", "error", err, "path", cfg.Vaccination.Schedule)
+		os.Exit(1)
+	}
+
+	app := application.NewApplication(
+		application.Repositories{
+			User:        repo,
+			Patient:     repo,
+			CareTeam:    repo,
+			Consent:     repo,
+			Erasure:     repo,
+			Merge:       repo,
+			Contact:     repo,
+			Appointment: repo,
+			Calendar:    repo,
+			Observation: repo,
+			Lab:         repo,
+			Attachment:  repo,
+			Blobs:       blobs,
+			Vaccination: repo,
+			Schedule:    vaccinationSchedule,
+			Referral:    repo,
+			Encounter:   repo,
+			BulkExport:  repo,
+			ExportFiles: exportFiles,
+		},
+		shared.NewSupport(),
+		cfg,
+	)
+
+	switch args[0] {
+	case "set-role":
+		if len(args) != 3 {
+			usage()
+			os.Exit(2)
+		}
+		err = app.Auth().SetRole(args[1], args[2])
+	case "set-specialty":
+		if len(args) != 2 && len(args) != 3 {
+			usage()
+			os.Exit(2)
+		}
+		var specialty string
+		if len(args) == 3 {
+			specialty = args[2]
+		}
+		err = app.Auth().SetSpecialty(args[1], specialty)
+	case "purge-erased":
+		var purged int
+		purged, err = app.Erasure().PurgeExpiredRecords(time.Now())
+		slog.Info("Purge of erased patients finished", "purged", purged)
+	case "purge-exports":
+		var purged int
+		purged, err = app.BulkExport().PurgeExpiredExports(time.Now())
+		slog.Info("Purge of expired bulk exports finished", "purged", purged)
+	case "rotate-keys":
+		if len(args) > 2 {
+			usage()
+			os.Exit(2)
+		}
+		var newMaster []byte
+		if len(args) == 2 {
+			newMaster, err = persistence.LoadMasterKey("", args[1])
+			if err != nil {
+				break
+			}
+		}
+		var rotated int
+		rotated, err = repo.RotateKeys(newMaster)
+		if err == nil && newMaster != nil {
+			// A running API cannot unwrap the keys with the old master key
+			slog.Warn("Master key replaced, update the encryption configuration before restarting the API", "master_key_file", args[1])
+		}
+		slog.Info("Key rotation finished", "patients", rotated)
+	case "normalize-phones":
+		var normalized, invalid int
+		normalized, invalid, err = repo.NormalizePhones()
+		slog.Info("Phone normalization finished", "normalized", normalized, "invalid", invalid)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		slog.Error("Command failed", "command", args[0], "error", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: manage [-config=path] <command> [args]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  set-role <username> <role>   assign a role (practitioner, admin, integration) to a user")
+	fmt.Fprintln(os.Stderr, "  set-specialty <username> [specialty] assign a specialty (e.g. cardiology) to a practitioner, or clear it")
+	fmt.Fprintln(os.Stderr, "  purge-erased                 delete clinical records of erased patients past their retention period")
+	fmt.Fprintln(os.Stderr, "  purge-exports                delete bulk exports past their retention period, with their files")
+	fmt.Fprintln(os.Stderr, "  rotate-keys [master-key-file] re-encrypt patient data with a new data key, optionally re-wrapping keys with a new master key")
+	fmt.Fprintln(os.Stderr, "  normalize-phones             rewrite stored patient phones in E.164")
+}
@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/diagnostics": {
-            "get": {
+        "/appointments": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of diagnostics filtering by patient name and/or date range",
+                "description": "Book a patient's appointment with a practitioner, the caller when practitioner_id is omitted.\nFails with 409 when the practitioner already has an appointment overlapping the time slot.",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,47 +39,99 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnostics"
+                    "Appointments"
                 ],
-                "summary": "Search diagnostics",
+                "summary": "Book appointment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by patient name",
-                        "name": "patient_name",
-                        "in": "query"
+                        "description": "Appointment Info",
+                        "name": "appointment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AppointmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.AppointmentResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by start date (YYYY-MM-DD)",
-                        "name": "date_start",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/appointments/{id}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download an appointment as an RFC 5545 .ics file to add it to a calendar app. Like the feed, the event\ncarries no patient or diagnosis details.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Appointment calendar file",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by end date (YYYY-MM-DD)",
-                        "name": "date_end",
-                        "in": "query"
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "iCalendar file",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/http.DiagnosisResponse"
-                            }
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "string"
                         }
@@ -91,50 +143,53 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/appointments/{id}/cancel": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new diagnosis to a patient",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Cancel an appointment, freeing its time slot in the practitioner's agenda",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Diagnostics"
+                    "Appointments"
                 ],
-                "summary": "Create diagnosis",
+                "summary": "Cancel appointment",
                 "parameters": [
                     {
-                        "description": "Diagnosis Info",
-                        "name": "diagnosis",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.CreateDiagnosisRequest"
-                        }
+                        "type": "string",
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.AppointmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "string"
                         }
@@ -148,9 +203,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
+        "/appointments/{id}/reschedule": {
             "post": {
-                "description": "Authenticate a user and return a JWT token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an appointment to a new time slot. Rescheduling a pending follow-up books it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -158,17 +218,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Appointments"
                 ],
-                "summary": "User login",
+                "summary": "Reschedule appointment",
                 "parameters": [
                     {
-                        "description": "Login Credentials",
-                        "name": "login",
+                        "type": "string",
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New time slot",
+                        "name": "slot",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.LoginRequest"
+                            "$ref": "#/definitions/http.RescheduleAppointmentRequest"
                         }
                     }
                 ],
@@ -176,11 +243,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.LoginResponse"
+                            "$ref": "#/definitions/http.AppointmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
@@ -188,14 +279,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/patients": {
-            "post": {
+        "/diagnostics": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Record a new patient in the system",
+                "description": "Retrieve a list of diagnostics filtering by patient name, date range, patient age and sex and/or full-text query.\nName filters ignore case and accents; with fuzzy=true close spellings match too and results are\nordered by name similarity (match.name_score).\nName similarity is only known once every candidate is decrypted, so searches by name only page the best 1000\nresults.\nThe full-text query is accent-insensitive, matches every term against diagnosis and prescription text,\norders results by relevance and highlights the matching terms in the returned snippets.\nThe age range applies to the patient's age on the diagnosis date; patients without a birth date are excluded.\nOnly patients in the caller's care team or under an active break-glass grant are returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -203,25 +294,117 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Diagnostics"
                 ],
-                "summary": "Create patient",
+                "summary": "Search diagnostics",
                 "parameters": [
                     {
-                        "description": "Patient Info",
-                        "name": "patient",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.CreatePatientRequest"
-                        }
+                        "type": "string",
+                        "description": "Filter by patient name",
+                        "name": "patient_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by patient given name",
+                        "name": "given_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by either patient surname",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match close spellings of the patient name",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start date (YYYY-MM-DD)",
+                        "name": "date_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by end date (YYYY-MM-DD)",
+                        "name": "date_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search over diagnosis and prescription text",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum patient age at the diagnosis date, inclusive",
+                        "name": "age_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum patient age at the diagnosis date, inclusive",
+                        "name": "age_max",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "male",
+                            "female",
+                            "other",
+                            "unknown"
+                        ],
+                        "type": "string",
+                        "description": "Patient sex",
+                        "name": "sex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "28",
+                        "description": "INE province code of the patient's address",
+                        "name": "province",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "28013",
+                        "description": "Postal code of the patient's address",
+                        "name": "postal_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the next link of the previous one",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.PatientResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.DiagnosisResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Next page, as \u003curl\u003e; rel=\\\"next\\"
+                            }
                         }
                     },
                     "400": {
@@ -230,6 +413,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -237,11 +426,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/register": {
+            },
             "post": {
-                "description": "Register a new user in the system",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new diagnosis to a patient. With follow_up_in_days (1 to 365) a pending follow-up with the caller\nis created, due that many days after the diagnosis date, to be booked later in the caller's agenda.",
                 "consumes": [
                     "application/json"
                 ],
@@ -249,17 +441,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Diagnostics"
                 ],
-                "summary": "Register user",
+                "summary": "Create diagnosis",
                 "parameters": [
                     {
-                        "description": "Registration Info",
-                        "name": "register",
+                        "description": "Diagnosis Info",
+                        "name": "diagnosis",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.RegisterRequest"
+                            "$ref": "#/definitions/http.CreateDiagnosisRequest"
                         }
                     }
                 ],
@@ -267,7 +459,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.CreateDiagnosisResponse"
                         }
                     },
                     "400": {
@@ -276,83 +468,5973 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/diagnostics/{id}/attachments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a file, such as a scanned report or an image, to a diagnosis. The file is sent as the \"file\"\npart of a multipart form. Its type is detected from the content, only PDF, JPEG, PNG, WebP and DICOM\nfiles up to 20 MiB are accepted. When the X-Content-SHA256 header is given the upload is rejected\nunless the content matches it.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Upload attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Diagnosis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex encoded SHA-256 of the file",
+                        "name": "X-Content-SHA256",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/diagnostics/{id}/attachments/{attachmentId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a file attached to a diagnosis with the type detected on upload. Its SHA-256 is sent as the\nETag, and range requests are supported.",
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp",
+                    "application/dicom"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Download attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Diagnosis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.CreateDiagnosisRequest": {
+        },
+        "/encounters": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open an encounter for a visit of a patient to the caller, optionally with a first version of its SOAP\nnote. Diagnoses made during the visit reference the encounter through their encounter_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "Open encounter",
+                "parameters": [
+                    {
+                        "description": "Encounter",
+                        "name": "encounter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.OpenEncounterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.EncounterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/encounters/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an encounter with its SOAP note and the diagnoses, with their prescriptions, made during it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "Get encounter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Encounter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.EncounterResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/encounters/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close an encounter, optionally with the final version of its SOAP note. An encounter cannot be closed\nwithout a note, and no diagnosis can be added to it once closed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "Close encounter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Encounter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Final note",
+                        "name": "closing",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.CloseEncounterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.EncounterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/encounters/{id}/note": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the SOAP note of an encounter while it is open",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "Update encounter note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Encounter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "SOAP note",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.SOAPNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.EncounterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/$export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start an export of every patient and condition the caller may access, following the FHIR Bulk Data\nAccess specification. Restricted to integration clients, and limited to what each patient consented to\nshare with third parties. Answers 202 with the status URL in Content-Location; poll it until the export completes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR bulk export kick-off",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Must be respond-async",
+                        "name": "Prefer",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated resource types, Patient and Condition by default",
+                        "name": "_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only resources updated after this instant, e.g. 2026-02-12T02:00:00Z",
+                        "name": "_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/fhir+ndjson, the only format",
+                        "name": "_outputFormat",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted, status URL in Content-Location",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/Condition": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search the diagnoses of the patients the caller can access, by patient and recorded date. recorded-date\ntakes the eq, ge, gt, le and lt prefixes at day precision and can be repeated to give a range.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR search Condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient reference, e.g. Patient/01HMGNBPJNX0G2BZXJ7XW1RHPR",
+                        "name": "subject",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patient ID or reference, the same patient as subject when both are given",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Recorded date with prefix, e.g. ge2026-01-01",
+                        "name": "recorded-date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 200",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the next link of the previous one",
+                        "name": "_cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Bundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a diagnosis from a FHIR Condition: code.text is the diagnosis, subject the patient and\nrecordedDate the date (now when omitted). An encounter reference adds it to that open encounter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR create Condition",
+                "parameters": [
+                    {
+                        "description": "Condition resource",
+                        "name": "condition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Condition"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Condition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/Condition/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Read a diagnosis as a FHIR Condition. Integration clients need the patient's consent to share diagnoses.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR read Condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Diagnosis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Condition"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/MedicationRequest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search the prescriptions of the patients the caller can access, by patient and date of issue. authoredon\ntakes the eq, ge, gt, le and lt prefixes at day precision, so a dispensing system can poll the new ones\nwith authoredon=ge\u003clast poll day\u003e.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR search MedicationRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient reference, e.g. Patient/01HMGNBPJNX0G2BZXJ7XW1RHPR",
+                        "name": "subject",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patient ID or reference, the same patient as subject when both are given",
+                        "name": "patient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Date of issue with prefix, e.g. ge2026-01-01",
+                        "name": "authoredon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 200",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the next link of the previous one",
+                        "name": "_cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Bundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/MedicationRequest/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Read the prescription issued along with a diagnosis as a FHIR MedicationRequest, which shares the\ndiagnosis ID and references its Condition as reason. Integration clients need the patient's consent to\nshare prescriptions, otherwise the prescription is not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR read MedicationRequest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Diagnosis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.MedicationRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/Patient": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search the patients the caller can access. name matches any part of the name ignoring case and accents;\nidentifier matches the DNI, with or without the system (urn:oid:1.3.6.1.4.1.19126.3|12345678Z).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR search Patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Any part of the name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DNI, optionally preceded by its system and |",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 200",
+                        "name": "_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the next link of the previous one",
+                        "name": "_cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Bundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a new patient from a FHIR Patient. The DNI is read from the identifier with system\nurn:oid:1.3.6.1.4.1.19126.3 and the surnames from the family name. The creator joins the care team.\nIntegration clients cannot create patients.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR create Patient",
+                "parameters": [
+                    {
+                        "description": "Patient resource",
+                        "name": "patient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Patient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/Patient/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Read a patient as a FHIR Patient, with the DNI as identifier. Records merged as duplicates are inactive\nand link to the surviving one. Same access rules as GET /patients/{id}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR read Patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.Patient"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/bulk-files/{id}/{file}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the NDJSON file of a resource type of a completed bulk export, as listed in its manifest",
+                "produces": [
+                    "application/fhir+ndjson"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR bulk export file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name, e.g. Patient.ndjson",
+                        "name": "file",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One resource per line",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/bulk-status/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Poll a bulk export. Answers 202 while it runs, 200 with the manifest of the NDJSON files once completed\nand 500 with an OperationOutcome if it failed. Only the client that kicked it off can see it. The files are\nkept until the time in the Expires header of the manifest, after which the export is gone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR bulk export status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.ExportManifest"
+                        },
+                        "headers": {
+                            "Expires": {
+                                "type": "string",
+                                "description": "When the files are removed"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "In progress, see X-Progress and Retry-After",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.OperationOutcome"
+                        }
+                    }
+                }
+            }
+        },
+        "/fhir/r4/metadata": {
+            "get": {
+                "description": "Describe the FHIR R4 resources, interactions and search parameters served under /fhir/r4",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FHIR"
+                ],
+                "summary": "FHIR capability statement",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fhir.CapabilityStatement"
+                        }
+                    }
+                }
+            }
+        },
+        "/lab-results/abnormal": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the lab results out of range collected in a date range, of every patient in the caller's care\nteams or under an active break-glass grant. The range cannot exceed a year. Not available to\nintegration clients.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lab Results"
+                ],
+                "summary": "Abnormal lab results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.LabResultResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticate a user and return a JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login Credentials",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the patients in the caller's care teams or under an active break-glass grant, optionally filtered by name.\nName filters ignore case and accents; with fuzzy=true close spellings match too. When filtering by name,\nresults are ordered by name similarity (score); sort=surname orders them alphabetically by surnames instead.\nResults are paged, the Link header points to the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "List patients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by any part of the name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by given name",
+                        "name": "given_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by either surname",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also match close spellings",
+                        "name": "fuzzy",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "surname"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from the next link of the previous one",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.PatientSearchResponse"
+                            }
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Next page, as \u003curl\u003e; rel=\\\"next\\"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a new patient in the system. The creator becomes the first member of the patient's care team.\nIntegration clients cannot create patients.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Create patient",
+                "parameters": [
+                    {
+                        "description": "Patient Info",
+                        "name": "patient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreatePatientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.PatientResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a patient by ID. Restricted to the patient's care team or an active break-glass grant.\nRecords merged as duplicates redirect to the surviving patient.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Get patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PatientResponse"
+                        }
+                    },
+                    "308": {
+                        "description": "Permanent Redirect to the surviving patient",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/appointments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the booked, pending and cancelled appointments of a patient",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "List patient appointments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.AppointmentResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/break-glass": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant the caller temporary access to a patient outside their care team.\nA justification is mandatory and the access is flagged for review.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Care Team"
+                ],
+                "summary": "Break-glass access",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Justification",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.BreakGlassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.BreakGlassResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/care-team": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the users in a patient's care team",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Care Team"
+                ],
+                "summary": "Get care team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.CareTeamMemberResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a user to the care team of a patient the caller has access to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Care Team"
+                ],
+                "summary": "Add care team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member Info",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AddCareTeamMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/care-team/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from the care team of a patient. The last member cannot be removed.",
+                "tags": [
+                    "Care Team"
+                ],
+                "summary": "Remove care team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/consents": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every consent record of a patient, including revoked ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consents"
+                ],
+                "summary": "List consents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.ConsentResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a patient's consent for a purpose (third-party sharing or research) and scope, with its evidence.\nPatients under 16 consent through a contact who is their legal representative (representative_id).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consents"
+                ],
+                "summary": "Grant consent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Consent Info",
+                        "name": "consent",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.GrantConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.ConsentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/consents/{consentId}/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a previously granted consent. The record is kept for traceability.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Consents"
+                ],
+                "summary": "Revoke consent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Consent ID",
+                        "name": "consentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ConsentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/contacts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the emergency contacts and legal representatives of a patient",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "List contacts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.ContactResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an emergency contact to a patient. Legal representatives (parents, guardians) can consent on behalf\nof patients under 16.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Add contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact Info",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.ContactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/contacts/{contactId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the details of a patient's contact. Unmarking a legal representative revokes the active consents\nthey gave on the patient's behalf.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Update contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact ID",
+                        "name": "contactId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact Info",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ContactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a contact from a patient. Active consents the contact gave on the patient's behalf are revoked.",
+                "tags": [
+                    "Contacts"
+                ],
+                "summary": "Delete contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact ID",
+                        "name": "contactId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the patients the caller can access that are likely the same person, scored between 0 and 1\nby name similarity (accent-insensitive, tolerating typos and a missing surname), email, phone and birth date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Find duplicate patients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.DuplicateCandidateResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/encounters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the encounters of a patient with their SOAP notes, the oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Encounters"
+                ],
+                "summary": "List patient encounters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.EncounterResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/erasure": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Anonymize the identifying data of a patient (name, DNI, email, phone, address).\nClinical records are kept until the legal retention period ends. Restricted to administrators.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Erase patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Erasure reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ErasePatientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErasureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GDPR right-of-access export: patient record, diagnoses, prescriptions, consents, contacts, appointments, observations, lab results, attachments, vaccinations, referrals, encounters and access log.\nUse format=zip to get the JSON bundle together with a human-readable summary and a copy of the attached files. Restricted to administrators.",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Export patient data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "zip"
+                        ],
+                        "type": "string",
+                        "description": "Bundle format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PatientExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/lab-results": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the lab results of a patient in order of collection, optionally of one panel or analyte, only\nthe abnormal ones or in a date range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lab Results"
+                ],
+                "summary": "List lab results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Panel name",
+                        "name": "panel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Analyte name",
+                        "name": "analyte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only results out of range",
+                        "name": "abnormal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.LabResultResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Store a batch of lab results of a patient, usually one report, with the reference range the lab gave\nfor each analyte. Values out of range are flagged L (low) or H (high). The batch is rejected as a\nwhole when any result is invalid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lab Results"
+                ],
+                "summary": "Ingest lab results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lab results",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.LabResultBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.LabResultBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the diagnoses and care team of the duplicate patient to this one, in a single transaction.\nThe duplicate is kept for audit and GET /patients/{duplicate_id} redirects here. Requires access to both patients.\nFails with 409 when either patient is merged or erased, or the duplicate gains records, while the merge runs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Merge duplicate patient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Surviving patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Duplicate to merge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.MergePatientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PatientMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/observations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the observations of a patient in chronological order, optionally of one type and date range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observations"
+                ],
+                "summary": "List observations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "8867-4",
+                        "description": "LOINC code of the observation type",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.ObservationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a measurement of a patient, identified by its LOINC code: systolic (8480-6) and diastolic (8462-4)\nblood pressure in mm[Hg], heart rate (8867-4) in /min, body temperature (8310-5) in Cel or [degF], body\nweight (29463-7) in kg, g or [lb_av] and blood glucose (2339-0) in mg/dL or mmol/L. Values outside the\nplausible range of the type are rejected. Without reference_range the adult range of the type applies,\nand the value is interpreted against it (L, N or H).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observations"
+                ],
+                "summary": "Record observation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Observation",
+                        "name": "observation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.ObservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.ObservationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/observations/series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Time series of one observation type of a patient for charting, with every value converted to the\ncanonical unit of the type (e.g. Cel for temperatures recorded in [degF])",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observations"
+                ],
+                "summary": "Observation time series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "8867-4",
+                        "description": "LOINC code of the observation type",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, inclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ObservationSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/referrals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the referrals made for a patient in the order they were made",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "List patient referrals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.ReferralResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/vaccinations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the vaccine doses given to a patient in the order they were given",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vaccinations"
+                ],
+                "summary": "List vaccinations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.VaccinationResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a dose of a vaccine given to a patient with its lot. Doses count towards the vaccination\nschedule when recorded with the vaccine code the schedule uses. Each dose of a vaccine can only be\nrecorded once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vaccinations"
+                ],
+                "summary": "Record vaccination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vaccination",
+                        "name": "vaccination",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.VaccinationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.VaccinationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}/vaccinations/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the patient's vaccinations with the vaccination schedule from the birth date. Doses past their\ndue date are overdue, unless the patient is already too old for them, and doses due in the next days\nare upcoming. Requires the patient's birth date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vaccinations"
+                ],
+                "summary": "Overdue and upcoming vaccine doses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 90,
+                        "description": "Days ahead to look for upcoming doses (1-365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.VaccinationForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/practitioners/{id}/agenda": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List a practitioner's booked appointments and the pending follow-ups due in the day or week\n(Monday to Sunday) containing the date. Practitioners can only see their own agenda, admins any.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Practitioner agenda",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Practitioner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day to show (YYYY-MM-DD), today when omitted",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "description": "Agenda view, day when omitted",
+                        "name": "view",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.AppointmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/practitioners/{id}/calendar-feed": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue the signed URL to subscribe to a practitioner's agenda from a calendar app. The URL carries its\nown token, so keep it private; issuing a new one revokes the previous. Own agenda only, admins any.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Create calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Practitioner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.CalendarFeedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the calendar subscription URL of a practitioner",
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Revoke calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Practitioner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/practitioners/{id}/calendar.ics": {
+            "get": {
+                "description": "RFC 5545 feed of a practitioner's appointments from 30 days ago to 180 days ahead, for calendar apps.\nAuthenticated by the token of the URL issued by POST /practitioners/{id}/calendar-feed instead of a\nbearer token. Events only carry times and generic summaries, never patient or diagnosis details;\npending follow-ups show as all-day events on their due date.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Appointments"
+                ],
+                "summary": "Calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Practitioner ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/referrals": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refer a patient to a practitioner, or to any practitioner of a specialty, optionally about one of the\npatient's diagnoses. The referral stays pending until a recipient accepts or rejects it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Create referral",
+                "parameters": [
+                    {
+                        "description": "Referral",
+                        "name": "referral",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreateReferralRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReferralResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/referrals/inbox": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the pending referrals addressed to the caller or to their specialty, and the ones they\nanswered, the most urgent first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Referral inbox",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "rejected",
+                            "completed"
+                        ],
+                        "type": "string",
+                        "description": "Only referrals with this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/http.ReferralResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/referrals/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept a pending referral addressed to the caller or to their specialty. The caller joins the\npatient's care team.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Accept referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReferralResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/referrals/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close a referral the caller accepted once the patient was seen, with an optional note for the\nreferring practitioner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Complete referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Completion",
+                        "name": "completion",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/http.CompleteReferralRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReferralResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/referrals/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending referral addressed to the caller or to their specialty, telling the referring\npractitioner why",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Referrals"
+                ],
+                "summary": "Reject referral",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referral ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.RejectReferralRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.ReferralResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Register a new user in the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register user",
+                "parameters": [
+                    {
+                        "description": "Registration Info",
+                        "name": "register",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "fhir.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Madrid"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "ES"
+                },
+                "line": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "postalCode": {
+                    "type": "string",
+                    "example": "28013"
+                },
+                "state": {
+                    "description": "Province name",
+                    "type": "string",
+                    "example": "Madrid"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Calle Mayor 1, 3º B, 28013 Madrid"
+                }
+            }
+        },
+        "fhir.Bundle": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.BundleEntry"
+                    }
+                },
+                "link": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.BundleLink"
+                    }
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "Bundle"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "example": "searchset"
+                }
+            }
+        },
+        "fhir.BundleEntry": {
+            "type": "object",
+            "properties": {
+                "fullUrl": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "object"
+                },
+                "search": {
+                    "$ref": "#/definitions/fhir.BundleSearch"
+                }
+            }
+        },
+        "fhir.BundleLink": {
+            "type": "object",
+            "properties": {
+                "relation": {
+                    "type": "string",
+                    "example": "self"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "fhir.BundleSearch": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "example": "match"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "fhir.CapabilityImplementation": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "fhir.CapabilityInteraction": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "read"
+                }
+            }
+        },
+        "fhir.CapabilityOperation": {
+            "type": "object",
+            "properties": {
+                "definition": {
+                    "type": "string",
+                    "example": "http://hl7.org/fhir/uv/bulkdata/OperationDefinition/export"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "export"
+                }
+            }
+        },
+        "fhir.CapabilityResource": {
+            "type": "object",
+            "properties": {
+                "interaction": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.CapabilityInteraction"
+                    }
+                },
+                "searchParam": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.CapabilitySearchParam"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Patient"
+                }
+            }
+        },
+        "fhir.CapabilityRest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "example": "server"
+                },
+                "operation": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.CapabilityOperation"
+                    }
+                },
+                "resource": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.CapabilityResource"
+                    }
+                }
+            }
+        },
+        "fhir.CapabilitySearchParam": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "name"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "string"
+                }
+            }
+        },
+        "fhir.CapabilitySoftware": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "TopDoctors"
+                }
+            }
+        },
+        "fhir.CapabilityStatement": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-13"
+                },
+                "fhirVersion": {
+                    "type": "string",
+                    "example": "4.0.1"
+                },
+                "format": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "implementation": {
+                    "$ref": "#/definitions/fhir.CapabilityImplementation"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "instance"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "CapabilityStatement"
+                },
+                "rest": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.CapabilityRest"
+                    }
+                },
+                "software": {
+                    "$ref": "#/definitions/fhir.CapabilitySoftware"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "fhir.CodeableConcept": {
+            "type": "object",
+            "properties": {
+                "coding": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.Coding"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "fhir.Coding": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "display": {
+                    "type": "string"
+                },
+                "system": {
+                    "type": "string"
+                }
+            }
+        },
+        "fhir.Communication": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "$ref": "#/definitions/fhir.CodeableConcept"
+                },
+                "preferred": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "fhir.Condition": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.CodeableConcept"
+                    }
+                },
+                "code": {
+                    "$ref": "#/definitions/fhir.CodeableConcept"
+                },
+                "encounter": {
+                    "$ref": "#/definitions/fhir.Reference"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "recordedDate": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "Condition"
+                },
+                "subject": {
+                    "$ref": "#/definitions/fhir.Reference"
+                }
+            }
+        },
+        "fhir.ContactPoint": {
+            "type": "object",
+            "properties": {
+                "system": {
+                    "type": "string",
+                    "enum": [
+                        "phone",
+                        "email"
+                    ],
+                    "example": "phone"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "home"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "+34600123456"
+                }
+            }
+        },
+        "fhir.ExportManifest": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.ExportOutput"
+                    }
+                },
+                "output": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.ExportOutput"
+                    }
+                },
+                "request": {
+                    "type": "string",
+                    "example": "https://api.example.com/fhir/r4/$export?_since=2026-02-12T02:00:00Z"
+                },
+                "requiresAccessToken": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "transactionTime": {
+                    "type": "string",
+                    "example": "2026-02-13T02:00:00Z"
+                }
+            }
+        },
+        "fhir.ExportOutput": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1250
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Patient"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://api.example.com/fhir/r4/bulk-files/01HMGNBPJNX0G2BZXJ7XW1RHPR/Patient.ndjson"
+                }
+            }
+        },
+        "fhir.HumanName": {
+            "type": "object",
+            "properties": {
+                "family": {
+                    "type": "string",
+                    "example": "García López"
+                },
+                "given": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "text": {
+                    "type": "string",
+                    "example": "María García López"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "official"
+                }
+            }
+        },
+        "fhir.Identifier": {
+            "type": "object",
+            "properties": {
+                "system": {
+                    "type": "string",
+                    "example": "urn:oid:1.3.6.1.4.1.19126.3"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "official"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "12345678Z"
+                }
+            }
+        },
+        "fhir.Issue": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not-found"
+                },
+                "diagnostics": {
+                    "type": "string",
+                    "example": "patient not found"
+                },
+                "severity": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "fhir.MedicationRequest": {
+            "type": "object",
+            "properties": {
+                "authoredOn": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "encounter": {
+                    "$ref": "#/definitions/fhir.Reference"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "intent": {
+                    "type": "string",
+                    "example": "order"
+                },
+                "medicationCodeableConcept": {
+                    "$ref": "#/definitions/fhir.CodeableConcept"
+                },
+                "reasonReference": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.Reference"
+                    }
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "MedicationRequest"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "subject": {
+                    "$ref": "#/definitions/fhir.Reference"
+                }
+            }
+        },
+        "fhir.OperationOutcome": {
+            "type": "object",
+            "properties": {
+                "issue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.Issue"
+                    }
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "OperationOutcome"
+                }
+            }
+        },
+        "fhir.Patient": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "address": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.Address"
+                    }
+                },
+                "birthDate": {
+                    "type": "string",
+                    "example": "1980-05-17"
+                },
+                "communication": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.Communication"
+                    }
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other",
+                        "unknown"
+                    ],
+                    "example": "female"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "identifier": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.Identifier"
+                    }
+                },
+                "link": {
+                    "description": "Set on records merged into another",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.PatientLink"
+                    }
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.HumanName"
+                    }
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "Patient"
+                },
+                "telecom": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fhir.ContactPoint"
+                    }
+                }
+            }
+        },
+        "fhir.PatientLink": {
+            "type": "object",
+            "properties": {
+                "other": {
+                    "$ref": "#/definitions/fhir.Reference"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "replaced-by"
+                }
+            }
+        },
+        "fhir.Reference": {
+            "type": "object",
+            "properties": {
+                "display": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "Patient/01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                }
+            }
+        },
+        "http.AccessLogEntryResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "read"
+                },
+                "at": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "break_glass": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                }
+            }
+        },
+        "http.AddCareTeamMemberRequest": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                }
+            }
+        },
+        "http.AddressDTO": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Madrid"
+                },
+                "country": {
+                    "description": "ISO 3166-1 alpha-2, ES when omitted",
+                    "type": "string",
+                    "example": "ES"
+                },
+                "floor": {
+                    "type": "string",
+                    "example": "3º B"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "28013"
+                },
+                "province": {
+                    "description": "INE province code",
+                    "type": "string",
+                    "example": "28"
+                },
+                "province_name": {
+                    "description": "Set in responses only",
+                    "type": "string",
+                    "readOnly": true,
+                    "example": "Madrid"
+                },
+                "street": {
+                    "type": "string",
+                    "example": "Calle Mayor"
+                }
+            }
+        },
+        "http.AppointmentRequest": {
+            "type": "object",
+            "properties": {
+                "diagnosis_id": {
+                    "description": "Diagnosis the visit follows up",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPV"
+                },
+                "end": {
+                    "description": "ISO 8601 format",
+                    "type": "string",
+                    "example": "2026-03-02T09:20:00Z"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "practitioner_id": {
+                    "description": "The caller when omitted",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Revisión"
+                },
+                "start": {
+                    "description": "ISO 8601 format",
+                    "type": "string",
+                    "example": "2026-03-02T09:00:00Z"
+                }
+            }
+        },
+        "http.AppointmentResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string",
+                    "example": "2026-02-20T08:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "diagnosis_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPV"
+                },
+                "due_date": {
+                    "description": "Pending follow-ups only",
+                    "type": "string",
+                    "example": "2026-03-15"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "2026-03-02T09:20:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPW"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "practitioner_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Revisión"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2026-03-02T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "booked",
+                        "cancelled"
+                    ],
+                    "example": "booked"
+                }
+            }
+        },
+        "http.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/png"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "rx-torax.png"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPA"
+                },
+                "sha256": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 482133
+                },
+                "uploaded_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPU"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/diagnostics/01HMGNBPJNX0G2BZXJ7XW1RHPR/attachments/01HMGNBPJNX0G2BZXJ7XW1RHPA"
+                }
+            }
+        },
+        "http.BreakGlassRequest": {
+            "type": "object",
+            "properties": {
+                "justification": {
+                    "type": "string",
+                    "example": "Paciente inconsciente en urgencias"
+                }
+            }
+        },
+        "http.BreakGlassResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-02-13T22:23:00Z"
+                },
+                "flagged_for_review": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "granted_at": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "justification": {
+                    "type": "string",
+                    "example": "Paciente inconsciente en urgencias"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                }
+            }
+        },
+        "http.CalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://api.example.com/practitioners/01HMGNBPJNX0G2BZXJ7XW1RHPS/calendar.ics?token=..."
+                }
+            }
+        },
+        "http.CareTeamMemberResponse": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "added_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                }
+            }
+        },
+        "http.CloseEncounterRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "Final version of the note, the current one is kept when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/http.SOAPNoteRequest"
+                        }
+                    ]
+                }
+            }
+        },
+        "http.CompleteReferralRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Ecocardiograma normal, alta"
+                }
+            }
+        },
+        "http.ConsentResponse": {
+            "type": "object",
+            "properties": {
+                "evidence": {
+                    "type": "string",
+                    "example": "Formulario firmado CI-2026-0042"
+                },
+                "granted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "granted_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "third_party_sharing"
+                },
+                "recorded_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "representative_id": {
+                    "description": "Legal representative who consented",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPT"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2026-03-01T09:30:00Z"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "diagnoses"
+                }
+            }
+        },
+        "http.ContactRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "carmen@example.com"
+                },
+                "legal_representative": {
+                    "description": "Can consent on behalf of the patient",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Carmen López Ruiz"
+                },
+                "phone": {
+                    "description": "Spanish, or international with its country code",
+                    "type": "string",
+                    "example": "600 65 43 21"
+                },
+                "relationship": {
+                    "type": "string",
+                    "enum": [
+                        "parent",
+                        "guardian",
+                        "spouse",
+                        "partner",
+                        "child",
+                        "sibling",
+                        "relative",
+                        "caregiver",
+                        "friend",
+                        "other"
+                    ],
+                    "example": "parent"
+                }
+            }
+        },
+        "http.ContactResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "carmen@example.com"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPT"
+                },
+                "legal_representative": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Carmen López Ruiz"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "phone": {
+                    "description": "E.164",
+                    "type": "string",
+                    "example": "+34600654321"
+                },
+                "phone_display": {
+                    "type": "string",
+                    "example": "+34 600 65 43 21"
+                },
+                "relationship": {
+                    "type": "string",
+                    "example": "parent"
+                }
+            }
+        },
+        "http.CreateDiagnosisRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "ISO 8601 format",
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "diagnosis": {
+                    "type": "string",
+                    "example": "Gripe común"
+                },
+                "encounter_id": {
+                    "description": "Open encounter the diagnosis is made in",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPE"
+                },
+                "follow_up_in_days": {
+                    "description": "Creates a pending follow-up due that many days later",
+                    "type": "integer",
+                    "example": 14
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "prescription": {
+                    "type": "string",
+                    "example": "Ibuprofeno 600mg cada 8h"
+                }
+            }
+        },
+        "http.CreateDiagnosisResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AttachmentResponse"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "diagnosis": {
+                    "type": "string",
+                    "example": "Fiebre alta y tos persistente"
+                },
+                "encounter_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPE"
+                },
+                "follow_up": {
+                    "$ref": "#/definitions/http.AppointmentResponse"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "match": {
+                    "$ref": "#/definitions/http.SearchMatchResponse"
+                },
+                "patient": {
+                    "$ref": "#/definitions/http.PatientResponse"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "prescription": {
+                    "type": "string",
+                    "example": "Paracetamol 1g cada 8 horas"
+                }
+            }
+        },
+        "http.CreatePatientRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Deprecated: use postal_address",
+                    "type": "string",
+                    "example": "Calle Mayor 1, Madrid"
+                },
+                "birth_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string",
+                    "example": "1980-05-17"
+                },
+                "dni": {
+                    "type": "string",
+                    "example": "12345678Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "maria@example.com"
+                },
+                "first_surname": {
+                    "type": "string",
+                    "example": "García"
+                },
+                "given_name": {
+                    "type": "string",
+                    "example": "María"
+                },
+                "name": {
+                    "description": "Deprecated: use the structured fields",
+                    "type": "string",
+                    "example": "María García López"
+                },
+                "nationality": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string",
+                    "example": "ES"
+                },
+                "phone": {
+                    "description": "Spanish, or international with its country code",
+                    "type": "string",
+                    "example": "600 12 34 56"
+                },
+                "postal_address": {
+                    "$ref": "#/definitions/http.AddressDTO"
+                },
+                "preferred_language": {
+                    "description": "ISO 639-1",
+                    "type": "string",
+                    "example": "es"
+                },
+                "second_surname": {
+                    "type": "string",
+                    "example": "López"
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other",
+                        "unknown"
+                    ],
+                    "example": "female"
+                }
+            }
+        },
+        "http.CreateReferralRequest": {
+            "type": "object",
+            "properties": {
+                "diagnosis_id": {
+                    "description": "Diagnosis the referral is about, optional",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPD"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Soplo sistólico a estudio"
+                },
+                "to_practitioner_id": {
+                    "description": "Either the practitioner or the specialty",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPN"
+                },
+                "to_specialty": {
+                    "type": "string",
+                    "example": "cardiology"
+                },
+                "urgency": {
+                    "description": "routine when omitted",
+                    "type": "string",
+                    "enum": [
+                        "routine",
+                        "urgent",
+                        "asap",
+                        "stat"
+                    ],
+                    "example": "urgent"
+                }
+            }
+        },
+        "http.DiagnosisResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AttachmentResponse"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "diagnosis": {
+                    "type": "string",
+                    "example": "Fiebre alta y tos persistente"
+                },
+                "encounter_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPE"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "match": {
+                    "$ref": "#/definitions/http.SearchMatchResponse"
+                },
+                "patient": {
+                    "$ref": "#/definitions/http.PatientResponse"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "prescription": {
+                    "type": "string",
+                    "example": "Paracetamol 1g cada 8 horas"
+                }
+            }
+        },
+        "http.DueDoseResponse": {
+            "type": "object",
+            "properties": {
+                "age_months": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "dose_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "due_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-05-04"
+                },
+                "max_age_months": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "overdue",
+                        "upcoming"
+                    ],
+                    "example": "overdue"
+                },
+                "vaccine_code": {
+                    "type": "string",
+                    "example": "TV"
+                },
+                "vaccine_name": {
+                    "type": "string",
+                    "example": "Triple vírica (SRP)"
+                }
+            }
+        },
+        "http.DuplicateCandidateResponse": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "phone"
+                    ]
+                },
+                "patient": {
+                    "$ref": "#/definitions/http.PatientResponse"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.75
+                }
+            }
+        },
+        "http.EncounterResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:25:00Z"
+                },
+                "diagnoses": {
+                    "description": "Only when reading a single encounter",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DiagnosisResponse"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPE"
+                },
+                "note": {
+                    "$ref": "#/definitions/http.SOAPNoteResponse"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "practitioner_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPN"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "closed"
+                    ],
+                    "example": "open"
+                }
+            }
+        },
+        "http.ErasePatientRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Solicitud de supresión recibida el 2026-02-10"
+                }
+            }
+        },
+        "http.ErasureResponse": {
+            "type": "object",
+            "properties": {
+                "erased_at": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Solicitud de supresión recibida el 2026-02-10"
+                },
+                "retain_until": {
+                    "type": "string",
+                    "example": "2031-02-12T18:23:00Z"
+                }
+            }
+        },
+        "http.ExportedDiagnosis": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "diagnosis": {
+                    "type": "string",
+                    "example": "Fiebre alta y tos persistente"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "prescription": {
+                    "type": "string",
+                    "example": "Paracetamol 1g cada 8 horas"
+                }
+            }
+        },
+        "http.GrantConsentRequest": {
+            "type": "object",
+            "properties": {
+                "evidence": {
+                    "type": "string",
+                    "example": "Formulario firmado CI-2026-0042"
+                },
+                "granted_at": {
+                    "description": "ISO 8601 format, defaults to now",
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "purpose": {
+                    "type": "string",
+                    "enum": [
+                        "third_party_sharing",
+                        "research"
+                    ],
+                    "example": "third_party_sharing"
+                },
+                "representative_id": {
+                    "description": "Contact consenting on behalf of a patient under 16",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPT"
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "demographics",
+                        "diagnoses",
+                        "prescriptions"
+                    ],
+                    "example": "diagnoses"
+                }
+            }
+        },
+        "http.LabResultBatchRequest": {
+            "type": "object",
+            "properties": {
+                "collected_at": {
+                    "description": "ISO 8601 format, now when omitted",
+                    "type": "string",
+                    "example": "2026-02-13T08:30:00Z"
+                },
+                "diagnosis_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPV"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.LabResultRequest"
+                    }
+                }
+            }
+        },
+        "http.LabResultBatchResponse": {
+            "type": "object",
+            "properties": {
+                "abnormal": {
+                    "description": "Number of results out of range",
+                    "type": "integer",
+                    "example": 1
+                },
+                "batch_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPB"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.LabResultResponse"
+                    }
+                }
+            }
+        },
+        "http.LabResultRequest": {
+            "type": "object",
+            "properties": {
+                "analyte": {
+                    "type": "string",
+                    "example": "Hemoglobin"
+                },
+                "code": {
+                    "description": "LOINC, if the lab sent it",
+                    "type": "string",
+                    "example": "718-7"
+                },
+                "panel": {
+                    "type": "string",
+                    "example": "Complete blood count"
+                },
+                "reference_range": {
+                    "$ref": "#/definitions/http.ReferenceRangeDTO"
+                },
+                "unit": {
+                    "description": "UCUM",
+                    "type": "string",
+                    "example": "g/dL"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10.9
+                }
+            }
+        },
+        "http.LabResultResponse": {
+            "type": "object",
+            "properties": {
+                "abnormal": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "analyte": {
+                    "type": "string",
+                    "example": "Hemoglobin"
+                },
+                "batch_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPB"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "718-7"
+                },
+                "collected_at": {
+                    "type": "string",
+                    "example": "2026-02-13T08:30:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "diagnosis_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPV"
+                },
+                "flag": {
+                    "type": "string",
+                    "enum": [
+                        "L",
+                        "N",
+                        "H"
+                    ],
+                    "example": "L"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPX"
+                },
+                "panel": {
+                    "type": "string",
+                    "example": "Complete blood count"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "recorded_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "reference_range": {
+                    "$ref": "#/definitions/http.ReferenceRangeDTO"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g/dL"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10.9
+                }
+            }
+        },
+        "http.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "secure_password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "doctor"
+                }
+            }
+        },
+        "http.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "string"
+                }
+            }
+        },
+        "http.MergePatientRequest": {
+            "type": "object",
+            "properties": {
+                "duplicate_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Registrada sin documentación el 2026-02-10"
+                }
+            }
+        },
+        "http.ObservationPointResponse": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "interpretation": {
+                    "type": "string",
+                    "example": "N"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 72
+                }
+            }
+        },
+        "http.ObservationRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "LOINC",
+                    "type": "string",
+                    "enum": [
+                        "8480-6",
+                        "8462-4",
+                        "8867-4",
+                        "8310-5",
+                        "29463-7",
+                        "2339-0"
+                    ],
+                    "example": "8867-4"
+                },
+                "diagnosis_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPV"
+                },
+                "effective_at": {
+                    "description": "ISO 8601 format, now when omitted",
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "reference_range": {
+                    "description": "Adult range of the type when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/http.ReferenceRangeDTO"
+                        }
+                    ]
+                },
+                "unit": {
+                    "description": "UCUM",
+                    "type": "string",
+                    "example": "/min"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 72
+                }
+            }
+        },
+        "http.ObservationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "8867-4"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "diagnosis_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPV"
+                },
+                "display": {
+                    "type": "string",
+                    "example": "Heart rate"
+                },
+                "effective_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPX"
+                },
+                "interpretation": {
+                    "type": "string",
+                    "enum": [
+                        "L",
+                        "N",
+                        "H"
+                    ],
+                    "example": "N"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "recorded_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "reference_range": {
+                    "$ref": "#/definitions/http.ReferenceRangeDTO"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "/min"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 72
+                }
+            }
+        },
+        "http.ObservationSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "8867-4"
+                },
+                "display": {
+                    "type": "string",
+                    "example": "Heart rate"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ObservationPointResponse"
+                    }
+                },
+                "reference_range": {
+                    "$ref": "#/definitions/http.ReferenceRangeDTO"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "/min"
+                }
+            }
+        },
+        "http.OpenEncounterRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "$ref": "#/definitions/http.SOAPNoteRequest"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "started_at": {
+                    "description": "ISO 8601 format, now when omitted",
+                    "type": "string",
+                    "example": "2026-02-13T10:00:00Z"
+                }
+            }
+        },
+        "http.PatientExportResponse": {
+            "type": "object",
+            "properties": {
+                "access_log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AccessLogEntryResponse"
+                    }
+                },
+                "appointments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AppointmentResponse"
+                    }
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.AttachmentResponse"
+                    }
+                },
+                "consents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ConsentResponse"
+                    }
+                },
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ContactResponse"
+                    }
+                },
+                "diagnoses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ExportedDiagnosis"
+                    }
+                },
+                "encounters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.EncounterResponse"
+                    }
+                },
+                "generated_at": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "generated_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "lab_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.LabResultResponse"
+                    }
+                },
+                "observations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ObservationResponse"
+                    }
+                },
+                "patient": {
+                    "$ref": "#/definitions/http.PatientResponse"
+                },
+                "prescriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.PrescriptionResponse"
+                    }
+                },
+                "referrals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.ReferralResponse"
+                    }
+                },
+                "vaccinations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.VaccinationResponse"
+                    }
+                }
+            }
+        },
+        "http.PatientMergeResponse": {
             "type": "object",
             "properties": {
-                "date": {
-                    "description": "ISO 8601 format",
-                    "type": "string",
-                    "example": "2026-02-13T10:00:00Z"
+                "diagnoses_moved": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "diagnosis": {
+                "duplicate_id": {
                     "type": "string",
-                    "example": "Gripe común"
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
                 },
-                "patient_id": {
+                "id": {
                     "type": "string",
                     "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
                 },
-                "prescription": {
+                "merged_at": {
                     "type": "string",
-                    "example": "Ibuprofeno 600mg cada 8h"
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Registrada sin documentación el 2026-02-10"
+                },
+                "survivor_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
                 }
             }
         },
-        "http.CreatePatientRequest": {
+        "http.PatientResponse": {
             "type": "object",
             "properties": {
                 "address": {
+                    "description": "Single line, kept for older clients",
                     "type": "string",
-                    "example": "Calle Mayor 1, Madrid"
+                    "example": "Calle Mayor 1, 3º B, 28013 Madrid"
+                },
+                "age": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "birth_date": {
+                    "type": "string",
+                    "example": "1980-05-17"
                 },
                 "dni": {
                     "type": "string",
-                    "example": "12345678Z"
+                    "example": "12345678X"
                 },
                 "email": {
                     "type": "string",
                     "example": "maria@example.com"
                 },
+                "first_surname": {
+                    "type": "string",
+                    "example": "García"
+                },
+                "given_name": {
+                    "type": "string",
+                    "example": "María"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
                 "name": {
+                    "description": "Display name, kept for older clients",
+                    "type": "string",
+                    "example": "María García López"
+                },
+                "nationality": {
                     "type": "string",
-                    "example": "Maria Garcia"
+                    "example": "ES"
                 },
                 "phone": {
+                    "description": "E.164",
                     "type": "string",
                     "example": "+34600123456"
+                },
+                "phone_display": {
+                    "type": "string",
+                    "example": "+34 600 12 34 56"
+                },
+                "postal_address": {
+                    "$ref": "#/definitions/http.AddressDTO"
+                },
+                "preferred_language": {
+                    "type": "string",
+                    "example": "es"
+                },
+                "second_surname": {
+                    "type": "string",
+                    "example": "López"
+                },
+                "sex": {
+                    "type": "string",
+                    "example": "female"
                 }
             }
         },
-        "http.DiagnosisResponse": {
+        "http.PatientSearchResponse": {
             "type": "object",
             "properties": {
-                "date": {
+                "address": {
+                    "description": "Single line, kept for older clients",
                     "type": "string",
-                    "example": "2026-02-13T18:23:00Z"
+                    "example": "Calle Mayor 1, 3º B, 28013 Madrid"
                 },
-                "diagnosis": {
+                "age": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "birth_date": {
                     "type": "string",
-                    "example": "Fiebre alta y tos persistente"
+                    "example": "1980-05-17"
+                },
+                "dni": {
+                    "type": "string",
+                    "example": "12345678X"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "maria@example.com"
+                },
+                "first_surname": {
+                    "type": "string",
+                    "example": "García"
+                },
+                "given_name": {
+                    "type": "string",
+                    "example": "María"
                 },
                 "id": {
                     "type": "string",
                     "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
                 },
-                "patient": {
-                    "$ref": "#/definitions/http.PatientResponse"
+                "name": {
+                    "description": "Display name, kept for older clients",
+                    "type": "string",
+                    "example": "María García López"
                 },
-                "patient_id": {
+                "nationality": {
+                    "type": "string",
+                    "example": "ES"
+                },
+                "phone": {
+                    "description": "E.164",
+                    "type": "string",
+                    "example": "+34600123456"
+                },
+                "phone_display": {
+                    "type": "string",
+                    "example": "+34 600 12 34 56"
+                },
+                "postal_address": {
+                    "$ref": "#/definitions/http.AddressDTO"
+                },
+                "preferred_language": {
+                    "type": "string",
+                    "example": "es"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.83
+                },
+                "second_surname": {
+                    "type": "string",
+                    "example": "López"
+                },
+                "sex": {
+                    "type": "string",
+                    "example": "female"
+                }
+            }
+        },
+        "http.PrescriptionResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-13T18:23:00Z"
+                },
+                "diagnosis_id": {
                     "type": "string",
                     "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
                 },
@@ -362,7 +6444,101 @@ const docTemplate = `{
                 }
             }
         },
-        "http.LoginRequest": {
+        "http.ReferenceRangeDTO": {
+            "type": "object",
+            "properties": {
+                "high": {
+                    "type": "number",
+                    "example": 100
+                },
+                "low": {
+                    "type": "number",
+                    "example": 60
+                }
+            }
+        },
+        "http.ReferralResponse": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string",
+                    "example": "2026-02-20T12:00:00Z"
+                },
+                "completion_note": {
+                    "type": "string",
+                    "example": "Ecocardiograma normal, alta"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:35:00Z"
+                },
+                "diagnosis_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPD"
+                },
+                "from_practitioner_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPF"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Soplo sistólico a estudio"
+                },
+                "rejection_reason": {
+                    "type": "string",
+                    "example": "Derivar a cardiología infantil"
+                },
+                "responded_at": {
+                    "type": "string",
+                    "example": "2026-02-14T09:00:00Z"
+                },
+                "responded_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPN"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "accepted",
+                        "rejected",
+                        "completed"
+                    ],
+                    "example": "pending"
+                },
+                "to_practitioner_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPN"
+                },
+                "to_specialty": {
+                    "type": "string",
+                    "example": "cardiology"
+                },
+                "to_specialty_name": {
+                    "type": "string",
+                    "example": "Cardiología"
+                },
+                "urgency": {
+                    "type": "string",
+                    "enum": [
+                        "routine",
+                        "urgent",
+                        "asap",
+                        "stat"
+                    ],
+                    "example": "urgent"
+                }
+            }
+        },
+        "http.RegisterRequest": {
             "type": "object",
             "properties": {
                 "password": {
@@ -375,54 +6551,188 @@ const docTemplate = `{
                 }
             }
         },
-        "http.LoginResponse": {
+        "http.RejectReferralRequest": {
             "type": "object",
             "properties": {
-                "token": {
+                "reason": {
                     "type": "string",
-                    "example": "string"
+                    "example": "Derivar a cardiología infantil"
                 }
             }
         },
-        "http.PatientResponse": {
+        "http.RescheduleAppointmentRequest": {
             "type": "object",
             "properties": {
-                "address": {
+                "end": {
+                    "description": "ISO 8601 format",
                     "type": "string",
-                    "example": "Calle Mayor 1, Madrid"
+                    "example": "2026-03-03T10:20:00Z"
                 },
-                "dni": {
+                "start": {
+                    "description": "ISO 8601 format",
                     "type": "string",
-                    "example": "12345678X"
+                    "example": "2026-03-03T10:00:00Z"
+                }
+            }
+        },
+        "http.SOAPNoteRequest": {
+            "type": "object",
+            "properties": {
+                "assessment": {
+                    "type": "string",
+                    "example": "Dolor torácico atípico, probable origen musculoesquelético"
                 },
-                "email": {
+                "objective": {
                     "type": "string",
-                    "example": "maria@example.com"
+                    "example": "TA 150/95, FC 98 lpm. ECG sin alteraciones agudas"
                 },
-                "id": {
+                "plan": {
+                    "type": "string",
+                    "example": "Analgesia y control en 48 horas"
+                },
+                "subjective": {
+                    "type": "string",
+                    "example": "Dolor torácico opresivo de 2 horas de evolución"
+                }
+            }
+        },
+        "http.SOAPNoteResponse": {
+            "type": "object",
+            "properties": {
+                "assessment": {
+                    "type": "string",
+                    "example": "Dolor torácico atípico, probable origen musculoesquelético"
+                },
+                "objective": {
+                    "type": "string",
+                    "example": "TA 150/95, FC 98 lpm. ECG sin alteraciones agudas"
+                },
+                "plan": {
+                    "type": "string",
+                    "example": "Analgesia y control en 48 horas"
+                },
+                "subjective": {
+                    "type": "string",
+                    "example": "Dolor torácico opresivo de 2 horas de evolución"
+                }
+            }
+        },
+        "http.SearchMatchResponse": {
+            "type": "object",
+            "properties": {
+                "diagnosis_snippet": {
+                    "type": "string",
+                    "example": "Fiebre alta y \u003cmark\u003eneumonía\u003c/mark\u003e bilateral"
+                },
+                "name_score": {
+                    "type": "number",
+                    "example": 0.83
+                },
+                "prescription_snippet": {
+                    "type": "string",
+                    "example": "Amoxicilina 1g cada 8 horas"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 3.2
+                }
+            }
+        },
+        "http.VaccinationForecastResponse": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string",
+                    "example": "2026-02-13T10:35:00Z"
+                },
+                "overdue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DueDoseResponse"
+                    }
+                },
+                "patient_id": {
                     "type": "string",
                     "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
                 },
-                "name": {
+                "schedule": {
                     "type": "string",
-                    "example": "Maria Garcia"
+                    "example": "Calendario común de vacunación infantil (CISNS)"
                 },
-                "phone": {
+                "upcoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.DueDoseResponse"
+                    }
+                }
+            }
+        },
+        "http.VaccinationRequest": {
+            "type": "object",
+            "properties": {
+                "administered_at": {
+                    "description": "ISO 8601 format, now when omitted",
                     "type": "string",
-                    "example": "+34600123456"
+                    "example": "2026-02-13T10:30:00Z"
+                },
+                "administered_by": {
+                    "description": "The caller when omitted",
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPN"
+                },
+                "dose_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lot": {
+                    "type": "string",
+                    "example": "A12B345"
+                },
+                "vaccine_code": {
+                    "description": "Code of the vaccine in the schedule",
+                    "type": "string",
+                    "example": "HEXA"
                 }
             }
         },
-        "http.RegisterRequest": {
+        "http.VaccinationResponse": {
             "type": "object",
             "properties": {
-                "password": {
+                "administered_at": {
                     "type": "string",
-                    "example": "secure_password"
+                    "example": "2026-02-13T10:30:00Z"
                 },
-                "username": {
+                "administered_by": {
                     "type": "string",
-                    "example": "doctor"
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPN"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-02-13T10:35:00Z"
+                },
+                "dose_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPV"
+                },
+                "lot": {
+                    "type": "string",
+                    "example": "A12B345"
+                },
+                "patient_id": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+                },
+                "recorded_by": {
+                    "type": "string",
+                    "example": "01HMGNBPJNX0G2BZXJ7XW1RHPS"
+                },
+                "vaccine_code": {
+                    "type": "string",
+                    "example": "HEXA"
                 }
             }
         }
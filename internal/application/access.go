@@ -0,0 +1,116 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// accessGuard enforces care team based access to patients and records every
+// granted access in the access log. Integration clients are never part of a
+// care team; they are authorized by the patient's consent to share data.
+type accessGuard struct {
+	repo    domain.CareTeamRepository
+	consent *consentGuard
+	support domain.Support
+}
+
+func newAccessGuard(repo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *accessGuard {
+	return &accessGuard{
+		repo:    repo,
+		consent: &consentGuard{repo: consentRepo},
+		support: support,
+	}
+}
+
+// authorize checks that the caller belongs to the patient's care team or holds
+// an active break-glass grant, and logs the access
+func (g *accessGuard) authorize(caller domain.Caller, patientID, action string) error {
+	if caller.IsIntegration() {
+		if action != domain.AccessActionRead {
+			slog.Warn("Access denied to integration client", "patient_id", patientID, "user_id", caller.UserID, "action", action)
+			return domain.ErrAccessDenied
+		}
+		if err := g.consent.require(patientID, domain.ConsentPurposeThirdPartySharing, domain.ConsentScopeDemographics); err != nil {
+			return err
+		}
+		g.record(caller, patientID, action, false)
+		return nil
+	}
+
+	member, err := g.repo.IsCareTeamMember(patientID, caller.UserID)
+	if err != nil {
+		slog.Error("Care team lookup failed", "patient_id", patientID, "user_id", caller.UserID, "error", err)
+		return err
+	}
+
+	breakGlass := false
+	if !member {
+		access, err := g.repo.GetActiveBreakGlassAccess(patientID, caller.UserID, time.Now())
+		if err != nil || access == nil {
+			slog.Warn("Access denied to patient", "patient_id", patientID, "user_id", caller.UserID, "action", action)
+			return domain.ErrAccessDenied
+		}
+		breakGlass = true
+	}
+
+	g.record(caller, patientID, action, breakGlass)
+	return nil
+}
+
+// authorizeClinicalRead authorizes reading the patient's clinical records.
+// Integration clients also need the patient's consent to share diagnoses,
+// demographics alone are not enough.
+func (g *accessGuard) authorizeClinicalRead(caller domain.Caller, patientID string) error {
+	if err := g.authorize(caller, patientID, domain.AccessActionRead); err != nil {
+		return err
+	}
+	if caller.IsIntegration() {
+		return g.consent.require(patientID, domain.ConsentPurposeThirdPartySharing, domain.ConsentScopeDiagnoses)
+	}
+	return nil
+}
+
+// recordSearch logs an access for every distinct patient present in a search result
+func (g *accessGuard) recordSearch(caller domain.Caller, patientIDs []string) {
+	seen := make(map[string]bool)
+	for _, patientID := range patientIDs {
+		if seen[patientID] {
+			continue
+		}
+		seen[patientID] = true
+
+		if caller.IsIntegration() {
+			g.record(caller, patientID, domain.AccessActionSearch, false)
+			continue
+		}
+
+		member, err := g.repo.IsCareTeamMember(patientID, caller.UserID)
+		if err != nil {
+			slog.Error("Care team lookup failed", "patient_id", patientID, "user_id", caller.UserID, "error", err)
+		}
+		g.record(caller, patientID, domain.AccessActionSearch, !member)
+	}
+}
+
+// record stores an access log entry. Failures are logged but never block the
+// access itself.
+func (g *accessGuard) record(caller domain.Caller, patientID, action string, breakGlass bool) {
+	id, err := g.support.CreateNewID()
+	if err != nil {
+		slog.Error("ID creation failed for access log entry", "error", err)
+		return
+	}
+
+	entry := &domain.AccessLogEntry{
+		ID:         id,
+		PatientID:  patientID,
+		UserID:     caller.UserID,
+		Action:     action,
+		BreakGlass: breakGlass,
+		At:         time.Now(),
+	}
+	if err := g.repo.CreateAccessLogEntry(entry); err != nil {
+		slog.Error("Access log entry creation failed", "patient_id", patientID, "error", err)
+	}
+}
@@ -7,22 +7,74 @@ import (
 
 // Application is the container for all application services
 type Application struct {
-	auth    domain.UserService
-	patient domain.PatientService
-	support domain.Support
+	auth        domain.UserService
+	patient     domain.PatientService
+	careTeam    domain.CareTeamService
+	consent     domain.ConsentService
+	export      domain.ExportService
+	erasure     domain.ErasureService
+	merge       domain.MergeService
+	contact     domain.ContactService
+	appointment domain.AppointmentService
+	calendar    domain.CalendarService
+	observation domain.ObservationService
+	lab         domain.LabResultService
+	attachment  domain.AttachmentService
+	vaccination domain.VaccinationService
+	referral    domain.ReferralService
+	encounter   domain.EncounterService
+	bulkExport  domain.BulkExportService
+	support     domain.Support
+}
+
+// Repositories groups the driven ports the application services depend on
+type Repositories struct {
+	User        domain.UserRepository
+	Patient     domain.PatientRepository
+	CareTeam    domain.CareTeamRepository
+	Consent     domain.ConsentRepository
+	Erasure     domain.ErasureRepository
+	Merge       domain.MergeRepository
+	Contact     domain.ContactRepository
+	Appointment domain.AppointmentRepository
+	Calendar    domain.CalendarFeedRepository
+	Observation domain.ObservationRepository
+	Lab         domain.LabResultRepository
+	Attachment  domain.AttachmentRepository
+	Blobs       domain.BlobStorage
+	Vaccination domain.VaccinationRepository
+	Schedule    domain.VaccinationScheduleSource
+	Referral    domain.ReferralRepository
+	Encounter   domain.EncounterRepository
+	BulkExport  domain.BulkExportRepository
+	ExportFiles domain.BulkExportStorage
 }
 
 // NewApplication creates a new application instance with all services
 func NewApplication(
-	userRepo domain.UserRepository,
-	patientRepo domain.PatientRepository,
+	repos Repositories,
 	support domain.Support,
 	cfg *config.Config,
 ) *Application {
 
 	return &Application{
-		auth:    NewAuthService(userRepo, support, cfg),
-		patient: NewPatientService(patientRepo, support),
+		auth:        NewAuthService(repos.User, support, cfg),
+		patient:     NewPatientService(repos.Patient, repos.Encounter, repos.CareTeam, repos.Consent, support),
+		careTeam:    NewCareTeamService(repos.CareTeam, repos.Patient, repos.User, repos.Consent, support),
+		consent:     NewConsentService(repos.Consent, repos.CareTeam, repos.Patient, repos.Contact, support),
+		export:      NewExportService(repos, support),
+		erasure:     NewErasureService(repos.Erasure, repos.Patient, repos.Attachment, repos.Blobs, repos.BulkExport, repos.ExportFiles, repos.CareTeam, repos.Consent, support),
+		merge:       NewMergeService(repos.Merge, repos.Patient, repos.CareTeam, repos.Consent, support),
+		contact:     NewContactService(repos.Contact, repos.Patient, repos.CareTeam, repos.Consent, support),
+		appointment: NewAppointmentService(repos.Appointment, repos.Patient, repos.User, repos.CareTeam, repos.Consent, support),
+		calendar:    NewCalendarService(repos.Calendar, repos.Appointment, cfg),
+		observation: NewObservationService(repos.Observation, repos.Patient, repos.CareTeam, repos.Consent, support),
+		lab:         NewLabResultService(repos.Lab, repos.Patient, repos.CareTeam, repos.Consent, support),
+		attachment:  NewAttachmentService(repos.Attachment, repos.Blobs, repos.Patient, repos.CareTeam, repos.Consent, support),
+		vaccination: NewVaccinationService(repos.Vaccination, repos.Schedule, repos.Patient, repos.CareTeam, repos.Consent, support),
+		referral:    NewReferralService(repos.Referral, repos.Patient, repos.User, repos.CareTeam, repos.Consent, support),
+		encounter:   NewEncounterService(repos.Encounter, repos.Patient, repos.CareTeam, repos.Consent, support),
+		bulkExport:  NewBulkExportService(repos.BulkExport, repos.ExportFiles, repos.CareTeam, repos.Consent, support),
 	}
 }
 
@@ -35,3 +87,78 @@ func (a *Application) Auth() domain.UserService {
 func (a *Application) Patient() domain.PatientService {
 	return a.patient
 }
+
+// CareTeam returns the care team service
+func (a *Application) CareTeam() domain.CareTeamService {
+	return a.careTeam
+}
+
+// Consent returns the consent service
+func (a *Application) Consent() domain.ConsentService {
+	return a.consent
+}
+
+// Export returns the data export service
+func (a *Application) Export() domain.ExportService {
+	return a.export
+}
+
+// Erasure returns the erasure service
+func (a *Application) Erasure() domain.ErasureService {
+	return a.erasure
+}
+
+// Merge returns the duplicate detection and merge service
+func (a *Application) Merge() domain.MergeService {
+	return a.merge
+}
+
+// Contact returns the emergency contact and guardian service
+func (a *Application) Contact() domain.ContactService {
+	return a.contact
+}
+
+// Appointment returns the appointment and agenda service
+func (a *Application) Appointment() domain.AppointmentService {
+	return a.appointment
+}
+
+// Calendar returns the iCalendar feed service
+func (a *Application) Calendar() domain.CalendarService {
+	return a.calendar
+}
+
+// Observation returns the vital sign and clinical observation service
+func (a *Application) Observation() domain.ObservationService {
+	return a.observation
+}
+
+// Lab returns the lab result service
+func (a *Application) Lab() domain.LabResultService {
+	return a.lab
+}
+
+// Attachment returns the diagnosis attachment service
+func (a *Application) Attachment() domain.AttachmentService {
+	return a.attachment
+}
+
+// Vaccination returns the vaccination record and schedule service
+func (a *Application) Vaccination() domain.VaccinationService {
+	return a.vaccination
+}
+
+// Referral returns the referral service
+func (a *Application) Referral() domain.ReferralService {
+	return a.referral
+}
+
+// Encounter returns the encounter and clinical note service
+func (a *Application) Encounter() domain.EncounterService {
+	return a.encounter
+}
+
+// BulkExport returns the FHIR bulk data export service
+func (a *Application) BulkExport() domain.BulkExportService {
+	return a.bulkExport
+}
@@ -0,0 +1,187 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type AppointmentService struct {
+	repo        domain.AppointmentRepository
+	patientRepo domain.PatientRepository
+	userRepo    domain.UserRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewAppointmentService(repo domain.AppointmentRepository, patientRepo domain.PatientRepository, userRepo domain.UserRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *AppointmentService {
+	return &AppointmentService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		userRepo:    userRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+func (s *AppointmentService) BookAppointment(caller domain.Caller, appointment *domain.Appointment) error {
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for appointment", "error", errCreateID)
+		return errCreateID
+	}
+	appointment.ID = id
+	appointment.Status = domain.AppointmentStatusBooked
+	appointment.DueDate = nil
+	appointment.CancelledAt = nil
+	appointment.CreatedBy = caller.UserID
+	appointment.CreatedAt = time.Now()
+	if appointment.PractitionerID == "" {
+		appointment.PractitionerID = caller.UserID
+	}
+
+	// Enforce domain invariants
+	if errValidate := appointment.Validate(); errValidate != nil {
+		slog.Warn("Appointment validation failed", "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, appointment.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+	if err := checkActivePatient(s.patientRepo, appointment.PatientID); err != nil {
+		return err
+	}
+	if err := s.checkPractitioner(appointment.PractitionerID); err != nil {
+		return err
+	}
+	if appointment.DiagnosisID != "" {
+		if err := checkPatientDiagnosis(s.patientRepo, appointment.PatientID, appointment.DiagnosisID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.CreateAppointment(appointment); err != nil {
+		slog.Warn("Appointment booking failed", "patient_id", appointment.PatientID, "practitioner_id", appointment.PractitionerID, "error", err)
+		return err
+	}
+
+	slog.Info("Appointment booked", "appointment_id", appointment.ID, "patient_id", appointment.PatientID, "practitioner_id", appointment.PractitionerID)
+	return nil
+}
+
+func (s *AppointmentService) RescheduleAppointment(caller domain.Caller, id string, start, end time.Time) (*domain.Appointment, error) {
+	appointment, err := s.authorizedAppointment(caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := appointment.Reschedule(start, end); err != nil {
+		slog.Warn("Appointment rescheduling rejected", "appointment_id", id, "error", err)
+		return nil, err
+	}
+
+	if err := s.repo.UpdateAppointment(appointment); err != nil {
+		slog.Warn("Appointment rescheduling failed", "appointment_id", id, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Appointment rescheduled", "appointment_id", id, "patient_id", appointment.PatientID, "start", start)
+	return appointment, nil
+}
+
+func (s *AppointmentService) CancelAppointment(caller domain.Caller, id string) (*domain.Appointment, error) {
+	appointment, err := s.authorizedAppointment(caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := appointment.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateAppointment(appointment); err != nil {
+		slog.Error("Appointment cancellation in repository failed", "appointment_id", id, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Appointment cancelled", "appointment_id", id, "patient_id", appointment.PatientID)
+	return appointment, nil
+}
+
+func (s *AppointmentService) GetAppointment(caller domain.Caller, id string) (*domain.Appointment, error) {
+	appointment, err := s.repo.GetAppointmentByID(id)
+	if err != nil {
+		slog.Warn("Appointment not found", "appointment_id", id)
+		return nil, err
+	}
+	if err := s.access.authorize(caller, appointment.PatientID, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+func (s *AppointmentService) GetPatientAppointments(caller domain.Caller, patientID string) ([]domain.Appointment, error) {
+	if err := s.access.authorize(caller, patientID, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppointmentsByPatientID(patientID)
+}
+
+// GetAgenda returns the appointments of a practitioner for the day or week
+// containing the given date. Practitioners only see their own agenda.
+func (s *AppointmentService) GetAgenda(caller domain.Caller, practitionerID string, date time.Time, view string) ([]domain.Appointment, error) {
+	if caller.UserID != practitionerID && !caller.IsAdmin() {
+		slog.Warn("Agenda access denied", "practitioner_id", practitionerID, "user_id", caller.UserID)
+		return nil, domain.ErrAccessDenied
+	}
+
+	from, to, err := domain.AgendaRange(date, view)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, err := s.repo.GetAgenda(practitionerID, from, to)
+	if err != nil {
+		slog.Error("Agenda lookup failed", "practitioner_id", practitionerID, "error", err)
+		return nil, err
+	}
+
+	patientIDs := make([]string, len(appointments))
+	for i, a := range appointments {
+		patientIDs[i] = a.PatientID
+	}
+	s.access.recordSearch(caller, patientIDs)
+	return appointments, nil
+}
+
+// authorizedAppointment loads an appointment the caller may change, of a
+// patient neither erased nor merged
+func (s *AppointmentService) authorizedAppointment(caller domain.Caller, id string) (*domain.Appointment, error) {
+	appointment, err := s.repo.GetAppointmentByID(id)
+	if err != nil {
+		slog.Warn("Appointment not found", "appointment_id", id)
+		return nil, err
+	}
+	if err := s.access.authorize(caller, appointment.PatientID, domain.AccessActionWrite); err != nil {
+		return nil, err
+	}
+	if err := checkActivePatient(s.patientRepo, appointment.PatientID); err != nil {
+		slog.Warn("Appointment change rejected", "appointment_id", id, "patient_id", appointment.PatientID, "error", err)
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// checkPractitioner ensures the appointment is with a user who sees patients
+func (s *AppointmentService) checkPractitioner(practitionerID string) error {
+	user, err := s.userRepo.GetByID(practitionerID)
+	if err != nil {
+		slog.Warn("Appointment booking failed: practitioner not found", "practitioner_id", practitionerID)
+		return domain.ErrInvalidPractitioner
+	}
+	if user.Role == domain.RoleIntegration {
+		return domain.ErrInvalidPractitioner
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAppointmentService_BookAppointment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewAppointmentService(mockRepo, mockPatientRepo, mockUserRepo, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	newAppointment := func() *domain.Appointment {
+		return &domain.Appointment{PatientID: "p1", Start: start, End: start.Add(20 * time.Minute), Reason: "Revisión"}
+	}
+	expectAuthorized := func() {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+	}
+
+	t.Run("successful booking with the caller", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("appointment-id", nil)
+		expectAuthorized()
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Role: domain.RolePractitioner}, nil)
+		mockRepo.EXPECT().CreateAppointment(gomock.Any()).Return(nil)
+
+		appointment := newAppointment()
+		if err := service.BookAppointment(caller, appointment); err != nil {
+			t.Fatalf("BookAppointment() unexpected error = %v", err)
+		}
+		if appointment.ID != "appointment-id" || appointment.PractitionerID != caller.UserID || appointment.Status != domain.AppointmentStatusBooked {
+			t.Errorf("BookAppointment() = %+v", appointment)
+		}
+	})
+
+	t.Run("slot taken", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("appointment-id", nil)
+		expectAuthorized()
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Role: domain.RolePractitioner}, nil)
+		mockRepo.EXPECT().CreateAppointment(gomock.Any()).Return(domain.ErrAppointmentOverlap)
+
+		if err := service.BookAppointment(caller, newAppointment()); !errors.Is(err, domain.ErrAppointmentOverlap) {
+			t.Errorf("BookAppointment() expected ErrAppointmentOverlap, got %v", err)
+		}
+	})
+
+	t.Run("integration client is not a practitioner", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("appointment-id", nil)
+		expectAuthorized()
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockUserRepo.EXPECT().GetByID("client-id").Return(&domain.User{ID: "client-id", Role: domain.RoleIntegration}, nil)
+
+		appointment := newAppointment()
+		appointment.PractitionerID = "client-id"
+		if err := service.BookAppointment(caller, appointment); !errors.Is(err, domain.ErrInvalidPractitioner) {
+			t.Errorf("BookAppointment() expected ErrInvalidPractitioner, got %v", err)
+		}
+	})
+
+	t.Run("diagnosis of another patient", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("appointment-id", nil)
+		expectAuthorized()
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Role: domain.RolePractitioner}, nil)
+		mockPatientRepo.EXPECT().GetDiagnosisByPatientID("p1").Return([]domain.Diagnosis{{ID: "d1", PatientID: "p1"}}, nil)
+
+		appointment := newAppointment()
+		appointment.DiagnosisID = "d2"
+		if err := service.BookAppointment(caller, appointment); !errors.Is(err, domain.ErrDiagnosisPatientMismatch) {
+			t.Errorf("BookAppointment() expected ErrDiagnosisPatientMismatch, got %v", err)
+		}
+	})
+
+	t.Run("ends before it starts", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("appointment-id", nil)
+
+		appointment := newAppointment()
+		appointment.End = start.Add(-time.Minute)
+		if err := service.BookAppointment(caller, appointment); !errors.Is(err, domain.ErrInvalidAppointmentTime) {
+			t.Errorf("BookAppointment() expected ErrInvalidAppointmentTime, got %v", err)
+		}
+	})
+}
+
+func TestAppointmentService_ChangeAppointment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewAppointmentService(mockRepo, mockPatientRepo, mocks.NewMockUserRepository(ctrl), mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	expectAppointment := func(patient *domain.Patient) {
+		mockRepo.EXPECT().GetAppointmentByID("a1").Return(&domain.Appointment{ID: "a1", PatientID: "p1", PractitionerID: caller.UserID, Status: domain.AppointmentStatusBooked,
+			Start: start, End: start.Add(20 * time.Minute), Reason: "Revisión"}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(patient, nil)
+	}
+	erasedAt := time.Now()
+
+	t.Run("reschedule", func(t *testing.T) {
+		expectAppointment(&domain.Patient{ID: "p1"})
+		mockRepo.EXPECT().UpdateAppointment(gomock.Any()).Return(nil)
+
+		appointment, err := service.RescheduleAppointment(caller, "a1", start.Add(time.Hour), start.Add(80*time.Minute))
+		if err != nil || !appointment.Start.Equal(start.Add(time.Hour)) {
+			t.Errorf("RescheduleAppointment() = %+v, %v", appointment, err)
+		}
+	})
+
+	t.Run("reschedule for an erased patient", func(t *testing.T) {
+		expectAppointment(&domain.Patient{ID: "p1", ErasedAt: &erasedAt})
+
+		if _, err := service.RescheduleAppointment(caller, "a1", start.Add(time.Hour), start.Add(80*time.Minute)); !errors.Is(err, domain.ErrPatientAlreadyErased) {
+			t.Errorf("RescheduleAppointment() expected ErrPatientAlreadyErased, got %v", err)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		expectAppointment(&domain.Patient{ID: "p1"})
+		mockRepo.EXPECT().UpdateAppointment(gomock.Any()).Return(nil)
+
+		appointment, err := service.CancelAppointment(caller, "a1")
+		if err != nil || !appointment.IsCancelled() {
+			t.Errorf("CancelAppointment() = %+v, %v", appointment, err)
+		}
+	})
+
+	t.Run("cancel for an erased patient", func(t *testing.T) {
+		expectAppointment(&domain.Patient{ID: "p1", ErasedAt: &erasedAt})
+
+		if _, err := service.CancelAppointment(caller, "a1"); !errors.Is(err, domain.ErrPatientAlreadyErased) {
+			t.Errorf("CancelAppointment() expected ErrPatientAlreadyErased, got %v", err)
+		}
+	})
+}
+
+func TestAppointmentService_GetAgenda(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewAppointmentService(mockRepo, mocks.NewMockPatientRepository(ctrl), mocks.NewMockUserRepository(ctrl), mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	date := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	t.Run("own weekly agenda", func(t *testing.T) {
+		from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+		mockRepo.EXPECT().GetAgenda(caller.UserID, from, from.AddDate(0, 0, 7)).Return([]domain.Appointment{{ID: "a1", PatientID: "p1"}}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+
+		appointments, err := service.GetAgenda(caller, caller.UserID, date, domain.AgendaViewWeek)
+		if err != nil || len(appointments) != 1 {
+			t.Errorf("GetAgenda() = %+v, %v", appointments, err)
+		}
+	})
+
+	t.Run("another practitioner's agenda", func(t *testing.T) {
+		if _, err := service.GetAgenda(caller, "other-id", date, domain.AgendaViewDay); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("GetAgenda() expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
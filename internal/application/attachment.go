@@ -0,0 +1,143 @@
+package application
+
+import (
+	"io"
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type AttachmentService struct {
+	repo        domain.AttachmentRepository
+	blobs       domain.BlobStorage
+	patientRepo domain.PatientRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewAttachmentService(repo domain.AttachmentRepository, blobs domain.BlobStorage, patientRepo domain.PatientRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *AttachmentService {
+	return &AttachmentService{
+		repo:        repo,
+		blobs:       blobs,
+		patientRepo: patientRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+func (s *AttachmentService) AddAttachment(caller domain.Caller, attachment *domain.Attachment, content io.Reader, expectedChecksum string) error {
+	// Reject what can be rejected before reading the content
+	if expectedChecksum != "" {
+		if err := domain.ValidateChecksum(expectedChecksum); err != nil {
+			slog.Warn("Invalid attachment checksum", "diagnosis_id", attachment.DiagnosisID)
+			return err
+		}
+	}
+	if attachment.FileName == "" {
+		return domain.ErrEmptyAttachmentName
+	}
+	if !domain.AllowedAttachmentType(attachment.ContentType) {
+		slog.Warn("Attachment type not allowed", "diagnosis_id", attachment.DiagnosisID, "content_type", attachment.ContentType)
+		return domain.ErrUnsupportedAttachmentType
+	}
+
+	diagnosis, err := s.patientRepo.GetDiagnosisByID(attachment.DiagnosisID)
+	if err != nil {
+		slog.Warn("Attachment rejected: diagnosis lookup failed", "diagnosis_id", attachment.DiagnosisID, "error", err)
+		return err
+	}
+	if err := s.access.authorize(caller, diagnosis.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+	if err := checkActivePatient(s.patientRepo, diagnosis.PatientID); err != nil {
+		return err
+	}
+
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for attachment", "error", errCreateID)
+		return errCreateID
+	}
+
+	stored, err := s.blobs.Put(id, &sizeLimitedReader{r: content, remaining: domain.MaxAttachmentSize}, expectedChecksum)
+	if err != nil {
+		slog.Warn("Attachment content storage failed", "diagnosis_id", attachment.DiagnosisID, "error", err)
+		return err
+	}
+
+	attachment.ID = id
+	attachment.PatientID = diagnosis.PatientID
+	attachment.Size = stored.Size
+	attachment.Checksum = stored.Checksum
+	attachment.ContentKey = stored.Key
+	attachment.UploadedBy = caller.UserID
+	attachment.CreatedAt = time.Now()
+
+	// Enforce domain invariants
+	if errValidate := attachment.Validate(); errValidate != nil {
+		slog.Warn("Attachment validation failed", "diagnosis_id", attachment.DiagnosisID, "error", errValidate)
+		discardBlob(s.blobs, id)
+		return errValidate
+	}
+
+	if err := s.repo.CreateAttachment(attachment); err != nil {
+		slog.Error("Attachment creation in repository failed", "diagnosis_id", attachment.DiagnosisID, "error", err)
+		discardBlob(s.blobs, id)
+		return err
+	}
+
+	slog.Info("Attachment added", "attachment_id", attachment.ID, "diagnosis_id", attachment.DiagnosisID, "size", stored.Size)
+	return nil
+}
+
+func (s *AttachmentService) OpenAttachment(caller domain.Caller, diagnosisID, attachmentID string) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetAttachmentByID(attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Attachments are only reachable through their own diagnosis
+	if attachment.DiagnosisID != diagnosisID {
+		return nil, nil, domain.ErrAttachmentNotFound
+	}
+
+	if err := s.access.authorizeClinicalRead(caller, attachment.PatientID); err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(attachment.ID, attachment.ContentKey, attachment.Checksum)
+	if err != nil {
+		slog.Error("Attachment content could not be opened", "attachment_id", attachmentID, "error", err)
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// discardBlob deletes the stored content of an attachment. Each attachment
+// has its own, so no other attachment is affected.
+func discardBlob(blobs domain.BlobStorage, attachmentID string) {
+	if err := blobs.Delete(attachmentID); err != nil {
+		slog.Error("Blob deletion failed", "attachment_id", attachmentID, "error", err)
+	}
+}
+
+// sizeLimitedReader fails with ErrAttachmentTooLarge as soon as more than the
+// allowed bytes are read, so oversized uploads are never stored whole
+type sizeLimitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, domain.ErrAttachmentTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, domain.ErrAttachmentTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAttachmentService_AddAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockBlobs := mocks.NewMockBlobStorage(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewAttachmentService(mockRepo, mockBlobs, mockPatientRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	checksum := strings.Repeat("ab", 32)
+
+	expectAllowedUpload := func() {
+		mockPatientRepo.EXPECT().GetDiagnosisByID("d1").Return(&domain.Diagnosis{ID: "d1", PatientID: "p1"}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockSupport.EXPECT().CreateNewID().Return("attachment-id", nil)
+	}
+	storeContent := func(_ string, content io.Reader, _ string) (*domain.StoredBlob, error) {
+		n, err := io.Copy(io.Discard, content)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.StoredBlob{Key: []byte("key"), Checksum: checksum, Size: n}, nil
+	}
+	newAttachment := func() *domain.Attachment {
+		return &domain.Attachment{DiagnosisID: "d1", FileName: "informe.pdf", ContentType: domain.AttachmentTypePDF}
+	}
+
+	t.Run("successful upload", func(t *testing.T) {
+		expectAllowedUpload()
+		mockBlobs.EXPECT().Put("attachment-id", gomock.Any(), "").DoAndReturn(storeContent)
+		mockRepo.EXPECT().CreateAttachment(gomock.Any()).Return(nil)
+
+		attachment := newAttachment()
+		if err := service.AddAttachment(caller, attachment, strings.NewReader("%PDF-1.7"), ""); err != nil {
+			t.Fatalf("AddAttachment() unexpected error = %v", err)
+		}
+		if attachment.ID != "attachment-id" || attachment.PatientID != "p1" || attachment.Size != 8 || attachment.Checksum != checksum || string(attachment.ContentKey) != "key" {
+			t.Errorf("AddAttachment() = %+v", attachment)
+		}
+	})
+
+	t.Run("type not allowed is rejected before reading", func(t *testing.T) {
+		attachment := newAttachment()
+		attachment.ContentType = "text/html"
+		if err := service.AddAttachment(caller, attachment, strings.NewReader("<html>"), ""); !errors.Is(err, domain.ErrUnsupportedAttachmentType) {
+			t.Errorf("AddAttachment() expected ErrUnsupportedAttachmentType, got %v", err)
+		}
+	})
+
+	t.Run("oversized content", func(t *testing.T) {
+		expectAllowedUpload()
+		mockBlobs.EXPECT().Put("attachment-id", gomock.Any(), "").DoAndReturn(storeContent)
+
+		content := strings.NewReader(strings.Repeat("a", domain.MaxAttachmentSize+1))
+		if err := service.AddAttachment(caller, newAttachment(), content, ""); !errors.Is(err, domain.ErrAttachmentTooLarge) {
+			t.Errorf("AddAttachment() expected ErrAttachmentTooLarge, got %v", err)
+		}
+	})
+
+	t.Run("stored content is released when the metadata is not saved", func(t *testing.T) {
+		expectAllowedUpload()
+		mockBlobs.EXPECT().Put("attachment-id", gomock.Any(), checksum).DoAndReturn(storeContent)
+		mockRepo.EXPECT().CreateAttachment(gomock.Any()).Return(errors.New("db error"))
+		mockBlobs.EXPECT().Delete("attachment-id").Return(nil)
+
+		if err := service.AddAttachment(caller, newAttachment(), strings.NewReader("%PDF-1.7"), checksum); err == nil {
+			t.Error("AddAttachment() expected error, got nil")
+		}
+	})
+
+	t.Run("invalid checksum", func(t *testing.T) {
+		if err := service.AddAttachment(caller, newAttachment(), strings.NewReader("%PDF-1.7"), "md5:abc"); !errors.Is(err, domain.ErrInvalidChecksum) {
+			t.Errorf("AddAttachment() expected ErrInvalidChecksum, got %v", err)
+		}
+	})
+}
+
+func TestAttachmentService_OpenAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockBlobs := mocks.NewMockBlobStorage(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewAttachmentService(mockRepo, mockBlobs, mocks.NewMockPatientRepository(ctrl), mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	stored := &domain.Attachment{ID: "a1", DiagnosisID: "d1", PatientID: "p1", Checksum: strings.Repeat("ab", 32), ContentKey: []byte("key")}
+
+	t.Run("successful download", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttachmentByID("a1").Return(stored, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockBlobs.EXPECT().Open("a1", stored.ContentKey, stored.Checksum).Return(io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+
+		attachment, content, err := service.OpenAttachment(caller, "d1", "a1")
+		if err != nil {
+			t.Fatalf("OpenAttachment() unexpected error = %v", err)
+		}
+		defer content.Close()
+		if attachment.ID != "a1" {
+			t.Errorf("OpenAttachment() = %+v", attachment)
+		}
+	})
+
+	t.Run("attachment of another diagnosis", func(t *testing.T) {
+		mockRepo.EXPECT().GetAttachmentByID("a1").Return(stored, nil)
+
+		if _, _, err := service.OpenAttachment(caller, "d2", "a1"); !errors.Is(err, domain.ErrAttachmentNotFound) {
+			t.Errorf("OpenAttachment() expected ErrAttachmentNotFound, got %v", err)
+		}
+	})
+}
@@ -68,6 +68,7 @@ func (s *AuthService) Register(username, password string) error {
 		ID:       id,
 		Username: username,
 		Password: string(hashedPassword),
+		Role:     domain.RolePractitioner,
 	}
 
 	err = s.userRepo.CreateUser(user)
@@ -88,3 +89,63 @@ func (s *AuthService) ValidateToken(tokenString string) error {
 
 	return nil
 }
+
+// CurrentRole reads the role of a user from the repository. Tokens stay valid
+// for days, so role changes must not wait for them to expire.
+func (s *AuthService) CurrentRole(userID string) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		slog.Warn("Role lookup failed: user not found", "user_id", userID)
+		return "", domain.ErrInvalidCredentials
+	}
+
+	// Users created before roles existed have none
+	if user.Role == "" {
+		return domain.RolePractitioner, nil
+	}
+	return user.Role, nil
+}
+
+func (s *AuthService) SetRole(username, role string) error {
+	if !domain.ValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		slog.Warn("Role change failed: user not found", "username", username)
+		return err
+	}
+
+	if err := s.userRepo.UpdateUserRole(user.ID, role); err != nil {
+		slog.Error("Role update in repository failed", "username", username, "error", err)
+		return err
+	}
+
+	slog.Info("User role updated", "username", username, "role", role)
+	return nil
+}
+
+// SetSpecialty assigns a specialty to a user, so referrals to it reach them
+func (s *AuthService) SetSpecialty(username, specialty string) error {
+	if specialty != "" && !domain.ValidSpecialty(specialty) {
+		return domain.ErrUnknownSpecialty
+	}
+
+	user, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		slog.Warn("Specialty change failed: user not found", "username", username)
+		return err
+	}
+	if user.Role == domain.RoleIntegration {
+		return domain.ErrInvalidReferralRecipient
+	}
+
+	if err := s.userRepo.UpdateUserSpecialty(user.ID, specialty); err != nil {
+		slog.Error("Specialty update in repository failed", "username", username, "error", err)
+		return err
+	}
+
+	slog.Info("User specialty updated", "username", username, "specialty", specialty)
+	return nil
+}
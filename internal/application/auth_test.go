@@ -98,3 +98,32 @@ func TestAuthService_Register(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthService_CurrentRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	service := NewAuthService(mockRepo, mocks.NewMockSupport(ctrl), &config.Config{})
+
+	t.Run("role of the user", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID("user-id").Return(&domain.User{ID: "user-id", Role: domain.RoleAdmin}, nil)
+		if role, err := service.CurrentRole("user-id"); err != nil || role != domain.RoleAdmin {
+			t.Errorf("CurrentRole() = %q, %v, want %q", role, err, domain.RoleAdmin)
+		}
+	})
+
+	t.Run("user without role", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID("user-id").Return(&domain.User{ID: "user-id"}, nil)
+		if role, err := service.CurrentRole("user-id"); err != nil || role != domain.RolePractitioner {
+			t.Errorf("CurrentRole() = %q, %v, want %q", role, err, domain.RolePractitioner)
+		}
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		mockRepo.EXPECT().GetByID("gone").Return(nil, errors.New("not found"))
+		if _, err := service.CurrentRole("gone"); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Errorf("CurrentRole() expected ErrInvalidCredentials, got %v", err)
+		}
+	})
+}
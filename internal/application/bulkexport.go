@@ -0,0 +1,308 @@
+package application
+
+import (
+	"io"
+	"log/slog"
+	"runtime/debug"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type BulkExportService struct {
+	repo    domain.BulkExportRepository
+	files   domain.BulkExportStorage
+	access  *accessGuard
+	support domain.Support
+	// background runs a job once kicked off, in its own goroutine
+	background func(job func())
+}
+
+func NewBulkExportService(repo domain.BulkExportRepository, files domain.BulkExportStorage, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *BulkExportService {
+	return &BulkExportService{
+		repo:       repo,
+		files:      files,
+		access:     newAccessGuard(careTeamRepo, consentRepo, support),
+		support:    support,
+		background: func(job func()) { go job() },
+	}
+}
+
+// StartExport records the job and returns while it runs. Bulk exports serve
+// partners, so they are restricted to integration clients and only hold what
+// each patient consented to share with them.
+func (s *BulkExportService) StartExport(caller domain.Caller, types []string, since *time.Time) (*domain.BulkExportJob, error) {
+	if !caller.IsIntegration() {
+		slog.Warn("Bulk export rejected: caller is not an integration client", "user_id", caller.UserID)
+		return nil, domain.ErrIntegrationRequired
+	}
+
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for bulk export job", "error", errCreateID)
+		return nil, errCreateID
+	}
+	if len(types) == 0 {
+		types = domain.BulkExportTypes
+	}
+	job := &domain.BulkExportJob{
+		ID:          id,
+		RequestedBy: caller.UserID,
+		Types:       types,
+		Since:       since,
+		Status:      domain.BulkExportStatusInProgress,
+		RequestedAt: time.Now(),
+	}
+
+	// Enforce domain invariants
+	if errValidate := job.Validate(); errValidate != nil {
+		slog.Warn("Bulk export job validation failed", "error", errValidate)
+		return nil, errValidate
+	}
+
+	key, err := s.files.Create(job.ID)
+	if err != nil {
+		slog.Error("Bulk export storage creation failed", "job_id", job.ID, "error", err)
+		return nil, err
+	}
+	job.ContentKey = key
+
+	if err := s.repo.CreateBulkExportJob(job); err != nil {
+		slog.Error("Bulk export job creation in repository failed", "error", err)
+		if errDelete := s.files.Delete(job.ID); errDelete != nil {
+			slog.Error("Failed to remove the files of a bulk export not created", "job_id", job.ID, "error", errDelete)
+		}
+		return nil, err
+	}
+
+	slog.Info("Bulk export started", "job_id", job.ID, "user_id", caller.UserID, "types", job.Types, "since", job.Since)
+	running := *job
+	s.background(func() { s.run(caller, &running) })
+	return job, nil
+}
+
+// run exports the job and records how it ended. The files of a failed job are
+// removed, a partial export is never served.
+//
+// Erasing a patient withdraws the completed exports holding them. A patient
+// erased while the job ran is caught once it completed: either the erasure
+// finds the job completed, or the job finds the patient erased.
+func (s *BulkExportService) run(caller domain.Caller, job *domain.BulkExportJob) {
+	output, err := s.exportRecovering(caller, job)
+	if err != nil {
+		slog.Error("Bulk export failed", "job_id", job.ID, "error", err)
+		failBulkExport(s.repo, s.files, job, err)
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(domain.BulkExportRetention)
+	job.Status = domain.BulkExportStatusCompleted
+	job.Output = output
+	job.CompletedAt = &now
+	job.ExpiresAt = &expiresAt
+	if err := s.repo.UpdateBulkExportJob(job); err != nil {
+		slog.Error("Bulk export job update in repository failed", "job_id", job.ID, "error", err)
+		return
+	}
+
+	erased, err := s.repo.HasErasedBulkExportPatients(job.ID)
+	if err != nil {
+		slog.Error("Erasure lookup failed for bulk export", "job_id", job.ID, "error", err)
+		failBulkExport(s.repo, s.files, job, err)
+		return
+	}
+	if erased {
+		slog.Info("Bulk export withdrawn: a patient was erased while it ran", "job_id", job.ID)
+		failBulkExport(s.repo, s.files, job, domain.ErrBulkExportWithdrawn)
+		return
+	}
+	slog.Info("Bulk export finished", "job_id", job.ID, "status", job.Status, "output", job.Output)
+}
+
+// exportRecovering is export turning a panic into a failed job. Jobs run in a
+// goroutine of their own, a panic would otherwise bring the server down.
+func (s *BulkExportService) exportRecovering(caller domain.Caller, job *domain.BulkExportJob) (output []domain.BulkExportFile, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Bulk export panicked", "job_id", job.ID, "panic", r, "stack", string(debug.Stack()))
+			output, err = nil, domain.ErrBulkExportInterrupted
+		}
+	}()
+	return s.export(caller, job)
+}
+
+// failBulkExport records the job as failed and removes its files
+func failBulkExport(repo domain.BulkExportRepository, files domain.BulkExportStorage, job *domain.BulkExportJob, cause error) {
+	now := time.Now()
+	job.Status = domain.BulkExportStatusFailed
+	job.Error = cause.Error()
+	job.Output = nil
+	job.CompletedAt = &now
+	job.ExpiresAt = nil
+	if err := files.Delete(job.ID); err != nil {
+		slog.Error("Failed to remove the files of a failed bulk export", "job_id", job.ID, "error", err)
+	}
+	if err := repo.UpdateBulkExportJob(job); err != nil {
+		slog.Error("Bulk export job update in repository failed", "job_id", job.ID, "error", err)
+	}
+}
+
+func (s *BulkExportService) FailInterruptedExports() (int, error) {
+	jobs, err := s.repo.GetBulkExportJobsByStatus(domain.BulkExportStatusInProgress)
+	if err != nil {
+		return 0, err
+	}
+	for i := range jobs {
+		slog.Warn("Bulk export interrupted by a restart", "job_id", jobs[i].ID)
+		failBulkExport(s.repo, s.files, &jobs[i], domain.ErrBulkExportInterrupted)
+	}
+	return len(jobs), nil
+}
+
+// export writes the file of every resource type of the job and logs an export
+// access for each patient included. The patients of a batch are stored with
+// the job before it is written, so an erasure finds every export that may
+// hold them.
+func (s *BulkExportService) export(caller domain.Caller, job *domain.BulkExportJob) ([]domain.BulkExportFile, error) {
+	exported := make(map[string]bool)
+	include := func(patientIDs []string) error {
+		var added []string
+		for _, id := range patientIDs {
+			if !exported[id] {
+				exported[id] = true
+				added = append(added, id)
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		if err := s.repo.AddBulkExportPatients(job.ID, added); err != nil {
+			return err
+		}
+		for _, id := range added {
+			s.access.record(caller, id, domain.AccessActionExport, false)
+		}
+		return nil
+	}
+
+	var output []domain.BulkExportFile
+	for _, resourceType := range job.Types {
+		count := 0
+		var err error
+		switch resourceType {
+		case domain.BulkExportTypePatient:
+			err = s.repo.ExportPatients(caller, job.Since, func(patients []domain.Patient) error {
+				ids := make([]string, len(patients))
+				for i, p := range patients {
+					ids[i] = p.ID
+				}
+				if err := include(ids); err != nil {
+					return err
+				}
+				if err := s.files.AppendPatients(job.ID, job.ContentKey, patients); err != nil {
+					return err
+				}
+				count += len(patients)
+				return nil
+			})
+		case domain.BulkExportTypeCondition:
+			err = s.repo.ExportDiagnoses(caller, job.Since, func(diagnoses []domain.Diagnosis) error {
+				diagnoses, err := s.access.consent.redactDiagnostics(diagnoses, domain.ConsentPurposeThirdPartySharing)
+				if err != nil || len(diagnoses) == 0 {
+					return err
+				}
+				ids := make([]string, len(diagnoses))
+				for i, d := range diagnoses {
+					ids[i] = d.PatientID
+				}
+				if err := include(ids); err != nil {
+					return err
+				}
+				if err := s.files.AppendConditions(job.ID, job.ContentKey, diagnoses); err != nil {
+					return err
+				}
+				count += len(diagnoses)
+				return nil
+			})
+		default:
+			err = domain.ErrUnsupportedBulkExportType
+		}
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			output = append(output, domain.BulkExportFile{Type: resourceType, Count: count})
+		}
+	}
+	return output, nil
+}
+
+// GetExportJob returns a job to the client that kicked it off. To anyone else,
+// and once past its retention, it does not exist.
+func (s *BulkExportService) GetExportJob(caller domain.Caller, id string) (*domain.BulkExportJob, error) {
+	job, err := s.repo.GetBulkExportJobByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.RequestedBy != caller.UserID {
+		slog.Warn("Bulk export job requested by another client", "job_id", id, "user_id", caller.UserID)
+		return nil, domain.ErrBulkExportJobNotFound
+	}
+	if job.Expired(time.Now()) {
+		return nil, domain.ErrBulkExportJobNotFound
+	}
+
+	if job.Status == domain.BulkExportStatusCompleted && len(job.ContentKey) == 0 {
+		// Written before exports were encrypted, its files are left to the purge
+		slog.Warn("Unencrypted bulk export requested", "job_id", id)
+		return nil, domain.ErrBulkExportJobNotFound
+	}
+	return job, nil
+}
+
+func (s *BulkExportService) OpenExportFile(caller domain.Caller, id, resourceType string) (io.ReadCloser, error) {
+	job, err := s.GetExportJob(caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != domain.BulkExportStatusCompleted {
+		return nil, domain.ErrBulkExportNotCompleted
+	}
+	if !job.HasFile(resourceType) {
+		return nil, domain.ErrBulkExportFileNotFound
+	}
+	return s.files.Open(job.ID, job.ContentKey, resourceType)
+}
+
+func (s *BulkExportService) PurgeExpiredExports(at time.Time) (int, error) {
+	jobs, err := s.repo.GetExpiredBulkExportJobs(at)
+	if err != nil {
+		return 0, err
+	}
+	for i, job := range jobs {
+		if err := s.files.Delete(job.ID); err != nil {
+			slog.Error("Failed to remove the files of an expired bulk export", "job_id", job.ID, "error", err)
+			return i, err
+		}
+		if err := s.repo.DeleteBulkExportJob(job.ID); err != nil {
+			slog.Error("Bulk export job deletion in repository failed", "job_id", job.ID, "error", err)
+			return i, err
+		}
+		slog.Info("Expired bulk export removed", "job_id", job.ID)
+	}
+	return len(jobs), nil
+}
+
+// withdrawBulkExports fails the completed exports holding an erased patient,
+// removing their files. It returns the number of exports withdrawn.
+func withdrawBulkExports(repo domain.BulkExportRepository, files domain.BulkExportStorage, patientID string) (int, error) {
+	jobs, err := repo.GetBulkExportJobsWithPatient(patientID, domain.BulkExportStatusCompleted)
+	if err != nil {
+		return 0, err
+	}
+	for i := range jobs {
+		slog.Info("Bulk export withdrawn after an erasure", "job_id", jobs[i].ID, "patient_id", patientID)
+		failBulkExport(repo, files, &jobs[i], domain.ErrBulkExportWithdrawn)
+	}
+	return len(jobs), nil
+}
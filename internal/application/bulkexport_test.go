@@ -0,0 +1,282 @@
+package application
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestBulkExportService_StartExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockBulkExportRepository(ctrl)
+	mockFiles := mocks.NewMockBulkExportStorage(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewBulkExportService(mockRepo, mockFiles, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	// Run the jobs before StartExport returns
+	service.background = func(job func()) { job() }
+	caller := domain.Caller{UserID: "partner", Role: domain.RoleIntegration}
+	key := []byte("job-key")
+
+	t.Run("successful export", func(t *testing.T) {
+		since := time.Now().Add(-24 * time.Hour)
+		mockSupport.EXPECT().CreateNewID().Return("job-id", nil)
+		mockFiles.EXPECT().Create("job-id").Return(key, nil)
+		mockRepo.EXPECT().CreateBulkExportJob(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().ExportPatients(caller, &since, gomock.Any()).DoAndReturn(
+			func(_ domain.Caller, _ *time.Time, fn func([]domain.Patient) error) error {
+				return fn([]domain.Patient{{ID: "p1"}})
+			})
+		mockRepo.EXPECT().AddBulkExportPatients("job-id", []string{"p1"}).Return(nil)
+		mockFiles.EXPECT().AppendPatients("job-id", key, []domain.Patient{{ID: "p1"}}).Return(nil)
+		mockRepo.EXPECT().ExportDiagnoses(caller, &since, gomock.Any()).DoAndReturn(
+			func(_ domain.Caller, _ *time.Time, fn func([]domain.Diagnosis) error) error {
+				return fn([]domain.Diagnosis{{ID: "d1", PatientID: "p1"}, {ID: "d2", PatientID: "p2"}})
+			})
+		// p2 withdrew its consent since the diagnoses were read
+		mockConsentRepo.EXPECT().GetConsentsByPatientID("p1").Return([]domain.Consent{{PatientID: "p1",
+			Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeAll, GrantedAt: since}}, nil)
+		mockConsentRepo.EXPECT().GetConsentsByPatientID("p2").Return(nil, nil)
+		mockFiles.EXPECT().AppendConditions("job-id", key, gomock.Len(1)).Return(nil)
+		// One export access logged per patient
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).DoAndReturn(func(entry *domain.AccessLogEntry) error {
+			if entry.PatientID != "p1" || entry.Action != domain.AccessActionExport {
+				t.Errorf("unexpected access log entry %+v", entry)
+			}
+			return nil
+		})
+		mockRepo.EXPECT().UpdateBulkExportJob(gomock.Any()).DoAndReturn(func(job *domain.BulkExportJob) error {
+			want := []domain.BulkExportFile{{Type: domain.BulkExportTypePatient, Count: 1}, {Type: domain.BulkExportTypeCondition, Count: 1}}
+			if job.Status != domain.BulkExportStatusCompleted || job.CompletedAt == nil || job.ExpiresAt == nil || len(job.Output) != 2 || job.Output[0] != want[0] || job.Output[1] != want[1] {
+				t.Errorf("UpdateBulkExportJob() with %+v", job)
+			}
+			return nil
+		})
+		mockRepo.EXPECT().HasErasedBulkExportPatients("job-id").Return(false, nil)
+
+		job, err := service.StartExport(caller, nil, &since)
+		if err != nil {
+			t.Fatalf("StartExport() unexpected error = %v", err)
+		}
+		if job.ID != "job-id" || job.RequestedBy != caller.UserID || job.Status != domain.BulkExportStatusInProgress || len(job.Types) != 2 ||
+			string(job.ContentKey) != string(key) {
+			t.Errorf("StartExport() = %+v", job)
+		}
+	})
+
+	t.Run("patient erased while the export ran", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("job-id", nil)
+		mockFiles.EXPECT().Create("job-id").Return(key, nil)
+		mockRepo.EXPECT().CreateBulkExportJob(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().ExportPatients(caller, nil, gomock.Any()).DoAndReturn(
+			func(_ domain.Caller, _ *time.Time, fn func([]domain.Patient) error) error {
+				return fn([]domain.Patient{{ID: "p1"}})
+			})
+		mockRepo.EXPECT().AddBulkExportPatients("job-id", []string{"p1"}).Return(nil)
+		mockFiles.EXPECT().AppendPatients("job-id", key, gomock.Len(1)).Return(nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		completed := mockRepo.EXPECT().UpdateBulkExportJob(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().HasErasedBulkExportPatients("job-id").Return(true, nil).After(completed)
+		mockFiles.EXPECT().Delete("job-id").Return(nil)
+		mockRepo.EXPECT().UpdateBulkExportJob(gomock.Any()).DoAndReturn(func(job *domain.BulkExportJob) error {
+			if job.Status != domain.BulkExportStatusFailed || job.Output != nil || job.Error != domain.ErrBulkExportWithdrawn.Error() {
+				t.Errorf("UpdateBulkExportJob() with %+v", job)
+			}
+			return nil
+		})
+
+		if _, err := service.StartExport(caller, []string{domain.BulkExportTypePatient}, nil); err != nil {
+			t.Fatalf("StartExport() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("job not stored leaves no file", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("job-id", nil)
+		mockFiles.EXPECT().Create("job-id").Return(key, nil)
+		mockRepo.EXPECT().CreateBulkExportJob(gomock.Any()).Return(errors.New("database is locked"))
+		mockFiles.EXPECT().Delete("job-id").Return(nil)
+
+		if _, err := service.StartExport(caller, nil, nil); err == nil {
+			t.Fatal("StartExport() expected error, got nil")
+		}
+	})
+
+	t.Run("failed export leaves no file", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("job-id", nil)
+		mockFiles.EXPECT().Create("job-id").Return(key, nil)
+		mockRepo.EXPECT().CreateBulkExportJob(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().ExportDiagnoses(caller, nil, gomock.Any()).Return(errors.New("disk full"))
+		mockFiles.EXPECT().Delete("job-id").Return(nil)
+		mockRepo.EXPECT().UpdateBulkExportJob(gomock.Any()).DoAndReturn(func(job *domain.BulkExportJob) error {
+			if job.Status != domain.BulkExportStatusFailed || job.Error != "disk full" || len(job.Output) != 0 {
+				t.Errorf("UpdateBulkExportJob() with %+v", job)
+			}
+			return nil
+		})
+
+		if _, err := service.StartExport(caller, []string{domain.BulkExportTypeCondition}, nil); err != nil {
+			t.Fatalf("StartExport() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("panicking export fails the job", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("job-id", nil)
+		mockFiles.EXPECT().Create("job-id").Return(key, nil)
+		mockRepo.EXPECT().CreateBulkExportJob(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().ExportDiagnoses(caller, nil, gomock.Any()).DoAndReturn(
+			func(_ domain.Caller, _ *time.Time, _ func([]domain.Diagnosis) error) error {
+				panic("unexpected")
+			})
+		mockFiles.EXPECT().Delete("job-id").Return(nil)
+		mockRepo.EXPECT().UpdateBulkExportJob(gomock.Any()).DoAndReturn(func(job *domain.BulkExportJob) error {
+			if job.Status != domain.BulkExportStatusFailed || job.Error != domain.ErrBulkExportInterrupted.Error() {
+				t.Errorf("UpdateBulkExportJob() with %+v", job)
+			}
+			return nil
+		})
+
+		if _, err := service.StartExport(caller, []string{domain.BulkExportTypeCondition}, nil); err != nil {
+			t.Fatalf("StartExport() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("practitioner", func(t *testing.T) {
+		practitioner := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+		if _, err := service.StartExport(practitioner, nil, nil); !errors.Is(err, domain.ErrIntegrationRequired) {
+			t.Errorf("StartExport() expected ErrIntegrationRequired, got %v", err)
+		}
+	})
+}
+
+func TestBulkExportService_OpenExportFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockBulkExportRepository(ctrl)
+	mockFiles := mocks.NewMockBulkExportStorage(ctrl)
+	service := NewBulkExportService(mockRepo, mockFiles, mocks.NewMockCareTeamRepository(ctrl), mocks.NewMockConsentRepository(ctrl), mocks.NewMockSupport(ctrl))
+	caller := domain.Caller{UserID: "partner", Role: domain.RoleIntegration}
+
+	completed := &domain.BulkExportJob{ID: "job-id", RequestedBy: caller.UserID, Status: domain.BulkExportStatusCompleted, ContentKey: []byte("job-key"),
+		Output: []domain.BulkExportFile{{Type: domain.BulkExportTypePatient, Count: 1}}}
+
+	t.Run("successful download", func(t *testing.T) {
+		mockRepo.EXPECT().GetBulkExportJobByID("job-id").Return(completed, nil)
+		mockFiles.EXPECT().Open("job-id", completed.ContentKey, domain.BulkExportTypePatient).Return(io.NopCloser(strings.NewReader("{}\n")), nil)
+
+		file, err := service.OpenExportFile(caller, "job-id", domain.BulkExportTypePatient)
+		if err != nil {
+			t.Fatalf("OpenExportFile() unexpected error = %v", err)
+		}
+		file.Close()
+	})
+
+	t.Run("type without resources", func(t *testing.T) {
+		mockRepo.EXPECT().GetBulkExportJobByID("job-id").Return(completed, nil)
+		if _, err := service.OpenExportFile(caller, "job-id", domain.BulkExportTypeCondition); !errors.Is(err, domain.ErrBulkExportFileNotFound) {
+			t.Errorf("OpenExportFile() expected ErrBulkExportFileNotFound, got %v", err)
+		}
+	})
+
+	t.Run("job still running", func(t *testing.T) {
+		mockRepo.EXPECT().GetBulkExportJobByID("job-id").Return(&domain.BulkExportJob{ID: "job-id", RequestedBy: caller.UserID, Status: domain.BulkExportStatusInProgress}, nil)
+		if _, err := service.OpenExportFile(caller, "job-id", domain.BulkExportTypePatient); !errors.Is(err, domain.ErrBulkExportNotCompleted) {
+			t.Errorf("OpenExportFile() expected ErrBulkExportNotCompleted, got %v", err)
+		}
+	})
+
+	t.Run("job of another client", func(t *testing.T) {
+		mockRepo.EXPECT().GetBulkExportJobByID("job-id").Return(completed, nil)
+		other := domain.Caller{UserID: "other-partner", Role: domain.RoleIntegration}
+		if _, err := service.OpenExportFile(other, "job-id", domain.BulkExportTypePatient); !errors.Is(err, domain.ErrBulkExportJobNotFound) {
+			t.Errorf("OpenExportFile() expected ErrBulkExportJobNotFound, got %v", err)
+		}
+	})
+
+	t.Run("job past its retention", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		mockRepo.EXPECT().GetBulkExportJobByID("job-id").Return(&domain.BulkExportJob{ID: "job-id", RequestedBy: caller.UserID,
+			Status: domain.BulkExportStatusCompleted, ExpiresAt: &expiresAt, Output: completed.Output}, nil)
+		if _, err := service.OpenExportFile(caller, "job-id", domain.BulkExportTypePatient); !errors.Is(err, domain.ErrBulkExportJobNotFound) {
+			t.Errorf("OpenExportFile() expected ErrBulkExportJobNotFound, got %v", err)
+		}
+	})
+
+	t.Run("job written before exports were encrypted", func(t *testing.T) {
+		mockRepo.EXPECT().GetBulkExportJobByID("job-id").Return(&domain.BulkExportJob{ID: "job-id", RequestedBy: caller.UserID,
+			Status: domain.BulkExportStatusCompleted, Output: completed.Output}, nil)
+
+		if _, err := service.OpenExportFile(caller, "job-id", domain.BulkExportTypePatient); !errors.Is(err, domain.ErrBulkExportJobNotFound) {
+			t.Errorf("OpenExportFile() expected ErrBulkExportJobNotFound, got %v", err)
+		}
+	})
+}
+
+func TestBulkExportService_FailInterruptedExports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockBulkExportRepository(ctrl)
+	mockFiles := mocks.NewMockBulkExportStorage(ctrl)
+	service := NewBulkExportService(mockRepo, mockFiles, mocks.NewMockCareTeamRepository(ctrl), mocks.NewMockConsentRepository(ctrl), mocks.NewMockSupport(ctrl))
+
+	mockRepo.EXPECT().GetBulkExportJobsByStatus(domain.BulkExportStatusInProgress).Return([]domain.BulkExportJob{
+		{ID: "job-id", RequestedBy: "partner", Status: domain.BulkExportStatusInProgress},
+	}, nil)
+	mockFiles.EXPECT().Delete("job-id").Return(nil)
+	mockRepo.EXPECT().UpdateBulkExportJob(gomock.Any()).DoAndReturn(func(job *domain.BulkExportJob) error {
+		if job.ID != "job-id" || job.Status != domain.BulkExportStatusFailed || job.CompletedAt == nil {
+			t.Errorf("UpdateBulkExportJob() with %+v", job)
+		}
+		return nil
+	})
+
+	failed, err := service.FailInterruptedExports()
+	if err != nil || failed != 1 {
+		t.Errorf("FailInterruptedExports() = %d, %v", failed, err)
+	}
+}
+
+func TestBulkExportService_PurgeExpiredExports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockBulkExportRepository(ctrl)
+	mockFiles := mocks.NewMockBulkExportStorage(ctrl)
+	service := NewBulkExportService(mockRepo, mockFiles, mocks.NewMockCareTeamRepository(ctrl), mocks.NewMockConsentRepository(ctrl), mocks.NewMockSupport(ctrl))
+	now := time.Now()
+
+	t.Run("successful purge", func(t *testing.T) {
+		mockRepo.EXPECT().GetExpiredBulkExportJobs(now).Return([]domain.BulkExportJob{{ID: "job-id"}, {ID: "other-job-id"}}, nil)
+		mockFiles.EXPECT().Delete("job-id").Return(nil)
+		mockRepo.EXPECT().DeleteBulkExportJob("job-id").Return(nil)
+		mockFiles.EXPECT().Delete("other-job-id").Return(nil)
+		mockRepo.EXPECT().DeleteBulkExportJob("other-job-id").Return(nil)
+
+		purged, err := service.PurgeExpiredExports(now)
+		if err != nil || purged != 2 {
+			t.Errorf("PurgeExpiredExports() = %d, %v", purged, err)
+		}
+	})
+
+	t.Run("keeps the job when its files cannot be removed", func(t *testing.T) {
+		mockRepo.EXPECT().GetExpiredBulkExportJobs(now).Return([]domain.BulkExportJob{{ID: "job-id"}}, nil)
+		mockFiles.EXPECT().Delete("job-id").Return(errors.New("permission denied"))
+
+		purged, err := service.PurgeExpiredExports(now)
+		if err == nil || purged != 0 {
+			t.Errorf("PurgeExpiredExports() = %d, %v, want an error", purged, err)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"log/slog"
+	"strings"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/infrastructure/config"
+)
+
+type CalendarService struct {
+	repo            domain.CalendarFeedRepository
+	appointmentRepo domain.AppointmentRepository
+	cfg             *config.Config
+}
+
+func NewCalendarService(repo domain.CalendarFeedRepository, appointmentRepo domain.AppointmentRepository, cfg *config.Config) *CalendarService {
+	return &CalendarService{
+		repo:            repo,
+		appointmentRepo: appointmentRepo,
+		cfg:             cfg,
+	}
+}
+
+func (s *CalendarService) CreateFeed(caller domain.Caller, practitionerID string) (string, *domain.CalendarFeed, error) {
+	if caller.UserID != practitionerID && !caller.IsAdmin() {
+		slog.Warn("Calendar feed creation denied", "practitioner_id", practitionerID, "user_id", caller.UserID)
+		return "", nil, domain.ErrAccessDenied
+	}
+
+	nonce := make([]byte, 18)
+	if _, err := rand.Read(nonce); err != nil {
+		slog.Error("Nonce generation failed for calendar feed", "error", err)
+		return "", nil, err
+	}
+	feed := &domain.CalendarFeed{
+		PractitionerID: practitionerID,
+		Nonce:          base64.RawURLEncoding.EncodeToString(nonce),
+		CreatedAt:      time.Now(),
+	}
+
+	// Enforce domain invariants
+	if errValidate := feed.Validate(); errValidate != nil {
+		slog.Warn("Calendar feed validation failed", "error", errValidate)
+		return "", nil, errValidate
+	}
+
+	if err := s.repo.SaveCalendarFeed(feed); err != nil {
+		slog.Error("Calendar feed creation in repository failed", "practitioner_id", practitionerID, "error", err)
+		return "", nil, err
+	}
+
+	slog.Info("Calendar feed issued", "practitioner_id", practitionerID, "user_id", caller.UserID)
+	return feed.Nonce + "." + s.sign(feed), feed, nil
+}
+
+func (s *CalendarService) RevokeFeed(caller domain.Caller, practitionerID string) error {
+	if caller.UserID != practitionerID && !caller.IsAdmin() {
+		slog.Warn("Calendar feed revocation denied", "practitioner_id", practitionerID, "user_id", caller.UserID)
+		return domain.ErrAccessDenied
+	}
+
+	if err := s.repo.DeleteCalendarFeed(practitionerID); err != nil {
+		slog.Error("Calendar feed deletion in repository failed", "practitioner_id", practitionerID, "error", err)
+		return err
+	}
+
+	slog.Info("Calendar feed revoked", "practitioner_id", practitionerID, "user_id", caller.UserID)
+	return nil
+}
+
+// GetFeedAppointments returns the practitioner's appointments from
+// CalendarFeedPast ago to CalendarFeedFuture ahead. The feed only carries
+// times, so no patient access is logged.
+func (s *CalendarService) GetFeedAppointments(practitionerID, token string) ([]domain.Appointment, error) {
+	nonce, signature, ok := strings.Cut(token, ".")
+	if !ok || nonce == "" {
+		return nil, domain.ErrInvalidFeedToken
+	}
+	// The signature rejects forged tokens before any lookup
+	expected := s.sign(&domain.CalendarFeed{PractitionerID: practitionerID, Nonce: nonce})
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		slog.Warn("Calendar feed token with invalid signature", "practitioner_id", practitionerID)
+		return nil, domain.ErrInvalidFeedToken
+	}
+
+	feed, err := s.repo.GetCalendarFeed(practitionerID)
+	if err != nil || !hmac.Equal([]byte(feed.Nonce), []byte(nonce)) {
+		slog.Warn("Calendar feed token revoked", "practitioner_id", practitionerID)
+		return nil, domain.ErrInvalidFeedToken
+	}
+
+	now := time.Now()
+	appointments, err := s.appointmentRepo.GetAgenda(practitionerID, now.Add(-domain.CalendarFeedPast), now.Add(domain.CalendarFeedFuture))
+	if err != nil {
+		slog.Error("Calendar feed lookup failed", "practitioner_id", practitionerID, "error", err)
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// sign returns the signature binding a feed nonce to its practitioner. The
+// key is derived from the JWT secret so feed tokens cannot be used as JWTs.
+func (s *CalendarService) sign(feed *domain.CalendarFeed) string {
+	key := hmac.New(sha256.New, []byte(s.cfg.Api.JWTSecret))
+	key.Write([]byte("calendar-feed"))
+	mac := hmac.New(sha256.New, key.Sum(nil))
+	mac.Write([]byte(feed.PractitionerID + "." + feed.Nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
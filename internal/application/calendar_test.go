@@ -0,0 +1,68 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/infrastructure/config"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalendarService_Feed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalendarFeedRepository(ctrl)
+	mockAppointmentRepo := mocks.NewMockAppointmentRepository(ctrl)
+	cfg := &config.Config{Api: config.ApiConfig{JWTSecret: "test-secret"}}
+	service := NewCalendarService(mockRepo, mockAppointmentRepo, cfg)
+	caller := domain.Caller{UserID: "doctor-id", Role: domain.RolePractitioner}
+
+	var saved *domain.CalendarFeed
+	mockRepo.EXPECT().SaveCalendarFeed(gomock.Any()).DoAndReturn(func(feed *domain.CalendarFeed) error {
+		saved = feed
+		return nil
+	})
+	token, _, err := service.CreateFeed(caller, caller.UserID)
+	if err != nil {
+		t.Fatalf("CreateFeed() unexpected error = %v", err)
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		mockRepo.EXPECT().GetCalendarFeed(caller.UserID).Return(saved, nil)
+		mockAppointmentRepo.EXPECT().GetAgenda(caller.UserID, gomock.Any(), gomock.Any()).Return([]domain.Appointment{{ID: "a1"}}, nil)
+
+		appointments, err := service.GetFeedAppointments(caller.UserID, token)
+		if err != nil || len(appointments) != 1 {
+			t.Errorf("GetFeedAppointments() = %+v, %v", appointments, err)
+		}
+	})
+
+	t.Run("token of another practitioner", func(t *testing.T) {
+		if _, err := service.GetFeedAppointments("other-id", token); !errors.Is(err, domain.ErrInvalidFeedToken) {
+			t.Errorf("GetFeedAppointments() expected ErrInvalidFeedToken, got %v", err)
+		}
+	})
+
+	t.Run("forged signature", func(t *testing.T) {
+		if _, err := service.GetFeedAppointments(caller.UserID, saved.Nonce+".forged"); !errors.Is(err, domain.ErrInvalidFeedToken) {
+			t.Errorf("GetFeedAppointments() expected ErrInvalidFeedToken, got %v", err)
+		}
+	})
+
+	t.Run("revoked by a newer token", func(t *testing.T) {
+		mockRepo.EXPECT().GetCalendarFeed(caller.UserID).Return(&domain.CalendarFeed{PractitionerID: caller.UserID, Nonce: "newer"}, nil)
+
+		if _, err := service.GetFeedAppointments(caller.UserID, token); !errors.Is(err, domain.ErrInvalidFeedToken) {
+			t.Errorf("GetFeedAppointments() expected ErrInvalidFeedToken, got %v", err)
+		}
+	})
+
+	t.Run("another practitioner's feed", func(t *testing.T) {
+		if _, _, err := service.CreateFeed(caller, "other-id"); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("CreateFeed() expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
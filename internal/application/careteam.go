@@ -0,0 +1,136 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type CareTeamService struct {
+	repo        domain.CareTeamRepository
+	patientRepo domain.PatientRepository
+	userRepo    domain.UserRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewCareTeamService(repo domain.CareTeamRepository, patientRepo domain.PatientRepository, userRepo domain.UserRepository, consentRepo domain.ConsentRepository, support domain.Support) *CareTeamService {
+	return &CareTeamService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		userRepo:    userRepo,
+		access:      newAccessGuard(repo, consentRepo, support),
+		support:     support,
+	}
+}
+
+func (s *CareTeamService) GetCareTeam(caller domain.Caller, patientID string) ([]domain.CareTeamMember, error) {
+	if err := s.access.authorize(caller, patientID, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCareTeam(patientID)
+}
+
+func (s *CareTeamService) AddMember(caller domain.Caller, patientID, userID string) error {
+	member := &domain.CareTeamMember{
+		PatientID: patientID,
+		UserID:    userID,
+		AddedBy:   caller.UserID,
+		AddedAt:   time.Now(),
+	}
+	if errValidate := member.Validate(); errValidate != nil {
+		slog.Warn("Care team member validation failed", "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, patientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		slog.Warn("Care team member addition failed: user not found", "user_id", userID)
+		return err
+	}
+
+	isMember, err := s.repo.IsCareTeamMember(patientID, userID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return domain.ErrAlreadyCareTeamMember
+	}
+
+	if err := s.repo.AddCareTeamMember(member); err != nil {
+		slog.Error("Care team member creation in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Care team member added", "patient_id", patientID, "user_id", userID, "added_by", caller.UserID)
+	return nil
+}
+
+func (s *CareTeamService) RemoveMember(caller domain.Caller, patientID, userID string) error {
+	if err := s.access.authorize(caller, patientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+
+	team, err := s.repo.GetCareTeam(patientID)
+	if err != nil {
+		return err
+	}
+	if len(team) == 1 && team[0].UserID == userID {
+		slog.Warn("Care team member removal rejected: last member", "patient_id", patientID, "user_id", userID)
+		return domain.ErrLastCareTeamMember
+	}
+
+	if err := s.repo.RemoveCareTeamMember(patientID, userID); err != nil {
+		slog.Error("Care team member removal in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Care team member removed", "patient_id", patientID, "user_id", userID, "removed_by", caller.UserID)
+	return nil
+}
+
+func (s *CareTeamService) BreakGlass(caller domain.Caller, patientID, justification string) (*domain.BreakGlassAccess, error) {
+	if caller.IsIntegration() {
+		slog.Warn("Break-glass rejected: integration client", "user_id", caller.UserID)
+		return nil, domain.ErrAccessDenied
+	}
+
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for break-glass access", "error", errCreateID)
+		return nil, errCreateID
+	}
+
+	now := time.Now()
+	access := &domain.BreakGlassAccess{
+		ID:               id,
+		PatientID:        patientID,
+		UserID:           caller.UserID,
+		Justification:    justification,
+		GrantedAt:        now,
+		ExpiresAt:        now.Add(domain.BreakGlassDuration),
+		FlaggedForReview: true,
+	}
+	if errValidate := access.Validate(); errValidate != nil {
+		slog.Warn("Break-glass validation failed", "error", errValidate)
+		return nil, errValidate
+	}
+
+	if _, err := s.patientRepo.GetPatientByID(patientID); err != nil {
+		slog.Warn("Break-glass failed: patient not found", "patient_id", patientID)
+		return nil, err
+	}
+
+	if err := s.repo.CreateBreakGlassAccess(access); err != nil {
+		slog.Error("Break-glass access creation in repository failed", "error", err)
+		return nil, err
+	}
+	s.access.record(caller, patientID, domain.AccessActionBreakGlass, true)
+
+	slog.Warn("Break-glass access granted, flagged for review",
+		"patient_id", patientID, "user_id", caller.UserID, "expires_at", access.ExpiresAt)
+	return access, nil
+}
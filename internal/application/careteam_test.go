@@ -0,0 +1,97 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCareTeamService_RemoveMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewCareTeamService(mockRepo, mockPatientRepo, mockUserRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id"}
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+
+	expectAuthorized := func() {
+		mockRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+	}
+
+	t.Run("successful removal", func(t *testing.T) {
+		expectAuthorized()
+		mockRepo.EXPECT().GetCareTeam(patientID).Return([]domain.CareTeamMember{
+			{PatientID: patientID, UserID: caller.UserID},
+			{PatientID: patientID, UserID: "other-id"},
+		}, nil)
+		mockRepo.EXPECT().RemoveCareTeamMember(patientID, "other-id").Return(nil)
+
+		if err := service.RemoveMember(caller, patientID, "other-id"); err != nil {
+			t.Errorf("RemoveMember() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("last member", func(t *testing.T) {
+		expectAuthorized()
+		mockRepo.EXPECT().GetCareTeam(patientID).Return([]domain.CareTeamMember{
+			{PatientID: patientID, UserID: caller.UserID},
+		}, nil)
+
+		err := service.RemoveMember(caller, patientID, caller.UserID)
+		if !errors.Is(err, domain.ErrLastCareTeamMember) {
+			t.Errorf("RemoveMember() expected ErrLastCareTeamMember, got %v", err)
+		}
+	})
+}
+
+func TestCareTeamService_BreakGlass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewCareTeamService(mockRepo, mockPatientRepo, mockUserRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id"}
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+
+	t.Run("successful grant is flagged for review", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("grant-id", nil)
+		mockPatientRepo.EXPECT().GetPatientByID(patientID).Return(&domain.Patient{ID: patientID}, nil)
+		mockRepo.EXPECT().CreateBreakGlassAccess(gomock.Any()).Return(nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+
+		access, err := service.BreakGlass(caller, patientID, "Emergency room admission")
+		if err != nil {
+			t.Fatalf("BreakGlass() unexpected error = %v", err)
+		}
+		if !access.FlaggedForReview {
+			t.Error("BreakGlass() expected access to be flagged for review")
+		}
+		if !access.ExpiresAt.Equal(access.GrantedAt.Add(domain.BreakGlassDuration)) {
+			t.Errorf("BreakGlass() unexpected expiry %v", access.ExpiresAt)
+		}
+	})
+
+	t.Run("missing justification", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("grant-id", nil)
+
+		_, err := service.BreakGlass(caller, patientID, "  ")
+		if !errors.Is(err, domain.ErrEmptyJustification) {
+			t.Errorf("BreakGlass() expected ErrEmptyJustification, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type ConsentService struct {
+	repo        domain.ConsentRepository
+	patientRepo domain.PatientRepository
+	contactRepo domain.ContactRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewConsentService(repo domain.ConsentRepository, careTeamRepo domain.CareTeamRepository, patientRepo domain.PatientRepository, contactRepo domain.ContactRepository, support domain.Support) *ConsentService {
+	return &ConsentService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		contactRepo: contactRepo,
+		access:      newAccessGuard(careTeamRepo, repo, support),
+		support:     support,
+	}
+}
+
+func (s *ConsentService) GrantConsent(caller domain.Caller, consent *domain.Consent) error {
+	if caller.IsIntegration() {
+		slog.Warn("Consent grant rejected: integration client", "user_id", caller.UserID)
+		return domain.ErrConsentManagementDenied
+	}
+
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for consent", "error", errCreateID)
+		return errCreateID
+	}
+	consent.ID = id
+	consent.RecordedBy = caller.UserID
+	consent.RevokedAt = nil
+	if consent.GrantedAt.IsZero() {
+		consent.GrantedAt = time.Now()
+	}
+
+	// Enforce domain invariants
+	if errValidate := consent.Validate(); errValidate != nil {
+		slog.Warn("Consent validation failed", "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, consent.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+
+	// Minors consent through their legal representative
+	patient, err := s.patientRepo.GetPatientByID(consent.PatientID)
+	if err != nil {
+		return err
+	}
+	var representative *domain.Contact
+	if consent.RepresentativeID != "" {
+		representative, err = s.contactRepo.GetContactByID(consent.RepresentativeID)
+		if err != nil {
+			slog.Warn("Consent grant failed: representative not found", "contact_id", consent.RepresentativeID)
+			return err
+		}
+	}
+	if err := consent.ValidateAuthorizingParty(patient, representative); err != nil {
+		slog.Warn("Consent authorizing party rejected", "patient_id", consent.PatientID, "contact_id", consent.RepresentativeID, "error", err)
+		return err
+	}
+
+	if err := s.repo.CreateConsent(consent); err != nil {
+		slog.Error("Consent creation in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Consent granted", "consent_id", consent.ID, "patient_id", consent.PatientID, "purpose", consent.Purpose, "scope", consent.Scope, "representative_id", consent.RepresentativeID)
+	return nil
+}
+
+func (s *ConsentService) GetConsents(caller domain.Caller, patientID string) ([]domain.Consent, error) {
+	if err := s.access.authorize(caller, patientID, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetConsentsByPatientID(patientID)
+}
+
+func (s *ConsentService) RevokeConsent(caller domain.Caller, patientID, consentID string) (*domain.Consent, error) {
+	if caller.IsIntegration() {
+		slog.Warn("Consent revocation rejected: integration client", "user_id", caller.UserID)
+		return nil, domain.ErrConsentManagementDenied
+	}
+
+	if err := s.access.authorize(caller, patientID, domain.AccessActionWrite); err != nil {
+		return nil, err
+	}
+
+	consent, err := s.repo.GetConsentByID(consentID)
+	if err != nil {
+		slog.Warn("Consent revocation failed: consent not found", "consent_id", consentID)
+		return nil, err
+	}
+	if consent.PatientID != patientID {
+		return nil, domain.ErrConsentPatientMismatch
+	}
+	if consent.RevokedAt != nil {
+		return nil, domain.ErrConsentAlreadyRevoked
+	}
+
+	now := time.Now()
+	if err := s.repo.RevokeConsent(consentID, now); err != nil {
+		slog.Error("Consent revocation in repository failed", "error", err)
+		return nil, err
+	}
+	consent.RevokedAt = &now
+
+	slog.Info("Consent revoked", "consent_id", consentID, "patient_id", patientID)
+	return consent, nil
+}
+
+// consentGuard enforces patient consent for any use of data beyond treatment
+type consentGuard struct {
+	repo domain.ConsentRepository
+}
+
+// require fails with ErrConsentRequired unless the patient has an active
+// consent for the given purpose and scope
+func (g *consentGuard) require(patientID, purpose, scope string) error {
+	consents, err := g.repo.GetConsentsByPatientID(patientID)
+	if err != nil {
+		return err
+	}
+	if !domain.HasConsent(consents, purpose, scope, time.Now()) {
+		slog.Warn("Consent required", "patient_id", patientID, "purpose", purpose, "scope", scope)
+		return domain.ErrConsentRequired
+	}
+	return nil
+}
+
+// redactDiagnostics removes from a result set everything the patients have not
+// consented to share for the given purpose
+func (g *consentGuard) redactDiagnostics(diagnostics []domain.Diagnosis, purpose string) ([]domain.Diagnosis, error) {
+	now := time.Now()
+	cache := make(map[string][]domain.Consent)
+
+	result := make([]domain.Diagnosis, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		consents, ok := cache[d.PatientID]
+		if !ok {
+			var err error
+			consents, err = g.repo.GetConsentsByPatientID(d.PatientID)
+			if err != nil {
+				return nil, err
+			}
+			cache[d.PatientID] = consents
+		}
+
+		if !domain.HasConsent(consents, purpose, domain.ConsentScopeDiagnoses, now) {
+			continue
+		}
+		if !domain.HasConsent(consents, purpose, domain.ConsentScopePrescriptions, now) {
+			d.Prescription = ""
+			if d.Match != nil {
+				match := *d.Match
+				match.PrescriptionSnippet = ""
+				d.Match = &match
+			}
+		}
+		if !domain.HasConsent(consents, purpose, domain.ConsentScopeDemographics, now) {
+			d.Patient = domain.Patient{ID: d.PatientID}
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestConsentService_GrantConsent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockConsentRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewConsentService(mockRepo, mockCareTeamRepo, mockPatientRepo, mockContactRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+
+	t.Run("successful grant", func(t *testing.T) {
+		consent := &domain.Consent{
+			PatientID: patientID,
+			Purpose:   domain.ConsentPurposeThirdPartySharing,
+			Scope:     domain.ConsentScopeAll,
+			Evidence:  "Signed form CI-42",
+		}
+		mockSupport.EXPECT().CreateNewID().Return("consent-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID(patientID).Return(&domain.Patient{ID: patientID}, nil)
+		mockRepo.EXPECT().CreateConsent(consent).Return(nil)
+
+		if err := service.GrantConsent(caller, consent); err != nil {
+			t.Fatalf("GrantConsent() unexpected error = %v", err)
+		}
+		if consent.RecordedBy != caller.UserID || consent.GrantedAt.IsZero() {
+			t.Errorf("GrantConsent() expected recorder and grant date to be set, got %+v", consent)
+		}
+	})
+
+	birthDate := time.Now().AddDate(-10, 0, 0)
+	minor := &domain.Patient{ID: patientID, BirthDate: &birthDate}
+
+	t.Run("minor needs a legal representative", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("consent-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID(patientID).Return(minor, nil)
+
+		err := service.GrantConsent(caller, &domain.Consent{
+			PatientID: patientID,
+			Purpose:   domain.ConsentPurposeThirdPartySharing,
+			Scope:     domain.ConsentScopeAll,
+			Evidence:  "Signed form CI-42",
+		})
+		if !errors.Is(err, domain.ErrRepresentativeRequired) {
+			t.Errorf("GrantConsent() expected ErrRepresentativeRequired, got %v", err)
+		}
+	})
+
+	t.Run("guardian consents for a minor", func(t *testing.T) {
+		consent := &domain.Consent{
+			PatientID:        patientID,
+			Purpose:          domain.ConsentPurposeThirdPartySharing,
+			Scope:            domain.ConsentScopeAll,
+			Evidence:         "Signed form CI-43",
+			RepresentativeID: "contact-id",
+		}
+		mockSupport.EXPECT().CreateNewID().Return("consent-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID(patientID).Return(minor, nil)
+		mockContactRepo.EXPECT().GetContactByID("contact-id").Return(&domain.Contact{
+			ID: "contact-id", PatientID: patientID, Relationship: domain.RelationshipParent, LegalRepresentative: true,
+		}, nil)
+		mockRepo.EXPECT().CreateConsent(consent).Return(nil)
+
+		if err := service.GrantConsent(caller, consent); err != nil {
+			t.Errorf("GrantConsent() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("missing evidence", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("consent-id", nil)
+
+		err := service.GrantConsent(caller, &domain.Consent{
+			PatientID: patientID,
+			Purpose:   domain.ConsentPurposeThirdPartySharing,
+			Scope:     domain.ConsentScopeAll,
+		})
+		if !errors.Is(err, domain.ErrEmptyConsentEvidence) {
+			t.Errorf("GrantConsent() expected ErrEmptyConsentEvidence, got %v", err)
+		}
+	})
+
+	t.Run("integration clients cannot grant", func(t *testing.T) {
+		err := service.GrantConsent(domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}, &domain.Consent{})
+		if !errors.Is(err, domain.ErrConsentManagementDenied) {
+			t.Errorf("GrantConsent() expected ErrConsentManagementDenied, got %v", err)
+		}
+	})
+}
+
+func TestConsentService_RevokeConsent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockConsentRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewConsentService(mockRepo, mockCareTeamRepo, mockPatientRepo, mockContactRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+
+	expectAuthorized := func() {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+	}
+
+	t.Run("successful revocation", func(t *testing.T) {
+		expectAuthorized()
+		mockRepo.EXPECT().GetConsentByID("consent-id").Return(&domain.Consent{ID: "consent-id", PatientID: patientID}, nil)
+		mockRepo.EXPECT().RevokeConsent("consent-id", gomock.Any()).Return(nil)
+
+		consent, err := service.RevokeConsent(caller, patientID, "consent-id")
+		if err != nil {
+			t.Fatalf("RevokeConsent() unexpected error = %v", err)
+		}
+		if consent.RevokedAt == nil || consent.IsActive(time.Now()) {
+			t.Error("RevokeConsent() expected consent to be revoked")
+		}
+	})
+
+	t.Run("already revoked", func(t *testing.T) {
+		revokedAt := time.Now().Add(-time.Hour)
+		expectAuthorized()
+		mockRepo.EXPECT().GetConsentByID("consent-id").Return(&domain.Consent{ID: "consent-id", PatientID: patientID, RevokedAt: &revokedAt}, nil)
+
+		_, err := service.RevokeConsent(caller, patientID, "consent-id")
+		if !errors.Is(err, domain.ErrConsentAlreadyRevoked) {
+			t.Errorf("RevokeConsent() expected ErrConsentAlreadyRevoked, got %v", err)
+		}
+	})
+}
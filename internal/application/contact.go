@@ -0,0 +1,172 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type ContactService struct {
+	repo        domain.ContactRepository
+	patientRepo domain.PatientRepository
+	consentRepo domain.ConsentRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewContactService(repo domain.ContactRepository, patientRepo domain.PatientRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *ContactService {
+	return &ContactService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		consentRepo: consentRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+func (s *ContactService) AddContact(caller domain.Caller, contact *domain.Contact) error {
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for contact", "error", errCreateID)
+		return errCreateID
+	}
+	contact.ID = id
+	contact.CreatedBy = caller.UserID
+	contact.CreatedAt = time.Now()
+
+	if err := s.prepare(caller, contact); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateContact(contact); err != nil {
+		slog.Error("Contact creation in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Contact added", "contact_id", contact.ID, "patient_id", contact.PatientID, "legal_representative", contact.LegalRepresentative)
+	return nil
+}
+
+func (s *ContactService) GetContacts(caller domain.Caller, patientID string) ([]domain.Contact, error) {
+	if err := s.access.authorize(caller, patientID, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetContactsByPatientID(patientID)
+}
+
+func (s *ContactService) UpdateContact(caller domain.Caller, contact *domain.Contact) error {
+	stored, err := s.repo.GetContactByID(contact.ID)
+	if err != nil {
+		slog.Warn("Contact update failed: contact not found", "contact_id", contact.ID)
+		return err
+	}
+	if stored.PatientID != contact.PatientID {
+		return domain.ErrContactPatientMismatch
+	}
+	contact.CreatedBy = stored.CreatedBy
+	contact.CreatedAt = stored.CreatedAt
+
+	if err := s.prepare(caller, contact); err != nil {
+		return err
+	}
+
+	if stored.LegalRepresentative && !contact.LegalRepresentative {
+		if err := s.revokeConsentsGivenBy(contact.PatientID, contact.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpdateContact(contact); err != nil {
+		slog.Error("Contact update in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Contact updated", "contact_id", contact.ID, "patient_id", contact.PatientID, "legal_representative", contact.LegalRepresentative)
+	return nil
+}
+
+func (s *ContactService) DeleteContact(caller domain.Caller, patientID, contactID string) error {
+	if err := s.access.authorize(caller, patientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+
+	contact, err := s.repo.GetContactByID(contactID)
+	if err != nil {
+		slog.Warn("Contact deletion failed: contact not found", "contact_id", contactID)
+		return err
+	}
+	if contact.PatientID != patientID {
+		return domain.ErrContactPatientMismatch
+	}
+
+	if err := s.revokeConsentsGivenBy(patientID, contactID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteContact(contactID); err != nil {
+		slog.Error("Contact deletion in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Contact deleted", "contact_id", contactID, "patient_id", patientID)
+	return nil
+}
+
+// revokeConsentsGivenBy revokes the active consents a contact gave as the
+// patient's legal representative, before it stops being one. They are revoked
+// first so a failure leaves the consents revoked rather than unbacked.
+func (s *ContactService) revokeConsentsGivenBy(patientID, contactID string) error {
+	consents, err := s.consentRepo.GetConsentsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Consents lookup for contact change failed", "patient_id", patientID, "error", err)
+		return err
+	}
+
+	now := time.Now()
+	for _, c := range consents {
+		if c.RepresentativeID != contactID || !c.IsActive(now) {
+			continue
+		}
+		if err := s.consentRepo.RevokeConsent(c.ID, now); err != nil {
+			slog.Error("Consent revocation for contact change failed", "consent_id", c.ID, "error", err)
+			return err
+		}
+		slog.Info("Consent revoked with its representative", "consent_id", c.ID, "patient_id", patientID, "contact_id", contactID)
+	}
+	return nil
+}
+
+// prepare normalizes and validates a contact and checks the caller may edit
+// the contacts of a patient who is still active
+func (s *ContactService) prepare(caller domain.Caller, contact *domain.Contact) error {
+	if contact.Phone != "" {
+		phone, err := domain.NormalizePhone(contact.Phone)
+		if err != nil {
+			slog.Warn("Contact phone normalization failed", "error", err)
+			return err
+		}
+		contact.Phone = phone
+	}
+
+	// Enforce domain invariants
+	if errValidate := contact.Validate(); errValidate != nil {
+		slog.Warn("Contact validation failed", "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, contact.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+
+	patient, err := s.patientRepo.GetPatientByID(contact.PatientID)
+	if err != nil {
+		return err
+	}
+	if patient.IsErased() {
+		return domain.ErrPatientAlreadyErased
+	}
+	if patient.IsMerged() {
+		return domain.ErrPatientMerged
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestContactService_AddContact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockContactRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewContactService(mockRepo, mockPatientRepo, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	newContact := func() *domain.Contact {
+		return &domain.Contact{PatientID: "p1", Relationship: domain.RelationshipParent, Name: "Carmen López", Phone: "600 65 43 21", LegalRepresentative: true}
+	}
+
+	t.Run("successful addition", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("contact-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockRepo.EXPECT().CreateContact(gomock.Any()).Return(nil)
+
+		contact := newContact()
+		if err := service.AddContact(caller, contact); err != nil {
+			t.Fatalf("AddContact() unexpected error = %v", err)
+		}
+		if contact.ID != "contact-id" || contact.Phone != "+34600654321" || contact.CreatedBy != caller.UserID {
+			t.Errorf("AddContact() = %+v", contact)
+		}
+	})
+
+	t.Run("unknown relationship", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("contact-id", nil)
+
+		contact := newContact()
+		contact.Relationship = "neighbour"
+		if err := service.AddContact(caller, contact); !errors.Is(err, domain.ErrInvalidRelationship) {
+			t.Errorf("AddContact() expected ErrInvalidRelationship, got %v", err)
+		}
+	})
+
+	t.Run("erased patient", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("contact-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		erased := &domain.Patient{ID: "p1"}
+		erased.Anonymize("erasure-id", time.Now())
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(erased, nil)
+
+		if err := service.AddContact(caller, newContact()); !errors.Is(err, domain.ErrPatientAlreadyErased) {
+			t.Errorf("AddContact() expected ErrPatientAlreadyErased, got %v", err)
+		}
+	})
+}
+
+func TestContactService_DeleteContact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockContactRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewContactService(mockRepo, mockPatientRepo, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	t.Run("contact of another patient", func(t *testing.T) {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetContactByID("contact-id").Return(&domain.Contact{ID: "contact-id", PatientID: "p2"}, nil)
+
+		if err := service.DeleteContact(caller, "p1", "contact-id"); !errors.Is(err, domain.ErrContactPatientMismatch) {
+			t.Errorf("DeleteContact() expected ErrContactPatientMismatch, got %v", err)
+		}
+	})
+
+	t.Run("revokes the consents given by the contact", func(t *testing.T) {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetContactByID("contact-id").Return(&domain.Contact{ID: "contact-id", PatientID: "p1", LegalRepresentative: true}, nil)
+		revoked := time.Now().Add(-time.Hour)
+		mockConsentRepo.EXPECT().GetConsentsByPatientID("p1").Return([]domain.Consent{
+			{ID: "given-by-contact", PatientID: "p1", RepresentativeID: "contact-id", GrantedAt: time.Now().Add(-24 * time.Hour)},
+			{ID: "already-revoked", PatientID: "p1", RepresentativeID: "contact-id", GrantedAt: time.Now().Add(-24 * time.Hour), RevokedAt: &revoked},
+			{ID: "given-by-other", PatientID: "p1", RepresentativeID: "other-id", GrantedAt: time.Now().Add(-24 * time.Hour)},
+		}, nil)
+		mockConsentRepo.EXPECT().RevokeConsent("given-by-contact", gomock.Any()).Return(nil)
+		mockRepo.EXPECT().DeleteContact("contact-id").Return(nil)
+
+		if err := service.DeleteContact(caller, "p1", "contact-id"); err != nil {
+			t.Errorf("DeleteContact() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("integration clients cannot delete", func(t *testing.T) {
+		integration := domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}
+		if err := service.DeleteContact(integration, "p1", "contact-id"); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("DeleteContact() expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
+
+func TestContactService_UpdateContact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockContactRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewContactService(mockRepo, mockPatientRepo, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	t.Run("no longer legal representative", func(t *testing.T) {
+		mockRepo.EXPECT().GetContactByID("contact-id").Return(&domain.Contact{ID: "contact-id", PatientID: "p1",
+			Relationship: domain.RelationshipParent, Name: "Carmen López", LegalRepresentative: true}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockConsentRepo.EXPECT().GetConsentsByPatientID("p1").Return([]domain.Consent{
+			{ID: "given-by-contact", PatientID: "p1", RepresentativeID: "contact-id", GrantedAt: time.Now().Add(-24 * time.Hour)},
+		}, nil)
+		mockConsentRepo.EXPECT().RevokeConsent("given-by-contact", gomock.Any()).Return(nil)
+		mockRepo.EXPECT().UpdateContact(gomock.Any()).Return(nil)
+
+		contact := &domain.Contact{ID: "contact-id", PatientID: "p1", Relationship: domain.RelationshipParent, Name: "Carmen López", Phone: "600654321"}
+		if err := service.UpdateContact(caller, contact); err != nil {
+			t.Errorf("UpdateContact() unexpected error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type EncounterService struct {
+	repo        domain.EncounterRepository
+	patientRepo domain.PatientRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewEncounterService(repo domain.EncounterRepository, patientRepo domain.PatientRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *EncounterService {
+	return &EncounterService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+func (s *EncounterService) OpenEncounter(caller domain.Caller, encounter *domain.Encounter) error {
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for encounter", "error", errCreateID)
+		return errCreateID
+	}
+	encounter.ID = id
+	encounter.PractitionerID = caller.UserID
+	encounter.Status = domain.EncounterStatusOpen
+	encounter.ClosedAt = nil
+	encounter.CreatedAt = time.Now()
+	if encounter.StartedAt.IsZero() {
+		encounter.StartedAt = encounter.CreatedAt
+	}
+	encounter.Note.Normalize()
+
+	// Enforce domain invariants
+	if errValidate := encounter.Validate(); errValidate != nil {
+		slog.Warn("Encounter validation failed", "patient_id", encounter.PatientID, "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, encounter.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+	if err := checkActivePatient(s.patientRepo, encounter.PatientID); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateEncounter(encounter); err != nil {
+		slog.Error("Encounter creation in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Encounter opened", "encounter_id", encounter.ID, "patient_id", encounter.PatientID, "practitioner_id", caller.UserID)
+	return nil
+}
+
+func (s *EncounterService) UpdateEncounterNote(caller domain.Caller, id string, note domain.SOAPNote) (*domain.Encounter, error) {
+	encounter, err := s.authorizedEncounter(caller, id, domain.AccessActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := encounter.UpdateNote(note); err != nil {
+		slog.Warn("Encounter note update refused", "encounter_id", id, "error", err)
+		return nil, err
+	}
+
+	if err := s.repo.UpdateEncounter(encounter); err != nil {
+		slog.Error("Encounter update in repository failed", "encounter_id", id, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Encounter note updated", "encounter_id", id, "user_id", caller.UserID)
+	return encounter, nil
+}
+
+func (s *EncounterService) CloseEncounter(caller domain.Caller, id string, note *domain.SOAPNote) (*domain.Encounter, error) {
+	encounter, err := s.authorizedEncounter(caller, id, domain.AccessActionWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := encounter.Close(note, time.Now()); err != nil {
+		slog.Warn("Encounter closing refused", "encounter_id", id, "error", err)
+		return nil, err
+	}
+
+	if err := s.repo.UpdateEncounter(encounter); err != nil {
+		slog.Error("Encounter update in repository failed", "encounter_id", id, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Encounter closed", "encounter_id", id, "patient_id", encounter.PatientID, "closed_by", caller.UserID)
+	return encounter, nil
+}
+
+func (s *EncounterService) GetEncounter(caller domain.Caller, id string) (*domain.Encounter, error) {
+	encounter, err := s.authorizedEncounter(caller, id, domain.AccessActionRead)
+	if err != nil {
+		return nil, err
+	}
+	if caller.IsIntegration() {
+		if err := s.access.consent.require(encounter.PatientID, domain.ConsentPurposeThirdPartySharing, domain.ConsentScopeDiagnoses); err != nil {
+			return nil, err
+		}
+	}
+
+	encounter.Diagnoses, err = s.repo.GetDiagnosesByEncounterID(id)
+	if err != nil {
+		slog.Error("Encounter diagnoses lookup failed", "encounter_id", id, "error", err)
+		return nil, err
+	}
+	return encounter, nil
+}
+
+func (s *EncounterService) GetPatientEncounters(caller domain.Caller, patientID string) ([]domain.Encounter, error) {
+	if err := s.access.authorizeClinicalRead(caller, patientID); err != nil {
+		return nil, err
+	}
+
+	encounters, err := s.repo.GetEncountersByPatientID(patientID)
+	if err != nil {
+		slog.Error("Encounter lookup failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+	return encounters, nil
+}
+
+// authorizedEncounter loads an encounter and checks the caller may act on its
+// patient
+func (s *EncounterService) authorizedEncounter(caller domain.Caller, id, action string) (*domain.Encounter, error) {
+	encounter, err := s.repo.GetEncounterByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.access.authorize(caller, encounter.PatientID, action); err != nil {
+		return nil, err
+	}
+	return encounter, nil
+}
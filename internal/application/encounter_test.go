@@ -0,0 +1,101 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestEncounterService_OpenEncounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEncounterRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewEncounterService(mockRepo, mockPatientRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	t.Run("successful open", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("encounter-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockRepo.EXPECT().CreateEncounter(gomock.Any()).Return(nil)
+
+		encounter := &domain.Encounter{PatientID: "p1", Note: domain.SOAPNote{Subjective: " Fiebre "}}
+		if err := service.OpenEncounter(caller, encounter); err != nil {
+			t.Fatalf("OpenEncounter() unexpected error = %v", err)
+		}
+		if encounter.ID != "encounter-id" || encounter.PractitionerID != caller.UserID || !encounter.IsOpen() || encounter.StartedAt.IsZero() || encounter.Note.Subjective != "Fiebre" {
+			t.Errorf("OpenEncounter() = %+v", encounter)
+		}
+	})
+
+	t.Run("starting in the future", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("encounter-id", nil)
+
+		encounter := &domain.Encounter{PatientID: "p1", StartedAt: time.Now().Add(time.Hour)}
+		if err := service.OpenEncounter(caller, encounter); !errors.Is(err, domain.ErrFutureEncounter) {
+			t.Errorf("OpenEncounter() expected ErrFutureEncounter, got %v", err)
+		}
+	})
+}
+
+func TestEncounterService_CloseEncounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEncounterRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewEncounterService(mockRepo, mocks.NewMockPatientRepository(ctrl), mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	expectAllowedWrite := func(encounter *domain.Encounter) {
+		mockRepo.EXPECT().GetEncounterByID("e1").Return(encounter, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+	}
+	open := func() *domain.Encounter {
+		return &domain.Encounter{ID: "e1", PatientID: "p1", PractitionerID: caller.UserID, Status: domain.EncounterStatusOpen}
+	}
+
+	t.Run("close with the final note", func(t *testing.T) {
+		expectAllowedWrite(open())
+		mockRepo.EXPECT().UpdateEncounter(gomock.Any()).Return(nil)
+
+		encounter, err := service.CloseEncounter(caller, "e1", &domain.SOAPNote{Assessment: "Faringitis"})
+		if err != nil {
+			t.Fatalf("CloseEncounter() unexpected error = %v", err)
+		}
+		if encounter.Status != domain.EncounterStatusClosed || encounter.ClosedAt == nil || encounter.Note.Assessment != "Faringitis" {
+			t.Errorf("CloseEncounter() = %+v", encounter)
+		}
+	})
+
+	t.Run("without a note", func(t *testing.T) {
+		expectAllowedWrite(open())
+
+		if _, err := service.CloseEncounter(caller, "e1", nil); !errors.Is(err, domain.ErrEmptySOAPNote) {
+			t.Errorf("CloseEncounter() expected ErrEmptySOAPNote, got %v", err)
+		}
+	})
+
+	t.Run("already closed", func(t *testing.T) {
+		closed := open()
+		closed.Status = domain.EncounterStatusClosed
+		expectAllowedWrite(closed)
+
+		if _, err := service.CloseEncounter(caller, "e1", &domain.SOAPNote{Plan: "Reposo"}); !errors.Is(err, domain.ErrEncounterClosed) {
+			t.Errorf("CloseEncounter() expected ErrEncounterClosed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type ErasureService struct {
+	repo           domain.ErasureRepository
+	patientRepo    domain.PatientRepository
+	attachmentRepo domain.AttachmentRepository
+	blobs          domain.BlobStorage
+	exportRepo     domain.BulkExportRepository
+	exportFiles    domain.BulkExportStorage
+	access         *accessGuard
+	support        domain.Support
+}
+
+func NewErasureService(repo domain.ErasureRepository, patientRepo domain.PatientRepository, attachmentRepo domain.AttachmentRepository, blobs domain.BlobStorage, exportRepo domain.BulkExportRepository, exportFiles domain.BulkExportStorage, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *ErasureService {
+	return &ErasureService{
+		repo:           repo,
+		patientRepo:    patientRepo,
+		attachmentRepo: attachmentRepo,
+		blobs:          blobs,
+		exportRepo:     exportRepo,
+		exportFiles:    exportFiles,
+		access:         newAccessGuard(careTeamRepo, consentRepo, support),
+		support:        support,
+	}
+}
+
+// ErasePatient anonymizes the identifying data of a patient while keeping
+// their clinical records for the legal retention period. The bulk exports
+// holding the patient are withdrawn.
+func (s *ErasureService) ErasePatient(caller domain.Caller, patientID, reason string) (*domain.Erasure, error) {
+	if !caller.IsAdmin() {
+		slog.Warn("Patient erasure rejected: caller is not an administrator", "user_id", caller.UserID)
+		return nil, domain.ErrAdminRequired
+	}
+
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for erasure", "error", errCreateID)
+		return nil, errCreateID
+	}
+
+	now := time.Now()
+	erasure := &domain.Erasure{
+		ID:          id,
+		PatientID:   patientID,
+		RequestedBy: caller.UserID,
+		Reason:      reason,
+		ErasedAt:    now,
+	}
+	if errValidate := erasure.Validate(); errValidate != nil {
+		slog.Warn("Erasure validation failed", "error", errValidate)
+		return nil, errValidate
+	}
+
+	patient, err := s.patientRepo.GetPatientByID(patientID)
+	if err != nil {
+		slog.Warn("Patient erasure failed: patient not found", "patient_id", patientID)
+		return nil, err
+	}
+	if patient.IsErased() {
+		return nil, domain.ErrPatientAlreadyErased
+	}
+
+	diagnoses, err := s.patientRepo.GetDiagnosisByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient erasure failed: diagnoses lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+	erasure.RetainUntil = domain.RetentionDeadline(diagnoses, now)
+
+	patient.Anonymize(erasure.ID, now)
+	if err := s.repo.ErasePatient(patient, erasure); err != nil {
+		slog.Error("Patient erasure in repository failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+	s.access.record(caller, patientID, domain.AccessActionWrite, false)
+
+	// The patient is erased by now, a failure here must not report otherwise.
+	// An export still running finds the patient erased once it completes.
+	if _, err := withdrawBulkExports(s.exportRepo, s.exportFiles, patientID); err != nil {
+		slog.Error("Bulk exports of an erased patient could not be withdrawn", "patient_id", patientID, "error", err)
+	}
+
+	slog.Info("Patient erased", "patient_id", patientID, "erasure_id", erasure.ID, "retain_until", erasure.RetainUntil)
+	return erasure, nil
+}
+
+// PurgeExpiredRecords deletes the clinical records of erased patients whose
+// retention period is over. It returns the number of patients purged.
+func (s *ErasureService) PurgeExpiredRecords(at time.Time) (int, error) {
+	erasures, err := s.repo.GetErasuresDueForPurge(at)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, e := range erasures {
+		attachments, err := s.attachmentRepo.GetAttachmentsByPatientID(e.PatientID)
+		if err != nil {
+			slog.Error("Clinical records purge failed: attachments lookup", "patient_id", e.PatientID, "error", err)
+			return i, err
+		}
+		if err := s.repo.PurgeClinicalRecords(&e, at); err != nil {
+			slog.Error("Clinical records purge failed", "patient_id", e.PatientID, "error", err)
+			return i, err
+		}
+		// The files go once their metadata is gone. Their keys went with the
+		// patient's key, so a file left behind can no longer be read.
+		for _, a := range attachments {
+			discardBlob(s.blobs, a.ID)
+		}
+		slog.Info("Clinical records purged", "patient_id", e.PatientID, "erasure_id", e.ID)
+	}
+	return len(erasures), nil
+}
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestErasureService_ErasePatient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockErasureRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	mockExportRepo := mocks.NewMockBulkExportRepository(ctrl)
+	mockExportFiles := mocks.NewMockBulkExportStorage(ctrl)
+	service := NewErasureService(mockRepo, mockPatientRepo, mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockBlobStorage(ctrl),
+		mockExportRepo, mockExportFiles, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	admin := domain.Caller{UserID: "admin-id", Role: domain.RoleAdmin}
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+
+	t.Run("successful erasure keeps clinical records", func(t *testing.T) {
+		lastVisit := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+		mockSupport.EXPECT().CreateNewID().Return("erasure-id", nil)
+		mockPatientRepo.EXPECT().GetPatientByID(patientID).Return(&domain.Patient{
+			ID: patientID, GivenName: "Maria", FirstSurname: "Garcia", DNI: "12345678Z", Email: "maria@example.com", Phone: "600123456",
+		}, nil)
+		mockPatientRepo.EXPECT().GetDiagnosisByPatientID(patientID).Return([]domain.Diagnosis{
+			{ID: "d1", PatientID: patientID, Date: lastVisit.AddDate(-1, 0, 0)},
+			{ID: "d2", PatientID: patientID, Date: lastVisit},
+		}, nil)
+		mockRepo.EXPECT().ErasePatient(gomock.Any(), gomock.Any()).DoAndReturn(func(p *domain.Patient, e *domain.Erasure) error {
+			if p.GivenName != domain.ErasedPatientName || p.FirstSurname != "" || p.DNI == "12345678Z" || p.Email != "" || p.Phone != "" {
+				t.Errorf("ErasePatient() expected anonymized patient, got %+v", p)
+			}
+			if !p.IsErased() {
+				t.Error("ErasePatient() expected patient to be marked as erased")
+			}
+			return nil
+		})
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		// Only the exports holding the patient are withdrawn
+		mockExportRepo.EXPECT().GetBulkExportJobsWithPatient(patientID, domain.BulkExportStatusCompleted).Return([]domain.BulkExportJob{
+			{ID: "job-id", Status: domain.BulkExportStatusCompleted, Output: []domain.BulkExportFile{{Type: domain.BulkExportTypePatient, Count: 1}}},
+		}, nil)
+		mockExportFiles.EXPECT().Delete("job-id").Return(nil)
+		mockExportRepo.EXPECT().UpdateBulkExportJob(gomock.Any()).DoAndReturn(func(job *domain.BulkExportJob) error {
+			if job.ID != "job-id" || job.Status != domain.BulkExportStatusFailed || job.Output != nil || job.Error != domain.ErrBulkExportWithdrawn.Error() {
+				t.Errorf("UpdateBulkExportJob() with %+v", job)
+			}
+			return nil
+		})
+
+		erasure, err := service.ErasePatient(admin, patientID, "Patient request")
+		if err != nil {
+			t.Fatalf("ErasePatient() unexpected error = %v", err)
+		}
+		if want := lastVisit.Add(domain.ClinicalRecordRetention); !erasure.RetainUntil.Equal(want) {
+			t.Errorf("ErasePatient() expected retention until %v, got %v", want, erasure.RetainUntil)
+		}
+	})
+
+	t.Run("already erased", func(t *testing.T) {
+		erasedAt := time.Now()
+		mockSupport.EXPECT().CreateNewID().Return("erasure-id", nil)
+		mockPatientRepo.EXPECT().GetPatientByID(patientID).Return(&domain.Patient{ID: patientID, ErasedAt: &erasedAt}, nil)
+
+		_, err := service.ErasePatient(admin, patientID, "Patient request")
+		if !errors.Is(err, domain.ErrPatientAlreadyErased) {
+			t.Errorf("ErasePatient() expected ErrPatientAlreadyErased, got %v", err)
+		}
+	})
+
+	t.Run("non admin", func(t *testing.T) {
+		_, err := service.ErasePatient(domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}, patientID, "Patient request")
+		if !errors.Is(err, domain.ErrAdminRequired) {
+			t.Errorf("ErasePatient() expected ErrAdminRequired, got %v", err)
+		}
+	})
+}
+
+func TestErasureService_PurgeExpiredRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockErasureRepository(ctrl)
+	mockAttachmentRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockBlobs := mocks.NewMockBlobStorage(ctrl)
+	service := NewErasureService(mockRepo, mocks.NewMockPatientRepository(ctrl), mockAttachmentRepo, mockBlobs,
+		mocks.NewMockBulkExportRepository(ctrl), mocks.NewMockBulkExportStorage(ctrl), mocks.NewMockCareTeamRepository(ctrl), mocks.NewMockConsentRepository(ctrl), mocks.NewMockSupport(ctrl))
+	now := time.Now()
+	erasure := domain.Erasure{ID: "erasure-id", PatientID: "p1"}
+
+	mockRepo.EXPECT().GetErasuresDueForPurge(now).Return([]domain.Erasure{erasure}, nil)
+	mockAttachmentRepo.EXPECT().GetAttachmentsByPatientID("p1").Return([]domain.Attachment{
+		{ID: "a1"}, {ID: "a2"},
+	}, nil)
+	purge := mockRepo.EXPECT().PurgeClinicalRecords(gomock.Any(), now).Return(nil)
+	mockBlobs.EXPECT().Delete("a1").Return(nil).After(purge)
+	mockBlobs.EXPECT().Delete("a2").Return(nil).After(purge)
+
+	purged, err := service.PurgeExpiredRecords(now)
+	if err != nil || purged != 1 {
+		t.Errorf("PurgeExpiredRecords() = %d, %v", purged, err)
+	}
+}
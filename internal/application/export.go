@@ -0,0 +1,168 @@
+package application
+
+import (
+	"io"
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type ExportService struct {
+	patientRepo     domain.PatientRepository
+	careTeamRepo    domain.CareTeamRepository
+	consentRepo     domain.ConsentRepository
+	contactRepo     domain.ContactRepository
+	appointmentRepo domain.AppointmentRepository
+	observationRepo domain.ObservationRepository
+	labRepo         domain.LabResultRepository
+	attachmentRepo  domain.AttachmentRepository
+	blobs           domain.BlobStorage
+	vaccinationRepo domain.VaccinationRepository
+	referralRepo    domain.ReferralRepository
+	encounterRepo   domain.EncounterRepository
+	access          *accessGuard
+}
+
+func NewExportService(repos Repositories, support domain.Support) *ExportService {
+	return &ExportService{
+		patientRepo:     repos.Patient,
+		careTeamRepo:    repos.CareTeam,
+		consentRepo:     repos.Consent,
+		contactRepo:     repos.Contact,
+		appointmentRepo: repos.Appointment,
+		observationRepo: repos.Observation,
+		labRepo:         repos.Lab,
+		attachmentRepo:  repos.Attachment,
+		blobs:           repos.Blobs,
+		vaccinationRepo: repos.Vaccination,
+		referralRepo:    repos.Referral,
+		encounterRepo:   repos.Encounter,
+		access:          newAccessGuard(repos.CareTeam, repos.Consent, support),
+	}
+}
+
+// ExportPatient builds the right-of-access bundle for a patient. The export
+// answers the patient's own request, so it is not subject to sharing consent,
+// but it is restricted to administrators.
+func (s *ExportService) ExportPatient(caller domain.Caller, patientID string) (*domain.PatientExport, error) {
+	if !caller.IsAdmin() {
+		slog.Warn("Patient export rejected: caller is not an administrator", "user_id", caller.UserID)
+		return nil, domain.ErrAdminRequired
+	}
+
+	patient, err := s.patientRepo.GetPatientByID(patientID)
+	if err != nil {
+		slog.Warn("Patient export failed: patient not found", "patient_id", patientID)
+		return nil, err
+	}
+
+	diagnoses, err := s.patientRepo.GetDiagnosisByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: diagnoses lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	consents, err := s.consentRepo.GetConsentsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: consents lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	contacts, err := s.contactRepo.GetContactsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: contacts lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	appointments, err := s.appointmentRepo.GetAppointmentsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: appointments lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	observations, err := s.observationRepo.GetObservationsByPatientID(patientID, domain.ObservationFilter{})
+	if err != nil {
+		slog.Error("Patient export failed: observations lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	labResults, err := s.labRepo.GetLabResultsByPatientID(patientID, domain.LabResultFilter{})
+	if err != nil {
+		slog.Error("Patient export failed: lab results lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetAttachmentsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: attachments lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	vaccinations, err := s.vaccinationRepo.GetVaccinationsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: vaccinations lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	referrals, err := s.referralRepo.GetReferralsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: referrals lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	encounters, err := s.encounterRepo.GetEncountersByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: encounters lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	// Record the export before reading the log so it is part of the bundle
+	s.access.record(caller, patientID, domain.AccessActionExport, false)
+
+	accessLog, err := s.careTeamRepo.GetAccessLogByPatientID(patientID)
+	if err != nil {
+		slog.Error("Patient export failed: access log lookup", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Patient data exported", "patient_id", patientID, "user_id", caller.UserID)
+	return &domain.PatientExport{
+		GeneratedAt:   time.Now(),
+		GeneratedBy:   caller.UserID,
+		Patient:       *patient,
+		Diagnoses:     diagnoses,
+		Prescriptions: domain.PrescriptionsFromDiagnoses(diagnoses),
+		Consents:      consents,
+		Contacts:      contacts,
+		Appointments:  appointments,
+		Observations:  observations,
+		LabResults:    labResults,
+		Attachments:   attachments,
+		Vaccinations:  vaccinations,
+		Referrals:     referrals,
+		Encounters:    encounters,
+		AccessLog:     accessLog,
+	}, nil
+}
+
+func (s *ExportService) OpenExportedAttachment(caller domain.Caller, patientID, attachmentID string) (io.ReadCloser, error) {
+	if !caller.IsAdmin() {
+		slog.Warn("Exported attachment rejected: caller is not an administrator", "user_id", caller.UserID)
+		return nil, domain.ErrAdminRequired
+	}
+
+	attachment, err := s.attachmentRepo.GetAttachmentByID(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.PatientID != patientID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+
+	content, err := s.blobs.Open(attachment.ID, attachment.ContentKey, attachment.Checksum)
+	if err != nil {
+		slog.Error("Exported attachment content could not be opened", "attachment_id", attachmentID, "error", err)
+		return nil, err
+	}
+	return content, nil
+}
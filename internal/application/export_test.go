@@ -0,0 +1,138 @@
+package application
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportService_ExportPatient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockAppointmentRepo := mocks.NewMockAppointmentRepository(ctrl)
+	mockObservationRepo := mocks.NewMockObservationRepository(ctrl)
+	mockLabRepo := mocks.NewMockLabResultRepository(ctrl)
+	mockAttachmentRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockVaccinationRepo := mocks.NewMockVaccinationRepository(ctrl)
+	mockReferralRepo := mocks.NewMockReferralRepository(ctrl)
+	mockEncounterRepo := mocks.NewMockEncounterRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewExportService(Repositories{
+		Patient:     mockPatientRepo,
+		CareTeam:    mockCareTeamRepo,
+		Consent:     mockConsentRepo,
+		Contact:     mockContactRepo,
+		Appointment: mockAppointmentRepo,
+		Observation: mockObservationRepo,
+		Lab:         mockLabRepo,
+		Attachment:  mockAttachmentRepo,
+		Blobs:       mocks.NewMockBlobStorage(ctrl),
+		Vaccination: mockVaccinationRepo,
+		Referral:    mockReferralRepo,
+		Encounter:   mockEncounterRepo,
+	}, mockSupport)
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+
+	t.Run("successful export", func(t *testing.T) {
+		admin := domain.Caller{UserID: "admin-id", Role: domain.RoleAdmin}
+		diagnoses := []domain.Diagnosis{
+			{ID: "d1", PatientID: patientID, Diagnosis: "Fever", Prescription: "Paracetamol", Date: time.Now()},
+			{ID: "d2", PatientID: patientID, Diagnosis: "Checkup", Date: time.Now()},
+		}
+		mockPatientRepo.EXPECT().GetPatientByID(patientID).Return(&domain.Patient{ID: patientID}, nil)
+		mockPatientRepo.EXPECT().GetDiagnosisByPatientID(patientID).Return(diagnoses, nil)
+		mockConsentRepo.EXPECT().GetConsentsByPatientID(patientID).Return(nil, nil)
+		mockContactRepo.EXPECT().GetContactsByPatientID(patientID).Return([]domain.Contact{
+			{ID: "c1", PatientID: patientID, Relationship: domain.RelationshipParent, Name: "Carmen López", LegalRepresentative: true},
+		}, nil)
+		mockAppointmentRepo.EXPECT().GetAppointmentsByPatientID(patientID).Return([]domain.Appointment{
+			{ID: "a1", PatientID: patientID, Status: domain.AppointmentStatusPending, Reason: "Follow-up"},
+		}, nil)
+		mockObservationRepo.EXPECT().GetObservationsByPatientID(patientID, domain.ObservationFilter{}).Return([]domain.Observation{
+			{ID: "o1", PatientID: patientID, Code: domain.ObservationHeartRate, Value: 72, Unit: "/min"},
+		}, nil)
+		mockLabRepo.EXPECT().GetLabResultsByPatientID(patientID, domain.LabResultFilter{}).Return([]domain.LabResult{
+			{ID: "l1", PatientID: patientID, Panel: "Lipid panel", Analyte: "Cholesterol", Value: 240, Unit: "mg/dL"},
+		}, nil)
+		mockAttachmentRepo.EXPECT().GetAttachmentsByPatientID(patientID).Return([]domain.Attachment{
+			{ID: "a1", DiagnosisID: "d1", PatientID: patientID, FileName: "informe.pdf", ContentType: domain.AttachmentTypePDF},
+		}, nil)
+		mockVaccinationRepo.EXPECT().GetVaccinationsByPatientID(patientID).Return([]domain.Vaccination{
+			{ID: "v1", PatientID: patientID, VaccineCode: "HEXA", DoseNumber: 1, AdministeredAt: time.Now()},
+		}, nil)
+		mockReferralRepo.EXPECT().GetReferralsByPatientID(patientID).Return([]domain.Referral{
+			{ID: "r1", PatientID: patientID, ToSpecialty: "cardiology", Reason: "Soplo sistólico", Status: domain.ReferralStatusPending},
+		}, nil)
+		mockEncounterRepo.EXPECT().GetEncountersByPatientID(patientID).Return([]domain.Encounter{
+			{ID: "e1", PatientID: patientID, Status: domain.EncounterStatusClosed, Note: domain.SOAPNote{Assessment: "Bronquitis aguda"}, StartedAt: time.Now()},
+		}, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockCareTeamRepo.EXPECT().GetAccessLogByPatientID(patientID).Return([]domain.AccessLogEntry{
+			{PatientID: patientID, UserID: "admin-id", Action: domain.AccessActionExport},
+		}, nil)
+
+		export, err := service.ExportPatient(admin, patientID)
+		if err != nil {
+			t.Fatalf("ExportPatient() unexpected error = %v", err)
+		}
+		if len(export.Diagnoses) != 2 || len(export.Prescriptions) != 1 || len(export.Contacts) != 1 || len(export.Appointments) != 1 || len(export.Observations) != 1 || len(export.LabResults) != 1 || len(export.Attachments) != 1 || len(export.Vaccinations) != 1 || len(export.Referrals) != 1 || len(export.Encounters) != 1 || len(export.AccessLog) != 1 {
+			t.Errorf("ExportPatient() unexpected bundle %+v", export)
+		}
+	})
+
+	t.Run("non admin", func(t *testing.T) {
+		_, err := service.ExportPatient(domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}, patientID)
+		if !errors.Is(err, domain.ErrAdminRequired) {
+			t.Errorf("ExportPatient() expected ErrAdminRequired, got %v", err)
+		}
+	})
+}
+
+func TestExportService_OpenExportedAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachmentRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockBlobs := mocks.NewMockBlobStorage(ctrl)
+	service := NewExportService(Repositories{Attachment: mockAttachmentRepo, Blobs: mockBlobs}, mocks.NewMockSupport(ctrl))
+	admin := domain.Caller{UserID: "admin-id", Role: domain.RoleAdmin}
+	stored := &domain.Attachment{ID: "a1", PatientID: "p1", Checksum: strings.Repeat("ab", 32), ContentKey: []byte("key")}
+
+	t.Run("opens the patient's attachment", func(t *testing.T) {
+		mockAttachmentRepo.EXPECT().GetAttachmentByID("a1").Return(stored, nil)
+		mockBlobs.EXPECT().Open("a1", stored.ContentKey, stored.Checksum).Return(io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+
+		content, err := service.OpenExportedAttachment(admin, "p1", "a1")
+		if err != nil {
+			t.Fatalf("OpenExportedAttachment() unexpected error = %v", err)
+		}
+		content.Close()
+	})
+
+	t.Run("attachment of another patient", func(t *testing.T) {
+		mockAttachmentRepo.EXPECT().GetAttachmentByID("a1").Return(stored, nil)
+
+		if _, err := service.OpenExportedAttachment(admin, "p2", "a1"); !errors.Is(err, domain.ErrAttachmentNotFound) {
+			t.Errorf("OpenExportedAttachment() expected ErrAttachmentNotFound, got %v", err)
+		}
+	})
+
+	t.Run("non admin", func(t *testing.T) {
+		caller := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+		if _, err := service.OpenExportedAttachment(caller, "p1", "a1"); !errors.Is(err, domain.ErrAdminRequired) {
+			t.Errorf("OpenExportedAttachment() expected ErrAdminRequired, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type LabResultService struct {
+	repo        domain.LabResultRepository
+	patientRepo domain.PatientRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewLabResultService(repo domain.LabResultRepository, patientRepo domain.PatientRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *LabResultService {
+	return &LabResultService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+// IngestLabResults stores a batch of results of a patient, usually one lab
+// report. The batch is rejected as a whole when any result is invalid.
+func (s *LabResultService) IngestLabResults(caller domain.Caller, patientID string, results []domain.LabResult) ([]domain.LabResult, error) {
+	// Enforce domain invariants
+	if err := domain.ValidateLabBatch(results); err != nil {
+		slog.Warn("Lab result batch validation failed", "patient_id", patientID, "size", len(results), "error", err)
+		return nil, err
+	}
+
+	batchID, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for lab result batch", "error", errCreateID)
+		return nil, errCreateID
+	}
+	now := time.Now()
+	diagnosisIDs := make(map[string]bool)
+	for i := range results {
+		id, errCreateID := s.support.CreateNewID()
+		if errCreateID != nil {
+			slog.Error("ID creation failed for lab result", "error", errCreateID)
+			return nil, errCreateID
+		}
+		result := &results[i]
+		result.ID = id
+		result.PatientID = patientID
+		result.BatchID = batchID
+		result.RecordedBy = caller.UserID
+		result.CreatedAt = now
+		if result.CollectedAt.IsZero() {
+			result.CollectedAt = now
+		}
+
+		if errValidate := result.Validate(); errValidate != nil {
+			slog.Warn("Lab result validation failed", "patient_id", patientID, "index", i, "analyte", result.Analyte, "error", errValidate)
+			return nil, errValidate
+		}
+		if result.DiagnosisID != "" {
+			diagnosisIDs[result.DiagnosisID] = true
+		}
+	}
+
+	if err := s.access.authorize(caller, patientID, domain.AccessActionWrite); err != nil {
+		return nil, err
+	}
+	if err := checkActivePatient(s.patientRepo, patientID); err != nil {
+		return nil, err
+	}
+	for diagnosisID := range diagnosisIDs {
+		if err := checkPatientDiagnosis(s.patientRepo, patientID, diagnosisID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.CreateLabResults(results); err != nil {
+		slog.Error("Lab result creation in repository failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	abnormal := 0
+	for i := range results {
+		if results[i].IsAbnormal() {
+			abnormal++
+		}
+	}
+	slog.Info("Lab results ingested", "batch_id", batchID, "patient_id", patientID, "results", len(results), "abnormal", abnormal)
+	return results, nil
+}
+
+func (s *LabResultService) GetLabResults(caller domain.Caller, patientID string, filter domain.LabResultFilter) ([]domain.LabResult, error) {
+	if err := s.access.authorizeClinicalRead(caller, patientID); err != nil {
+		return nil, err
+	}
+
+	results, err := s.repo.GetLabResultsByPatientID(patientID, filter)
+	if err != nil {
+		slog.Error("Lab result lookup failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetAbnormalLabResults lists the out of range results collected in [from,
+// to) across the patients of the caller's care teams, for clinicians to
+// follow up on
+func (s *LabResultService) GetAbnormalLabResults(caller domain.Caller, from, to time.Time) ([]domain.LabResult, error) {
+	if caller.IsIntegration() {
+		slog.Warn("Abnormal lab results denied to integration client", "user_id", caller.UserID)
+		return nil, domain.ErrAccessDenied
+	}
+	if err := domain.ValidateAbnormalLabRange(from, to); err != nil {
+		slog.Warn("Invalid abnormal lab results range", "from", from, "to", to)
+		return nil, err
+	}
+
+	results, err := s.repo.GetAbnormalLabResults(caller, from, to)
+	if err != nil {
+		slog.Error("Abnormal lab result search failed", "user_id", caller.UserID, "error", err)
+		return nil, err
+	}
+
+	patientIDs := make([]string, len(results))
+	for i, r := range results {
+		patientIDs[i] = r.PatientID
+	}
+	s.access.recordSearch(caller, patientIDs)
+	return results, nil
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestLabResultService_IngestLabResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLabResultRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewLabResultService(mockRepo, mockPatientRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	low, high := 12.0, 16.0
+	newBatch := func() []domain.LabResult {
+		return []domain.LabResult{
+			{Panel: "Complete blood count", Analyte: "Hemoglobin", Value: 10.9, Unit: "g/dL", Reference: domain.ReferenceRange{Low: &low, High: &high}},
+			{Panel: "Complete blood count", Analyte: "Hematocrit", Value: 41, Unit: "%"},
+		}
+	}
+
+	t.Run("successful ingestion flags values out of range", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("batch-id", nil)
+		mockSupport.EXPECT().CreateNewID().Return("result-1", nil)
+		mockSupport.EXPECT().CreateNewID().Return("result-2", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockRepo.EXPECT().CreateLabResults(gomock.Len(2)).Return(nil)
+
+		results, err := service.IngestLabResults(caller, "p1", newBatch())
+		if err != nil {
+			t.Fatalf("IngestLabResults() unexpected error = %v", err)
+		}
+		if results[0].ID != "result-1" || results[1].BatchID != "batch-id" || results[1].PatientID != "p1" || results[0].CollectedAt.IsZero() {
+			t.Errorf("IngestLabResults() = %+v", results)
+		}
+		if !results[0].IsAbnormal() || results[1].IsAbnormal() {
+			t.Errorf("expected only the hemoglobin flagged, got %q and %q", results[0].Flag(), results[1].Flag())
+		}
+	})
+
+	t.Run("one invalid result rejects the batch", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("batch-id", nil)
+		mockSupport.EXPECT().CreateNewID().Return("result-1", nil)
+		mockSupport.EXPECT().CreateNewID().Return("result-2", nil)
+
+		batch := newBatch()
+		batch[1].Analyte = ""
+		if _, err := service.IngestLabResults(caller, "p1", batch); !errors.Is(err, domain.ErrEmptyLabAnalyte) {
+			t.Errorf("IngestLabResults() expected ErrEmptyLabAnalyte, got %v", err)
+		}
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		if _, err := service.IngestLabResults(caller, "p1", nil); !errors.Is(err, domain.ErrEmptyLabBatch) {
+			t.Errorf("IngestLabResults() expected ErrEmptyLabBatch, got %v", err)
+		}
+	})
+}
+
+func TestLabResultService_GetAbnormalLabResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLabResultRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewLabResultService(mockRepo, mocks.NewMockPatientRepository(ctrl), mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("logs access to every patient listed", func(t *testing.T) {
+		mockRepo.EXPECT().GetAbnormalLabResults(caller, from, to).Return([]domain.LabResult{
+			{ID: "l1", PatientID: "p1"}, {ID: "l2", PatientID: "p1"}, {ID: "l3", PatientID: "p2"},
+		}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p2", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil).Times(2)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil).Times(2)
+
+		results, err := service.GetAbnormalLabResults(caller, from, to)
+		if err != nil || len(results) != 3 {
+			t.Errorf("GetAbnormalLabResults() = %+v, %v", results, err)
+		}
+	})
+
+	t.Run("range longer than a year", func(t *testing.T) {
+		if _, err := service.GetAbnormalLabResults(caller, from, from.AddDate(2, 0, 0)); !errors.Is(err, domain.ErrInvalidLabRange) {
+			t.Errorf("GetAbnormalLabResults() expected ErrInvalidLabRange, got %v", err)
+		}
+	})
+
+	t.Run("denied to integration clients", func(t *testing.T) {
+		integration := domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}
+		if _, err := service.GetAbnormalLabResults(integration, from, to); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("GetAbnormalLabResults() expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
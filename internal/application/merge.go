@@ -0,0 +1,107 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type MergeService struct {
+	repo        domain.MergeRepository
+	patientRepo domain.PatientRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewMergeService(repo domain.MergeRepository, patientRepo domain.PatientRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *MergeService {
+	return &MergeService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+// FindDuplicates lists the patients the caller may access that are likely
+// the same person as the given one
+func (s *MergeService) FindDuplicates(caller domain.Caller, patientID string) ([]domain.DuplicateCandidate, error) {
+	if caller.IsIntegration() {
+		slog.Warn("Duplicate search rejected for integration client", "user_id", caller.UserID)
+		return nil, domain.ErrAccessDenied
+	}
+	if err := s.access.authorize(caller, patientID, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+
+	patient, err := s.patientRepo.GetPatientByID(patientID)
+	if err != nil {
+		slog.Warn("Duplicate search failed: patient not found", "patient_id", patientID)
+		return nil, err
+	}
+	if patient.IsMerged() {
+		return nil, domain.ErrPatientMerged
+	}
+
+	candidates, err := s.repo.FindDuplicateCandidates(caller, patient)
+	if err != nil {
+		slog.Error("Duplicate search in repository failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+
+	candidateIDs := make([]string, len(candidates))
+	for i, c := range candidates {
+		candidateIDs[i] = c.Patient.ID
+	}
+	s.access.recordSearch(caller, candidateIDs)
+
+	slog.Info("Duplicate candidates found", "patient_id", patientID, "count", len(candidates))
+	return candidates, nil
+}
+
+// MergePatients merges a duplicate record into the surviving one. The caller
+// needs write access to both patients.
+func (s *MergeService) MergePatients(caller domain.Caller, survivorID, duplicateID, reason string) (*domain.PatientMerge, error) {
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for patient merge", "error", errCreateID)
+		return nil, errCreateID
+	}
+
+	merge := &domain.PatientMerge{
+		ID:          id,
+		SurvivorID:  survivorID,
+		DuplicateID: duplicateID,
+		MergedBy:    caller.UserID,
+		Reason:      reason,
+		MergedAt:    time.Now(),
+	}
+	if errValidate := merge.Validate(); errValidate != nil {
+		slog.Warn("Patient merge validation failed", "error", errValidate)
+		return nil, errValidate
+	}
+
+	for _, patientID := range []string{survivorID, duplicateID} {
+		if err := s.access.authorize(caller, patientID, domain.AccessActionWrite); err != nil {
+			return nil, err
+		}
+		patient, err := s.patientRepo.GetPatientByID(patientID)
+		if err != nil {
+			slog.Warn("Patient merge failed: patient not found", "patient_id", patientID)
+			return nil, err
+		}
+		if patient.IsErased() {
+			return nil, domain.ErrPatientAlreadyErased
+		}
+		if patient.IsMerged() {
+			return nil, domain.ErrPatientMerged
+		}
+	}
+
+	if err := s.repo.MergePatients(merge); err != nil {
+		slog.Error("Patient merge in repository failed", "survivor_id", survivorID, "duplicate_id", duplicateID, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Patients merged", "merge_id", merge.ID, "survivor_id", survivorID, "duplicate_id", duplicateID, "diagnoses_moved", merge.DiagnosesMoved)
+	return merge, nil
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeService_MergePatients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMergeRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewMergeService(mockRepo, mockPatientRepo, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	t.Run("successful merge", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("merge-id", nil)
+		for _, id := range []string{"survivor", "duplicate"} {
+			mockCareTeamRepo.EXPECT().IsCareTeamMember(id, caller.UserID).Return(true, nil)
+			mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+			mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+			mockPatientRepo.EXPECT().GetPatientByID(id).Return(&domain.Patient{ID: id}, nil)
+		}
+		mockRepo.EXPECT().MergePatients(gomock.Any()).DoAndReturn(func(m *domain.PatientMerge) error {
+			if m.SurvivorID != "survivor" || m.DuplicateID != "duplicate" || m.MergedBy != caller.UserID {
+				t.Errorf("MergePatients() unexpected merge %+v", m)
+			}
+			m.DiagnosesMoved = 2
+			return nil
+		})
+
+		merge, err := service.MergePatients(caller, "survivor", "duplicate", "Registered twice")
+		if err != nil {
+			t.Fatalf("MergePatients() unexpected error = %v", err)
+		}
+		if merge.ID != "merge-id" || merge.DiagnosesMoved != 2 {
+			t.Errorf("MergePatients() = %+v", merge)
+		}
+	})
+
+	t.Run("same patient", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("merge-id", nil)
+		if _, err := service.MergePatients(caller, "survivor", "survivor", ""); !errors.Is(err, domain.ErrMergeSamePatient) {
+			t.Errorf("MergePatients() expected ErrMergeSamePatient, got %v", err)
+		}
+	})
+
+	t.Run("duplicate already merged", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("merge-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("survivor", caller.UserID).Return(true, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("duplicate", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil).Times(2)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil).Times(2)
+		mockPatientRepo.EXPECT().GetPatientByID("survivor").Return(&domain.Patient{ID: "survivor"}, nil)
+		mockPatientRepo.EXPECT().GetPatientByID("duplicate").Return(&domain.Patient{ID: "duplicate", MergedInto: "other"}, nil)
+
+		if _, err := service.MergePatients(caller, "survivor", "duplicate", ""); !errors.Is(err, domain.ErrPatientMerged) {
+			t.Errorf("MergePatients() expected ErrPatientMerged, got %v", err)
+		}
+	})
+
+	t.Run("no access to the duplicate", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("merge-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("survivor", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("survivor").Return(&domain.Patient{ID: "survivor"}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("duplicate", caller.UserID).Return(false, nil)
+		mockCareTeamRepo.EXPECT().GetActiveBreakGlassAccess("duplicate", caller.UserID, gomock.Any()).Return(nil, nil)
+
+		if _, err := service.MergePatients(caller, "survivor", "duplicate", ""); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("MergePatients() expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
+
+func TestMergeService_FindDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMergeRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewMergeService(mockRepo, mockPatientRepo, mockCareTeamRepo, mockConsentRepo, mockSupport)
+
+	t.Run("integration clients are rejected", func(t *testing.T) {
+		integration := domain.Caller{UserID: "app", Role: domain.RoleIntegration}
+		if _, err := service.FindDuplicates(integration, "p1"); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("FindDuplicates() expected ErrAccessDenied, got %v", err)
+		}
+	})
+
+	t.Run("logs a search access per candidate", func(t *testing.T) {
+		caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+		patient := &domain.Patient{ID: "p1"}
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p2", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil).Times(2)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil).Times(2)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(patient, nil)
+		mockRepo.EXPECT().FindDuplicateCandidates(caller, patient).Return([]domain.DuplicateCandidate{
+			{Patient: domain.Patient{ID: "p2"}, Score: 0.75, Matches: []string{domain.DuplicateMatchName}},
+		}, nil)
+
+		candidates, err := service.FindDuplicates(caller, "p1")
+		if err != nil || len(candidates) != 1 {
+			t.Errorf("FindDuplicates() = %+v, %v", candidates, err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"log/slog"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type ObservationService struct {
+	repo        domain.ObservationRepository
+	patientRepo domain.PatientRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewObservationService(repo domain.ObservationRepository, patientRepo domain.PatientRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *ObservationService {
+	return &ObservationService{
+		repo:        repo,
+		patientRepo: patientRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+func (s *ObservationService) RecordObservation(caller domain.Caller, observation *domain.Observation) error {
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for observation", "error", errCreateID)
+		return errCreateID
+	}
+	observation.ID = id
+	observation.RecordedBy = caller.UserID
+	observation.CreatedAt = time.Now()
+	if observation.EffectiveAt.IsZero() {
+		observation.EffectiveAt = observation.CreatedAt
+	}
+	observation.ApplyDefaultReference()
+
+	// Enforce domain invariants
+	if errValidate := observation.Validate(); errValidate != nil {
+		slog.Warn("Observation validation failed", "code", observation.Code, "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, observation.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+	if err := checkActivePatient(s.patientRepo, observation.PatientID); err != nil {
+		return err
+	}
+	if observation.DiagnosisID != "" {
+		if err := checkPatientDiagnosis(s.patientRepo, observation.PatientID, observation.DiagnosisID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.CreateObservation(observation); err != nil {
+		slog.Error("Observation creation in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Observation recorded", "observation_id", observation.ID, "patient_id", observation.PatientID, "code", observation.Code)
+	return nil
+}
+
+func (s *ObservationService) GetObservations(caller domain.Caller, patientID string, filter domain.ObservationFilter) ([]domain.Observation, error) {
+	if filter.Code != nil {
+		if _, ok := domain.LookupObservationType(*filter.Code); !ok {
+			return nil, domain.ErrUnknownObservationCode
+		}
+	}
+
+	if err := s.access.authorizeClinicalRead(caller, patientID); err != nil {
+		return nil, err
+	}
+
+	observations, err := s.repo.GetObservationsByPatientID(patientID, filter)
+	if err != nil {
+		slog.Error("Observation lookup failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+	return observations, nil
+}
+
+// GetObservationSeries returns the time series of one observation type for
+// charting, with every value in the canonical unit of the type
+func (s *ObservationService) GetObservationSeries(caller domain.Caller, patientID, code string, from, to *time.Time) (*domain.ObservationSeries, error) {
+	observations, err := s.GetObservations(caller, patientID, domain.ObservationFilter{Code: &code, From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewObservationSeries(code, observations)
+}
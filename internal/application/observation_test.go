@@ -0,0 +1,129 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestObservationService_RecordObservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockObservationRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewObservationService(mockRepo, mockPatientRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	expectAuthorized := func() {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+	}
+
+	t.Run("successful record with the default reference range", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("observation-id", nil)
+		expectAuthorized()
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockRepo.EXPECT().CreateObservation(gomock.Any()).Return(nil)
+
+		observation := &domain.Observation{PatientID: "p1", Code: domain.ObservationHeartRate, Value: 112, Unit: "/min"}
+		if err := service.RecordObservation(caller, observation); err != nil {
+			t.Fatalf("RecordObservation() unexpected error = %v", err)
+		}
+		if observation.ID != "observation-id" || observation.RecordedBy != caller.UserID || observation.EffectiveAt.IsZero() {
+			t.Errorf("RecordObservation() = %+v", observation)
+		}
+		if got := observation.Interpretation(); got != domain.InterpretationHigh {
+			t.Errorf("Interpretation() = %q, want %q", got, domain.InterpretationHigh)
+		}
+	})
+
+	t.Run("diagnosis of another patient", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("observation-id", nil)
+		expectAuthorized()
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockPatientRepo.EXPECT().GetDiagnosisByPatientID("p1").Return([]domain.Diagnosis{{ID: "d1", PatientID: "p1"}}, nil)
+
+		observation := &domain.Observation{PatientID: "p1", Code: domain.ObservationHeartRate, Value: 72, Unit: "/min", DiagnosisID: "d2"}
+		if err := service.RecordObservation(caller, observation); !errors.Is(err, domain.ErrDiagnosisPatientMismatch) {
+			t.Errorf("RecordObservation() expected ErrDiagnosisPatientMismatch, got %v", err)
+		}
+	})
+
+	t.Run("implausible value", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("observation-id", nil)
+
+		observation := &domain.Observation{PatientID: "p1", Code: domain.ObservationSystolicBP, Value: 1200, Unit: "mm[Hg]"}
+		if err := service.RecordObservation(caller, observation); !errors.Is(err, domain.ErrImplausibleObservation) {
+			t.Errorf("RecordObservation() expected ErrImplausibleObservation, got %v", err)
+		}
+	})
+
+	t.Run("integration client cannot record", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("observation-id", nil)
+
+		integration := domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}
+		observation := &domain.Observation{PatientID: "p1", Code: domain.ObservationHeartRate, Value: 72, Unit: "/min"}
+		if err := service.RecordObservation(integration, observation); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("RecordObservation() expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
+
+func TestObservationService_GetObservationSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockObservationRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewObservationService(mockRepo, mocks.NewMockPatientRepository(ctrl), mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	code := domain.ObservationTemperature
+
+	t.Run("values converted to the canonical unit", func(t *testing.T) {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetObservationsByPatientID("p1", domain.ObservationFilter{Code: &code}).Return([]domain.Observation{
+			{PatientID: "p1", Code: code, Value: 36.5, Unit: "Cel"},
+			{PatientID: "p1", Code: code, Value: 104, Unit: "[degF]"},
+		}, nil)
+
+		series, err := service.GetObservationSeries(caller, "p1", code, nil, nil)
+		if err != nil {
+			t.Fatalf("GetObservationSeries() unexpected error = %v", err)
+		}
+		if len(series.Points) != 2 || series.Points[1].Value != 40 {
+			t.Errorf("GetObservationSeries() points = %+v", series.Points)
+		}
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		if _, err := service.GetObservationSeries(caller, "p1", "0000-0", nil, nil); !errors.Is(err, domain.ErrUnknownObservationCode) {
+			t.Errorf("GetObservationSeries() expected ErrUnknownObservationCode, got %v", err)
+		}
+	})
+
+	t.Run("integration client without consent to share diagnoses", func(t *testing.T) {
+		integration := domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}
+		consents := []domain.Consent{
+			{PatientID: "p1", Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeDemographics, GrantedAt: time.Now().Add(-time.Hour)},
+		}
+		mockConsentRepo.EXPECT().GetConsentsByPatientID("p1").Return(consents, nil).Times(2)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+
+		if _, err := service.GetObservationSeries(integration, "p1", code, nil, nil); !errors.Is(err, domain.ErrConsentRequired) {
+			t.Errorf("GetObservationSeries() expected ErrConsentRequired, got %v", err)
+		}
+	})
+}
@@ -7,15 +7,28 @@ import (
 )
 
 type PatientService struct {
-	repo    domain.PatientRepository
-	support domain.Support
+	repo          domain.PatientRepository
+	encounterRepo domain.EncounterRepository
+	access        *accessGuard
+	support       domain.Support
 }
 
-func NewPatientService(repo domain.PatientRepository, support domain.Support) *PatientService {
-	return &PatientService{repo: repo, support: support}
+func NewPatientService(repo domain.PatientRepository, encounterRepo domain.EncounterRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *PatientService {
+	return &PatientService{
+		repo:          repo,
+		encounterRepo: encounterRepo,
+		access:        newAccessGuard(careTeamRepo, consentRepo, support),
+		support:       support,
+	}
 }
 
-func (s *PatientService) CreatePatient(patient *domain.Patient) error {
+func (s *PatientService) CreatePatient(caller domain.Caller, patient *domain.Patient) error {
+	// Integration clients are read only and never join a care team
+	if caller.IsIntegration() {
+		slog.Warn("Patient creation denied to integration client", "user_id", caller.UserID)
+		return domain.ErrAccessDenied
+	}
+
 	id, errCreateID := s.support.CreateNewID()
 	if errCreateID != nil {
 		slog.Error("ID creation failed for patient", "error", errCreateID)
@@ -23,14 +36,33 @@ func (s *PatientService) CreatePatient(patient *domain.Patient) error {
 	}
 	patient.ID = id
 
+	// Phones are stored in E.164 so reminders can be sent to them
+	if patient.Phone != "" {
+		phone, err := domain.NormalizePhone(patient.Phone)
+		if err != nil {
+			slog.Warn("Patient phone normalization failed", "error", err)
+			return err
+		}
+		patient.Phone = phone
+	}
+
+	patient.Address.Complete()
+
 	// Enforce domain invariants
 	if errValidate := patient.Validate(); errValidate != nil {
 		slog.Warn("Patient validation failed", "error", errValidate)
 		return errValidate
 	}
 
-	err := s.repo.CreatePatient(patient)
-	if err != nil {
+	// The creator becomes the first member of the patient's care team, stored
+	// with the patient so no patient is left without anyone able to access it
+	member := &domain.CareTeamMember{
+		PatientID: patient.ID,
+		UserID:    caller.UserID,
+		AddedBy:   caller.UserID,
+		AddedAt:   time.Now(),
+	}
+	if err := s.repo.CreatePatient(patient, member); err != nil {
 		slog.Error("Patient creation in repository failed", "error", err)
 		return err
 	}
@@ -39,41 +71,160 @@ func (s *PatientService) CreatePatient(patient *domain.Patient) error {
 	return nil
 }
 
-func (s *PatientService) GetPatient(dni string) (*domain.Patient, error) {
-	return s.repo.GetPatientByDNI(dni)
+func (s *PatientService) GetPatient(caller domain.Caller, id string) (*domain.Patient, error) {
+	if err := s.access.authorize(caller, id, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPatientByID(id)
 }
 
-func (s *PatientService) CreateDiagnosis(diagnosis *domain.Diagnosis) error {
+func (s *PatientService) CreateDiagnosis(caller domain.Caller, diagnosis *domain.Diagnosis, followUpDays *int) (*domain.Appointment, error) {
 	id, errCreateID := s.support.CreateNewID()
 	if errCreateID != nil {
 		slog.Error("ID creation failed for diagnosis", "error", errCreateID)
-		return errCreateID
+		return nil, errCreateID
 	}
 	diagnosis.ID = id
 
 	// Enforce domain invariants
 	if errValidate := diagnosis.Validate(); errValidate != nil {
 		slog.Warn("Diagnosis validation failed", "error", errValidate)
-		return errValidate
+		return nil, errValidate
+	}
+
+	var followUp *domain.Appointment
+	if followUpDays != nil {
+		var err error
+		if followUp, err = s.newFollowUp(caller, diagnosis, *followUpDays); err != nil {
+			return nil, err
+		}
+	}
+
+	// Internal logic: Validate patient exists in DB. Erased patients are
+	// frozen until their records are purged, and merged ones live on in
+	// another record.
+	if _, err := activePatient(s.repo, diagnosis.PatientID); err != nil {
+		slog.Warn("Diagnosis creation failed: patient not active", "patient_id", diagnosis.PatientID, "error", err)
+		return nil, err
 	}
 
-	// Internal logic: Validate patient exists in DB
-	_, errGetPatient := s.repo.GetPatientByID(diagnosis.PatientID)
-	if errGetPatient != nil {
-		slog.Warn("Diagnosis creation failed: patient not found", "patient_id", diagnosis.PatientID)
-		return errGetPatient
+	if err := s.access.authorize(caller, diagnosis.PatientID, domain.AccessActionWrite); err != nil {
+		return nil, err
+	}
+	if diagnosis.EncounterID != "" {
+		if err := checkOpenEncounter(s.encounterRepo, diagnosis.PatientID, diagnosis.EncounterID); err != nil {
+			slog.Warn("Diagnosis encounter check failed", "encounter_id", diagnosis.EncounterID, "error", err)
+			return nil, err
+		}
 	}
 
-	err := s.repo.CreateDiagnosis(diagnosis)
+	// The follow-up is stored with the diagnosis, so a failure never leaves
+	// a diagnosis behind for a retry to duplicate
+	err := s.repo.CreateDiagnosis(diagnosis, followUp)
 	if err != nil {
 		slog.Error("Diagnosis creation in repository failed", "error", err)
-		return err
+		return nil, err
 	}
 
-	slog.Info("Diagnosis created successfully", "diagnosis_id", diagnosis.ID, "patient_id", diagnosis.PatientID)
-	return nil
+	slog.Info("Diagnosis created successfully", "diagnosis_id", diagnosis.ID, "patient_id", diagnosis.PatientID, "follow_up", followUp != nil)
+	return followUp, nil
 }
 
-func (s *PatientService) GetDiagnostics(patientName *string, dateStart, dateEnd *time.Time) ([]domain.Diagnosis, error) {
-	return s.repo.SearchDiagnosis(patientName, dateStart, dateEnd)
+// newFollowUp returns the pending follow-up of a new diagnosis with the caller
+func (s *PatientService) newFollowUp(caller domain.Caller, diagnosis *domain.Diagnosis, days int) (*domain.Appointment, error) {
+	followUp, err := domain.NewFollowUp(diagnosis, caller.UserID, days)
+	if err != nil {
+		slog.Warn("Follow-up validation failed", "error", err)
+		return nil, err
+	}
+
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for follow-up", "error", errCreateID)
+		return nil, errCreateID
+	}
+	followUp.ID = id
+	followUp.CreatedAt = time.Now()
+
+	// Enforce domain invariants
+	if errValidate := followUp.Validate(); errValidate != nil {
+		slog.Warn("Follow-up validation failed", "error", errValidate)
+		return nil, errValidate
+	}
+	return followUp, nil
+}
+
+func (s *PatientService) GetDiagnosis(caller domain.Caller, id string) (*domain.Diagnosis, error) {
+	diagnosis, err := s.repo.GetDiagnosisByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.access.authorize(caller, diagnosis.PatientID, domain.AccessActionRead); err != nil {
+		return nil, err
+	}
+	if !caller.IsIntegration() {
+		return diagnosis, nil
+	}
+
+	// Integration clients only get what the patient consented to share
+	redacted, err := s.access.consent.redactDiagnostics([]domain.Diagnosis{*diagnosis}, domain.ConsentPurposeThirdPartySharing)
+	if err != nil {
+		return nil, err
+	}
+	if len(redacted) == 0 {
+		return nil, domain.ErrConsentRequired
+	}
+	return &redacted[0], nil
+}
+
+func (s *PatientService) GetDiagnostics(caller domain.Caller, filter domain.DiagnosisFilter) (*domain.DiagnosisPage, error) {
+	// Results are restricted to patients in the caller's care team or under an
+	// active break-glass grant. Integration clients only get what patients
+	// consented to share.
+	if err := filter.Validate(); err != nil {
+		slog.Warn("Invalid diagnosis search filter", "error", err)
+		return nil, err
+	}
+
+	page, err := s.repo.SearchDiagnosis(caller, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.IsIntegration() {
+		page.Diagnoses, err = s.access.consent.redactDiagnostics(page.Diagnoses, domain.ConsentPurposeThirdPartySharing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	patientIDs := make([]string, len(page.Diagnoses))
+	for i, d := range page.Diagnoses {
+		patientIDs[i] = d.PatientID
+	}
+	s.access.recordSearch(caller, patientIDs)
+	return page, nil
+}
+
+func (s *PatientService) ListPatients(caller domain.Caller, filter domain.PatientFilter) (*domain.PatientPage, error) {
+	if err := filter.Validate(); err != nil {
+		slog.Warn("Invalid patient listing filter", "sort", filter.Sort, "error", err)
+		return nil, err
+	}
+
+	// Same visibility as diagnosis search: care team, break-glass or, for
+	// integration clients, consent to share demographics
+	page, err := s.repo.SearchPatients(caller, filter)
+	if err != nil {
+		slog.Error("Patient search in repository failed", "error", err)
+		return nil, err
+	}
+
+	// Only the patients of the page are logged
+	patientIDs := make([]string, len(page.Patients))
+	for i, p := range page.Patients {
+		patientIDs[i] = p.Patient.ID
+	}
+	s.access.recordSearch(caller, patientIDs)
+	return page, nil
 }
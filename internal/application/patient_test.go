@@ -15,20 +15,29 @@ func TestPatientService_CreatePatient(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
 	mockSupport := mocks.NewMockSupport(ctrl)
-	service := NewPatientService(mockRepo, mockSupport)
+	service := NewPatientService(mockRepo, mocks.NewMockEncounterRepository(ctrl), mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id"}
 
 	patient := &domain.Patient{
-		Name:  "Maria Garcia",
-		DNI:   "12345678Z",
-		Email: "maria@example.com",
+		GivenName:    "Maria",
+		FirstSurname: "Garcia",
+		DNI:          "12345678Z",
+		Email:        "maria@example.com",
 	}
 
 	t.Run("successful creation", func(t *testing.T) {
 		mockSupport.EXPECT().CreateNewID().Return("01HMGNBPJNX0G2BZXJ7XW1RHPR", nil)
-		mockRepo.EXPECT().CreatePatient(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreatePatient(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *domain.Patient, m *domain.CareTeamMember) error {
+			if m == nil || m.UserID != caller.UserID || m.PatientID != "01HMGNBPJNX0G2BZXJ7XW1RHPR" {
+				t.Errorf("CreatePatient() expected creator in care team, got %+v", m)
+			}
+			return nil
+		})
 
-		err := service.CreatePatient(patient)
+		err := service.CreatePatient(caller, patient)
 		if err != nil {
 			t.Errorf("CreatePatient() unexpected error = %v", err)
 		}
@@ -37,20 +46,53 @@ func TestPatientService_CreatePatient(t *testing.T) {
 		}
 	})
 
+	t.Run("phone normalized to E.164", func(t *testing.T) {
+		withPhone := *patient
+		withPhone.Phone = "600 12 34 56"
+		mockSupport.EXPECT().CreateNewID().Return("01HMGNBPJNX0G2BZXJ7XW1RHPR", nil)
+		mockRepo.EXPECT().CreatePatient(gomock.Any(), gomock.Any()).DoAndReturn(func(p *domain.Patient, _ *domain.CareTeamMember) error {
+			if p.Phone != "+34600123456" {
+				t.Errorf("CreatePatient() expected E.164 phone, got %q", p.Phone)
+			}
+			return nil
+		})
+
+		if err := service.CreatePatient(caller, &withPhone); err != nil {
+			t.Errorf("CreatePatient() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("invalid phone", func(t *testing.T) {
+		withPhone := *patient
+		withPhone.Phone = "12345"
+		mockSupport.EXPECT().CreateNewID().Return("01HMGNBPJNX0G2BZXJ7XW1RHPR", nil)
+
+		if err := service.CreatePatient(caller, &withPhone); !errors.Is(err, domain.ErrInvalidPhone) {
+			t.Errorf("CreatePatient() expected ErrInvalidPhone, got %v", err)
+		}
+	})
+
+	t.Run("integration client", func(t *testing.T) {
+		partner := domain.Caller{UserID: "partner", Role: domain.RoleIntegration}
+		if err := service.CreatePatient(partner, patient); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("CreatePatient() expected ErrAccessDenied, got %v", err)
+		}
+	})
+
 	t.Run("ID creation failure", func(t *testing.T) {
 		mockSupport.EXPECT().CreateNewID().Return("", errors.New("id error"))
 
-		err := service.CreatePatient(patient)
+		err := service.CreatePatient(caller, patient)
 		if err == nil {
 			t.Error("CreatePatient() expected error, got nil")
 		}
 	})
 
 	t.Run("validation failure", func(t *testing.T) {
-		invalidPatient := &domain.Patient{Name: ""} // Missing ID, Name, DNI, etc.
+		invalidPatient := &domain.Patient{} // Missing ID, name, DNI, etc.
 		mockSupport.EXPECT().CreateNewID().Return("valid-id", nil)
 
-		err := service.CreatePatient(invalidPatient)
+		err := service.CreatePatient(caller, invalidPatient)
 		if err == nil {
 			t.Error("CreatePatient() expected validation error, got nil")
 		}
@@ -62,8 +104,12 @@ func TestPatientService_CreateDiagnosis(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
 	mockSupport := mocks.NewMockSupport(ctrl)
-	service := NewPatientService(mockRepo, mockSupport)
+	mockEncounterRepo := mocks.NewMockEncounterRepository(ctrl)
+	service := NewPatientService(mockRepo, mockEncounterRepo, mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id"}
 
 	diagnosis := &domain.Diagnosis{
 		PatientID: "01HMGNBPJNX0G2BZXJ7XW1RHPR",
@@ -74,21 +120,322 @@ func TestPatientService_CreateDiagnosis(t *testing.T) {
 	t.Run("successful creation", func(t *testing.T) {
 		mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
 		mockRepo.EXPECT().GetPatientByID(diagnosis.PatientID).Return(&domain.Patient{}, nil)
-		mockRepo.EXPECT().CreateDiagnosis(diagnosis).Return(nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(diagnosis.PatientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreateDiagnosis(diagnosis, nil).Return(nil)
 
-		err := service.CreateDiagnosis(diagnosis)
+		_, err := service.CreateDiagnosis(caller, diagnosis, nil)
 		if err != nil {
 			t.Errorf("CreateDiagnosis() unexpected error = %v", err)
 		}
 	})
 
+	t.Run("with follow-up", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
+		mockSupport.EXPECT().CreateNewID().Return("appointment-id", nil)
+		mockRepo.EXPECT().GetPatientByID(diagnosis.PatientID).Return(&domain.Patient{}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(diagnosis.PatientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreateDiagnosis(gomock.Any(), gomock.Any()).Return(nil)
+
+		dated := *diagnosis
+		dated.Date = time.Date(2026, 2, 13, 18, 23, 0, 0, time.UTC)
+		days := 14
+		followUp, err := service.CreateDiagnosis(caller, &dated, &days)
+		if err != nil {
+			t.Fatalf("CreateDiagnosis() unexpected error = %v", err)
+		}
+		if followUp.ID != "appointment-id" || followUp.DiagnosisID != "diag-id" || followUp.Status != domain.AppointmentStatusPending ||
+			followUp.DueDate.Format("2006-01-02") != "2026-02-27" {
+			t.Errorf("CreateDiagnosis() follow-up = %+v", followUp)
+		}
+	})
+
+	t.Run("invalid follow-up", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
+
+		days := 0
+		if _, err := service.CreateDiagnosis(caller, diagnosis, &days); !errors.Is(err, domain.ErrInvalidFollowUpDays) {
+			t.Errorf("CreateDiagnosis() expected ErrInvalidFollowUpDays, got %v", err)
+		}
+	})
+
 	t.Run("patient not found", func(t *testing.T) {
 		mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
 		mockRepo.EXPECT().GetPatientByID(diagnosis.PatientID).Return(nil, errors.New("not found"))
 
-		err := service.CreateDiagnosis(diagnosis)
+		_, err := service.CreateDiagnosis(caller, diagnosis, nil)
 		if err == nil {
 			t.Error("CreateDiagnosis() expected error, got nil")
 		}
 	})
+
+	t.Run("erased patient", func(t *testing.T) {
+		erasedAt := time.Now()
+		mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
+		mockRepo.EXPECT().GetPatientByID(diagnosis.PatientID).Return(&domain.Patient{ID: diagnosis.PatientID, ErasedAt: &erasedAt}, nil)
+
+		if _, err := service.CreateDiagnosis(caller, diagnosis, nil); !errors.Is(err, domain.ErrPatientAlreadyErased) {
+			t.Errorf("CreateDiagnosis() expected ErrPatientAlreadyErased, got %v", err)
+		}
+	})
+
+	t.Run("merged patient", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
+		mockRepo.EXPECT().GetPatientByID(diagnosis.PatientID).Return(&domain.Patient{ID: diagnosis.PatientID, MergedInto: "survivor"}, nil)
+
+		if _, err := service.CreateDiagnosis(caller, diagnosis, nil); !errors.Is(err, domain.ErrPatientMerged) {
+			t.Errorf("CreateDiagnosis() expected ErrPatientMerged, got %v", err)
+		}
+	})
+
+	t.Run("caller outside care team", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
+		mockRepo.EXPECT().GetPatientByID(diagnosis.PatientID).Return(&domain.Patient{}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(diagnosis.PatientID, caller.UserID).Return(false, nil)
+		mockCareTeamRepo.EXPECT().GetActiveBreakGlassAccess(diagnosis.PatientID, caller.UserID, gomock.Any()).Return(nil, nil)
+
+		_, err := service.CreateDiagnosis(caller, diagnosis, nil)
+		if !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("CreateDiagnosis() expected ErrAccessDenied, got %v", err)
+		}
+	})
+
+	encounterTests := []struct {
+		name      string
+		encounter *domain.Encounter
+		wantErr   error
+	}{
+		{"encounter of another patient", &domain.Encounter{ID: "e1", PatientID: "another", Status: domain.EncounterStatusOpen}, domain.ErrEncounterPatientMismatch},
+		{"closed encounter", &domain.Encounter{ID: "e1", PatientID: diagnosis.PatientID, Status: domain.EncounterStatusClosed}, domain.ErrEncounterClosed},
+	}
+	for _, tt := range encounterTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSupport.EXPECT().CreateNewID().Return("diag-id", nil)
+			mockRepo.EXPECT().GetPatientByID(diagnosis.PatientID).Return(&domain.Patient{}, nil)
+			mockCareTeamRepo.EXPECT().IsCareTeamMember(diagnosis.PatientID, caller.UserID).Return(true, nil)
+			mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+			mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+			mockEncounterRepo.EXPECT().GetEncounterByID("e1").Return(tt.encounter, nil)
+
+			withEncounter := *diagnosis
+			withEncounter.EncounterID = "e1"
+			if _, err := service.CreateDiagnosis(caller, &withEncounter, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateDiagnosis() expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPatientService_GetDiagnosis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewPatientService(mockRepo, mocks.NewMockEncounterRepository(ctrl), mockCareTeamRepo, mockConsentRepo, mockSupport)
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+	stored := func() *domain.Diagnosis {
+		return &domain.Diagnosis{ID: "d1", PatientID: patientID, Diagnosis: "Fever", Prescription: "Paracetamol", Date: time.Now()}
+	}
+
+	t.Run("care team member", func(t *testing.T) {
+		caller := domain.Caller{UserID: "user-id"}
+		mockRepo.EXPECT().GetDiagnosisByID("d1").Return(stored(), nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+
+		diagnosis, err := service.GetDiagnosis(caller, "d1")
+		if err != nil || diagnosis.Prescription != "Paracetamol" {
+			t.Errorf("GetDiagnosis() = %+v, %v", diagnosis, err)
+		}
+	})
+
+	t.Run("integration client without consent to share prescriptions", func(t *testing.T) {
+		integration := domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}
+		granted := time.Now().Add(-time.Hour)
+		mockRepo.EXPECT().GetDiagnosisByID("d1").Return(stored(), nil)
+		mockConsentRepo.EXPECT().GetConsentsByPatientID(patientID).Return([]domain.Consent{
+			{PatientID: patientID, Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeDemographics, GrantedAt: granted},
+			{PatientID: patientID, Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeDiagnoses, GrantedAt: granted},
+		}, nil).Times(2)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+
+		diagnosis, err := service.GetDiagnosis(integration, "d1")
+		if err != nil || diagnosis.Diagnosis != "Fever" || diagnosis.Prescription != "" {
+			t.Errorf("GetDiagnosis() = %+v, %v", diagnosis, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetDiagnosisByID("d2").Return(nil, domain.ErrDiagnosisNotFound)
+
+		if _, err := service.GetDiagnosis(domain.Caller{UserID: "user-id"}, "d2"); !errors.Is(err, domain.ErrDiagnosisNotFound) {
+			t.Errorf("GetDiagnosis() expected ErrDiagnosisNotFound, got %v", err)
+		}
+	})
+}
+
+func TestPatientService_GetPatient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewPatientService(mockRepo, mocks.NewMockEncounterRepository(ctrl), mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id"}
+	patientID := "01HMGNBPJNX0G2BZXJ7XW1RHPR"
+
+	t.Run("break-glass access is logged", func(t *testing.T) {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(false, nil)
+		mockCareTeamRepo.EXPECT().GetActiveBreakGlassAccess(patientID, caller.UserID, gomock.Any()).
+			Return(&domain.BreakGlassAccess{PatientID: patientID, UserID: caller.UserID}, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).DoAndReturn(func(e *domain.AccessLogEntry) error {
+			if !e.BreakGlass || e.Action != domain.AccessActionRead {
+				t.Errorf("GetPatient() expected break-glass read entry, got %+v", e)
+			}
+			return nil
+		})
+		mockRepo.EXPECT().GetPatientByID(patientID).Return(&domain.Patient{ID: patientID}, nil)
+
+		patient, err := service.GetPatient(caller, patientID)
+		if err != nil {
+			t.Errorf("GetPatient() unexpected error = %v", err)
+		}
+		if patient == nil || patient.ID != patientID {
+			t.Errorf("GetPatient() expected patient %s, got %+v", patientID, patient)
+		}
+	})
+
+	t.Run("access denied", func(t *testing.T) {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember(patientID, caller.UserID).Return(false, nil)
+		mockCareTeamRepo.EXPECT().GetActiveBreakGlassAccess(patientID, caller.UserID, gomock.Any()).Return(nil, nil)
+
+		_, err := service.GetPatient(caller, patientID)
+		if !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("GetPatient() expected ErrAccessDenied, got %v", err)
+		}
+	})
+
+	t.Run("integration client without consent", func(t *testing.T) {
+		integration := domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}
+		mockConsentRepo.EXPECT().GetConsentsByPatientID(patientID).Return([]domain.Consent{
+			{PatientID: patientID, Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeDiagnoses, GrantedAt: time.Now().Add(-time.Hour)},
+		}, nil)
+
+		_, err := service.GetPatient(integration, patientID)
+		if !errors.Is(err, domain.ErrConsentRequired) {
+			t.Errorf("GetPatient() expected ErrConsentRequired, got %v", err)
+		}
+	})
+}
+
+func TestPatientService_GetDiagnostics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewPatientService(mockRepo, mocks.NewMockEncounterRepository(ctrl), mockCareTeamRepo, mockConsentRepo, mockSupport)
+	integration := domain.Caller{UserID: "client-id", Role: domain.RoleIntegration}
+
+	t.Run("invalid age range", func(t *testing.T) {
+		from, to := 30, 18
+		if _, err := service.GetDiagnostics(integration, domain.DiagnosisFilter{AgeMin: &from, AgeMax: &to}); !errors.Is(err, domain.ErrInvalidAgeRange) {
+			t.Errorf("GetDiagnostics() expected ErrInvalidAgeRange, got %v", err)
+		}
+	})
+
+	t.Run("integration client only receives consented scopes", func(t *testing.T) {
+		granted := time.Now().Add(-time.Hour)
+		diagnostics := []domain.Diagnosis{
+			{ID: "d1", PatientID: "p1", Diagnosis: "Fever", Prescription: "Paracetamol", Patient: domain.Patient{ID: "p1", GivenName: "Maria", FirstSurname: "Garcia"},
+				Match: &domain.SearchMatch{Score: 1, PrescriptionSnippet: "<mark>Paracetamol</mark>"}},
+			{ID: "d2", PatientID: "p2", Diagnosis: "Flu", Prescription: "Rest", Patient: domain.Patient{ID: "p2", GivenName: "Juan", FirstSurname: "Perez"}},
+		}
+		mockRepo.EXPECT().SearchDiagnosis(integration, domain.DiagnosisFilter{}).Return(&domain.DiagnosisPage{Diagnoses: diagnostics}, nil)
+		mockConsentRepo.EXPECT().GetConsentsByPatientID("p1").Return([]domain.Consent{
+			{PatientID: "p1", Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeDiagnoses, GrantedAt: granted},
+		}, nil)
+		mockConsentRepo.EXPECT().GetConsentsByPatientID("p2").Return(nil, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+
+		page, err := service.GetDiagnostics(integration, domain.DiagnosisFilter{})
+		if err != nil {
+			t.Fatalf("GetDiagnostics() unexpected error = %v", err)
+		}
+		result := page.Diagnoses
+		if len(result) != 1 {
+			t.Fatalf("GetDiagnostics() expected 1 consented diagnosis, got %d", len(result))
+		}
+		if result[0].Prescription != "" {
+			t.Error("GetDiagnostics() expected prescription to be redacted")
+		}
+		if result[0].Match.PrescriptionSnippet != "" {
+			t.Error("GetDiagnostics() expected prescription snippet to be redacted")
+		}
+		if result[0].Patient.DisplayName() != "" {
+			t.Error("GetDiagnostics() expected demographics to be redacted")
+		}
+	})
+}
+
+func TestPatientService_ListPatients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockConsentRepo := mocks.NewMockConsentRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewPatientService(mockRepo, mocks.NewMockEncounterRepository(ctrl), mockCareTeamRepo, mockConsentRepo, mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	t.Run("records a search access per listed patient", func(t *testing.T) {
+		name := "garsia"
+		filter := domain.PatientFilter{Name: domain.NameFilter{Name: &name, Fuzzy: true}}
+		mockRepo.EXPECT().SearchPatients(caller, filter).Return(&domain.PatientPage{Patients: []domain.PatientSearchResult{
+			{Patient: domain.Patient{ID: "p1", GivenName: "María", FirstSurname: "García"}, Score: 0.83},
+		}, NextCursor: "next"}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).DoAndReturn(func(entry *domain.AccessLogEntry) error {
+			if entry.Action != domain.AccessActionSearch || entry.PatientID != "p1" {
+				t.Errorf("unexpected access log entry %+v", entry)
+			}
+			return nil
+		})
+
+		page, err := service.ListPatients(caller, filter)
+		if err != nil {
+			t.Fatalf("ListPatients() unexpected error = %v", err)
+		}
+		if len(page.Patients) != 1 || page.Patients[0].Score != 0.83 || page.NextCursor != "next" {
+			t.Errorf("ListPatients() = %+v", page)
+		}
+	})
+	t.Run("rejects unknown sort orders", func(t *testing.T) {
+		_, err := service.ListPatients(caller, domain.PatientFilter{Sort: "dni"})
+		if !errors.Is(err, domain.ErrInvalidPatientSort) {
+			t.Errorf("ListPatients() expected ErrInvalidPatientSort, got %v", err)
+		}
+	})
+	t.Run("rejects pages over the limit", func(t *testing.T) {
+		_, err := service.ListPatients(caller, domain.PatientFilter{Page: domain.Page{Limit: domain.MaxPageLimit + 1}})
+		if !errors.Is(err, domain.ErrInvalidPageLimit) {
+			t.Errorf("ListPatients() expected ErrInvalidPageLimit, got %v", err)
+		}
+	})
 }
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"slices"
+	"topdoctors/internal/domain"
+)
+
+// checkActivePatient ensures new records are only added to patients who have
+// been neither erased nor merged into another
+func checkActivePatient(patientRepo domain.PatientRepository, patientID string) error {
+	_, err := activePatient(patientRepo, patientID)
+	return err
+}
+
+// activePatient is checkActivePatient for callers that also need the patient
+func activePatient(patientRepo domain.PatientRepository, patientID string) (*domain.Patient, error) {
+	patient, err := patientRepo.GetPatientByID(patientID)
+	if err != nil {
+		return nil, err
+	}
+	if patient.IsErased() {
+		return nil, domain.ErrPatientAlreadyErased
+	}
+	if patient.IsMerged() {
+		return nil, domain.ErrPatientMerged
+	}
+	return patient, nil
+}
+
+// checkPatientDiagnosis ensures a diagnosis a record refers to belongs to the
+// same patient
+func checkPatientDiagnosis(patientRepo domain.PatientRepository, patientID, diagnosisID string) error {
+	diagnoses, err := patientRepo.GetDiagnosisByPatientID(patientID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(diagnoses, func(d domain.Diagnosis) bool { return d.ID == diagnosisID }) {
+		return domain.ErrDiagnosisPatientMismatch
+	}
+	return nil
+}
+
+// checkOpenEncounter ensures a record made during an encounter belongs to the
+// encounter's patient, and that the encounter is still open
+func checkOpenEncounter(encounterRepo domain.EncounterRepository, patientID, encounterID string) error {
+	encounter, err := encounterRepo.GetEncounterByID(encounterID)
+	if err != nil {
+		return err
+	}
+	if encounter.PatientID != patientID {
+		return domain.ErrEncounterPatientMismatch
+	}
+	if !encounter.IsOpen() {
+		return domain.ErrEncounterClosed
+	}
+	return nil
+}
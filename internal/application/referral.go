@@ -0,0 +1,224 @@
+package application
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type ReferralService struct {
+	repo         domain.ReferralRepository
+	patientRepo  domain.PatientRepository
+	userRepo     domain.UserRepository
+	careTeamRepo domain.CareTeamRepository
+	access       *accessGuard
+	support      domain.Support
+}
+
+func NewReferralService(repo domain.ReferralRepository, patientRepo domain.PatientRepository, userRepo domain.UserRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *ReferralService {
+	return &ReferralService{
+		repo:         repo,
+		patientRepo:  patientRepo,
+		userRepo:     userRepo,
+		careTeamRepo: careTeamRepo,
+		access:       newAccessGuard(careTeamRepo, consentRepo, support),
+		support:      support,
+	}
+}
+
+func (s *ReferralService) CreateReferral(caller domain.Caller, referral *domain.Referral) error {
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for referral", "error", errCreateID)
+		return errCreateID
+	}
+	referral.ID = id
+	referral.FromPractitionerID = caller.UserID
+	referral.Status = domain.ReferralStatusPending
+	referral.RespondedBy, referral.RespondedAt, referral.CompletedAt = "", nil, nil
+	referral.CreatedAt = time.Now()
+	referral.Normalize()
+
+	// Enforce domain invariants
+	if errValidate := referral.Validate(); errValidate != nil {
+		slog.Warn("Referral validation failed", "patient_id", referral.PatientID, "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, referral.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+	if err := checkActivePatient(s.patientRepo, referral.PatientID); err != nil {
+		return err
+	}
+	if referral.DiagnosisID != "" {
+		if err := checkPatientDiagnosis(s.patientRepo, referral.PatientID, referral.DiagnosisID); err != nil {
+			slog.Warn("Referral diagnosis check failed", "diagnosis_id", referral.DiagnosisID, "error", err)
+			return err
+		}
+	}
+	if referral.ToPractitionerID != "" {
+		if err := s.checkRecipient(referral.ToPractitionerID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.CreateReferral(referral); err != nil {
+		slog.Error("Referral creation in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Referral created", "referral_id", referral.ID, "patient_id", referral.PatientID, "to_practitioner_id", referral.ToPractitionerID, "to_specialty", referral.ToSpecialty, "urgency", referral.Urgency)
+	return nil
+}
+
+// AcceptReferral makes the caller responsible for the referral. They join the
+// patient's care team, so they can read and add to the patient's records.
+func (s *ReferralService) AcceptReferral(caller domain.Caller, id string) (*domain.Referral, error) {
+	referral, err := s.addressedReferral(caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkActivePatient(s.patientRepo, referral.PatientID); err != nil {
+		return nil, err
+	}
+	if err := referral.Accept(caller.UserID, time.Now()); err != nil {
+		return nil, err
+	}
+	member, err := s.newCareTeamMember(referral)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only one of the practitioners accepting a referral at once gets it, and
+	// joins the care team
+	if err := s.repo.AcceptReferral(referral, member); err != nil {
+		slog.Error("Referral acceptance in repository failed", "referral_id", id, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Referral accepted", "referral_id", id, "patient_id", referral.PatientID, "accepted_by", caller.UserID)
+	return referral, nil
+}
+
+func (s *ReferralService) RejectReferral(caller domain.Caller, id, reason string) (*domain.Referral, error) {
+	referral, err := s.addressedReferral(caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := referral.Reject(caller.UserID, reason, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateReferral(referral, domain.ReferralStatusPending); err != nil {
+		slog.Error("Referral update in repository failed", "referral_id", id, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Referral rejected", "referral_id", id, "patient_id", referral.PatientID, "rejected_by", caller.UserID)
+	return referral, nil
+}
+
+func (s *ReferralService) CompleteReferral(caller domain.Caller, id, note string) (*domain.Referral, error) {
+	referral, err := s.repo.GetReferralByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := referral.Complete(caller.UserID, note, time.Now()); err != nil {
+		slog.Warn("Referral completion refused", "referral_id", id, "user_id", caller.UserID, "error", err)
+		return nil, err
+	}
+	if err := s.access.authorize(caller, referral.PatientID, domain.AccessActionWrite); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateReferral(referral, domain.ReferralStatusAccepted); err != nil {
+		slog.Error("Referral update in repository failed", "referral_id", id, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Referral completed", "referral_id", id, "patient_id", referral.PatientID, "completed_by", caller.UserID)
+	return referral, nil
+}
+
+func (s *ReferralService) GetPatientReferrals(caller domain.Caller, patientID string) ([]domain.Referral, error) {
+	if err := s.access.authorizeClinicalRead(caller, patientID); err != nil {
+		return nil, err
+	}
+
+	referrals, err := s.repo.GetReferralsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Referral lookup failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+	return referrals, nil
+}
+
+func (s *ReferralService) GetInbox(caller domain.Caller, filter domain.ReferralInboxFilter) ([]domain.Referral, error) {
+	if caller.IsIntegration() {
+		return nil, domain.ErrAccessDenied
+	}
+	if filter.Status != nil && !domain.ValidReferralStatus(*filter.Status) {
+		return nil, domain.ErrInvalidReferralStatus
+	}
+
+	user, err := s.userRepo.GetByID(caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+	referrals, err := s.repo.GetReferralInbox(caller.UserID, user.Specialty, filter)
+	if err != nil {
+		slog.Error("Referral inbox lookup failed", "user_id", caller.UserID, "error", err)
+		return nil, err
+	}
+	slices.SortStableFunc(referrals, domain.CompareReferralUrgency)
+	return referrals, nil
+}
+
+// addressedReferral loads a referral the caller can answer, either addressed
+// to them or to their specialty
+func (s *ReferralService) addressedReferral(caller domain.Caller, id string) (*domain.Referral, error) {
+	referral, err := s.repo.GetReferralByID(id)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == domain.RoleIntegration || !referral.IsAddressedTo(user.ID, user.Specialty) {
+		slog.Warn("Referral answer refused: not the recipient", "referral_id", id, "user_id", caller.UserID)
+		return nil, domain.ErrReferralNotRecipient
+	}
+	return referral, nil
+}
+
+// checkRecipient ensures a referral is sent to a practitioner
+func (s *ReferralService) checkRecipient(userID string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		slog.Warn("Referral creation failed: recipient not found", "to_practitioner_id", userID)
+		return domain.ErrInvalidReferralRecipient
+	}
+	if user.Role == domain.RoleIntegration {
+		return domain.ErrInvalidReferralRecipient
+	}
+	return nil
+}
+
+// newCareTeamMember returns the care team membership the practitioner who
+// accepted the referral gets, on behalf of the referring practitioner, or nil
+// when they already are in the patient's care team
+func (s *ReferralService) newCareTeamMember(referral *domain.Referral) (*domain.CareTeamMember, error) {
+	member, err := s.careTeamRepo.IsCareTeamMember(referral.PatientID, referral.RespondedBy)
+	if err != nil || member {
+		return nil, err
+	}
+	return &domain.CareTeamMember{
+		PatientID: referral.PatientID,
+		UserID:    referral.RespondedBy,
+		AddedBy:   referral.FromPractitionerID,
+		AddedAt:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestReferralService_CreateReferral(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReferralRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewReferralService(mockRepo, mockPatientRepo, mockUserRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+
+	expectAllowedWrite := func() {
+		mockSupport.EXPECT().CreateNewID().Return("referral-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+	}
+
+	t.Run("successful referral to a practitioner", func(t *testing.T) {
+		expectAllowedWrite()
+		mockUserRepo.EXPECT().GetByID("cardiologist").Return(&domain.User{ID: "cardiologist", Role: domain.RolePractitioner}, nil)
+		mockRepo.EXPECT().CreateReferral(gomock.Any()).Return(nil)
+
+		referral := &domain.Referral{PatientID: "p1", ToPractitionerID: "cardiologist", Reason: "Soplo sistólico"}
+		if err := service.CreateReferral(caller, referral); err != nil {
+			t.Fatalf("CreateReferral() unexpected error = %v", err)
+		}
+		if referral.ID != "referral-id" || referral.FromPractitionerID != caller.UserID || referral.Status != domain.ReferralStatusPending || referral.Urgency != domain.ReferralUrgencyRoutine {
+			t.Errorf("CreateReferral() = %+v", referral)
+		}
+	})
+
+	t.Run("diagnosis of another patient", func(t *testing.T) {
+		expectAllowedWrite()
+		mockPatientRepo.EXPECT().GetDiagnosisByPatientID("p1").Return([]domain.Diagnosis{{ID: "d1"}}, nil)
+
+		referral := &domain.Referral{PatientID: "p1", DiagnosisID: "d2", ToSpecialty: "cardiology", Reason: "Soplo sistólico"}
+		if err := service.CreateReferral(caller, referral); !errors.Is(err, domain.ErrDiagnosisPatientMismatch) {
+			t.Errorf("CreateReferral() expected ErrDiagnosisPatientMismatch, got %v", err)
+		}
+	})
+
+	t.Run("integration client as recipient", func(t *testing.T) {
+		expectAllowedWrite()
+		mockUserRepo.EXPECT().GetByID("client").Return(&domain.User{ID: "client", Role: domain.RoleIntegration}, nil)
+
+		referral := &domain.Referral{PatientID: "p1", ToPractitionerID: "client", Reason: "Soplo sistólico"}
+		if err := service.CreateReferral(caller, referral); !errors.Is(err, domain.ErrInvalidReferralRecipient) {
+			t.Errorf("CreateReferral() expected ErrInvalidReferralRecipient, got %v", err)
+		}
+	})
+
+	t.Run("without recipient", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("referral-id", nil)
+
+		referral := &domain.Referral{PatientID: "p1", Reason: "Soplo sistólico"}
+		if err := service.CreateReferral(caller, referral); !errors.Is(err, domain.ErrReferralRecipientRequired) {
+			t.Errorf("CreateReferral() expected ErrReferralRecipientRequired, got %v", err)
+		}
+	})
+}
+
+func TestReferralService_AcceptReferral(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReferralRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	service := NewReferralService(mockRepo, mockPatientRepo, mockUserRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mocks.NewMockSupport(ctrl))
+	caller := domain.Caller{UserID: "cardiologist", Role: domain.RolePractitioner}
+	pending := func() *domain.Referral {
+		return &domain.Referral{ID: "r1", PatientID: "p1", FromPractitionerID: "gp", ToSpecialty: "cardiology", Status: domain.ReferralStatusPending}
+	}
+
+	t.Run("a practitioner of the specialty joins the care team", func(t *testing.T) {
+		mockRepo.EXPECT().GetReferralByID("r1").Return(pending(), nil)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Role: domain.RolePractitioner, Specialty: "cardiology"}, nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(false, nil)
+		mockRepo.EXPECT().AcceptReferral(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *domain.Referral, m *domain.CareTeamMember) error {
+			if m == nil || m.UserID != caller.UserID || m.AddedBy != "gp" {
+				t.Errorf("AcceptReferral() member = %+v", m)
+			}
+			return nil
+		})
+
+		referral, err := service.AcceptReferral(caller, "r1")
+		if err != nil {
+			t.Fatalf("AcceptReferral() unexpected error = %v", err)
+		}
+		if referral.Status != domain.ReferralStatusAccepted || referral.RespondedBy != caller.UserID {
+			t.Errorf("AcceptReferral() = %+v", referral)
+		}
+	})
+
+	t.Run("accepted by another practitioner meanwhile", func(t *testing.T) {
+		mockRepo.EXPECT().GetReferralByID("r1").Return(pending(), nil)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Role: domain.RolePractitioner, Specialty: "cardiology"}, nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(false, nil)
+		mockRepo.EXPECT().AcceptReferral(gomock.Any(), gomock.Any()).Return(domain.ErrReferralNotPending)
+
+		if _, err := service.AcceptReferral(caller, "r1"); !errors.Is(err, domain.ErrReferralNotPending) {
+			t.Errorf("AcceptReferral() expected ErrReferralNotPending, got %v", err)
+		}
+	})
+
+	t.Run("a practitioner of another specialty", func(t *testing.T) {
+		mockRepo.EXPECT().GetReferralByID("r1").Return(pending(), nil)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Role: domain.RolePractitioner, Specialty: "dermatology"}, nil)
+
+		if _, err := service.AcceptReferral(caller, "r1"); !errors.Is(err, domain.ErrReferralNotRecipient) {
+			t.Errorf("AcceptReferral() expected ErrReferralNotRecipient, got %v", err)
+		}
+	})
+
+	t.Run("already answered", func(t *testing.T) {
+		answered := pending()
+		answered.Status = domain.ReferralStatusRejected
+		mockRepo.EXPECT().GetReferralByID("r1").Return(answered, nil)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Role: domain.RolePractitioner, Specialty: "cardiology"}, nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+
+		if _, err := service.AcceptReferral(caller, "r1"); !errors.Is(err, domain.ErrReferralNotPending) {
+			t.Errorf("AcceptReferral() expected ErrReferralNotPending, got %v", err)
+		}
+	})
+}
+
+func TestReferralService_GetInbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReferralRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	service := NewReferralService(mockRepo, mocks.NewMockPatientRepository(ctrl), mockUserRepo, mocks.NewMockCareTeamRepository(ctrl), mocks.NewMockConsentRepository(ctrl), mocks.NewMockSupport(ctrl))
+	caller := domain.Caller{UserID: "cardiologist", Role: domain.RolePractitioner}
+
+	t.Run("the most urgent first", func(t *testing.T) {
+		day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+		mockUserRepo.EXPECT().GetByID(caller.UserID).Return(&domain.User{ID: caller.UserID, Specialty: "cardiology"}, nil)
+		mockRepo.EXPECT().GetReferralInbox(caller.UserID, "cardiology", domain.ReferralInboxFilter{}).Return([]domain.Referral{
+			{ID: "routine", Urgency: domain.ReferralUrgencyRoutine, CreatedAt: day},
+			{ID: "urgent", Urgency: domain.ReferralUrgencyUrgent, CreatedAt: day.Add(time.Hour)},
+		}, nil)
+
+		referrals, err := service.GetInbox(caller, domain.ReferralInboxFilter{})
+		if err != nil || len(referrals) != 2 || referrals[0].ID != "urgent" {
+			t.Errorf("GetInbox() = %+v, %v", referrals, err)
+		}
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		status := "lost"
+		if _, err := service.GetInbox(caller, domain.ReferralInboxFilter{Status: &status}); !errors.Is(err, domain.ErrInvalidReferralStatus) {
+			t.Errorf("GetInbox() expected ErrInvalidReferralStatus, got %v", err)
+		}
+	})
+
+	t.Run("integration client", func(t *testing.T) {
+		client := domain.Caller{UserID: "client", Role: domain.RoleIntegration}
+		if _, err := service.GetInbox(client, domain.ReferralInboxFilter{}); !errors.Is(err, domain.ErrAccessDenied) {
+			t.Errorf("GetInbox() expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
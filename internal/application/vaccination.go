@@ -0,0 +1,120 @@
+package application
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type VaccinationService struct {
+	repo        domain.VaccinationRepository
+	schedule    domain.VaccinationScheduleSource
+	patientRepo domain.PatientRepository
+	access      *accessGuard
+	support     domain.Support
+}
+
+func NewVaccinationService(repo domain.VaccinationRepository, schedule domain.VaccinationScheduleSource, patientRepo domain.PatientRepository, careTeamRepo domain.CareTeamRepository, consentRepo domain.ConsentRepository, support domain.Support) *VaccinationService {
+	return &VaccinationService{
+		repo:        repo,
+		schedule:    schedule,
+		patientRepo: patientRepo,
+		access:      newAccessGuard(careTeamRepo, consentRepo, support),
+		support:     support,
+	}
+}
+
+func (s *VaccinationService) RecordVaccination(caller domain.Caller, vaccination *domain.Vaccination) error {
+	id, errCreateID := s.support.CreateNewID()
+	if errCreateID != nil {
+		slog.Error("ID creation failed for vaccination", "error", errCreateID)
+		return errCreateID
+	}
+	vaccination.ID = id
+	vaccination.RecordedBy = caller.UserID
+	vaccination.CreatedAt = time.Now()
+	if vaccination.AdministeredAt.IsZero() {
+		vaccination.AdministeredAt = vaccination.CreatedAt
+	}
+	if vaccination.AdministeredBy == "" {
+		vaccination.AdministeredBy = caller.UserID
+	}
+	vaccination.Normalize()
+
+	// Enforce domain invariants
+	if errValidate := vaccination.Validate(); errValidate != nil {
+		slog.Warn("Vaccination validation failed", "vaccine_code", vaccination.VaccineCode, "error", errValidate)
+		return errValidate
+	}
+
+	if err := s.access.authorize(caller, vaccination.PatientID, domain.AccessActionWrite); err != nil {
+		return err
+	}
+	patient, err := activePatient(s.patientRepo, vaccination.PatientID)
+	if err != nil {
+		return err
+	}
+	if patient.BirthDate != nil && vaccination.AdministeredAt.Before(*patient.BirthDate) {
+		slog.Warn("Vaccination dated before birth", "patient_id", vaccination.PatientID)
+		return domain.ErrVaccinationBeforeBirth
+	}
+
+	history, err := s.repo.GetVaccinationsByPatientID(vaccination.PatientID)
+	if err != nil {
+		slog.Error("Vaccination lookup failed", "patient_id", vaccination.PatientID, "error", err)
+		return err
+	}
+	if slices.ContainsFunc(history, func(v domain.Vaccination) bool {
+		return v.VaccineCode == vaccination.VaccineCode && v.DoseNumber == vaccination.DoseNumber
+	}) {
+		slog.Warn("Vaccine dose already recorded", "patient_id", vaccination.PatientID, "vaccine_code", vaccination.VaccineCode, "dose", vaccination.DoseNumber)
+		return domain.ErrDuplicateVaccinationDose
+	}
+
+	if err := s.repo.CreateVaccination(vaccination); err != nil {
+		slog.Error("Vaccination creation in repository failed", "error", err)
+		return err
+	}
+
+	slog.Info("Vaccination recorded", "vaccination_id", vaccination.ID, "patient_id", vaccination.PatientID, "vaccine_code", vaccination.VaccineCode)
+	return nil
+}
+
+func (s *VaccinationService) GetVaccinations(caller domain.Caller, patientID string) ([]domain.Vaccination, error) {
+	if err := s.access.authorizeClinicalRead(caller, patientID); err != nil {
+		return nil, err
+	}
+
+	vaccinations, err := s.repo.GetVaccinationsByPatientID(patientID)
+	if err != nil {
+		slog.Error("Vaccination lookup failed", "patient_id", patientID, "error", err)
+		return nil, err
+	}
+	return vaccinations, nil
+}
+
+func (s *VaccinationService) GetVaccinationForecast(caller domain.Caller, patientID string, at time.Time, horizonDays int) (*domain.VaccinationForecast, error) {
+	if err := domain.ValidateVaccinationHorizon(horizonDays); err != nil {
+		return nil, err
+	}
+
+	history, err := s.GetVaccinations(caller, patientID)
+	if err != nil {
+		return nil, err
+	}
+	patient, err := s.patientRepo.GetPatientByID(patientID)
+	if err != nil {
+		return nil, err
+	}
+	if patient.BirthDate == nil {
+		return nil, domain.ErrMissingBirthDate
+	}
+
+	schedule, err := s.schedule.GetVaccinationSchedule()
+	if err != nil {
+		slog.Error("Vaccination schedule unavailable", "error", err)
+		return nil, err
+	}
+	return schedule.Forecast(patientID, *patient.BirthDate, history, at, horizonDays), nil
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestVaccinationService_RecordVaccination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVaccinationRepository(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewVaccinationService(mockRepo, mocks.NewMockVaccinationScheduleSource(ctrl), mockPatientRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	birthDate := time.Now().AddDate(0, -3, 0)
+
+	expectAllowedWrite := func() {
+		mockSupport.EXPECT().CreateNewID().Return("vaccination-id", nil)
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1", BirthDate: &birthDate}, nil)
+	}
+	newVaccination := func() *domain.Vaccination {
+		return &domain.Vaccination{PatientID: "p1", VaccineCode: " hexa ", DoseNumber: 1, Lot: "A12B345", AdministeredAt: birthDate.AddDate(0, 2, 0)}
+	}
+
+	t.Run("successful recording", func(t *testing.T) {
+		expectAllowedWrite()
+		mockRepo.EXPECT().GetVaccinationsByPatientID("p1").Return(nil, nil)
+		mockRepo.EXPECT().CreateVaccination(gomock.Any()).Return(nil)
+
+		vaccination := newVaccination()
+		if err := service.RecordVaccination(caller, vaccination); err != nil {
+			t.Fatalf("RecordVaccination() unexpected error = %v", err)
+		}
+		if vaccination.ID != "vaccination-id" || vaccination.VaccineCode != "HEXA" || vaccination.AdministeredBy != caller.UserID {
+			t.Errorf("RecordVaccination() = %+v", vaccination)
+		}
+	})
+
+	t.Run("dose already recorded", func(t *testing.T) {
+		expectAllowedWrite()
+		mockRepo.EXPECT().GetVaccinationsByPatientID("p1").Return([]domain.Vaccination{{VaccineCode: "HEXA", DoseNumber: 1}}, nil)
+
+		if err := service.RecordVaccination(caller, newVaccination()); !errors.Is(err, domain.ErrDuplicateVaccinationDose) {
+			t.Errorf("RecordVaccination() expected ErrDuplicateVaccinationDose, got %v", err)
+		}
+	})
+
+	t.Run("given before birth", func(t *testing.T) {
+		expectAllowedWrite()
+
+		vaccination := newVaccination()
+		vaccination.AdministeredAt = birthDate.AddDate(0, 0, -1)
+		if err := service.RecordVaccination(caller, vaccination); !errors.Is(err, domain.ErrVaccinationBeforeBirth) {
+			t.Errorf("RecordVaccination() expected ErrVaccinationBeforeBirth, got %v", err)
+		}
+	})
+
+	t.Run("missing lot", func(t *testing.T) {
+		mockSupport.EXPECT().CreateNewID().Return("vaccination-id", nil)
+
+		vaccination := newVaccination()
+		vaccination.Lot = ""
+		if err := service.RecordVaccination(caller, vaccination); !errors.Is(err, domain.ErrEmptyVaccineLot) {
+			t.Errorf("RecordVaccination() expected ErrEmptyVaccineLot, got %v", err)
+		}
+	})
+}
+
+func TestVaccinationService_GetVaccinationForecast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVaccinationRepository(ctrl)
+	mockSchedule := mocks.NewMockVaccinationScheduleSource(ctrl)
+	mockPatientRepo := mocks.NewMockPatientRepository(ctrl)
+	mockCareTeamRepo := mocks.NewMockCareTeamRepository(ctrl)
+	mockSupport := mocks.NewMockSupport(ctrl)
+	service := NewVaccinationService(mockRepo, mockSchedule, mockPatientRepo, mockCareTeamRepo, mocks.NewMockConsentRepository(ctrl), mockSupport)
+	caller := domain.Caller{UserID: "user-id", Role: domain.RolePractitioner}
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	expectAllowedRead := func() {
+		mockCareTeamRepo.EXPECT().IsCareTeamMember("p1", caller.UserID).Return(true, nil)
+		mockSupport.EXPECT().CreateNewID().Return("log-id", nil)
+		mockCareTeamRepo.EXPECT().CreateAccessLogEntry(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetVaccinationsByPatientID("p1").Return([]domain.Vaccination{{VaccineCode: "TV", DoseNumber: 1}}, nil)
+	}
+
+	t.Run("computes the forecast from the birth date", func(t *testing.T) {
+		expectAllowedRead()
+		birthDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1", BirthDate: &birthDate}, nil)
+		mockSchedule.EXPECT().GetVaccinationSchedule().Return(&domain.VaccinationSchedule{Name: "Test", Doses: []domain.ScheduledDose{
+			{VaccineCode: "TV", DoseNumber: 1, AgeMonths: 12},
+			{VaccineCode: "TV", DoseNumber: 2, AgeMonths: 36},
+		}}, nil)
+
+		forecast, err := service.GetVaccinationForecast(caller, "p1", at, 90)
+		if err != nil {
+			t.Fatalf("GetVaccinationForecast() unexpected error = %v", err)
+		}
+		if len(forecast.Overdue) != 1 || forecast.Overdue[0].DoseNumber != 2 || forecast.Schedule != "Test" {
+			t.Errorf("GetVaccinationForecast() = %+v", forecast)
+		}
+	})
+
+	t.Run("patient without birth date", func(t *testing.T) {
+		expectAllowedRead()
+		mockPatientRepo.EXPECT().GetPatientByID("p1").Return(&domain.Patient{ID: "p1"}, nil)
+
+		if _, err := service.GetVaccinationForecast(caller, "p1", at, 90); !errors.Is(err, domain.ErrMissingBirthDate) {
+			t.Errorf("GetVaccinationForecast() expected ErrMissingBirthDate, got %v", err)
+		}
+	})
+
+	t.Run("horizon out of bounds", func(t *testing.T) {
+		if _, err := service.GetVaccinationForecast(caller, "p1", at, 0); !errors.Is(err, domain.ErrInvalidVaccinationHorizon) {
+			t.Errorf("GetVaccinationForecast() expected ErrInvalidVaccinationHorizon, got %v", err)
+		}
+	})
+}
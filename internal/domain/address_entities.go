@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidCountry             = errors.New("invalid country, expected an ISO 3166-1 alpha-2 country code")
+	ErrInvalidPostalCode          = errors.New("invalid postal code, Spanish postal codes have 5 digits starting with a province code")
+	ErrInvalidProvince            = errors.New("invalid province, expected a two-digit INE province code")
+	ErrPostalCodeProvinceMismatch = errors.New("postal code does not belong to the province")
+)
+
+// DefaultCountry is the country assumed for addresses without one
+const DefaultCountry = "ES"
+
+// provinces maps the INE province codes to their names. Spanish postal codes
+// start with the code of their province.
+var provinces = map[string]string{
+	"01": "Araba/Álava", "02": "Albacete", "03": "Alicante/Alacant", "04": "Almería",
+	"05": "Ávila", "06": "Badajoz", "07": "Illes Balears", "08": "Barcelona",
+	"09": "Burgos", "10": "Cáceres", "11": "Cádiz", "12": "Castellón/Castelló",
+	"13": "Ciudad Real", "14": "Córdoba", "15": "A Coruña", "16": "Cuenca",
+	"17": "Girona", "18": "Granada", "19": "Guadalajara", "20": "Gipuzkoa",
+	"21": "Huelva", "22": "Huesca", "23": "Jaén", "24": "León",
+	"25": "Lleida", "26": "La Rioja", "27": "Lugo", "28": "Madrid",
+	"29": "Málaga", "30": "Murcia", "31": "Navarra", "32": "Ourense",
+	"33": "Asturias", "34": "Palencia", "35": "Las Palmas", "36": "Pontevedra",
+	"37": "Salamanca", "38": "Santa Cruz de Tenerife", "39": "Cantabria", "40": "Segovia",
+	"41": "Sevilla", "42": "Soria", "43": "Tarragona", "44": "Teruel",
+	"45": "Toledo", "46": "Valencia/València", "47": "Valladolid", "48": "Bizkaia",
+	"49": "Zamora", "50": "Zaragoza", "51": "Ceuta", "52": "Melilla",
+}
+
+var spanishPostalCodeRegex = regexp.MustCompile(`^[0-9]{5}$`)
+
+// ProvinceName returns the name of a province given its INE code, and false
+// when the code is unknown
+func ProvinceName(code string) (string, bool) {
+	name, ok := provinces[code]
+	return name, ok
+}
+
+// ValidPostalCode reports whether a Spanish postal code has 5 digits and
+// starts with a known province code
+func ValidPostalCode(postalCode string) bool {
+	if !spanishPostalCodeRegex.MatchString(postalCode) {
+		return false
+	}
+	_, ok := provinces[postalCode[:2]]
+	return ok
+}
+
+// Address is a postal address. Patients registered before addresses were
+// structured keep their free-text address in Street.
+type Address struct {
+	Street     string
+	Number     string
+	Floor      string // Floor and door, e.g. "3º B"
+	PostalCode string
+	City       string
+	Province   string // INE province code of Spanish addresses, e.g. "28" for Madrid
+	Country    string // ISO 3166-1 alpha-2, DefaultCountry when empty
+}
+
+// IsEmpty reports whether no part of the address is known
+func (a Address) IsEmpty() bool {
+	return a == Address{}
+}
+
+// IsSpanish reports whether the address is in Spain
+func (a Address) IsSpanish() bool {
+	return a.Country == "" || a.Country == DefaultCountry
+}
+
+// Complete fills the country and, for Spanish addresses, the province from
+// the postal code when they are missing
+func (a *Address) Complete() {
+	if a.IsEmpty() {
+		return
+	}
+	if a.Country == "" {
+		a.Country = DefaultCountry
+	}
+	if a.IsSpanish() && a.Province == "" && ValidPostalCode(a.PostalCode) {
+		a.Province = a.PostalCode[:2]
+	}
+}
+
+// Validate checks the country and, for Spanish addresses, that the postal
+// code belongs to the province. Every part of the address is optional.
+func (a Address) Validate() error {
+	if a.Country != "" && !countryCodeRegex.MatchString(a.Country) {
+		return ErrInvalidCountry
+	}
+	if !a.IsSpanish() {
+		return nil
+	}
+	if a.PostalCode != "" && !ValidPostalCode(a.PostalCode) {
+		return ErrInvalidPostalCode
+	}
+	if a.Province != "" {
+		if _, ok := provinces[a.Province]; !ok {
+			return ErrInvalidProvince
+		}
+		if a.PostalCode != "" && a.PostalCode[:2] != a.Province {
+			return ErrPostalCodeProvinceMismatch
+		}
+	}
+	return nil
+}
+
+// String returns the address on a single line, as written on an envelope,
+// e.g. "Calle Mayor 1, 3º B, 28013 Madrid"
+func (a Address) String() string {
+	var parts []string
+	if street := joinNameParts(a.Street, a.Number); street != "" {
+		parts = append(parts, street)
+	}
+	if floor := strings.TrimSpace(a.Floor); floor != "" {
+		parts = append(parts, floor)
+	}
+	if locality := joinNameParts(a.PostalCode, a.City); locality != "" {
+		parts = append(parts, locality)
+	}
+	if name, ok := provinces[a.Province]; ok && a.IsSpanish() && !strings.EqualFold(name, a.City) {
+		parts = append(parts, name)
+	}
+	if !a.IsSpanish() {
+		parts = append(parts, a.Country)
+	}
+	return strings.Join(parts, ", ")
+}
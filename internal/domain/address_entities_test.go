@@ -0,0 +1,64 @@
+package domain
+
+import "testing"
+
+func TestAddress_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		address Address
+		wantErr error
+	}{
+		{"empty", Address{}, nil},
+		{"complete", Address{Street: "Calle Mayor", Number: "1", PostalCode: "28013", City: "Madrid", Province: "28", Country: "ES"}, nil},
+		{"legacy free text", Address{Street: "Calle Mayor 1, Madrid"}, nil},
+		{"postal code without province", Address{PostalCode: "08001"}, nil},
+		{"postal code too short", Address{PostalCode: "2801"}, ErrInvalidPostalCode},
+		{"postal code of no province", Address{PostalCode: "53001"}, ErrInvalidPostalCode},
+		{"unknown province", Address{Province: "99"}, ErrInvalidProvince},
+		{"province name instead of code", Address{Province: "Madrid"}, ErrInvalidProvince},
+		{"postal code from another province", Address{PostalCode: "08001", Province: "28"}, ErrPostalCodeProvinceMismatch},
+		{"foreign postal code", Address{PostalCode: "SW1A 1AA", City: "London", Country: "GB"}, nil},
+		{"invalid country", Address{Country: "Spain"}, ErrInvalidCountry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.address.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddress_Complete(t *testing.T) {
+	address := Address{Street: "Calle Mayor", PostalCode: "28013"}
+	address.Complete()
+	if address.Country != DefaultCountry || address.Province != "28" {
+		t.Errorf("Complete() = %+v, want country ES and province 28", address)
+	}
+
+	foreign := Address{PostalCode: "28013", Country: "DE"}
+	foreign.Complete()
+	if foreign.Province != "" {
+		t.Errorf("Complete() expected no province for a foreign address, got %q", foreign.Province)
+	}
+
+	empty := Address{}
+	empty.Complete()
+	if !empty.IsEmpty() {
+		t.Errorf("Complete() expected an empty address to stay empty, got %+v", empty)
+	}
+}
+
+func TestAddress_String(t *testing.T) {
+	tests := map[string]Address{
+		"Calle Mayor 1, 3º B, 28013 Madrid":       {Street: "Calle Mayor", Number: "1", Floor: "3º B", PostalCode: "28013", City: "Madrid", Province: "28", Country: "ES"},
+		"Calle Real 5, 28220 Majadahonda, Madrid": {Street: "Calle Real", Number: "5", PostalCode: "28220", City: "Majadahonda", Province: "28"},
+		"Downing Street 10, SW1A 2AA London, GB":  {Street: "Downing Street", Number: "10", PostalCode: "SW1A 2AA", City: "London", Country: "GB"},
+		"Calle Mayor 1, Madrid":                   {Street: "Calle Mayor 1, Madrid"},
+	}
+	for want, address := range tests {
+		if got := address.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
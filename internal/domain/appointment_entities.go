@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptyAppointmentID       = errors.New("appointment ID cannot be empty")
+	ErrEmptyPractitionerID      = errors.New("practitioner ID is required for appointment")
+	ErrInvalidAppointmentStatus = errors.New("invalid appointment status")
+	ErrInvalidAppointmentTime   = errors.New("appointment must end after it starts")
+	ErrAppointmentTooLong       = errors.New("appointment cannot last longer than a working day")
+	ErrEmptyDueDate             = errors.New("pending follow-up needs a due date")
+	ErrAppointmentOverlap       = errors.New("practitioner already has an appointment at that time")
+	ErrAppointmentCancelled     = errors.New("appointment is cancelled")
+	ErrInvalidPractitioner      = errors.New("appointments can only be booked with practitioners")
+	ErrDiagnosisPatientMismatch = errors.New("diagnosis does not belong to patient")
+	ErrInvalidFollowUpDays      = errors.New("follow-up must be due between 1 and 365 days after the diagnosis")
+	ErrInvalidAgendaView        = errors.New("invalid agenda view, expected day or week")
+)
+
+// Appointment statuses. A pending appointment is a follow-up with a due date
+// still to be booked in a time slot.
+const (
+	AppointmentStatusPending   = "pending"
+	AppointmentStatusBooked    = "booked"
+	AppointmentStatusCancelled = "cancelled"
+)
+
+// MaxAppointmentDuration bounds an appointment to a working day
+const MaxAppointmentDuration = 12 * time.Hour
+
+// MaxFollowUpDays is how far after a diagnosis a follow-up can be due
+const MaxFollowUpDays = 365
+
+// Appointment is a patient's visit to a practitioner
+type Appointment struct {
+	ID             string
+	PatientID      string
+	PractitionerID string
+	Start          time.Time // Zero while pending
+	End            time.Time // Zero while pending
+	DueDate        *time.Time
+	Status         string
+	Reason         string
+	DiagnosisID    string // Diagnosis that required the appointment, if any
+	CreatedBy      string
+	CreatedAt      time.Time
+	CancelledAt    *time.Time
+}
+
+// NewFollowUp returns a pending follow-up of a diagnosis, due the given
+// number of days after it, with the practitioner who made the diagnosis
+func NewFollowUp(diagnosis *Diagnosis, practitionerID string, days int) (*Appointment, error) {
+	if days < 1 || days > MaxFollowUpDays {
+		return nil, ErrInvalidFollowUpDays
+	}
+	due := diagnosis.Date.AddDate(0, 0, days)
+	due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
+	return &Appointment{
+		PatientID:      diagnosis.PatientID,
+		PractitionerID: practitionerID,
+		DueDate:        &due,
+		Status:         AppointmentStatusPending,
+		Reason:         "Follow-up",
+		DiagnosisID:    diagnosis.ID,
+		CreatedBy:      practitionerID,
+	}, nil
+}
+
+// Validate ensures the appointment's domain invariants are met
+func (a *Appointment) Validate() error {
+	if a.ID == "" {
+		return ErrEmptyAppointmentID
+	}
+	if a.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if a.PractitionerID == "" {
+		return ErrEmptyPractitionerID
+	}
+
+	switch a.Status {
+	case AppointmentStatusPending:
+		if a.DueDate == nil {
+			return ErrEmptyDueDate
+		}
+	case AppointmentStatusBooked, AppointmentStatusCancelled:
+		if a.Start.IsZero() && a.Status == AppointmentStatusBooked {
+			return ErrInvalidAppointmentTime
+		}
+		if !a.Start.IsZero() {
+			if !a.End.After(a.Start) {
+				return ErrInvalidAppointmentTime
+			}
+			if a.End.Sub(a.Start) > MaxAppointmentDuration {
+				return ErrAppointmentTooLong
+			}
+		}
+	default:
+		return ErrInvalidAppointmentStatus
+	}
+	return nil
+}
+
+// IsCancelled reports whether the appointment was cancelled
+func (a *Appointment) IsCancelled() bool {
+	return a.Status == AppointmentStatusCancelled
+}
+
+// Overlaps reports whether both appointments keep the same practitioner busy
+// at the same time. Pending and cancelled appointments take no time.
+func (a *Appointment) Overlaps(other *Appointment) bool {
+	if a.Status != AppointmentStatusBooked || other.Status != AppointmentStatusBooked {
+		return false
+	}
+	return a.PractitionerID == other.PractitionerID && a.ID != other.ID &&
+		a.Start.Before(other.End) && other.Start.Before(a.End)
+}
+
+// Reschedule books the appointment in a new time slot, which also books a
+// pending follow-up
+func (a *Appointment) Reschedule(start, end time.Time) error {
+	if a.IsCancelled() {
+		return ErrAppointmentCancelled
+	}
+	a.Start = start
+	a.End = end
+	a.Status = AppointmentStatusBooked
+	return a.Validate()
+}
+
+// Cancel cancels the appointment at the given time
+func (a *Appointment) Cancel(at time.Time) error {
+	if a.IsCancelled() {
+		return ErrAppointmentCancelled
+	}
+	a.Status = AppointmentStatusCancelled
+	a.CancelledAt = &at
+	return nil
+}
+
+// Agenda views
+const (
+	AgendaViewDay  = "day"
+	AgendaViewWeek = "week"
+)
+
+// AgendaRange returns the period shown by an agenda view containing the
+// given date: the day itself, or its week from Monday to Sunday
+func AgendaRange(date time.Time, view string) (from, to time.Time, err error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	switch view {
+	case AgendaViewDay, "":
+		return day, day.AddDate(0, 0, 1), nil
+	case AgendaViewWeek:
+		monday := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return monday, monday.AddDate(0, 0, 7), nil
+	default:
+		return time.Time{}, time.Time{}, ErrInvalidAgendaView
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAppointment_Validate(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := start
+	valid := Appointment{ID: "a1", PatientID: "p1", PractitionerID: "u1", Start: start, End: start.Add(20 * time.Minute), Status: AppointmentStatusBooked}
+
+	tests := []struct {
+		name    string
+		modify  func(a *Appointment)
+		wantErr error
+	}{
+		{"valid appointment", func(a *Appointment) {}, nil},
+		{"pending follow-up", func(a *Appointment) {
+			a.Start, a.End, a.DueDate, a.Status = time.Time{}, time.Time{}, &due, AppointmentStatusPending
+		}, nil},
+		{"missing ID", func(a *Appointment) { a.ID = "" }, ErrEmptyAppointmentID},
+		{"missing patient", func(a *Appointment) { a.PatientID = "" }, ErrEmptyPatientFK},
+		{"missing practitioner", func(a *Appointment) { a.PractitionerID = "" }, ErrEmptyPractitionerID},
+		{"unknown status", func(a *Appointment) { a.Status = "done" }, ErrInvalidAppointmentStatus},
+		{"ends before it starts", func(a *Appointment) { a.End = start.Add(-time.Minute) }, ErrInvalidAppointmentTime},
+		{"booked without time", func(a *Appointment) { a.Start, a.End = time.Time{}, time.Time{} }, ErrInvalidAppointmentTime},
+		{"longer than a working day", func(a *Appointment) { a.End = start.Add(13 * time.Hour) }, ErrAppointmentTooLong},
+		{"pending without due date", func(a *Appointment) { a.Status = AppointmentStatusPending }, ErrEmptyDueDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appointment := valid
+			tt.modify(&appointment)
+			if err := appointment.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAppointment_Overlaps(t *testing.T) {
+	nine := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	slot := func(id, practitioner string, start time.Time, minutes int) *Appointment {
+		return &Appointment{ID: id, PractitionerID: practitioner, Start: start, End: start.Add(time.Duration(minutes) * time.Minute), Status: AppointmentStatusBooked}
+	}
+	base := slot("a1", "u1", nine, 30)
+	cancelled := slot("a2", "u1", nine, 30)
+	cancelled.Status = AppointmentStatusCancelled
+
+	tests := []struct {
+		name  string
+		other *Appointment
+		want  bool
+	}{
+		{"same slot", slot("a2", "u1", nine, 30), true},
+		{"starts inside", slot("a2", "u1", nine.Add(15*time.Minute), 30), true},
+		{"contains it", slot("a2", "u1", nine.Add(-time.Hour), 120), true},
+		{"back to back", slot("a2", "u1", nine.Add(30*time.Minute), 30), false},
+		{"other practitioner", slot("a2", "u2", nine, 30), false},
+		{"itself", slot("a1", "u1", nine, 30), false},
+		{"cancelled", cancelled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Overlaps(tt.other); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppointment_RescheduleAndCancel(t *testing.T) {
+	due := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	followUp := &Appointment{ID: "a1", PatientID: "p1", PractitionerID: "u1", DueDate: &due, Status: AppointmentStatusPending}
+
+	start := time.Date(2026, 3, 16, 10, 0, 0, 0, time.UTC)
+	if err := followUp.Reschedule(start, start.Add(20*time.Minute)); err != nil || followUp.Status != AppointmentStatusBooked {
+		t.Fatalf("Reschedule() = %v, status %q", err, followUp.Status)
+	}
+	if err := followUp.Cancel(time.Now()); err != nil || followUp.CancelledAt == nil {
+		t.Fatalf("Cancel() = %v", err)
+	}
+	if err := followUp.Cancel(time.Now()); err != ErrAppointmentCancelled {
+		t.Errorf("second Cancel() = %v, want %v", err, ErrAppointmentCancelled)
+	}
+	if err := followUp.Reschedule(start, start.Add(20*time.Minute)); err != ErrAppointmentCancelled {
+		t.Errorf("Reschedule() of cancelled = %v, want %v", err, ErrAppointmentCancelled)
+	}
+}
+
+func TestNewFollowUp(t *testing.T) {
+	diagnosis := &Diagnosis{ID: "d1", PatientID: "p1", Date: time.Date(2026, 2, 13, 18, 23, 0, 0, time.UTC)}
+
+	followUp, err := NewFollowUp(diagnosis, "u1", 14)
+	if err != nil {
+		t.Fatalf("NewFollowUp() error = %v", err)
+	}
+	want := time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)
+	if !followUp.DueDate.Equal(want) || followUp.Status != AppointmentStatusPending || followUp.DiagnosisID != "d1" || followUp.PractitionerID != "u1" {
+		t.Errorf("NewFollowUp() = %+v", followUp)
+	}
+
+	for _, days := range []int{0, -1, MaxFollowUpDays + 1} {
+		if _, err := NewFollowUp(diagnosis, "u1", days); err != ErrInvalidFollowUpDays {
+			t.Errorf("NewFollowUp(%d) = %v, want %v", days, err, ErrInvalidFollowUpDays)
+		}
+	}
+}
+
+func TestAgendaRange(t *testing.T) {
+	wednesday := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	sunday := time.Date(2026, 3, 8, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		view     string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  error
+	}{
+		{"day", wednesday, AgendaViewDay, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), nil},
+		{"default view is day", wednesday, "", time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), nil},
+		{"week from Monday", wednesday, AgendaViewWeek, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), nil},
+		{"Sunday ends the week", sunday, AgendaViewWeek, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), nil},
+		{"unknown view", wednesday, "month", time.Time{}, time.Time{}, ErrInvalidAgendaView},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := AgendaRange(tt.date, tt.view)
+			if err != tt.wantErr || !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("AgendaRange() = %v, %v, %v, want %v, %v, %v", from, to, err, tt.wantFrom, tt.wantTo, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Appointment Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// AppointmentRepository defines operations for appointment persistence.
+// Creating or updating a booked appointment fails with ErrAppointmentOverlap
+// when it overlaps another of the practitioner's.
+type AppointmentRepository interface {
+	CreateAppointment(appointment *Appointment) error
+	UpdateAppointment(appointment *Appointment) error
+	GetAppointmentByID(id string) (*Appointment, error)
+	GetAppointmentsByPatientID(patientID string) ([]Appointment, error)
+	// GetAgenda returns the practitioner's booked appointments starting in
+	// [from, to) and the pending follow-ups due in it
+	GetAgenda(practitionerID string, from, to time.Time) ([]Appointment, error)
+}
+
+// Appointment Domain - Service Interfaces (Driving Ports - Inbound)
+
+// AppointmentService defines appointment booking and agenda operations
+type AppointmentService interface {
+	BookAppointment(caller Caller, appointment *Appointment) error
+	RescheduleAppointment(caller Caller, id string, start, end time.Time) (*Appointment, error)
+	CancelAppointment(caller Caller, id string) (*Appointment, error)
+	GetAppointment(caller Caller, id string) (*Appointment, error)
+	GetPatientAppointments(caller Caller, patientID string) ([]Appointment, error)
+	GetAgenda(caller Caller, practitionerID string, date time.Time, view string) ([]Appointment, error)
+}
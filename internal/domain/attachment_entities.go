@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrEmptyAttachmentID          = errors.New("attachment ID cannot be empty")
+	ErrEmptyAttachmentDiagnosis   = errors.New("attachment must belong to a diagnosis")
+	ErrEmptyAttachmentName        = errors.New("attachment file name is required")
+	ErrEmptyAttachment            = errors.New("attachment is empty")
+	ErrAttachmentTooLarge         = errors.New("attachment exceeds the maximum size")
+	ErrUnsupportedAttachmentType  = errors.New("attachment type is not allowed")
+	ErrInvalidChecksum            = errors.New("checksum must be a hex encoded SHA-256")
+	ErrAttachmentChecksumMismatch = errors.New("attachment content does not match its checksum")
+	ErrAttachmentNotFound         = errors.New("attachment not found")
+	ErrDiagnosisNotFound          = errors.New("diagnosis not found")
+	ErrBlobNotFound               = errors.New("stored content not found")
+	ErrBlobCorrupted              = errors.New("stored content does not match its checksum")
+)
+
+// MaxAttachmentSize bounds the size of an uploaded file, enough for a
+// multi-page scanned report or a compressed imaging study
+const MaxAttachmentSize = 20 << 20
+
+// Content types accepted as attachments: documents, photos and DICOM images
+const (
+	AttachmentTypePDF   = "application/pdf"
+	AttachmentTypeJPEG  = "image/jpeg"
+	AttachmentTypePNG   = "image/png"
+	AttachmentTypeWebP  = "image/webp"
+	AttachmentTypeDICOM = "application/dicom"
+)
+
+var allowedAttachmentTypes = map[string]bool{
+	AttachmentTypePDF:   true,
+	AttachmentTypeJPEG:  true,
+	AttachmentTypePNG:   true,
+	AttachmentTypeWebP:  true,
+	AttachmentTypeDICOM: true,
+}
+
+var checksumPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// AllowedAttachmentType reports whether files of the content type can be
+// attached
+func AllowedAttachmentType(contentType string) bool {
+	return allowedAttachmentTypes[contentType]
+}
+
+// ValidateChecksum ensures a checksum is a lowercase hex encoded SHA-256
+func ValidateChecksum(checksum string) error {
+	if !checksumPattern.MatchString(checksum) {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// Attachment is a file attached to a diagnosis, such as a scan or a report.
+// Its content is kept encrypted in blob storage under its ID.
+type Attachment struct {
+	ID          string
+	DiagnosisID string
+	PatientID   string
+	FileName    string
+	ContentType string // Detected from the content, never taken from the client
+	Size        int64
+	Checksum    string // Hex encoded SHA-256 of the content
+	ContentKey  []byte // Key the stored content is encrypted with
+	UploadedBy  string
+	CreatedAt   time.Time
+}
+
+// StoredBlob describes content written to blob storage
+type StoredBlob struct {
+	Key      []byte // Encrypts the content, only this attachment uses it
+	Checksum string
+	Size     int64
+}
+
+// Validate ensures the attachment's domain invariants are met
+func (a *Attachment) Validate() error {
+	if a.ID == "" {
+		return ErrEmptyAttachmentID
+	}
+	if a.DiagnosisID == "" {
+		return ErrEmptyAttachmentDiagnosis
+	}
+	if a.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if a.FileName == "" {
+		return ErrEmptyAttachmentName
+	}
+	if !AllowedAttachmentType(a.ContentType) {
+		return ErrUnsupportedAttachmentType
+	}
+	if a.Size <= 0 {
+		return ErrEmptyAttachment
+	}
+	if a.Size > MaxAttachmentSize {
+		return ErrAttachmentTooLarge
+	}
+	return ValidateChecksum(a.Checksum)
+}
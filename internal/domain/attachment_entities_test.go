@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttachment_Validate(t *testing.T) {
+	valid := Attachment{ID: "a1", DiagnosisID: "d1", PatientID: "p1", FileName: "informe.pdf", ContentType: AttachmentTypePDF,
+		Size: 1024, Checksum: strings.Repeat("ab", 32)}
+
+	tests := []struct {
+		name    string
+		modify  func(a *Attachment)
+		wantErr error
+	}{
+		{"valid attachment", func(a *Attachment) {}, nil},
+		{"DICOM image", func(a *Attachment) { a.ContentType = AttachmentTypeDICOM }, nil},
+		{"missing ID", func(a *Attachment) { a.ID = "" }, ErrEmptyAttachmentID},
+		{"missing diagnosis", func(a *Attachment) { a.DiagnosisID = "" }, ErrEmptyAttachmentDiagnosis},
+		{"missing patient", func(a *Attachment) { a.PatientID = "" }, ErrEmptyPatientFK},
+		{"missing file name", func(a *Attachment) { a.FileName = "" }, ErrEmptyAttachmentName},
+		{"type not allowed", func(a *Attachment) { a.ContentType = "text/html" }, ErrUnsupportedAttachmentType},
+		{"empty content", func(a *Attachment) { a.Size = 0 }, ErrEmptyAttachment},
+		{"too large", func(a *Attachment) { a.Size = MaxAttachmentSize + 1 }, ErrAttachmentTooLarge},
+		{"uppercase checksum", func(a *Attachment) { a.Checksum = strings.Repeat("AB", 32) }, ErrInvalidChecksum},
+		{"short checksum", func(a *Attachment) { a.Checksum = "abcd" }, ErrInvalidChecksum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachment := valid
+			tt.modify(&attachment)
+			if err := attachment.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
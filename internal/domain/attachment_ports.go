@@ -0,0 +1,38 @@
+package domain
+
+import "io"
+
+// Attachment Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// AttachmentRepository defines operations for attachment metadata persistence
+type AttachmentRepository interface {
+	CreateAttachment(attachment *Attachment) error
+	GetAttachmentByID(id string) (*Attachment, error)
+	GetAttachmentsByPatientID(patientID string) ([]Attachment, error)
+}
+
+// BlobStorage stores the content of each attachment under its ID, encrypted
+// with a key of its own. Contents are never shared between attachments, so
+// deleting one never affects another.
+type BlobStorage interface {
+	// Put encrypts the content with a new key and stores it under the ID.
+	// When an expected checksum is given the content is only kept if it
+	// matches.
+	Put(id string, content io.Reader, expectedChecksum string) (*StoredBlob, error)
+	// Open returns the content stored under the ID, decrypted with its key,
+	// after verifying it has not been altered
+	Open(id string, key []byte, checksum string) (io.ReadCloser, error)
+	Delete(id string) error
+}
+
+// Attachment Domain - Service Interfaces (Driving Ports - Inbound)
+
+// AttachmentService defines diagnosis attachment operations
+type AttachmentService interface {
+	// AddAttachment stores the content and attaches it to the diagnosis set in
+	// the attachment. The content type must have been detected by the caller.
+	AddAttachment(caller Caller, attachment *Attachment, content io.Reader, expectedChecksum string) error
+	// OpenAttachment returns an attachment of a diagnosis and its content,
+	// which the caller must close
+	OpenAttachment(caller Caller, diagnosisID, attachmentID string) (*Attachment, io.ReadCloser, error)
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyBulkExportJobID      = errors.New("bulk export job ID cannot be empty")
+	ErrEmptyBulkExportRequester  = errors.New("bulk export requester is required")
+	ErrUnsupportedBulkExportType = errors.New("unsupported resource type for bulk export, use Patient or Condition")
+	ErrInvalidBulkExportStatus   = errors.New("invalid bulk export status")
+	ErrIntegrationRequired       = errors.New("bulk export is restricted to integration clients")
+	ErrBulkExportJobNotFound     = errors.New("bulk export job not found")
+	ErrBulkExportFileNotFound    = errors.New("bulk export file not found")
+	ErrBulkExportNotCompleted    = errors.New("bulk export has not completed")
+	ErrBulkExportInterrupted     = errors.New("bulk export was interrupted, start a new one")
+	ErrBulkExportWithdrawn       = errors.New("bulk export was withdrawn, start a new one")
+	ErrBulkExportFileCorrupted   = errors.New("bulk export file does not decrypt")
+)
+
+// Resource types a bulk export can include, named as in FHIR. Each one is
+// written to its own NDJSON file.
+const (
+	BulkExportTypePatient   = "Patient"
+	BulkExportTypeCondition = "Condition"
+)
+
+// BulkExportRetention is how long the files of a completed bulk export are
+// kept for the client to download them
+const BulkExportRetention = 24 * time.Hour
+
+// BulkExportTypes lists every exportable resource type, in the order they
+// are exported
+var BulkExportTypes = []string{BulkExportTypePatient, BulkExportTypeCondition}
+
+// Bulk export statuses. A job runs in the background from its kick-off until
+// it either completes or fails.
+const (
+	BulkExportStatusInProgress = "in-progress"
+	BulkExportStatusCompleted  = "completed"
+	BulkExportStatusFailed     = "failed"
+)
+
+// ValidBulkExportStatus reports whether the status is a known bulk export status
+func ValidBulkExportStatus(status string) bool {
+	switch status {
+	case BulkExportStatusInProgress, BulkExportStatusCompleted, BulkExportStatusFailed:
+		return true
+	}
+	return false
+}
+
+// ParseBulkExportTypes reads the comma separated _type parameter of a bulk
+// export kick-off. Empty means every type.
+func ParseBulkExportTypes(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return BulkExportTypes, nil
+	}
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if !slices.Contains(BulkExportTypes, t) {
+			return nil, ErrUnsupportedBulkExportType
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// BulkExportJob is an asynchronous export of the data integration clients
+// may access, following the FHIR Bulk Data Access specification
+type BulkExportJob struct {
+	ID          string
+	RequestedBy string     // Integration client that kicked it off, the only one allowed to read its output
+	Types       []string   // Resource types exported
+	Since       *time.Time // Only resources updated after it are exported, if set
+	Status      string
+	Error       string // Why the job failed
+	Output      []BulkExportFile
+	RequestedAt time.Time // Transaction time of the export
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time // When the files of a completed job are removed
+	ContentKey  []byte     // Key the files of the job are encrypted with
+}
+
+// BulkExportFile is the NDJSON file holding the exported resources of a type.
+// Types without any resource to export have no file.
+type BulkExportFile struct {
+	Type  string
+	Count int
+}
+
+// Validate ensures the bulk export job's domain invariants are met
+func (j *BulkExportJob) Validate() error {
+	if j.ID == "" {
+		return ErrEmptyBulkExportJobID
+	}
+	if j.RequestedBy == "" {
+		return ErrEmptyBulkExportRequester
+	}
+	for _, t := range j.Types {
+		if !slices.Contains(BulkExportTypes, t) {
+			return ErrUnsupportedBulkExportType
+		}
+	}
+	if !ValidBulkExportStatus(j.Status) {
+		return ErrInvalidBulkExportStatus
+	}
+	return nil
+}
+
+// Expired reports whether the files of the job are past their retention.
+// Jobs that ended without an expiry, failed or completed before exports
+// expired, are kept as long from their completion.
+func (j *BulkExportJob) Expired(at time.Time) bool {
+	switch {
+	case j.ExpiresAt != nil:
+		return !at.Before(*j.ExpiresAt)
+	case j.CompletedAt != nil:
+		return !at.Before(j.CompletedAt.Add(BulkExportRetention))
+	default:
+		return false
+	}
+}
+
+// HasFile reports whether the completed job wrote a file for the resource type
+func (j *BulkExportJob) HasFile(resourceType string) bool {
+	return j.Status == BulkExportStatusCompleted && slices.ContainsFunc(j.Output, func(f BulkExportFile) bool {
+		return f.Type == resourceType
+	})
+}
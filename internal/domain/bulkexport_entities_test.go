@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBulkExportJob_Validate(t *testing.T) {
+	valid := BulkExportJob{ID: "j1", RequestedBy: "client", Types: BulkExportTypes, Status: BulkExportStatusInProgress}
+
+	tests := []struct {
+		name    string
+		modify  func(j *BulkExportJob)
+		wantErr error
+	}{
+		{"valid job", func(j *BulkExportJob) {}, nil},
+		{"missing ID", func(j *BulkExportJob) { j.ID = "" }, ErrEmptyBulkExportJobID},
+		{"missing requester", func(j *BulkExportJob) { j.RequestedBy = "" }, ErrEmptyBulkExportRequester},
+		{"unknown type", func(j *BulkExportJob) { j.Types = []string{"Observation"} }, ErrUnsupportedBulkExportType},
+		{"unknown status", func(j *BulkExportJob) { j.Status = "paused" }, ErrInvalidBulkExportStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := valid
+			tt.modify(&job)
+			if err := job.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseBulkExportTypes(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr error
+	}{
+		{"", BulkExportTypes, nil},
+		{"Condition", []string{BulkExportTypeCondition}, nil},
+		{"Condition, Patient,Condition", []string{BulkExportTypeCondition, BulkExportTypePatient}, nil},
+		{"Patient,Observation", nil, ErrUnsupportedBulkExportType},
+	}
+	for _, tt := range tests {
+		got, err := ParseBulkExportTypes(tt.value)
+		if err != tt.wantErr || !slices.Equal(got, tt.want) {
+			t.Errorf("ParseBulkExportTypes(%q) = %v, %v, want %v, %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestBulkExportJob_HasFile(t *testing.T) {
+	job := BulkExportJob{Status: BulkExportStatusCompleted, Output: []BulkExportFile{{Type: BulkExportTypePatient, Count: 2}}}
+	if !job.HasFile(BulkExportTypePatient) || job.HasFile(BulkExportTypeCondition) {
+		t.Errorf("HasFile() on %+v", job)
+	}
+	job.Status = BulkExportStatusInProgress
+	if job.HasFile(BulkExportTypePatient) {
+		t.Error("HasFile() = true before the job completed")
+	}
+}
+
+func TestBulkExportJob_Expired(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	longAgo := now.Add(-BulkExportRetention - time.Minute)
+
+	tests := []struct {
+		name string
+		job  BulkExportJob
+		want bool
+	}{
+		{"running", BulkExportJob{Status: BulkExportStatusInProgress}, false},
+		{"before its expiry", BulkExportJob{Status: BulkExportStatusCompleted, CompletedAt: &now, ExpiresAt: &expiresAt}, false},
+		{"at its expiry", BulkExportJob{Status: BulkExportStatusCompleted, CompletedAt: &now, ExpiresAt: &now}, true},
+		{"ended without expiry long ago", BulkExportJob{Status: BulkExportStatusFailed, CompletedAt: &longAgo}, true},
+		{"ended without expiry recently", BulkExportJob{Status: BulkExportStatusFailed, CompletedAt: &now}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.Expired(now); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+// Bulk Export Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// BulkExportRepository defines operations for bulk export job persistence and
+// the reads the jobs are made of
+type BulkExportRepository interface {
+	CreateBulkExportJob(job *BulkExportJob) error
+	// UpdateBulkExportJob stores the status, error, output and completion time
+	UpdateBulkExportJob(job *BulkExportJob) error
+	GetBulkExportJobByID(id string) (*BulkExportJob, error)
+	GetBulkExportJobsByStatus(status string) ([]BulkExportJob, error)
+	// GetExpiredBulkExportJobs returns the jobs whose files are past their
+	// retention at the given time
+	GetExpiredBulkExportJobs(at time.Time) ([]BulkExportJob, error)
+	DeleteBulkExportJob(id string) error
+	// AddBulkExportPatients records patients whose data the job holds
+	AddBulkExportPatients(jobID string, patientIDs []string) error
+	// GetBulkExportJobsWithPatient returns the jobs in the given status that
+	// hold a patient, or a duplicate merged into them
+	GetBulkExportJobsWithPatient(patientID, status string) ([]BulkExportJob, error)
+	// HasErasedBulkExportPatients reports whether any patient the job holds
+	// has been erased
+	HasErasedBulkExportPatients(jobID string) (bool, error)
+	// ExportPatients calls fn with batches of the active patients the caller
+	// may access, updated after since if set, until fn fails
+	ExportPatients(caller Caller, since *time.Time, fn func([]Patient) error) error
+	// ExportDiagnoses calls fn with batches of the diagnoses the caller may
+	// access, updated after since if set, until fn fails
+	ExportDiagnoses(caller Caller, since *time.Time, fn func([]Diagnosis) error) error
+}
+
+// BulkExportStorage keeps the NDJSON files of bulk export jobs, one per
+// resource type, encrypted with a key of the job
+type BulkExportStorage interface {
+	// Create prepares the files of a new job and returns the key they are
+	// encrypted with
+	Create(jobID string) ([]byte, error)
+	// AppendPatients writes patients to the Patient file of the job
+	AppendPatients(jobID string, key []byte, patients []Patient) error
+	// AppendConditions writes diagnoses to the Condition file of the job
+	AppendConditions(jobID string, key []byte, diagnoses []Diagnosis) error
+	// Open returns the file of a resource type of the job, decrypted with its
+	// key, which the caller must close
+	Open(jobID string, key []byte, resourceType string) (io.ReadCloser, error)
+	// Delete removes every file of the job
+	Delete(jobID string) error
+}
+
+// Bulk Export Domain - Service Interfaces (Driving Ports - Inbound)
+
+// BulkExportService defines asynchronous bulk export operations
+type BulkExportService interface {
+	// StartExport records a job exporting the resource types and runs it in
+	// the background
+	StartExport(caller Caller, types []string, since *time.Time) (*BulkExportJob, error)
+	GetExportJob(caller Caller, id string) (*BulkExportJob, error)
+	// OpenExportFile returns the file of a resource type of a completed job,
+	// which the caller must close
+	OpenExportFile(caller Caller, id, resourceType string) (io.ReadCloser, error)
+	// FailInterruptedExports marks the jobs a previous run of the server left
+	// in progress as failed. Jobs run inside the server, so none of them can
+	// still be running when it starts.
+	FailInterruptedExports() (int, error)
+	// PurgeExpiredExports removes the jobs past their retention at the given
+	// time, with their files
+	PurgeExpiredExports(at time.Time) (int, error)
+}
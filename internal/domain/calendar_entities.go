@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidFeedToken = errors.New("invalid calendar feed token")
+)
+
+// Calendar feed window: how far back and ahead the feed lists appointments
+const (
+	CalendarFeedPast   = 30 * 24 * time.Hour
+	CalendarFeedFuture = 180 * 24 * time.Hour
+)
+
+// CalendarFeed is a practitioner's subscription to their agenda from a
+// calendar app. Its token is only valid for the current nonce, so issuing a
+// new one revokes the previous.
+type CalendarFeed struct {
+	PractitionerID string
+	Nonce          string
+	CreatedAt      time.Time
+}
+
+// Validate ensures the calendar feed's domain invariants are met
+func (f *CalendarFeed) Validate() error {
+	if f.PractitionerID == "" {
+		return ErrEmptyPractitionerID
+	}
+	if f.Nonce == "" {
+		return ErrEmptyToken
+	}
+	return nil
+}
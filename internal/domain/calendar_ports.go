@@ -0,0 +1,24 @@
+package domain
+
+// Calendar Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// CalendarFeedRepository defines operations for calendar feed persistence.
+// A practitioner has at most one feed; saving replaces it.
+type CalendarFeedRepository interface {
+	SaveCalendarFeed(feed *CalendarFeed) error
+	GetCalendarFeed(practitionerID string) (*CalendarFeed, error)
+	DeleteCalendarFeed(practitionerID string) error
+}
+
+// Calendar Domain - Service Interfaces (Driving Ports - Inbound)
+
+// CalendarService defines the iCalendar subscription of practitioners to
+// their agenda
+type CalendarService interface {
+	// CreateFeed issues a new signed feed token, revoking the previous one
+	CreateFeed(caller Caller, practitionerID string) (string, *CalendarFeed, error)
+	RevokeFeed(caller Caller, practitionerID string) error
+	// GetFeedAppointments checks the token and returns the appointments in
+	// the feed window
+	GetFeedAppointments(practitionerID, token string) ([]Appointment, error)
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAccessDenied          = errors.New("access to patient denied")
+	ErrEmptyCareTeamUserID   = errors.New("care team member user ID cannot be empty")
+	ErrEmptyJustification    = errors.New("break-glass justification cannot be empty")
+	ErrLastCareTeamMember    = errors.New("cannot remove the last member of a care team")
+	ErrAlreadyCareTeamMember = errors.New("user is already a member of the care team")
+)
+
+// BreakGlassDuration is how long a break-glass access grant stays active
+const BreakGlassDuration = 4 * time.Hour
+
+// Access log actions
+const (
+	AccessActionRead       = "read"
+	AccessActionSearch     = "search"
+	AccessActionWrite      = "write"
+	AccessActionBreakGlass = "break_glass"
+	AccessActionExport     = "export"
+)
+
+// Caller identifies the authenticated user performing an operation
+type Caller struct {
+	UserID string
+	Role   string
+}
+
+// IsAdmin reports whether the caller is an administrator
+func (c Caller) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
+// IsIntegration reports whether the caller is a third-party integration client
+func (c Caller) IsIntegration() bool {
+	return c.Role == RoleIntegration
+}
+
+// CareTeamMember links a user to a patient they treat
+type CareTeamMember struct {
+	PatientID string
+	UserID    string
+	AddedBy   string
+	AddedAt   time.Time
+}
+
+// Validate ensures the care team member's domain invariants are met
+func (m *CareTeamMember) Validate() error {
+	if m.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if m.UserID == "" {
+		return ErrEmptyCareTeamUserID
+	}
+	return nil
+}
+
+// BreakGlassAccess is a temporary access grant to a patient outside the
+// caller's care team. It always carries a justification and is flagged for
+// review.
+type BreakGlassAccess struct {
+	ID               string
+	PatientID        string
+	UserID           string
+	Justification    string
+	GrantedAt        time.Time
+	ExpiresAt        time.Time
+	FlaggedForReview bool
+}
+
+// Validate ensures the break-glass access invariants are met
+func (b *BreakGlassAccess) Validate() error {
+	if b.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if b.UserID == "" {
+		return ErrEmptyUserID
+	}
+	if strings.TrimSpace(b.Justification) == "" {
+		return ErrEmptyJustification
+	}
+	return nil
+}
+
+// IsActive reports whether the grant is still valid at the given time
+func (b *BreakGlassAccess) IsActive(at time.Time) bool {
+	return !at.Before(b.GrantedAt) && at.Before(b.ExpiresAt)
+}
+
+// AccessLogEntry records an access to a patient's data
+type AccessLogEntry struct {
+	ID         string
+	PatientID  string
+	UserID     string
+	Action     string
+	BreakGlass bool
+	At         time.Time
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Access Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// CareTeamRepository defines operations for care team and access log persistence
+type CareTeamRepository interface {
+	AddCareTeamMember(member *CareTeamMember) error
+	RemoveCareTeamMember(patientID, userID string) error
+	GetCareTeam(patientID string) ([]CareTeamMember, error)
+	IsCareTeamMember(patientID, userID string) (bool, error)
+	CreateBreakGlassAccess(access *BreakGlassAccess) error
+	GetActiveBreakGlassAccess(patientID, userID string, at time.Time) (*BreakGlassAccess, error)
+	CreateAccessLogEntry(entry *AccessLogEntry) error
+	GetAccessLogByPatientID(patientID string) ([]AccessLogEntry, error)
+}
+
+// Access Domain - Service Interfaces (Driving Ports - Inbound)
+
+// CareTeamService defines care team management and break-glass operations
+type CareTeamService interface {
+	GetCareTeam(caller Caller, patientID string) ([]CareTeamMember, error)
+	AddMember(caller Caller, patientID, userID string) error
+	RemoveMember(caller Caller, patientID, userID string) error
+	BreakGlass(caller Caller, patientID, justification string) (*BreakGlassAccess, error)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyConsentID          = errors.New("consent ID cannot be empty")
+	ErrInvalidConsentPurpose   = errors.New("invalid consent purpose")
+	ErrInvalidConsentScope     = errors.New("invalid consent scope")
+	ErrEmptyConsentEvidence    = errors.New("consent evidence cannot be empty")
+	ErrConsentAlreadyRevoked   = errors.New("consent is already revoked")
+	ErrConsentPatientMismatch  = errors.New("consent does not belong to patient")
+	ErrConsentRequired         = errors.New("patient has not consented to this use of their data")
+	ErrConsentGrantedInFuture  = errors.New("consent grant date cannot be in the future")
+	ErrConsentManagementDenied = errors.New("integration clients cannot manage consents")
+)
+
+// Consent purposes. Treatment by the care team does not require an explicit
+// consent record; every other use of the data does. Integration clients and
+// bulk exports are held to third-party sharing. No feature uses data for
+// research yet: research consents are recorded now so they are on file, and
+// whatever first releases data for research must check them.
+const (
+	ConsentPurposeThirdPartySharing = "third_party_sharing"
+	ConsentPurposeResearch          = "research"
+)
+
+// Consent scopes
+const (
+	ConsentScopeAll           = "all"
+	ConsentScopeDemographics  = "demographics"
+	ConsentScopeDiagnoses     = "diagnoses"
+	ConsentScopePrescriptions = "prescriptions"
+)
+
+var consentPurposes = map[string]bool{
+	ConsentPurposeThirdPartySharing: true,
+	ConsentPurposeResearch:          true,
+}
+
+var consentScopes = map[string]bool{
+	ConsentScopeAll:           true,
+	ConsentScopeDemographics:  true,
+	ConsentScopeDiagnoses:     true,
+	ConsentScopePrescriptions: true,
+}
+
+// Consent records a patient's decision about a specific use of their data
+type Consent struct {
+	ID         string
+	PatientID  string
+	Purpose    string
+	Scope      string
+	GrantedAt  time.Time
+	RevokedAt  *time.Time
+	Evidence   string // Reference to the signed form, recording, etc.
+	RecordedBy string
+	// Contact ID of the legal representative who consented on the patient's
+	// behalf, empty when the patient consented
+	RepresentativeID string
+}
+
+// Validate ensures the consent's domain invariants are met
+func (c *Consent) Validate() error {
+	if c.ID == "" {
+		return ErrEmptyConsentID
+	}
+	if c.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if !consentPurposes[c.Purpose] {
+		return ErrInvalidConsentPurpose
+	}
+	if !consentScopes[c.Scope] {
+		return ErrInvalidConsentScope
+	}
+	if strings.TrimSpace(c.Evidence) == "" {
+		return ErrEmptyConsentEvidence
+	}
+	if c.GrantedAt.After(time.Now()) {
+		return ErrConsentGrantedInFuture
+	}
+	return nil
+}
+
+// ValidateAuthorizingParty checks who consents: a patient under the medical
+// age of consent needs a legal representative, and the representative, when
+// given, must be one of the patient's
+func (c *Consent) ValidateAuthorizingParty(patient *Patient, representative *Contact) error {
+	if c.RepresentativeID == "" {
+		if patient.IsMinorAt(c.GrantedAt) {
+			return ErrRepresentativeRequired
+		}
+		return nil
+	}
+	if representative == nil || representative.PatientID != patient.ID {
+		return ErrContactPatientMismatch
+	}
+	if !representative.LegalRepresentative {
+		return ErrNotLegalRepresentative
+	}
+	return nil
+}
+
+// IsActive reports whether the consent is granted and not revoked at the given time
+func (c *Consent) IsActive(at time.Time) bool {
+	if at.Before(c.GrantedAt) {
+		return false
+	}
+	return c.RevokedAt == nil || at.Before(*c.RevokedAt)
+}
+
+// Covers reports whether the consent authorizes the given purpose and scope
+func (c *Consent) Covers(purpose, scope string) bool {
+	return c.Purpose == purpose && (c.Scope == ConsentScopeAll || c.Scope == scope)
+}
+
+// HasConsent reports whether any active consent in the list authorizes the given purpose and scope
+func HasConsent(consents []Consent, purpose, scope string, at time.Time) bool {
+	for _, c := range consents {
+		if c.IsActive(at) && c.Covers(purpose, scope) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHasConsent(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+	consents := []Consent{
+		{Purpose: ConsentPurposeThirdPartySharing, Scope: ConsentScopeDiagnoses, GrantedAt: now.Add(-time.Hour)},
+		{Purpose: ConsentPurposeThirdPartySharing, Scope: ConsentScopePrescriptions, GrantedAt: now.Add(-time.Hour), RevokedAt: &revokedAt},
+		{Purpose: ConsentPurposeResearch, Scope: ConsentScopeAll, GrantedAt: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name    string
+		purpose string
+		scope   string
+		want    bool
+	}{
+		{"granted scope", ConsentPurposeThirdPartySharing, ConsentScopeDiagnoses, true},
+		{"scope not granted", ConsentPurposeThirdPartySharing, ConsentScopeDemographics, false},
+		{"revoked consent", ConsentPurposeThirdPartySharing, ConsentScopePrescriptions, false},
+		{"research consent", ConsentPurposeResearch, ConsentScopeDemographics, true},
+		{"research consent is not for sharing", ConsentPurposeThirdPartySharing, ConsentScopeAll, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasConsent(consents, tt.purpose, tt.scope, now); got != tt.want {
+				t.Errorf("HasConsent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsent_Validate(t *testing.T) {
+	valid := Consent{
+		ID:        "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+		PatientID: "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+		Purpose:   ConsentPurposeThirdPartySharing,
+		Scope:     ConsentScopeAll,
+		Evidence:  "Signed form",
+		GrantedAt: time.Now().Add(-time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *Consent)
+		wantErr error
+	}{
+		{"valid consent", func(c *Consent) {}, nil},
+		{"unknown purpose", func(c *Consent) { c.Purpose = "marketing" }, ErrInvalidConsentPurpose},
+		{"research", func(c *Consent) { c.Purpose = ConsentPurposeResearch }, nil},
+		{"unknown scope", func(c *Consent) { c.Scope = "genome" }, ErrInvalidConsentScope},
+		{"missing evidence", func(c *Consent) { c.Evidence = " " }, ErrEmptyConsentEvidence},
+		{"future grant", func(c *Consent) { c.GrantedAt = time.Now().Add(time.Hour) }, ErrConsentGrantedInFuture},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.mutate(&c)
+			if err := c.Validate(); err != tt.wantErr {
+				t.Errorf("Consent.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
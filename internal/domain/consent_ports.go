@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Consent Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// ConsentRepository defines operations for consent persistence
+type ConsentRepository interface {
+	CreateConsent(consent *Consent) error
+	GetConsentByID(id string) (*Consent, error)
+	GetConsentsByPatientID(patientID string) ([]Consent, error)
+	RevokeConsent(id string, at time.Time) error
+}
+
+// Consent Domain - Service Interfaces (Driving Ports - Inbound)
+
+// ConsentService defines consent management operations
+type ConsentService interface {
+	GrantConsent(caller Caller, consent *Consent) error
+	GetConsents(caller Caller, patientID string) ([]Consent, error)
+	RevokeConsent(caller Caller, patientID, consentID string) (*Consent, error)
+}
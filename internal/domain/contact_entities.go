@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyContactID         = errors.New("contact ID cannot be empty")
+	ErrEmptyContactName       = errors.New("contact name cannot be empty")
+	ErrInvalidRelationship    = errors.New("invalid contact relationship")
+	ErrContactUnreachable     = errors.New("contact needs a phone or an email")
+	ErrContactPatientMismatch = errors.New("contact does not belong to patient")
+	ErrNotLegalRepresentative = errors.New("contact is not a legal representative of the patient")
+	ErrRepresentativeRequired = errors.New("patient is a minor, a legal representative must consent on their behalf")
+)
+
+// MedicalAgeOfConsent is the age from which patients consent by themselves,
+// "mayoría de edad sanitaria" (Ley 41/2002, art. 9.4)
+const MedicalAgeOfConsent = 16
+
+// Relationships of a contact to the patient
+const (
+	RelationshipParent    = "parent"
+	RelationshipGuardian  = "guardian"
+	RelationshipSpouse    = "spouse"
+	RelationshipPartner   = "partner"
+	RelationshipChild     = "child"
+	RelationshipSibling   = "sibling"
+	RelationshipRelative  = "relative"
+	RelationshipCaregiver = "caregiver"
+	RelationshipFriend    = "friend"
+	RelationshipOther     = "other"
+)
+
+var relationships = map[string]bool{
+	RelationshipParent:    true,
+	RelationshipGuardian:  true,
+	RelationshipSpouse:    true,
+	RelationshipPartner:   true,
+	RelationshipChild:     true,
+	RelationshipSibling:   true,
+	RelationshipRelative:  true,
+	RelationshipCaregiver: true,
+	RelationshipFriend:    true,
+	RelationshipOther:     true,
+}
+
+// Contact is a person related to a patient: an emergency contact and, when
+// LegalRepresentative is set, a guardian who can consent on their behalf
+type Contact struct {
+	ID                  string
+	PatientID           string
+	Relationship        string
+	Name                string
+	Phone               string // E.164, see NormalizePhone
+	Email               string
+	LegalRepresentative bool
+	CreatedBy           string
+	CreatedAt           time.Time
+}
+
+// Validate ensures the contact's domain invariants are met
+func (c *Contact) Validate() error {
+	if c.ID == "" {
+		return ErrEmptyContactID
+	}
+	if c.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if !relationships[c.Relationship] {
+		return ErrInvalidRelationship
+	}
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrEmptyContactName
+	}
+	if c.Phone == "" && c.Email == "" {
+		return ErrContactUnreachable
+	}
+	if c.Phone != "" && !e164Regex.MatchString(c.Phone) {
+		return ErrInvalidPhone
+	}
+	if c.Email != "" && !ValidarEmail(c.Email) {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+// IsMinorAt reports whether the patient is known to be under the medical age
+// of consent at the given moment. Patients without a birth date are taken as
+// adults.
+func (p *Patient) IsMinorAt(at time.Time) bool {
+	age, ok := p.AgeAt(at)
+	return ok && age < MedicalAgeOfConsent
+}
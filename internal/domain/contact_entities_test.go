@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContact_Validate(t *testing.T) {
+	valid := Contact{ID: "c1", PatientID: "p1", Relationship: RelationshipParent, Name: "Carmen López", Phone: "+34600654321"}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Contact)
+		wantErr error
+	}{
+		{"valid contact", func(c *Contact) {}, nil},
+		{"email only", func(c *Contact) { c.Phone, c.Email = "", "carmen@example.com" }, nil},
+		{"missing ID", func(c *Contact) { c.ID = "" }, ErrEmptyContactID},
+		{"missing patient", func(c *Contact) { c.PatientID = "" }, ErrEmptyPatientFK},
+		{"unknown relationship", func(c *Contact) { c.Relationship = "neighbour" }, ErrInvalidRelationship},
+		{"missing name", func(c *Contact) { c.Name = " " }, ErrEmptyContactName},
+		{"no phone nor email", func(c *Contact) { c.Phone = "" }, ErrContactUnreachable},
+		{"phone not in E.164", func(c *Contact) { c.Phone = "600 65 43 21" }, ErrInvalidPhone},
+		{"invalid email", func(c *Contact) { c.Email = "carmen" }, ErrInvalidEmail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact := valid
+			tt.modify(&contact)
+			if err := contact.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConsent_ValidateAuthorizingParty(t *testing.T) {
+	now := time.Now()
+	childBirth := now.AddDate(-10, 0, 0)
+	teenBirth := now.AddDate(-16, 0, -1)
+	child := &Patient{ID: "p1", BirthDate: &childBirth}
+	teen := &Patient{ID: "p1", BirthDate: &teenBirth}
+	unknownAge := &Patient{ID: "p1"}
+	guardian := &Contact{ID: "c1", PatientID: "p1", Relationship: RelationshipGuardian, LegalRepresentative: true}
+	friend := &Contact{ID: "c2", PatientID: "p1", Relationship: RelationshipFriend}
+	otherGuardian := &Contact{ID: "c3", PatientID: "p2", Relationship: RelationshipParent, LegalRepresentative: true}
+
+	tests := []struct {
+		name           string
+		patient        *Patient
+		representative *Contact
+		wantErr        error
+	}{
+		{"adult consents", teen, nil, nil},
+		{"unknown age consents", unknownAge, nil, nil},
+		{"minor alone", child, nil, ErrRepresentativeRequired},
+		{"minor through guardian", child, guardian, nil},
+		{"adult through guardian", teen, guardian, nil},
+		{"contact is not a representative", child, friend, ErrNotLegalRepresentative},
+		{"representative of another patient", child, otherGuardian, ErrContactPatientMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consent := Consent{PatientID: "p1", GrantedAt: now}
+			if tt.representative != nil {
+				consent.RepresentativeID = tt.representative.ID
+			}
+			if err := consent.ValidateAuthorizingParty(tt.patient, tt.representative); err != tt.wantErr {
+				t.Errorf("ValidateAuthorizingParty() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+// Contact Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// ContactRepository defines operations for patient contact persistence
+type ContactRepository interface {
+	CreateContact(contact *Contact) error
+	GetContactByID(id string) (*Contact, error)
+	GetContactsByPatientID(patientID string) ([]Contact, error)
+	UpdateContact(contact *Contact) error
+	DeleteContact(id string) error
+}
+
+// Contact Domain - Service Interfaces (Driving Ports - Inbound)
+
+// ContactService defines emergency contact and guardian management operations
+type ContactService interface {
+	AddContact(caller Caller, contact *Contact) error
+	GetContacts(caller Caller, patientID string) ([]Contact, error)
+	UpdateContact(caller Caller, contact *Contact) error
+	DeleteContact(caller Caller, patientID, contactID string) error
+}
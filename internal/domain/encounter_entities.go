@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrEmptyEncounterID           = errors.New("encounter ID cannot be empty")
+	ErrEmptyEncounterPractitioner = errors.New("encounter practitioner is required")
+	ErrEmptyEncounterStart        = errors.New("encounter start is required")
+	ErrFutureEncounter            = errors.New("encounter cannot start in the future")
+	ErrInvalidEncounterStatus     = errors.New("invalid encounter status")
+	ErrSOAPSectionTooLong         = errors.New("clinical note section is too long")
+	ErrEmptySOAPNote              = errors.New("closing an encounter requires a clinical note")
+	ErrEncounterNotFound          = errors.New("encounter not found")
+	ErrEncounterClosed            = errors.New("encounter is already closed")
+	ErrEncounterPatientMismatch   = errors.New("encounter belongs to another patient")
+)
+
+// MaxSOAPSectionLength is the most characters a section of a clinical note
+// can hold
+const MaxSOAPSectionLength = 10000
+
+// Encounter statuses. Diagnoses can only be added to an open encounter.
+const (
+	EncounterStatusOpen   = "open"
+	EncounterStatusClosed = "closed"
+)
+
+// SOAPNote is the clinical note of a visit, written in the subjective,
+// objective, assessment and plan sections
+type SOAPNote struct {
+	Subjective string // What the patient reports
+	Objective  string // Findings of the examination and tests
+	Assessment string
+	Plan       string
+}
+
+// Normalize trims the sections of the note
+func (n *SOAPNote) Normalize() {
+	n.Subjective = strings.TrimSpace(n.Subjective)
+	n.Objective = strings.TrimSpace(n.Objective)
+	n.Assessment = strings.TrimSpace(n.Assessment)
+	n.Plan = strings.TrimSpace(n.Plan)
+}
+
+// IsEmpty reports whether no section of the note was written
+func (n SOAPNote) IsEmpty() bool {
+	return n.Subjective == "" && n.Objective == "" && n.Assessment == "" && n.Plan == ""
+}
+
+// Validate ensures every section of the note fits
+func (n SOAPNote) Validate() error {
+	for _, section := range []string{n.Subjective, n.Objective, n.Assessment, n.Plan} {
+		if utf8.RuneCountInString(section) > MaxSOAPSectionLength {
+			return ErrSOAPSectionTooLong
+		}
+	}
+	return nil
+}
+
+// Encounter is a visit of a patient to a practitioner. It groups the visit's
+// clinical note and the diagnoses, with their prescriptions, made during it.
+type Encounter struct {
+	ID             string
+	PatientID      string
+	PractitionerID string // Practitioner who opened the encounter
+	Status         string
+	Note           SOAPNote
+	StartedAt      time.Time
+	ClosedAt       *time.Time
+	CreatedAt      time.Time
+	Diagnoses      []Diagnosis // Only loaded when reading a single encounter
+}
+
+// Validate ensures the encounter's domain invariants are met
+func (e *Encounter) Validate() error {
+	if e.ID == "" {
+		return ErrEmptyEncounterID
+	}
+	if e.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if e.PractitionerID == "" {
+		return ErrEmptyEncounterPractitioner
+	}
+	if e.StartedAt.IsZero() {
+		return ErrEmptyEncounterStart
+	}
+	if e.StartedAt.After(time.Now()) {
+		return ErrFutureEncounter
+	}
+	if e.Status != EncounterStatusOpen && e.Status != EncounterStatusClosed {
+		return ErrInvalidEncounterStatus
+	}
+	return e.Note.Validate()
+}
+
+// IsOpen reports whether the encounter still takes notes and diagnoses
+func (e *Encounter) IsOpen() bool {
+	return e.Status == EncounterStatusOpen
+}
+
+// UpdateNote replaces the clinical note of an open encounter
+func (e *Encounter) UpdateNote(note SOAPNote) error {
+	if !e.IsOpen() {
+		return ErrEncounterClosed
+	}
+	note.Normalize()
+	if err := note.Validate(); err != nil {
+		return err
+	}
+	e.Note = note
+	return nil
+}
+
+// Close ends the encounter, with a final version of the note when given. A
+// closed encounter must have a note.
+func (e *Encounter) Close(note *SOAPNote, at time.Time) error {
+	if note != nil {
+		if err := e.UpdateNote(*note); err != nil {
+			return err
+		}
+	}
+	if !e.IsOpen() {
+		return ErrEncounterClosed
+	}
+	if e.Note.IsEmpty() {
+		return ErrEmptySOAPNote
+	}
+	e.Status = EncounterStatusClosed
+	e.ClosedAt = &at
+	return nil
+}
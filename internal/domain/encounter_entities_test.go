@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncounter_Validate(t *testing.T) {
+	valid := Encounter{ID: "e1", PatientID: "p1", PractitionerID: "u1", Status: EncounterStatusOpen, StartedAt: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name    string
+		modify  func(e *Encounter)
+		wantErr error
+	}{
+		{"valid open encounter", func(e *Encounter) {}, nil},
+		{"valid closed encounter", func(e *Encounter) { e.Status = EncounterStatusClosed }, nil},
+		{"missing ID", func(e *Encounter) { e.ID = "" }, ErrEmptyEncounterID},
+		{"missing patient", func(e *Encounter) { e.PatientID = "" }, ErrEmptyPatientFK},
+		{"missing practitioner", func(e *Encounter) { e.PractitionerID = "" }, ErrEmptyEncounterPractitioner},
+		{"missing start", func(e *Encounter) { e.StartedAt = time.Time{} }, ErrEmptyEncounterStart},
+		{"starting in the future", func(e *Encounter) { e.StartedAt = time.Now().Add(time.Hour) }, ErrFutureEncounter},
+		{"unknown status", func(e *Encounter) { e.Status = "paused" }, ErrInvalidEncounterStatus},
+		{"note section too long", func(e *Encounter) { e.Note.Plan = strings.Repeat("a", MaxSOAPSectionLength+1) }, ErrSOAPSectionTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encounter := valid
+			tt.modify(&encounter)
+			if err := encounter.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncounter_Transitions(t *testing.T) {
+	at := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	open := func() *Encounter {
+		return &Encounter{ID: "e1", PatientID: "p1", PractitionerID: "u1", Status: EncounterStatusOpen}
+	}
+
+	t.Run("closing needs a note", func(t *testing.T) {
+		encounter := open()
+		if err := encounter.Close(nil, at); err != ErrEmptySOAPNote {
+			t.Errorf("Close() without note = %v, want %v", err, ErrEmptySOAPNote)
+		}
+		if err := encounter.Close(&SOAPNote{Plan: "  "}, at); err != ErrEmptySOAPNote {
+			t.Errorf("Close() with a blank note = %v, want %v", err, ErrEmptySOAPNote)
+		}
+		if encounter.Status != EncounterStatusOpen {
+			t.Errorf("Status = %q, want the encounter still open", encounter.Status)
+		}
+	})
+
+	t.Run("update then close", func(t *testing.T) {
+		encounter := open()
+		if err := encounter.UpdateNote(SOAPNote{Subjective: " Tos seca "}); err != nil || encounter.Note.Subjective != "Tos seca" {
+			t.Fatalf("UpdateNote() = %v, %+v", err, encounter.Note)
+		}
+		if err := encounter.Close(nil, at); err != nil || encounter.Status != EncounterStatusClosed || encounter.ClosedAt == nil {
+			t.Fatalf("Close() = %v, %+v", err, encounter)
+		}
+		if err := encounter.UpdateNote(SOAPNote{Plan: "Reposo"}); err != ErrEncounterClosed {
+			t.Errorf("UpdateNote() after closing = %v, want %v", err, ErrEncounterClosed)
+		}
+		if err := encounter.Close(nil, at); err != ErrEncounterClosed {
+			t.Errorf("Close() twice = %v, want %v", err, ErrEncounterClosed)
+		}
+	})
+
+	t.Run("close with the final note", func(t *testing.T) {
+		encounter := open()
+		note := SOAPNote{Assessment: "Faringitis", Plan: "Ibuprofeno"}
+		if err := encounter.Close(&note, at); err != nil || encounter.Note != note {
+			t.Errorf("Close() = %v, %+v", err, encounter.Note)
+		}
+	})
+}
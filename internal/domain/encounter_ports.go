@@ -0,0 +1,24 @@
+package domain
+
+// Encounter Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// EncounterRepository defines operations for encounter persistence
+type EncounterRepository interface {
+	CreateEncounter(encounter *Encounter) error
+	UpdateEncounter(encounter *Encounter) error
+	GetEncounterByID(id string) (*Encounter, error)
+	GetEncountersByPatientID(patientID string) ([]Encounter, error)
+	GetDiagnosesByEncounterID(encounterID string) ([]Diagnosis, error)
+}
+
+// Encounter Domain - Service Interfaces (Driving Ports - Inbound)
+
+// EncounterService defines operations on patient visits and their notes
+type EncounterService interface {
+	OpenEncounter(caller Caller, encounter *Encounter) error
+	UpdateEncounterNote(caller Caller, id string, note SOAPNote) (*Encounter, error)
+	CloseEncounter(caller Caller, id string, note *SOAPNote) (*Encounter, error)
+	// GetEncounter returns the encounter with the diagnoses made during it
+	GetEncounter(caller Caller, id string) (*Encounter, error)
+	GetPatientEncounters(caller Caller, patientID string) ([]Encounter, error)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyErasureReason   = errors.New("erasure reason cannot be empty")
+	ErrPatientAlreadyErased = errors.New("patient has already been erased")
+)
+
+// ClinicalRecordRetention is the minimum time clinical records must be kept
+// after the last episode (Ley 41/2002, art. 17: at least five years)
+const ClinicalRecordRetention = 5 * 365 * 24 * time.Hour
+
+// ErasedPatientName replaces the name of an erased patient
+const ErasedPatientName = "[erased]"
+
+// Erasure records a GDPR erasure request applied to a patient. Identifying
+// data is anonymized at once; clinical records are kept until RetainUntil.
+type Erasure struct {
+	ID          string
+	PatientID   string
+	RequestedBy string
+	Reason      string
+	ErasedAt    time.Time
+	RetainUntil time.Time
+	PurgedAt    *time.Time
+}
+
+// Validate ensures the erasure's domain invariants are met
+func (e *Erasure) Validate() error {
+	if e.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if e.RequestedBy == "" {
+		return ErrEmptyUserID
+	}
+	if strings.TrimSpace(e.Reason) == "" {
+		return ErrEmptyErasureReason
+	}
+	return nil
+}
+
+// RetentionDeadline returns until when the clinical records in the given
+// diagnoses must be kept. Without diagnoses nothing has to be retained.
+func RetentionDeadline(diagnoses []Diagnosis, erasedAt time.Time) time.Time {
+	deadline := erasedAt
+	for _, d := range diagnoses {
+		if until := d.Date.Add(ClinicalRecordRetention); until.After(deadline) {
+			deadline = until
+		}
+	}
+	return deadline
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Erasure Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// ErasureRepository defines operations for erasure persistence
+type ErasureRepository interface {
+	// ErasePatient stores the anonymized patient and the erasure record atomically
+	ErasePatient(patient *Patient, erasure *Erasure) error
+	GetErasuresDueForPurge(at time.Time) ([]Erasure, error)
+	// PurgeClinicalRecords deletes the retained clinical records of an erased patient
+	PurgeClinicalRecords(erasure *Erasure, at time.Time) error
+}
+
+// Erasure Domain - Service Interfaces (Driving Ports - Inbound)
+
+// ErasureService defines GDPR erasure operations
+type ErasureService interface {
+	ErasePatient(caller Caller, patientID, reason string) (*Erasure, error)
+	PurgeExpiredRecords(at time.Time) (int, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// PatientExport gathers every piece of data held about a patient, as required
+// by the GDPR right of access
+type PatientExport struct {
+	GeneratedAt   time.Time
+	GeneratedBy   string
+	Patient       Patient
+	Diagnoses     []Diagnosis
+	Prescriptions []Prescription
+	Consents      []Consent
+	Contacts      []Contact
+	Appointments  []Appointment
+	Observations  []Observation
+	LabResults    []LabResult
+	Attachments   []Attachment
+	Vaccinations  []Vaccination
+	Referrals     []Referral
+	Encounters    []Encounter
+	AccessLog     []AccessLogEntry
+}
+
+// Prescription is a prescription issued along with a diagnosis
+type Prescription struct {
+	DiagnosisID  string
+	Prescription string
+	Date         time.Time
+}
+
+// PrescriptionsFromDiagnoses extracts the non-empty prescriptions of a list of diagnoses
+func PrescriptionsFromDiagnoses(diagnoses []Diagnosis) []Prescription {
+	result := make([]Prescription, 0, len(diagnoses))
+	for _, d := range diagnoses {
+		if d.Prescription == "" {
+			continue
+		}
+		result = append(result, Prescription{
+			DiagnosisID:  d.ID,
+			Prescription: d.Prescription,
+			Date:         d.Date,
+		})
+	}
+	return result
+}
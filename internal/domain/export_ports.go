@@ -0,0 +1,14 @@
+package domain
+
+import "io"
+
+// Export Domain - Service Interfaces (Driving Ports - Inbound)
+
+// ExportService defines data subject export operations
+type ExportService interface {
+	ExportPatient(caller Caller, patientID string) (*PatientExport, error)
+	// OpenExportedAttachment returns the content of an attachment of the
+	// patient, for the copy of the files that goes with the export. The
+	// caller must close it.
+	OpenExportedAttachment(caller Caller, patientID, attachmentID string) (io.ReadCloser, error)
+}
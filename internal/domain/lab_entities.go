@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptyLabResultID       = errors.New("lab result ID cannot be empty")
+	ErrEmptyLabPanel          = errors.New("lab panel is required")
+	ErrEmptyLabAnalyte        = errors.New("lab analyte is required")
+	ErrEmptyLabUnit           = errors.New("lab result unit is required")
+	ErrEmptyLabCollectionTime = errors.New("lab sample collection time is required")
+	ErrFutureLabCollection    = errors.New("lab sample collection time cannot be in the future")
+	ErrEmptyLabBatch          = errors.New("lab result batch cannot be empty")
+	ErrLabBatchTooLarge       = errors.New("lab result batch is too large")
+	ErrInvalidLabRange        = errors.New("lab result date range is invalid")
+)
+
+// MaxLabBatchSize bounds the results ingested at once, a full lab report
+// rarely has more than a few dozen
+const MaxLabBatchSize = 200
+
+// MaxAbnormalLabRange bounds the period of an abnormal results search across
+// patients
+const MaxAbnormalLabRange = 366 * 24 * time.Hour
+
+// LabResult is the value of one analyte in a lab report, such as the
+// hemoglobin of a complete blood count
+type LabResult struct {
+	ID          string
+	PatientID   string
+	BatchID     string // Results ingested together share it
+	Panel       string // e.g. "Complete blood count"
+	Analyte     string // e.g. "Hemoglobin"
+	Code        string // LOINC code of the analyte, if the lab sent it
+	Value       float64
+	Unit        string // UCUM unit
+	Reference   ReferenceRange
+	CollectedAt time.Time // When the sample was taken
+	DiagnosisID string    // Diagnosis the results support, if any
+	RecordedBy  string
+	CreatedAt   time.Time
+}
+
+// Validate ensures the lab result's domain invariants are met
+func (r *LabResult) Validate() error {
+	if r.ID == "" {
+		return ErrEmptyLabResultID
+	}
+	if r.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if r.Panel == "" {
+		return ErrEmptyLabPanel
+	}
+	if r.Analyte == "" {
+		return ErrEmptyLabAnalyte
+	}
+	if r.Unit == "" {
+		return ErrEmptyLabUnit
+	}
+	if err := r.Reference.Validate(); err != nil {
+		return err
+	}
+	if r.CollectedAt.IsZero() {
+		return ErrEmptyLabCollectionTime
+	}
+	if r.CollectedAt.After(time.Now().Add(observationClockSkew)) {
+		return ErrFutureLabCollection
+	}
+	return nil
+}
+
+// Flag classifies the value against the reference range the lab reported:
+// InterpretationLow, InterpretationNormal or InterpretationHigh, or "" when
+// there is no range
+func (r *LabResult) Flag() string {
+	return r.Reference.Interpret(r.Value)
+}
+
+// IsAbnormal reports whether the value is outside its reference range
+func (r *LabResult) IsAbnormal() bool {
+	flag := r.Flag()
+	return flag == InterpretationLow || flag == InterpretationHigh
+}
+
+// ValidateLabBatch ensures a batch of results to ingest is neither empty nor
+// too large
+func ValidateLabBatch(results []LabResult) error {
+	if len(results) == 0 {
+		return ErrEmptyLabBatch
+	}
+	if len(results) > MaxLabBatchSize {
+		return ErrLabBatchTooLarge
+	}
+	return nil
+}
+
+// ValidateAbnormalLabRange ensures the period of an abnormal results search
+// is ordered and not longer than MaxAbnormalLabRange
+func ValidateAbnormalLabRange(from, to time.Time) error {
+	if !to.After(from) || to.Sub(from) > MaxAbnormalLabRange {
+		return ErrInvalidLabRange
+	}
+	return nil
+}
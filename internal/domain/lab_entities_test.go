@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLabResult_Validate(t *testing.T) {
+	now := time.Now()
+	valid := LabResult{ID: "l1", PatientID: "p1", Panel: "Complete blood count", Analyte: "Hemoglobin", Value: 13.5, Unit: "g/dL",
+		Reference: ReferenceRange{Low: bound(12), High: bound(16)}, CollectedAt: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name    string
+		modify  func(r *LabResult)
+		wantErr error
+	}{
+		{"valid result", func(r *LabResult) {}, nil},
+		{"without reference range", func(r *LabResult) { r.Reference = ReferenceRange{} }, nil},
+		{"missing ID", func(r *LabResult) { r.ID = "" }, ErrEmptyLabResultID},
+		{"missing patient", func(r *LabResult) { r.PatientID = "" }, ErrEmptyPatientFK},
+		{"missing panel", func(r *LabResult) { r.Panel = "" }, ErrEmptyLabPanel},
+		{"missing analyte", func(r *LabResult) { r.Analyte = "" }, ErrEmptyLabAnalyte},
+		{"missing unit", func(r *LabResult) { r.Unit = "" }, ErrEmptyLabUnit},
+		{"inverted reference range", func(r *LabResult) { r.Reference = ReferenceRange{Low: bound(16), High: bound(12)} }, ErrInvalidReferenceRange},
+		{"missing collection time", func(r *LabResult) { r.CollectedAt = time.Time{} }, ErrEmptyLabCollectionTime},
+		{"collected in the future", func(r *LabResult) { r.CollectedAt = now.Add(time.Hour) }, ErrFutureLabCollection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := valid
+			tt.modify(&result)
+			if err := result.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLabResult_Flag(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        float64
+		reference    ReferenceRange
+		wantFlag     string
+		wantAbnormal bool
+	}{
+		{"low", 10.9, ReferenceRange{Low: bound(12), High: bound(16)}, InterpretationLow, true},
+		{"normal", 13.5, ReferenceRange{Low: bound(12), High: bound(16)}, InterpretationNormal, false},
+		{"high against an upper bound only", 240, ReferenceRange{High: bound(200)}, InterpretationHigh, true},
+		{"no reference range", 5, ReferenceRange{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LabResult{Value: tt.value, Reference: tt.reference}
+			if got := result.Flag(); got != tt.wantFlag {
+				t.Errorf("Flag() = %q, want %q", got, tt.wantFlag)
+			}
+			if got := result.IsAbnormal(); got != tt.wantAbnormal {
+				t.Errorf("IsAbnormal() = %v, want %v", got, tt.wantAbnormal)
+			}
+		})
+	}
+}
+
+func TestValidateLabBatch(t *testing.T) {
+	if err := ValidateLabBatch(nil); err != ErrEmptyLabBatch {
+		t.Errorf("ValidateLabBatch(nil) = %v, want %v", err, ErrEmptyLabBatch)
+	}
+	if err := ValidateLabBatch(make([]LabResult, MaxLabBatchSize+1)); err != ErrLabBatchTooLarge {
+		t.Errorf("ValidateLabBatch() = %v, want %v", err, ErrLabBatchTooLarge)
+	}
+	if err := ValidateLabBatch(make([]LabResult, 3)); err != nil {
+		t.Errorf("ValidateLabBatch() = %v, want nil", err)
+	}
+}
+
+func TestValidateAbnormalLabRange(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		to      time.Time
+		wantErr error
+	}{
+		{"one month", from.AddDate(0, 1, 0), nil},
+		{"empty range", from, ErrInvalidLabRange},
+		{"reversed", from.AddDate(0, 0, -1), ErrInvalidLabRange},
+		{"longer than a year", from.AddDate(1, 0, 2), ErrInvalidLabRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateAbnormalLabRange(from, tt.to); err != tt.wantErr {
+				t.Errorf("ValidateAbnormalLabRange() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
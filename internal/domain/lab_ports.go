@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// LabResultFilter narrows down the lab results of a patient
+type LabResultFilter struct {
+	Panel        *string
+	Analyte      *string
+	AbnormalOnly bool
+	From         *time.Time // Inclusive
+	To           *time.Time // Exclusive
+}
+
+// Lab Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// LabResultRepository defines operations for lab result persistence
+type LabResultRepository interface {
+	// CreateLabResults stores a batch of results, all or none
+	CreateLabResults(results []LabResult) error
+	// GetLabResultsByPatientID returns the matching results in order of
+	// collection
+	GetLabResultsByPatientID(patientID string, filter LabResultFilter) ([]LabResult, error)
+	// GetAbnormalLabResults returns the abnormal results collected in
+	// [from, to) of the patients the caller may access
+	GetAbnormalLabResults(caller Caller, from, to time.Time) ([]LabResult, error)
+}
+
+// Lab Domain - Service Interfaces (Driving Ports - Inbound)
+
+// LabResultService defines lab result ingestion and query operations
+type LabResultService interface {
+	IngestLabResults(caller Caller, patientID string, results []LabResult) ([]LabResult, error)
+	GetLabResults(caller Caller, patientID string, filter LabResultFilter) ([]LabResult, error)
+	GetAbnormalLabResults(caller Caller, from, to time.Time) ([]LabResult, error)
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrMergeSamePatient = errors.New("a patient cannot be merged into itself")
+	ErrPatientMerged    = errors.New("patient has been merged into another record")
+	ErrMergeConflict    = errors.New("the patients changed while being merged, try again")
+)
+
+// Duplicate candidate scoring. Each signal contributes its weight times its
+// similarity, between 0 and 1; candidates below DuplicateThreshold are not
+// reported.
+const (
+	DuplicateNameWeight      = 0.4
+	DuplicateEmailWeight     = 0.2
+	DuplicatePhoneWeight     = 0.2
+	DuplicateBirthDateWeight = 0.2
+	DuplicateThreshold       = 0.4
+)
+
+// Signals matched by a duplicate candidate
+const (
+	DuplicateMatchName      = "name"
+	DuplicateMatchEmail     = "email"
+	DuplicateMatchPhone     = "phone"
+	DuplicateMatchBirthDate = "birth_date"
+)
+
+// DuplicateCandidate is a patient that may be the same person as another
+type DuplicateCandidate struct {
+	Patient Patient
+	Score   float64
+	Matches []string // Signals that matched, e.g. DuplicateMatchEmail
+}
+
+// DuplicateScore combines the similarity of each signal into a score between
+// 0 and 1 and lists the signals that matched
+func DuplicateScore(nameSimilarity float64, sameEmail, samePhone, sameBirthDate bool) (float64, []string) {
+	score := DuplicateNameWeight * nameSimilarity
+	var matches []string
+	if nameSimilarity >= FuzzyNameThreshold {
+		matches = append(matches, DuplicateMatchName)
+	}
+	if sameEmail {
+		score += DuplicateEmailWeight
+		matches = append(matches, DuplicateMatchEmail)
+	}
+	if samePhone {
+		score += DuplicatePhoneWeight
+		matches = append(matches, DuplicateMatchPhone)
+	}
+	if sameBirthDate {
+		score += DuplicateBirthDateWeight
+		matches = append(matches, DuplicateMatchBirthDate)
+	}
+	return score, matches
+}
+
+// PatientMerge records that a duplicate patient record was merged into the
+// surviving one. The duplicate keeps its identifying data for audit and
+// redirects to the survivor.
+type PatientMerge struct {
+	ID             string
+	SurvivorID     string
+	DuplicateID    string
+	MergedBy       string
+	Reason         string
+	DiagnosesMoved int
+	MergedAt       time.Time
+}
+
+// Validate ensures the merge's domain invariants are met
+func (m *PatientMerge) Validate() error {
+	if m.SurvivorID == "" || m.DuplicateID == "" {
+		return ErrEmptyPatientFK
+	}
+	if m.SurvivorID == m.DuplicateID {
+		return ErrMergeSamePatient
+	}
+	if m.MergedBy == "" {
+		return ErrEmptyUserID
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDuplicateScore(t *testing.T) {
+	tests := []struct {
+		name                                string
+		nameSimilarity                      float64
+		sameEmail, samePhone, sameBirthDate bool
+		wantScore                           float64
+		wantMatches                         []string
+	}{
+		{"same name only", 1, false, false, false, 0.4, []string{DuplicateMatchName}},
+		{"same name and birth date", 1, false, false, true, 0.6, []string{DuplicateMatchName, DuplicateMatchBirthDate}},
+		{"different name, same contact", 0.5, true, true, false, 0.6, []string{DuplicateMatchEmail, DuplicateMatchPhone}},
+		{"everything matches", 1, true, true, true, 1, []string{DuplicateMatchName, DuplicateMatchEmail, DuplicateMatchPhone, DuplicateMatchBirthDate}},
+		{"nothing matches", 0, false, false, false, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, matches := DuplicateScore(tt.nameSimilarity, tt.sameEmail, tt.samePhone, tt.sameBirthDate)
+			if math.Abs(score-tt.wantScore) > 1e-9 || !reflect.DeepEqual(matches, tt.wantMatches) {
+				t.Errorf("DuplicateScore() = %v, %v, want %v, %v", score, matches, tt.wantScore, tt.wantMatches)
+			}
+		})
+	}
+}
+
+func TestPatientMerge_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		merge   PatientMerge
+		wantErr error
+	}{
+		{"valid", PatientMerge{SurvivorID: "p1", DuplicateID: "p2", MergedBy: "u1"}, nil},
+		{"missing duplicate", PatientMerge{SurvivorID: "p1", MergedBy: "u1"}, ErrEmptyPatientFK},
+		{"same patient", PatientMerge{SurvivorID: "p1", DuplicateID: "p1", MergedBy: "u1"}, ErrMergeSamePatient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.merge.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+// Merge Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// MergeRepository defines operations for duplicate detection and merging
+type MergeRepository interface {
+	// FindDuplicateCandidates returns the patients the caller may access that
+	// resemble the given one, best candidates first
+	FindDuplicateCandidates(caller Caller, patient *Patient) ([]DuplicateCandidate, error)
+	// MergePatients moves the duplicate's clinical records to the survivor and
+	// stores the merge record atomically, setting DiagnosesMoved
+	MergePatients(merge *PatientMerge) error
+}
+
+// Merge Domain - Service Interfaces (Driving Ports - Inbound)
+
+// MergeService defines duplicate detection and merge operations
+type MergeService interface {
+	FindDuplicates(caller Caller, patientID string) ([]DuplicateCandidate, error)
+	MergePatients(caller Caller, survivorID, duplicateID, reason string) (*PatientMerge, error)
+}
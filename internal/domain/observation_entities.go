@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptyObservationID     = errors.New("observation ID cannot be empty")
+	ErrUnknownObservationCode = errors.New("unknown observation type code")
+	ErrInvalidObservationUnit = errors.New("unit not allowed for the observation type")
+	ErrImplausibleObservation = errors.New("observation value is outside the plausible range for its type")
+	ErrInvalidReferenceRange  = errors.New("reference range low bound must be below its high bound")
+	ErrEmptyObservationTime   = errors.New("observation time is required")
+	ErrFutureObservation      = errors.New("observation time cannot be in the future")
+)
+
+// LOINC codes of the supported observation types
+const (
+	ObservationSystolicBP  = "8480-6"
+	ObservationDiastolicBP = "8462-4"
+	ObservationHeartRate   = "8867-4"
+	ObservationTemperature = "8310-5"
+	ObservationBodyWeight  = "29463-7"
+	ObservationGlucose     = "2339-0"
+)
+
+// Interpretation of a value against its reference range, with the HL7
+// ObservationInterpretation codes
+const (
+	InterpretationLow    = "L"
+	InterpretationNormal = "N"
+	InterpretationHigh   = "H"
+)
+
+// observationClockSkew tolerates devices whose clock runs slightly ahead
+const observationClockSkew = 5 * time.Minute
+
+// unitConversion converts a value to the canonical unit of its type as
+// value*factor + offset
+type unitConversion struct {
+	factor float64
+	offset float64
+}
+
+func (c unitConversion) toCanonical(value float64) float64 {
+	return value*c.factor + c.offset
+}
+
+func (c unitConversion) fromCanonical(value float64) float64 {
+	return (value - c.offset) / c.factor
+}
+
+// ObservationType describes a kind of observation: its UCUM units, the
+// values that are physically plausible and the adult reference range, both
+// in the canonical unit
+type ObservationType struct {
+	Code      string
+	Display   string
+	Unit      string // Canonical UCUM unit
+	Reference ReferenceRange
+	units     map[string]unitConversion
+	min       float64
+	max       float64
+}
+
+func bound(v float64) *float64 {
+	return &v
+}
+
+var observationTypes = map[string]ObservationType{
+	ObservationSystolicBP: {
+		Code: ObservationSystolicBP, Display: "Systolic blood pressure", Unit: "mm[Hg]",
+		Reference: ReferenceRange{Low: bound(90), High: bound(140)},
+		units:     map[string]unitConversion{"mm[Hg]": {1, 0}},
+		min:       40, max: 300,
+	},
+	ObservationDiastolicBP: {
+		Code: ObservationDiastolicBP, Display: "Diastolic blood pressure", Unit: "mm[Hg]",
+		Reference: ReferenceRange{Low: bound(60), High: bound(90)},
+		units:     map[string]unitConversion{"mm[Hg]": {1, 0}},
+		min:       20, max: 200,
+	},
+	ObservationHeartRate: {
+		Code: ObservationHeartRate, Display: "Heart rate", Unit: "/min",
+		Reference: ReferenceRange{Low: bound(60), High: bound(100)},
+		units:     map[string]unitConversion{"/min": {1, 0}},
+		min:       20, max: 300,
+	},
+	ObservationTemperature: {
+		Code: ObservationTemperature, Display: "Body temperature", Unit: "Cel",
+		Reference: ReferenceRange{Low: bound(36), High: bound(37.5)},
+		units:     map[string]unitConversion{"Cel": {1, 0}, "[degF]": {5.0 / 9, -32 * 5.0 / 9}},
+		min:       25, max: 45,
+	},
+	ObservationBodyWeight: {
+		Code: ObservationBodyWeight, Display: "Body weight", Unit: "kg",
+		units: map[string]unitConversion{"kg": {1, 0}, "g": {0.001, 0}, "[lb_av]": {0.45359237, 0}},
+		min:   0.2, max: 500,
+	},
+	ObservationGlucose: {
+		// Non-fasting reference; fasting values above 100 mg/dL are already high
+		Code: ObservationGlucose, Display: "Glucose in blood", Unit: "mg/dL",
+		Reference: ReferenceRange{Low: bound(70), High: bound(140)},
+		units:     map[string]unitConversion{"mg/dL": {1, 0}, "mmol/L": {18.016, 0}},
+		min:       10, max: 1500,
+	},
+}
+
+// LookupObservationType returns the observation type with the given LOINC code
+func LookupObservationType(code string) (ObservationType, bool) {
+	t, ok := observationTypes[code]
+	return t, ok
+}
+
+// AllowsUnit reports whether values of the type can be recorded in the unit
+func (t ObservationType) AllowsUnit(unit string) bool {
+	_, ok := t.units[unit]
+	return ok
+}
+
+// ToCanonical converts a value in an allowed unit to the canonical unit
+func (t ObservationType) ToCanonical(value float64, unit string) float64 {
+	return t.units[unit].toCanonical(value)
+}
+
+// ReferenceIn returns the reference range of the type in an allowed unit
+func (t ObservationType) ReferenceIn(unit string) ReferenceRange {
+	conversion := t.units[unit]
+	var r ReferenceRange
+	if t.Reference.Low != nil {
+		r.Low = bound(conversion.fromCanonical(*t.Reference.Low))
+	}
+	if t.Reference.High != nil {
+		r.High = bound(conversion.fromCanonical(*t.Reference.High))
+	}
+	return r
+}
+
+// ReferenceRange bounds the expected values of a measurement. Either bound
+// may be open.
+type ReferenceRange struct {
+	Low  *float64
+	High *float64
+}
+
+// IsEmpty reports whether the range has no bound at all
+func (r ReferenceRange) IsEmpty() bool {
+	return r.Low == nil && r.High == nil
+}
+
+// Validate ensures the range is not inverted
+func (r ReferenceRange) Validate() error {
+	if r.Low != nil && r.High != nil && *r.Low > *r.High {
+		return ErrInvalidReferenceRange
+	}
+	return nil
+}
+
+// Interpret classifies a value against the range, or returns "" when there
+// is no range to compare with
+func (r ReferenceRange) Interpret(value float64) string {
+	switch {
+	case r.IsEmpty():
+		return ""
+	case r.Low != nil && value < *r.Low:
+		return InterpretationLow
+	case r.High != nil && value > *r.High:
+		return InterpretationHigh
+	default:
+		return InterpretationNormal
+	}
+}
+
+// Observation is a measurement taken on a patient, such as a vital sign
+type Observation struct {
+	ID          string
+	PatientID   string
+	Code        string // LOINC code of the observation type
+	Value       float64
+	Unit        string // UCUM unit
+	Reference   ReferenceRange
+	EffectiveAt time.Time // When the measurement was taken
+	DiagnosisID string    // Diagnosis the observation supports, if any
+	RecordedBy  string
+	CreatedAt   time.Time
+}
+
+// Validate ensures the observation's domain invariants are met
+func (o *Observation) Validate() error {
+	if o.ID == "" {
+		return ErrEmptyObservationID
+	}
+	if o.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+
+	t, ok := LookupObservationType(o.Code)
+	if !ok {
+		return ErrUnknownObservationCode
+	}
+	if !t.AllowsUnit(o.Unit) {
+		return ErrInvalidObservationUnit
+	}
+	if canonical := t.ToCanonical(o.Value, o.Unit); canonical < t.min || canonical > t.max {
+		return ErrImplausibleObservation
+	}
+	if err := o.Reference.Validate(); err != nil {
+		return err
+	}
+
+	if o.EffectiveAt.IsZero() {
+		return ErrEmptyObservationTime
+	}
+	if o.EffectiveAt.After(time.Now().Add(observationClockSkew)) {
+		return ErrFutureObservation
+	}
+	return nil
+}
+
+// ApplyDefaultReference sets the adult reference range of the observation
+// type, in the observation's unit, when none was given
+func (o *Observation) ApplyDefaultReference() {
+	t, ok := LookupObservationType(o.Code)
+	if !ok || !t.AllowsUnit(o.Unit) || !o.Reference.IsEmpty() {
+		return
+	}
+	o.Reference = t.ReferenceIn(o.Unit)
+}
+
+// Interpretation classifies the value against the observation's reference
+// range
+func (o *Observation) Interpretation() string {
+	return o.Reference.Interpret(o.Value)
+}
+
+// ObservationPoint is a value in a time series
+type ObservationPoint struct {
+	At             time.Time
+	Value          float64
+	Interpretation string
+}
+
+// ObservationSeries is the time series of one observation type, with every
+// value converted to the canonical unit of the type
+type ObservationSeries struct {
+	Code      string
+	Display   string
+	Unit      string
+	Reference ReferenceRange
+	Points    []ObservationPoint
+}
+
+// NewObservationSeries builds the series of the given type from observations
+// in chronological order. Observations of other types are skipped.
+func NewObservationSeries(code string, observations []Observation) (*ObservationSeries, error) {
+	t, ok := LookupObservationType(code)
+	if !ok {
+		return nil, ErrUnknownObservationCode
+	}
+
+	series := &ObservationSeries{Code: t.Code, Display: t.Display, Unit: t.Unit, Reference: t.Reference, Points: []ObservationPoint{}}
+	for _, o := range observations {
+		if o.Code != code || !t.AllowsUnit(o.Unit) {
+			continue
+		}
+		series.Points = append(series.Points, ObservationPoint{
+			At:             o.EffectiveAt,
+			Value:          t.ToCanonical(o.Value, o.Unit),
+			Interpretation: o.Interpretation(),
+		})
+	}
+	return series, nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestObservation_Validate(t *testing.T) {
+	now := time.Now()
+	valid := Observation{ID: "o1", PatientID: "p1", Code: ObservationHeartRate, Value: 72, Unit: "/min", EffectiveAt: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name    string
+		modify  func(o *Observation)
+		wantErr error
+	}{
+		{"valid observation", func(o *Observation) {}, nil},
+		{"temperature in fahrenheit", func(o *Observation) { o.Code, o.Value, o.Unit = ObservationTemperature, 98.6, "[degF]" }, nil},
+		{"missing ID", func(o *Observation) { o.ID = "" }, ErrEmptyObservationID},
+		{"missing patient", func(o *Observation) { o.PatientID = "" }, ErrEmptyPatientFK},
+		{"unknown code", func(o *Observation) { o.Code = "0000-0" }, ErrUnknownObservationCode},
+		{"unit of another type", func(o *Observation) { o.Unit = "mm[Hg]" }, ErrInvalidObservationUnit},
+		{"implausible heart rate", func(o *Observation) { o.Value = 900 }, ErrImplausibleObservation},
+		{"fahrenheit checked after conversion", func(o *Observation) { o.Code, o.Value, o.Unit = ObservationTemperature, 130, "[degF]" }, ErrImplausibleObservation},
+		{"inverted reference range", func(o *Observation) { o.Reference = ReferenceRange{Low: bound(100), High: bound(60)} }, ErrInvalidReferenceRange},
+		{"missing time", func(o *Observation) { o.EffectiveAt = time.Time{} }, ErrEmptyObservationTime},
+		{"within clock skew", func(o *Observation) { o.EffectiveAt = now.Add(time.Minute) }, nil},
+		{"in the future", func(o *Observation) { o.EffectiveAt = now.Add(time.Hour) }, ErrFutureObservation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observation := valid
+			tt.modify(&observation)
+			if err := observation.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReferenceRange_Interpret(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     ReferenceRange
+		value float64
+		want  string
+	}{
+		{"no range", ReferenceRange{}, 50, ""},
+		{"below low", ReferenceRange{Low: bound(60), High: bound(100)}, 50, InterpretationLow},
+		{"on the bound", ReferenceRange{Low: bound(60), High: bound(100)}, 100, InterpretationNormal},
+		{"above high", ReferenceRange{Low: bound(60), High: bound(100)}, 120, InterpretationHigh},
+		{"open low bound", ReferenceRange{High: bound(100)}, 5, InterpretationNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Interpret(tt.value); got != tt.want {
+				t.Errorf("Interpret(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObservation_ApplyDefaultReference(t *testing.T) {
+	fever := Observation{Code: ObservationTemperature, Value: 101.3, Unit: "[degF]"}
+	fever.ApplyDefaultReference()
+	if fever.Reference.High == nil || math.Abs(*fever.Reference.High-99.5) > 1e-9 {
+		t.Fatalf("expected the reference in fahrenheit, got %+v", fever.Reference)
+	}
+	if got := fever.Interpretation(); got != InterpretationHigh {
+		t.Errorf("Interpretation() = %q, want %q", got, InterpretationHigh)
+	}
+
+	custom := Observation{Code: ObservationHeartRate, Value: 55, Unit: "/min", Reference: ReferenceRange{Low: bound(50)}}
+	custom.ApplyDefaultReference()
+	if custom.Reference.High != nil || custom.Interpretation() != InterpretationNormal {
+		t.Errorf("a given reference range must be kept, got %+v", custom.Reference)
+	}
+
+	weight := Observation{Code: ObservationBodyWeight, Value: 70, Unit: "kg"}
+	weight.ApplyDefaultReference()
+	if !weight.Reference.IsEmpty() || weight.Interpretation() != "" {
+		t.Errorf("body weight has no default reference, got %+v", weight.Reference)
+	}
+}
+
+func TestNewObservationSeries(t *testing.T) {
+	day := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	observations := []Observation{
+		{Code: ObservationTemperature, Value: 36.6, Unit: "Cel", EffectiveAt: day},
+		{Code: ObservationHeartRate, Value: 80, Unit: "/min", EffectiveAt: day},
+		{Code: ObservationTemperature, Value: 102.2, Unit: "[degF]", EffectiveAt: day.Add(time.Hour)},
+	}
+	for i := range observations {
+		observations[i].ApplyDefaultReference()
+	}
+
+	series, err := NewObservationSeries(ObservationTemperature, observations)
+	if err != nil {
+		t.Fatalf("NewObservationSeries() error = %v", err)
+	}
+	if series.Unit != "Cel" || len(series.Points) != 2 {
+		t.Fatalf("expected 2 points in Cel, got %d in %s", len(series.Points), series.Unit)
+	}
+	if math.Abs(series.Points[1].Value-39) > 1e-9 || series.Points[1].Interpretation != InterpretationHigh {
+		t.Errorf("expected 39 Cel flagged high, got %+v", series.Points[1])
+	}
+
+	if _, err := NewObservationSeries("0000-0", observations); err != ErrUnknownObservationCode {
+		t.Errorf("NewObservationSeries() error = %v, want %v", err, ErrUnknownObservationCode)
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// ObservationFilter narrows down the observations of a patient
+type ObservationFilter struct {
+	Code *string
+	From *time.Time // Inclusive
+	To   *time.Time // Exclusive
+}
+
+// Observation Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// ObservationRepository defines operations for observation persistence
+type ObservationRepository interface {
+	CreateObservation(observation *Observation) error
+	// GetObservationsByPatientID returns the matching observations in
+	// chronological order
+	GetObservationsByPatientID(patientID string, filter ObservationFilter) ([]Observation, error)
+}
+
+// Observation Domain - Service Interfaces (Driving Ports - Inbound)
+
+// ObservationService defines vital sign and clinical observation operations
+type ObservationService interface {
+	RecordObservation(caller Caller, observation *Observation) error
+	GetObservations(caller Caller, patientID string, filter ObservationFilter) ([]Observation, error)
+	GetObservationSeries(caller Caller, patientID, code string, from, to *time.Time) (*ObservationSeries, error)
+}
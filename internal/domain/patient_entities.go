@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ var (
 	ErrEmptyPatientID     = errors.New("patient ID cannot be empty")
 	ErrEmptyDNI           = errors.New("patient DNI cannot be empty")
 	ErrEmptyName          = errors.New("patient name cannot be empty")
+	ErrEmptySurname       = errors.New("patient first surname cannot be empty")
 	ErrEmptyEmail         = errors.New("patient email cannot be empty")
 	ErrEmptyDiagnosisID   = errors.New("diagnosis ID cannot be empty")
 	ErrEmptyDiagnosisText = errors.New("diagnosis text cannot be empty")
@@ -19,17 +21,171 @@ var (
 	ErrEmptyDate          = errors.New("diagnosis date is required")
 	ErrInvalidEmail       = errors.New("invalid email format")
 	ErrInvalidDNI         = errors.New("invalid DNI format")
+	ErrInvalidPhone       = errors.New("invalid phone number, expected a Spanish number or an international one with its country code")
+	ErrFutureBirthDate    = errors.New("birth date cannot be in the future")
+	ErrInvalidSex         = errors.New("invalid sex, expected male, female, other or unknown")
+	ErrInvalidNationality = errors.New("invalid nationality, expected an ISO 3166-1 alpha-2 country code")
+	ErrInvalidLanguage    = errors.New("invalid preferred language, expected an ISO 639-1 language code")
+)
+
+// Administrative sex codes, as in HL7 FHIR AdministrativeGender
+const (
+	SexMale    = "male"
+	SexFemale  = "female"
+	SexOther   = "other"
+	SexUnknown = "unknown"
+)
+
+// ValidSex reports whether the value is a known sex code
+func ValidSex(sex string) bool {
+	return sex == SexMale || sex == SexFemale || sex == SexOther || sex == SexUnknown
+}
+
+var (
+	countryCodeRegex  = regexp.MustCompile(`^[A-Z]{2}$`)
+	languageCodeRegex = regexp.MustCompile(`^[a-z]{2}$`)
 )
 
 // Patient represents a patient in the system
 type Patient struct {
-	ID        string
-	Name      string
-	DNI       string
-	Email     string
-	Phone     string
-	Address   string
-	Diagnosis []Diagnosis
+	ID                string
+	GivenName         string
+	FirstSurname      string
+	SecondSurname     string // Optional, many foreign patients have a single surname
+	DNI               string
+	BirthDate         *time.Time // Date only, at midnight UTC
+	Sex               string     // One of the Sex* codes
+	Nationality       string     // ISO 3166-1 alpha-2, e.g. "ES"
+	PreferredLanguage string     // ISO 639-1, e.g. "es"
+	Email             string
+	Phone             string
+	Address           Address
+	ErasedAt          *time.Time
+	MergedInto        string // ID of the surviving record when this one was merged as a duplicate
+	Diagnosis         []Diagnosis
+}
+
+// AgeAt returns the patient's age in completed years at the given moment,
+// and false when the birth date is unknown
+func (p *Patient) AgeAt(at time.Time) (int, bool) {
+	if p.BirthDate == nil {
+		return 0, false
+	}
+	birth := p.BirthDate.UTC()
+	at = at.UTC()
+	age := at.Year() - birth.Year()
+	if at.Month() < birth.Month() || (at.Month() == birth.Month() && at.Day() < birth.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// IsMerged reports whether the patient was merged into another record
+func (p *Patient) IsMerged() bool {
+	return p.MergedInto != ""
+}
+
+// DisplayName returns the full name as usually written in Spain: the given
+// name followed by both surnames
+func (p *Patient) DisplayName() string {
+	return joinNameParts(p.GivenName, p.FirstSurname, p.SecondSurname)
+}
+
+// SortName returns the name in the order used by alphabetical listings,
+// surnames first, e.g. "García López, María"
+func (p *Patient) SortName() string {
+	surnames := joinNameParts(p.FirstSurname, p.SecondSurname)
+	if surnames == "" {
+		return p.GivenName
+	}
+	if p.GivenName == "" {
+		return surnames
+	}
+	return surnames + ", " + p.GivenName
+}
+
+func joinNameParts(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
+
+// FormattedPhone returns the phone number for display, see FormatPhone
+func (p *Patient) FormattedPhone() string {
+	return FormatPhone(p.Phone)
+}
+
+// SetName fills the structured name from a full name written as a single
+// string, see SplitName
+func (p *Patient) SetName(full string) {
+	p.GivenName, p.FirstSurname, p.SecondSurname = SplitName(full)
+}
+
+// IsErased reports whether the patient's identifying data has been erased
+func (p *Patient) IsErased() bool {
+	return p.ErasedAt != nil
+}
+
+// Anonymize removes every identifying field of the patient. The DNI is
+// replaced by a placeholder derived from the erasure ID to keep it unique.
+func (p *Patient) Anonymize(erasureID string, at time.Time) {
+	p.GivenName = ErasedPatientName
+	p.FirstSurname = ""
+	p.SecondSurname = ""
+	p.DNI = "ERASED-" + erasureID
+	p.Email = ""
+	p.Phone = ""
+	p.Address = Address{}
+	p.BirthDate = nil
+	p.Sex = ""
+	p.Nationality = ""
+	p.PreferredLanguage = ""
+	p.ErasedAt = &at
+}
+
+// nameParticles join the following word in Spanish names, as in "de la Fuente"
+var nameParticles = map[string]bool{
+	"de": true, "del": true, "la": true, "las": true, "los": true, "y": true, "san": true,
+}
+
+// IsNameParticle reports whether a word is a particle such as "de" or "la"
+func IsNameParticle(word string) bool {
+	return nameParticles[strings.ToLower(word)]
+}
+
+// SplitName guesses the given name and the two surnames of a full Spanish
+// name: the last two words are the surnames and the rest the given name, with
+// particles kept together with the word that follows them. One word is taken
+// as a given name and two as a given name and a single surname.
+func SplitName(full string) (given, firstSurname, secondSurname string) {
+	var groups []string
+	var pending []string
+	for _, word := range strings.Fields(full) {
+		pending = append(pending, word)
+		if !IsNameParticle(word) {
+			groups = append(groups, strings.Join(pending, " "))
+			pending = nil
+		}
+	}
+	if len(pending) > 0 {
+		groups = append(groups, strings.Join(pending, " "))
+	}
+
+	switch len(groups) {
+	case 0:
+		return "", "", ""
+	case 1:
+		return groups[0], "", ""
+	case 2:
+		return groups[0], groups[1], ""
+	default:
+		n := len(groups)
+		return strings.Join(groups[:n-2], " "), groups[n-2], groups[n-1]
+	}
 }
 
 // Validate ensures the patient's domain invariants are met
@@ -37,9 +193,12 @@ func (p *Patient) Validate() error {
 	if p.ID == "" {
 		return ErrEmptyPatientID
 	}
-	if p.Name == "" {
+	if strings.TrimSpace(p.GivenName) == "" {
 		return ErrEmptyName
 	}
+	if strings.TrimSpace(p.FirstSurname) == "" {
+		return ErrEmptySurname
+	}
 
 	//Validate DNI format
 	if p.DNI == "" {
@@ -56,6 +215,28 @@ func (p *Patient) Validate() error {
 	if !ValidarEmail(p.Email) {
 		return ErrInvalidEmail
 	}
+	// Phones are stored in E.164, see NormalizePhone
+	if p.Phone != "" && !e164Regex.MatchString(p.Phone) {
+		return ErrInvalidPhone
+	}
+
+	if err := p.Address.Validate(); err != nil {
+		return err
+	}
+
+	// Demographics are optional, patients may arrive without documents
+	if p.BirthDate != nil && p.BirthDate.After(time.Now()) {
+		return ErrFutureBirthDate
+	}
+	if p.Sex != "" && !ValidSex(p.Sex) {
+		return ErrInvalidSex
+	}
+	if p.Nationality != "" && !countryCodeRegex.MatchString(p.Nationality) {
+		return ErrInvalidNationality
+	}
+	if p.PreferredLanguage != "" && !languageCodeRegex.MatchString(p.PreferredLanguage) {
+		return ErrInvalidLanguage
+	}
 	if p.Diagnosis != nil {
 		for _, d := range p.Diagnosis {
 			if err := d.Validate(); err != nil {
@@ -74,6 +255,9 @@ type Diagnosis struct {
 	Diagnosis    string
 	Prescription string
 	Date         time.Time
+	EncounterID  string       // Visit the diagnosis was made in, if any
+	Match        *SearchMatch // Set only by full-text searches
+	Attachments  []Attachment
 }
 
 // Validate ensures the diagnosis domain invariants are met
@@ -125,3 +309,51 @@ func ValidarEmail(email string) bool {
 	match, _ := regexp.MatchString(emailRegex, email)
 	return match
 }
+
+// DefaultPhoneCountryCode is the calling code assumed for phone numbers
+// written without one
+const DefaultPhoneCountryCode = "34"
+
+var (
+	e164Regex          = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	spanishNumberRegex = regexp.MustCompile(`^[6789][0-9]{8}$`)
+	phoneSeparators    = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+)
+
+// NormalizePhone parses a phone number as people write it, e.g.
+// "600 12 34 56", "+34600123456" or "0034-600-123-456", and returns it in
+// E.164. Numbers without a "+" or "00" prefix are taken as Spanish.
+func NormalizePhone(phone string) (string, error) {
+	digits := phoneSeparators.Replace(strings.TrimSpace(phone))
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+	default:
+		if !spanishNumberRegex.MatchString(digits) {
+			return "", ErrInvalidPhone
+		}
+		digits = DefaultPhoneCountryCode + digits
+	}
+
+	e164 := "+" + digits
+	if !e164Regex.MatchString(e164) {
+		return "", ErrInvalidPhone
+	}
+	if national, ok := strings.CutPrefix(digits, DefaultPhoneCountryCode); ok && !spanishNumberRegex.MatchString(national) {
+		return "", ErrInvalidPhone
+	}
+	return e164, nil
+}
+
+// FormatPhone returns an E.164 phone number grouped for display. Spanish
+// numbers are written as "+34 600 12 34 56"; numbers from other countries,
+// whose grouping we do not know, are returned as they are.
+func FormatPhone(e164 string) string {
+	national, ok := strings.CutPrefix(e164, "+"+DefaultPhoneCountryCode)
+	if !ok || !spanishNumberRegex.MatchString(national) {
+		return e164
+	}
+	return fmt.Sprintf("+%s %s %s %s %s", DefaultPhoneCountryCode, national[:3], national[3:5], national[5:7], national[7:])
+}
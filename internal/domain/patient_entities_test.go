@@ -2,9 +2,13 @@ package domain
 
 import (
 	"testing"
+	"time"
 )
 
 func TestPatient_Validate(t *testing.T) {
+	pastDate := time.Date(1980, 5, 17, 0, 0, 0, 0, time.UTC)
+	futureDate := time.Now().AddDate(1, 0, 0)
+
 	tests := []struct {
 		name    string
 		patient Patient
@@ -13,24 +17,38 @@ func TestPatient_Validate(t *testing.T) {
 		{
 			name: "valid patient",
 			patient: Patient{
-				ID:    "01HMGNBPJNX0G2BZXJ7XW1RHPR",
-				Name:  "Maria Garcia",
-				DNI:   "12345678Z",
-				Email: "maria@example.com",
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
+				Email:        "maria@example.com",
 			},
 			wantErr: nil,
 		},
+		{
+			name: "phone not in E.164",
+			patient: Patient{
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
+				Email:        "maria@example.com",
+				Phone:        "600 12 34 56",
+			},
+			wantErr: ErrInvalidPhone,
+		},
 		{
 			name: "missing ID",
 			patient: Patient{
-				Name:  "Maria Garcia",
-				DNI:   "12345678Z",
-				Email: "maria@example.com",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
+				Email:        "maria@example.com",
 			},
 			wantErr: ErrEmptyPatientID,
 		},
 		{
-			name: "missing Name",
+			name: "missing given name",
 			patient: Patient{
 				ID:    "01HMGNBPJNX0G2BZXJ7XW1RHPR",
 				DNI:   "12345678Z",
@@ -38,44 +56,122 @@ func TestPatient_Validate(t *testing.T) {
 			},
 			wantErr: ErrEmptyName,
 		},
+		{
+			name: "missing first surname",
+			patient: Patient{
+				ID:            "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:     "Maria",
+				SecondSurname: "Garcia",
+				DNI:           "12345678Z",
+				Email:         "maria@example.com",
+			},
+			wantErr: ErrEmptySurname,
+		},
 		{
 			name: "missing DNI",
 			patient: Patient{
-				ID:    "01HMGNBPJNX0G2BZXJ7XW1RHPR",
-				Name:  "Maria Garcia",
-				Email: "maria@example.com",
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				Email:        "maria@example.com",
 			},
 			wantErr: ErrEmptyDNI,
 		},
 		{
 			name: "invalid DNI format",
 			patient: Patient{
-				ID:    "01HMGNBPJNX0G2BZXJ7XW1RHPR",
-				Name:  "Maria Garcia",
-				DNI:   "12345678A", // Wrong letter
-				Email: "maria@example.com",
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678A", // Wrong letter
+				Email:        "maria@example.com",
 			},
 			wantErr: ErrInvalidDNI,
 		},
 		{
 			name: "missing Email",
 			patient: Patient{
-				ID:   "01HMGNBPJNX0G2BZXJ7XW1RHPR",
-				Name: "Maria Garcia",
-				DNI:  "12345678Z",
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
 			},
 			wantErr: ErrEmptyEmail,
 		},
 		{
 			name: "invalid Email format",
 			patient: Patient{
-				ID:    "01HMGNBPJNX0G2BZXJ7XW1RHPR",
-				Name:  "Maria Garcia",
-				DNI:   "12345678Z",
-				Email: "invalid-email",
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
+				Email:        "invalid-email",
 			},
 			wantErr: ErrInvalidEmail,
 		},
+		{
+			name: "valid demographics",
+			patient: Patient{
+				ID:                "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:         "Maria",
+				FirstSurname:      "Garcia",
+				DNI:               "12345678Z",
+				Email:             "maria@example.com",
+				BirthDate:         &pastDate,
+				Sex:               SexFemale,
+				Nationality:       "ES",
+				PreferredLanguage: "ca",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "future birth date",
+			patient: Patient{
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
+				Email:        "maria@example.com",
+				BirthDate:    &futureDate,
+			},
+			wantErr: ErrFutureBirthDate,
+		},
+		{
+			name: "uncoded sex",
+			patient: Patient{
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
+				Email:        "maria@example.com",
+				Sex:          "F",
+			},
+			wantErr: ErrInvalidSex,
+		},
+		{
+			name: "invalid nationality",
+			patient: Patient{
+				ID:           "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:    "Maria",
+				FirstSurname: "Garcia",
+				DNI:          "12345678Z",
+				Email:        "maria@example.com",
+				Nationality:  "Spain",
+			},
+			wantErr: ErrInvalidNationality,
+		},
+		{
+			name: "invalid preferred language",
+			patient: Patient{
+				ID:                "01HMGNBPJNX0G2BZXJ7XW1RHPR",
+				GivenName:         "Maria",
+				FirstSurname:      "Garcia",
+				DNI:               "12345678Z",
+				Email:             "maria@example.com",
+				PreferredLanguage: "spanish",
+			},
+			wantErr: ErrInvalidLanguage,
+		},
 	}
 
 	for _, tt := range tests {
@@ -134,3 +230,117 @@ func TestValidarEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		name                          string
+		full                          string
+		given, firstSurname, surname2 string
+	}{
+		{"given name only", "Cher", "Cher", "", ""},
+		{"single surname", "Jane Doe", "Jane", "Doe", ""},
+		{"two surnames", "María García López", "María", "García", "López"},
+		{"compound given name", "José Luis Pérez Gil", "José Luis", "Pérez", "Gil"},
+		{"particles", "Juan de la Fuente Ruiz", "Juan", "de la Fuente", "Ruiz"},
+		{"particle in given name", "María del Carmen Sanz Mora", "María del Carmen", "Sanz", "Mora"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			given, first, second := SplitName(tt.full)
+			if given != tt.given || first != tt.firstSurname || second != tt.surname2 {
+				t.Errorf("SplitName(%q) = %q, %q, %q, want %q, %q, %q",
+					tt.full, given, first, second, tt.given, tt.firstSurname, tt.surname2)
+			}
+		})
+	}
+}
+
+func TestPatient_DisplayName(t *testing.T) {
+	tests := []struct {
+		name              string
+		patient           Patient
+		display, sortName string
+	}{
+		{"two surnames", Patient{GivenName: "María", FirstSurname: "García", SecondSurname: "López"},
+			"María García López", "García López, María"},
+		{"single surname", Patient{GivenName: "Jane", FirstSurname: "Doe"}, "Jane Doe", "Doe, Jane"},
+		{"erased", Patient{GivenName: ErasedPatientName}, ErasedPatientName, ErasedPatientName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.patient.DisplayName(); got != tt.display {
+				t.Errorf("DisplayName() = %q, want %q", got, tt.display)
+			}
+			if got := tt.patient.SortName(); got != tt.sortName {
+				t.Errorf("SortName() = %q, want %q", got, tt.sortName)
+			}
+		})
+	}
+}
+
+func TestPatient_AgeAt(t *testing.T) {
+	birthDate := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+	patient := Patient{BirthDate: &birthDate}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want int
+	}{
+		{"day before birthday", time.Date(2024, 2, 28, 12, 0, 0, 0, time.UTC), 23},
+		{"on birthday", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 24},
+		{"non-leap year after February", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := patient.AgeAt(tt.at); !ok || got != tt.want {
+				t.Errorf("AgeAt(%v) = %d, %v, want %d", tt.at, got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := (&Patient{}).AgeAt(time.Now()); ok {
+		t.Error("AgeAt() without birth date expected false")
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone   string
+		want    string
+		wantErr error
+	}{
+		{"600 12 34 56", "+34600123456", nil},
+		{"+34600123456", "+34600123456", nil},
+		{"0034-600-123-456", "+34600123456", nil},
+		{"(+34) 912.34.56.78", "+34912345678", nil},
+		{"+44 20 7946 0958", "+442079460958", nil},
+		{"00 33 1 23 45 67 89", "+33123456789", nil},
+		{"12345", "", ErrInvalidPhone},
+		{"500123456", "", ErrInvalidPhone},
+		{"+34 600 12 34 5", "", ErrInvalidPhone},
+		{"600 12 34 56 ext 2", "", ErrInvalidPhone},
+		{"+0 600 12 34 56", "", ErrInvalidPhone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			got, err := NormalizePhone(tt.phone)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("NormalizePhone(%q) = %q, %v, want %q, %v", tt.phone, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatPhone(t *testing.T) {
+	tests := map[string]string{
+		"+34600123456":  "+34 600 12 34 56",
+		"+442079460958": "+442079460958",
+		"":              "",
+	}
+	for phone, want := range tests {
+		if got := FormatPhone(phone); got != want {
+			t.Errorf("FormatPhone(%q) = %q, want %q", phone, got, want)
+		}
+	}
+}
@@ -6,20 +6,30 @@ import "time"
 
 // PatientRepository defines operations for patient persistence
 type PatientRepository interface {
-	CreatePatient(patient *Patient) error
+	// CreatePatient stores the patient and, when creator is not nil, adds it to
+	// the care team in the same transaction
+	CreatePatient(patient *Patient, creator *CareTeamMember) error
 	GetPatientByID(id string) (*Patient, error)
 	GetPatientByDNI(dni string) (*Patient, error)
-	CreateDiagnosis(diagnosis *Diagnosis) error
+	// CreateDiagnosis stores the diagnosis and, when followUp is not nil, its
+	// follow-up appointment in the same transaction
+	CreateDiagnosis(diagnosis *Diagnosis, followUp *Appointment) error
+	GetDiagnosisByID(id string) (*Diagnosis, error)
 	GetDiagnosisByPatientID(patientID string) ([]Diagnosis, error)
 	GetByDiagnosisDateRange(startDate, endDate time.Time) ([]Diagnosis, error)
 	GetDiagnosisByPatientName(name string) ([]Diagnosis, error)
-	SearchDiagnosis(patientName *string, dateStart, dateEnd *time.Time) ([]Diagnosis, error)
+	SearchDiagnosis(caller Caller, filter DiagnosisFilter) (*DiagnosisPage, error)
+	SearchPatients(caller Caller, filter PatientFilter) (*PatientPage, error)
 }
 
 // PatientService defines patient business operations
 type PatientService interface {
-	CreatePatient(patient *Patient) error
-	GetPatient(dni string) (*Patient, error)
-	CreateDiagnosis(diagnosis *Diagnosis) error
-	GetDiagnostics(patientName *string, dateStart, dateEnd *time.Time) ([]Diagnosis, error)
+	CreatePatient(caller Caller, patient *Patient) error
+	GetPatient(caller Caller, id string) (*Patient, error)
+	// CreateDiagnosis adds a diagnosis and, when followUpDays is not nil, a
+	// pending follow-up with the caller due that many days after it
+	CreateDiagnosis(caller Caller, diagnosis *Diagnosis, followUpDays *int) (*Appointment, error)
+	GetDiagnosis(caller Caller, id string) (*Diagnosis, error)
+	GetDiagnostics(caller Caller, filter DiagnosisFilter) (*DiagnosisPage, error)
+	ListPatients(caller Caller, filter PatientFilter) (*PatientPage, error)
 }
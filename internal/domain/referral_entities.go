@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyReferralID            = errors.New("referral ID cannot be empty")
+	ErrEmptyReferringPractitioner = errors.New("referring practitioner is required")
+	ErrReferralRecipientRequired  = errors.New("referral must be addressed to either a practitioner or a specialty")
+	ErrInvalidReferralRecipient   = errors.New("referrals can only be sent to practitioners")
+	ErrSelfReferral               = errors.New("practitioners cannot refer patients to themselves")
+	ErrUnknownSpecialty           = errors.New("unknown specialty")
+	ErrEmptyReferralReason        = errors.New("referral reason is required")
+	ErrInvalidReferralUrgency     = errors.New("invalid referral urgency")
+	ErrInvalidReferralStatus      = errors.New("invalid referral status")
+	ErrEmptyRejectionReason       = errors.New("rejecting a referral requires a reason")
+	ErrReferralNotFound           = errors.New("referral not found")
+	ErrReferralNotRecipient       = errors.New("referral is not addressed to the caller")
+	ErrReferralNotPending         = errors.New("referral has already been answered")
+	ErrReferralNotAccepted        = errors.New("only accepted referrals can be completed")
+)
+
+// Referral statuses. A pending referral is accepted or rejected by its
+// recipient, and an accepted one is completed once the patient was seen.
+const (
+	ReferralStatusPending   = "pending"
+	ReferralStatusAccepted  = "accepted"
+	ReferralStatusRejected  = "rejected"
+	ReferralStatusCompleted = "completed"
+)
+
+// ValidReferralStatus reports whether the status is a known referral status
+func ValidReferralStatus(status string) bool {
+	switch status {
+	case ReferralStatusPending, ReferralStatusAccepted, ReferralStatusRejected, ReferralStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// Referral urgencies, with the HL7 request priority codes
+const (
+	ReferralUrgencyRoutine = "routine"
+	ReferralUrgencyUrgent  = "urgent"
+	ReferralUrgencyASAP    = "asap"
+	ReferralUrgencyStat    = "stat"
+)
+
+// referralUrgencyRank orders urgencies from the least to the most urgent
+var referralUrgencyRank = map[string]int{
+	ReferralUrgencyRoutine: 0,
+	ReferralUrgencyUrgent:  1,
+	ReferralUrgencyASAP:    2,
+	ReferralUrgencyStat:    3,
+}
+
+// Specialties practitioners can be referred to, by code
+var specialties = map[string]string{
+	"allergology":       "Alergología",
+	"cardiology":        "Cardiología",
+	"dermatology":       "Dermatología",
+	"endocrinology":     "Endocrinología y nutrición",
+	"family-medicine":   "Medicina familiar y comunitaria",
+	"gastroenterology":  "Aparato digestivo",
+	"general-surgery":   "Cirugía general",
+	"gynecology":        "Obstetricia y ginecología",
+	"hematology":        "Hematología",
+	"internal-medicine": "Medicina interna",
+	"nephrology":        "Nefrología",
+	"neurology":         "Neurología",
+	"oncology":          "Oncología médica",
+	"ophthalmology":     "Oftalmología",
+	"otolaryngology":    "Otorrinolaringología",
+	"pediatrics":        "Pediatría",
+	"psychiatry":        "Psiquiatría",
+	"pulmonology":       "Neumología",
+	"rheumatology":      "Reumatología",
+	"traumatology":      "Cirugía ortopédica y traumatología",
+	"urology":           "Urología",
+}
+
+// ValidSpecialty reports whether the code is a known specialty
+func ValidSpecialty(code string) bool {
+	_, ok := specialties[code]
+	return ok
+}
+
+// SpecialtyName returns the display name of a specialty
+func SpecialtyName(code string) string {
+	return specialties[code]
+}
+
+// Referral sends a patient from a practitioner to another, or to any
+// practitioner of a specialty
+type Referral struct {
+	ID                 string
+	PatientID          string
+	DiagnosisID        string // Diagnosis the referral is about, if any
+	FromPractitionerID string
+	ToPractitionerID   string // Either the practitioner or the specialty is set
+	ToSpecialty        string
+	Reason             string
+	Urgency            string
+	Status             string
+	RespondedBy        string // Recipient who accepted or rejected the referral
+	RejectionReason    string
+	CompletionNote     string
+	CreatedAt          time.Time
+	RespondedAt        *time.Time
+	CompletedAt        *time.Time
+}
+
+// Normalize applies the default urgency and tidies the specialty code
+func (r *Referral) Normalize() {
+	r.ToSpecialty = strings.ToLower(strings.TrimSpace(r.ToSpecialty))
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Urgency == "" {
+		r.Urgency = ReferralUrgencyRoutine
+	}
+}
+
+// Validate ensures the referral's domain invariants are met
+func (r *Referral) Validate() error {
+	if r.ID == "" {
+		return ErrEmptyReferralID
+	}
+	if r.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if r.FromPractitionerID == "" {
+		return ErrEmptyReferringPractitioner
+	}
+	if (r.ToPractitionerID == "") == (r.ToSpecialty == "") {
+		return ErrReferralRecipientRequired
+	}
+	if r.ToSpecialty != "" && !ValidSpecialty(r.ToSpecialty) {
+		return ErrUnknownSpecialty
+	}
+	if r.ToPractitionerID == r.FromPractitionerID {
+		return ErrSelfReferral
+	}
+	if r.Reason == "" {
+		return ErrEmptyReferralReason
+	}
+	if _, ok := referralUrgencyRank[r.Urgency]; !ok {
+		return ErrInvalidReferralUrgency
+	}
+	if !ValidReferralStatus(r.Status) {
+		return ErrInvalidReferralStatus
+	}
+	return nil
+}
+
+// IsAddressedTo reports whether the practitioner, with the given specialty,
+// can answer the referral
+func (r *Referral) IsAddressedTo(userID, specialty string) bool {
+	if r.ToPractitionerID != "" {
+		return r.ToPractitionerID == userID
+	}
+	return specialty != "" && r.ToSpecialty == specialty
+}
+
+// Accept makes the practitioner responsible for the referral
+func (r *Referral) Accept(userID string, at time.Time) error {
+	if r.Status != ReferralStatusPending {
+		return ErrReferralNotPending
+	}
+	r.Status = ReferralStatusAccepted
+	r.RespondedBy = userID
+	r.RespondedAt = &at
+	return nil
+}
+
+// Reject declines the referral, the referring practitioner is told why
+func (r *Referral) Reject(userID, reason string, at time.Time) error {
+	if r.Status != ReferralStatusPending {
+		return ErrReferralNotPending
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrEmptyRejectionReason
+	}
+	r.Status = ReferralStatusRejected
+	r.RespondedBy = userID
+	r.RejectionReason = reason
+	r.RespondedAt = &at
+	return nil
+}
+
+// Complete closes an accepted referral, only the practitioner who accepted it
+// can
+func (r *Referral) Complete(userID, note string, at time.Time) error {
+	if r.Status != ReferralStatusAccepted {
+		return ErrReferralNotAccepted
+	}
+	if r.RespondedBy != userID {
+		return ErrReferralNotRecipient
+	}
+	r.Status = ReferralStatusCompleted
+	r.CompletionNote = strings.TrimSpace(note)
+	r.CompletedAt = &at
+	return nil
+}
+
+// CompareReferralUrgency orders referrals from the most urgent, the oldest
+// first within the same urgency
+func CompareReferralUrgency(a, b Referral) int {
+	if rank := referralUrgencyRank[b.Urgency] - referralUrgencyRank[a.Urgency]; rank != 0 {
+		return rank
+	}
+	return a.CreatedAt.Compare(b.CreatedAt)
+}
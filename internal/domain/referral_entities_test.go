@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReferral_Validate(t *testing.T) {
+	valid := Referral{ID: "r1", PatientID: "p1", FromPractitionerID: "u1", ToSpecialty: "cardiology", Reason: "Soplo sistólico", Urgency: ReferralUrgencyRoutine, Status: ReferralStatusPending}
+
+	tests := []struct {
+		name    string
+		modify  func(r *Referral)
+		wantErr error
+	}{
+		{"valid referral to a specialty", func(r *Referral) {}, nil},
+		{"valid referral to a practitioner", func(r *Referral) { r.ToSpecialty, r.ToPractitionerID = "", "u2" }, nil},
+		{"missing ID", func(r *Referral) { r.ID = "" }, ErrEmptyReferralID},
+		{"missing patient", func(r *Referral) { r.PatientID = "" }, ErrEmptyPatientFK},
+		{"missing referring practitioner", func(r *Referral) { r.FromPractitionerID = "" }, ErrEmptyReferringPractitioner},
+		{"no recipient", func(r *Referral) { r.ToSpecialty = "" }, ErrReferralRecipientRequired},
+		{"both recipients", func(r *Referral) { r.ToPractitionerID = "u2" }, ErrReferralRecipientRequired},
+		{"unknown specialty", func(r *Referral) { r.ToSpecialty = "astrology" }, ErrUnknownSpecialty},
+		{"to themselves", func(r *Referral) { r.ToSpecialty, r.ToPractitionerID = "", "u1" }, ErrSelfReferral},
+		{"missing reason", func(r *Referral) { r.Reason = "" }, ErrEmptyReferralReason},
+		{"unknown urgency", func(r *Referral) { r.Urgency = "whenever" }, ErrInvalidReferralUrgency},
+		{"unknown status", func(r *Referral) { r.Status = "lost" }, ErrInvalidReferralStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			referral := valid
+			tt.modify(&referral)
+			if err := referral.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReferral_Normalize(t *testing.T) {
+	referral := Referral{ToSpecialty: " Cardiology ", Reason: " Soplo "}
+	referral.Normalize()
+	if referral.ToSpecialty != "cardiology" || referral.Reason != "Soplo" || referral.Urgency != ReferralUrgencyRoutine {
+		t.Errorf("Normalize() = %+v", referral)
+	}
+}
+
+func TestReferral_IsAddressedTo(t *testing.T) {
+	toPractitioner := Referral{ToPractitionerID: "u2"}
+	toSpecialty := Referral{ToSpecialty: "cardiology"}
+
+	tests := []struct {
+		name      string
+		referral  Referral
+		userID    string
+		specialty string
+		want      bool
+	}{
+		{"the practitioner", toPractitioner, "u2", "", true},
+		{"another practitioner of any specialty", toPractitioner, "u3", "cardiology", false},
+		{"a practitioner of the specialty", toSpecialty, "u3", "cardiology", true},
+		{"a practitioner of another specialty", toSpecialty, "u3", "dermatology", false},
+		{"a practitioner without specialty", toSpecialty, "u3", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.referral.IsAddressedTo(tt.userID, tt.specialty); got != tt.want {
+				t.Errorf("IsAddressedTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferral_Transitions(t *testing.T) {
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	pending := func() *Referral {
+		return &Referral{ID: "r1", FromPractitionerID: "u1", ToSpecialty: "cardiology", Status: ReferralStatusPending}
+	}
+
+	t.Run("accept then complete", func(t *testing.T) {
+		referral := pending()
+		if err := referral.Accept("u2", at); err != nil || referral.Status != ReferralStatusAccepted || referral.RespondedBy != "u2" {
+			t.Fatalf("Accept() = %v, %+v", err, referral)
+		}
+		if err := referral.Accept("u3", at); err != ErrReferralNotPending {
+			t.Errorf("Accept() twice = %v, want %v", err, ErrReferralNotPending)
+		}
+		if err := referral.Complete("u3", "", at); err != ErrReferralNotRecipient {
+			t.Errorf("Complete() by another practitioner = %v, want %v", err, ErrReferralNotRecipient)
+		}
+		if err := referral.Complete("u2", " Alta ", at); err != nil || referral.Status != ReferralStatusCompleted || referral.CompletionNote != "Alta" {
+			t.Errorf("Complete() = %v, %+v", err, referral)
+		}
+	})
+
+	t.Run("reject needs a reason", func(t *testing.T) {
+		referral := pending()
+		if err := referral.Reject("u2", " ", at); err != ErrEmptyRejectionReason {
+			t.Errorf("Reject() without reason = %v, want %v", err, ErrEmptyRejectionReason)
+		}
+		if err := referral.Reject("u2", "Fuera de cartera", at); err != nil || referral.Status != ReferralStatusRejected {
+			t.Fatalf("Reject() = %v, %+v", err, referral)
+		}
+		if err := referral.Complete("u2", "", at); err != ErrReferralNotAccepted {
+			t.Errorf("Complete() after rejection = %v, want %v", err, ErrReferralNotAccepted)
+		}
+	})
+}
+
+func TestCompareReferralUrgency(t *testing.T) {
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	referrals := []Referral{
+		{ID: "routine-old", Urgency: ReferralUrgencyRoutine, CreatedAt: day},
+		{ID: "urgent-new", Urgency: ReferralUrgencyUrgent, CreatedAt: day.Add(time.Hour)},
+		{ID: "stat", Urgency: ReferralUrgencyStat, CreatedAt: day.Add(2 * time.Hour)},
+		{ID: "urgent-old", Urgency: ReferralUrgencyUrgent, CreatedAt: day},
+	}
+	slices.SortStableFunc(referrals, CompareReferralUrgency)
+
+	var got []string
+	for _, r := range referrals {
+		got = append(got, r.ID)
+	}
+	want := []string{"stat", "urgent-old", "urgent-new", "routine-old"}
+	if !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
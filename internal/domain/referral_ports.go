@@ -0,0 +1,39 @@
+package domain
+
+// ReferralInboxFilter narrows down the referrals of a recipient's inbox
+type ReferralInboxFilter struct {
+	Status *string
+}
+
+// Referral Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// ReferralRepository defines operations for referral persistence
+type ReferralRepository interface {
+	CreateReferral(referral *Referral) error
+	// UpdateReferral stores the answer or completion of a referral provided
+	// it still has the status fromStatus, so of two concurrent answers only
+	// one is stored. The other fails with ErrReferralNotPending, or
+	// ErrReferralNotAccepted when completing.
+	UpdateReferral(referral *Referral, fromStatus string) error
+	// AcceptReferral stores the acceptance of a pending referral and adds the
+	// member, if any, to the patient's care team in the same transaction
+	AcceptReferral(referral *Referral, member *CareTeamMember) error
+	GetReferralByID(id string) (*Referral, error)
+	GetReferralsByPatientID(patientID string) ([]Referral, error)
+	// GetReferralInbox returns the pending referrals addressed to the
+	// practitioner or to their specialty, and the ones they answered
+	GetReferralInbox(userID, specialty string, filter ReferralInboxFilter) ([]Referral, error)
+}
+
+// Referral Domain - Service Interfaces (Driving Ports - Inbound)
+
+// ReferralService defines referral operations between practitioners
+type ReferralService interface {
+	CreateReferral(caller Caller, referral *Referral) error
+	AcceptReferral(caller Caller, id string) (*Referral, error)
+	RejectReferral(caller Caller, id, reason string) (*Referral, error)
+	CompleteReferral(caller Caller, id, note string) (*Referral, error)
+	GetPatientReferrals(caller Caller, patientID string) ([]Referral, error)
+	// GetInbox returns the caller's referral inbox, the most urgent first
+	GetInbox(caller Caller, filter ReferralInboxFilter) ([]Referral, error)
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptySearchText    = errors.New("search text has no searchable terms")
+	ErrInvalidPatientSort = errors.New("invalid patient sort order")
+	ErrInvalidAgeRange    = errors.New("invalid age range")
+	ErrInvalidPageLimit   = errors.New("page limit must be between 1 and 200")
+	ErrInvalidCursor      = errors.New("invalid page cursor")
+)
+
+// FuzzyNameThreshold is the minimum similarity, between 0 and 1, a name word
+// must reach to match a searched word in fuzzy mode
+const FuzzyNameThreshold = 0.6
+
+// Listings are returned in pages of at most MaxPageLimit results
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// MaxRankedResults bounds the results of a search ordered once decrypted,
+// such as diagnoses ordered by the similarity of the patient name: every
+// candidate must be read to rank them, and only the best are paged
+const MaxRankedResults = 1000
+
+// Page selects a page of a listing: the results following Cursor, the
+// NextCursor of the previous page or empty for the first one. A zero Limit
+// means DefaultPageLimit.
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+// Size returns the number of results of a full page
+func (p Page) Size() int {
+	if p.Limit == 0 {
+		return DefaultPageLimit
+	}
+	return p.Limit
+}
+
+// Validate checks the limit is within bounds. Cursors are opaque, only the
+// repository can tell whether they are valid.
+func (p Page) Validate() error {
+	if p.Limit < 0 || p.Limit > MaxPageLimit {
+		return ErrInvalidPageLimit
+	}
+	return nil
+}
+
+// NameFilter holds the patient name criteria of a search. Matching ignores
+// case and accents. Without Fuzzy every searched word must match the start
+// of a name word; with Fuzzy close spellings (e.g. "Garsia") match as well.
+type NameFilter struct {
+	Name      *string // Any part of the full name
+	GivenName *string
+	Surname   *string // Either surname
+	Fuzzy     bool
+}
+
+// IsEmpty reports whether no name criteria were given
+func (f NameFilter) IsEmpty() bool {
+	return f.Name == nil && f.GivenName == nil && f.Surname == nil
+}
+
+// DiagnosisFilter holds the criteria of a diagnosis search. Nil fields are
+// not filtered on.
+type DiagnosisFilter struct {
+	PatientID  *string
+	Patient    NameFilter
+	DateStart  *time.Time
+	DateEnd    *time.Time
+	Text       *string // Full-text query over diagnosis and prescription
+	AgeMin     *int    // Patient age at the diagnosis date, inclusive
+	AgeMax     *int    // Patient age at the diagnosis date, inclusive
+	Sex        *string
+	Province   *string // INE province code of the patient's address
+	PostalCode *string
+	Page       Page
+}
+
+// IsEmpty reports whether no criteria were given
+func (f DiagnosisFilter) IsEmpty() bool {
+	return f.PatientID == nil && f.Patient.IsEmpty() && f.DateStart == nil && f.DateEnd == nil && f.Text == nil && !f.HasDemographics()
+}
+
+// HasDemographics reports whether the patients are filtered by age, sex or
+// where they live
+func (f DiagnosisFilter) HasDemographics() bool {
+	return f.AgeMin != nil || f.AgeMax != nil || f.Sex != nil || f.Province != nil || f.PostalCode != nil
+}
+
+// Validate checks the age range, sex code, province, postal code and page
+func (f DiagnosisFilter) Validate() error {
+	if err := f.Page.Validate(); err != nil {
+		return err
+	}
+	if (f.AgeMin != nil && *f.AgeMin < 0) || (f.AgeMax != nil && *f.AgeMax < 0) ||
+		(f.AgeMin != nil && f.AgeMax != nil && *f.AgeMin > *f.AgeMax) {
+		return ErrInvalidAgeRange
+	}
+	if f.Sex != nil && !ValidSex(*f.Sex) {
+		return ErrInvalidSex
+	}
+	if f.Province != nil {
+		if _, ok := ProvinceName(*f.Province); !ok {
+			return ErrInvalidProvince
+		}
+	}
+	if f.PostalCode != nil && !ValidPostalCode(*f.PostalCode) {
+		return ErrInvalidPostalCode
+	}
+	return nil
+}
+
+// MatchesAge reports whether a patient's age at the given moment is within
+// the age range. Patients without a birth date never match a range.
+func (f DiagnosisFilter) MatchesAge(p *Patient, at time.Time) bool {
+	if f.AgeMin == nil && f.AgeMax == nil {
+		return true
+	}
+	age, ok := p.AgeAt(at)
+	if !ok {
+		return false
+	}
+	return (f.AgeMin == nil || age >= *f.AgeMin) && (f.AgeMax == nil || age <= *f.AgeMax)
+}
+
+// Patient listing orders
+const (
+	PatientSortRelevance = ""        // Name similarity when filtering by name, creation order otherwise
+	PatientSortSurname   = "surname" // Alphabetically by first surname, second surname and given name
+)
+
+// PatientFilter holds the criteria of a patient listing
+type PatientFilter struct {
+	Name NameFilter
+	DNI  *string // Exact match, ignoring case
+	Sort string
+	Page Page
+}
+
+// Validate checks the sort order is known and the page
+func (f PatientFilter) Validate() error {
+	if err := f.Page.Validate(); err != nil {
+		return err
+	}
+	switch f.Sort {
+	case PatientSortRelevance, PatientSortSurname:
+		return nil
+	default:
+		return ErrInvalidPatientSort
+	}
+}
+
+// SearchMatch describes how a diagnosis matched a search
+type SearchMatch struct {
+	Score               float64 // Full-text relevance, higher is more relevant
+	NameScore           float64 // Patient name similarity between 0 and 1
+	DiagnosisSnippet    string  // Excerpt with the matching terms wrapped in <mark> tags
+	PrescriptionSnippet string
+}
+
+// PatientSearchResult is a patient returned by a listing with the
+// similarity of their name to the searched one, between 0 and 1
+type PatientSearchResult struct {
+	Patient Patient
+	Score   float64
+}
+
+// PatientPage is a page of a patient listing
+type PatientPage struct {
+	Patients   []PatientSearchResult
+	NextCursor string // Cursor of the following page, empty on the last one
+}
+
+// DiagnosisPage is a page of a diagnosis search
+type DiagnosisPage struct {
+	Diagnoses  []Diagnosis
+	NextCursor string // Cursor of the following page, empty on the last one
+}
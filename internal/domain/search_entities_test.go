@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiagnosisFilter_Validate(t *testing.T) {
+	ten, twenty, negative := 10, 20, -1
+	female, invalid := SexFemale, "F"
+	madrid, postalCode := "28", "28013"
+
+	tests := []struct {
+		name    string
+		filter  DiagnosisFilter
+		wantErr error
+	}{
+		{"age range", DiagnosisFilter{AgeMin: &ten, AgeMax: &twenty}, nil},
+		{"open age range", DiagnosisFilter{AgeMin: &twenty}, nil},
+		{"inverted age range", DiagnosisFilter{AgeMin: &twenty, AgeMax: &ten}, ErrInvalidAgeRange},
+		{"negative age", DiagnosisFilter{AgeMax: &negative}, ErrInvalidAgeRange},
+		{"coded sex", DiagnosisFilter{Sex: &female}, nil},
+		{"uncoded sex", DiagnosisFilter{Sex: &invalid}, ErrInvalidSex},
+		{"province", DiagnosisFilter{Province: &madrid}, nil},
+		{"unknown province", DiagnosisFilter{Province: &invalid}, ErrInvalidProvince},
+		{"postal code", DiagnosisFilter{PostalCode: &postalCode}, nil},
+		{"invalid postal code", DiagnosisFilter{PostalCode: &madrid}, ErrInvalidPostalCode},
+		{"page limit", DiagnosisFilter{Page: Page{Limit: MaxPageLimit}}, nil},
+		{"page limit too high", DiagnosisFilter{Page: Page{Limit: MaxPageLimit + 1}}, ErrInvalidPageLimit},
+		{"negative page limit", DiagnosisFilter{Page: Page{Limit: -1}}, ErrInvalidPageLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiagnosisFilter_MatchesAge(t *testing.T) {
+	ten, twenty := 10, 20
+	filter := DiagnosisFilter{AgeMin: &ten, AgeMax: &twenty}
+	birthDate := time.Date(2000, 6, 1, 0, 0, 0, 0, time.UTC)
+	patient := &Patient{BirthDate: &birthDate}
+
+	if !filter.MatchesAge(patient, time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected a 14 year old to match 10-20")
+	}
+	if filter.MatchesAge(patient, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected a 21 year old not to match 10-20")
+	}
+	if filter.MatchesAge(&Patient{}, time.Now()) {
+		t.Error("expected a patient without birth date not to match an age range")
+	}
+	if !(DiagnosisFilter{}).MatchesAge(&Patient{}, time.Now()) {
+		t.Error("expected no age range to match every patient")
+	}
+}
@@ -9,14 +9,30 @@ var (
 	ErrEmptyToken         = errors.New("token cannot be empty")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidToken       = errors.New("invalid token")
+	ErrInvalidRole        = errors.New("invalid user role")
+	ErrAdminRequired      = errors.New("operation restricted to administrators")
 )
 
+// User roles
+const (
+	RolePractitioner = "practitioner"
+	RoleAdmin        = "admin"
+	RoleIntegration  = "integration" // Third-party applications consuming the API
+)
+
+// ValidRole reports whether the role is a known user role
+func ValidRole(role string) bool {
+	return role == RolePractitioner || role == RoleAdmin || role == RoleIntegration
+}
+
 // User represents an authenticated user
 type User struct {
-	ID       string
-	Username string
-	Password string // Stored as hash
-	Token    *UserToken
+	ID        string
+	Username  string
+	Password  string // Stored as hash
+	Role      string
+	Specialty string // Code of the practitioner's specialty, empty when they have none
+	Token     *UserToken
 }
 
 // Validate ensures the user's domain invariants are met
@@ -30,6 +46,12 @@ func (p *User) Validate() error {
 	if p.Password == "" {
 		return ErrEmptyPassword
 	}
+	if p.Role != "" && !ValidRole(p.Role) {
+		return ErrInvalidRole
+	}
+	if p.Specialty != "" && !ValidSpecialty(p.Specialty) {
+		return ErrUnknownSpecialty
+	}
 	if p.Token != nil {
 		return p.Token.Validate()
 	}
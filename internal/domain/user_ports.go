@@ -5,7 +5,10 @@ package domain
 // UserRepository defines operations for user persistence
 type UserRepository interface {
 	GetByUsername(username string) (*User, error)
+	GetByID(id string) (*User, error)
 	CreateUser(user *User) error
+	UpdateUserRole(id, role string) error
+	UpdateUserSpecialty(id, specialty string) error
 }
 
 // Authentication Domain - Service Interfaces (Driving Ports - Inbound)
@@ -15,4 +18,9 @@ type UserService interface {
 	Login(username, password string) (string, error)
 	Register(username, password string) error
 	ValidateToken(token string) error
+	// CurrentRole returns the role the user holds now, whatever the token says
+	CurrentRole(userID string) (string, error)
+	SetRole(username, role string) error
+	// SetSpecialty assigns a specialty to a user, an empty one clears it
+	SetSpecialty(username, specialty string) error
 }
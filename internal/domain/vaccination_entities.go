@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyVaccinationID         = errors.New("vaccination ID cannot be empty")
+	ErrEmptyVaccineCode           = errors.New("vaccine code is required")
+	ErrInvalidDoseNumber          = errors.New("dose number must be at least 1")
+	ErrEmptyVaccineLot            = errors.New("vaccine lot is required")
+	ErrEmptyVaccinator            = errors.New("administering practitioner is required")
+	ErrEmptyVaccinationTime       = errors.New("vaccination date is required")
+	ErrFutureVaccination          = errors.New("vaccination date cannot be in the future")
+	ErrVaccinationBeforeBirth     = errors.New("vaccination date is before the patient's birth")
+	ErrDuplicateVaccinationDose   = errors.New("dose already recorded for the vaccine")
+	ErrMissingBirthDate           = errors.New("patient birth date is required to compute the vaccination schedule")
+	ErrInvalidVaccinationHorizon  = errors.New("upcoming dose horizon must be between 1 and 365 days")
+	ErrInvalidVaccinationSchedule = errors.New("invalid vaccination schedule")
+)
+
+// How far ahead upcoming doses are listed, by default and at most
+const (
+	DefaultVaccinationHorizonDays = 90
+	MaxVaccinationHorizonDays     = 365
+)
+
+// Status of a dose of the schedule not given yet
+const (
+	DoseStatusOverdue  = "overdue"
+	DoseStatusUpcoming = "upcoming"
+)
+
+// Vaccination is a dose of a vaccine given to a patient
+type Vaccination struct {
+	ID             string
+	PatientID      string
+	VaccineCode    string // Code of the vaccine in the schedule, e.g. "HEXA"
+	DoseNumber     int
+	Lot            string
+	AdministeredAt time.Time
+	AdministeredBy string // Practitioner who gave the dose
+	RecordedBy     string
+	CreatedAt      time.Time
+}
+
+// Normalize uppercases the vaccine code, so it matches the schedule however
+// it was typed
+func (v *Vaccination) Normalize() {
+	v.VaccineCode = strings.ToUpper(strings.TrimSpace(v.VaccineCode))
+	v.Lot = strings.TrimSpace(v.Lot)
+}
+
+// Validate ensures the vaccination's domain invariants are met
+func (v *Vaccination) Validate() error {
+	if v.ID == "" {
+		return ErrEmptyVaccinationID
+	}
+	if v.PatientID == "" {
+		return ErrEmptyPatientFK
+	}
+	if v.VaccineCode == "" {
+		return ErrEmptyVaccineCode
+	}
+	if v.DoseNumber < 1 {
+		return ErrInvalidDoseNumber
+	}
+	if v.Lot == "" {
+		return ErrEmptyVaccineLot
+	}
+	if v.AdministeredBy == "" {
+		return ErrEmptyVaccinator
+	}
+	if v.AdministeredAt.IsZero() {
+		return ErrEmptyVaccinationTime
+	}
+	if v.AdministeredAt.After(time.Now()) {
+		return ErrFutureVaccination
+	}
+	return nil
+}
+
+// ScheduledDose is a dose of a vaccination schedule, due at an age
+type ScheduledDose struct {
+	VaccineCode  string
+	VaccineName  string
+	DoseNumber   int
+	AgeMonths    int
+	MaxAgeMonths int // Age after which the dose is no longer given, zero when there is none
+}
+
+// VaccinationSchedule is a vaccination calendar, such as the Spanish common
+// childhood calendar
+type VaccinationSchedule struct {
+	Name  string
+	Doses []ScheduledDose
+}
+
+// Validate ensures every dose of the schedule can be tracked
+func (s *VaccinationSchedule) Validate() error {
+	seen := make(map[string]map[int]bool)
+	for _, d := range s.Doses {
+		if d.VaccineCode == "" || d.DoseNumber < 1 || d.AgeMonths < 0 {
+			return ErrInvalidVaccinationSchedule
+		}
+		if d.MaxAgeMonths != 0 && d.MaxAgeMonths <= d.AgeMonths {
+			return ErrInvalidVaccinationSchedule
+		}
+		if seen[d.VaccineCode] == nil {
+			seen[d.VaccineCode] = make(map[int]bool)
+		}
+		if seen[d.VaccineCode][d.DoseNumber] {
+			return ErrInvalidVaccinationSchedule
+		}
+		seen[d.VaccineCode][d.DoseNumber] = true
+	}
+	return nil
+}
+
+// DueDose is a dose of the schedule the patient has not received
+type DueDose struct {
+	ScheduledDose
+	DueDate time.Time
+	Status  string
+}
+
+// VaccinationForecast lists the doses of the schedule a patient missed and
+// the ones due soon
+type VaccinationForecast struct {
+	PatientID string
+	Schedule  string
+	At        time.Time
+	Overdue   []DueDose
+	Upcoming  []DueDose
+}
+
+// Forecast compares the patient's vaccination history with the schedule.
+// Doses past their due date are overdue unless the patient is too old for
+// them, doses due within the horizon are upcoming. Both lists are ordered by
+// due date.
+func (s *VaccinationSchedule) Forecast(patientID string, birthDate time.Time, history []Vaccination, at time.Time, horizonDays int) *VaccinationForecast {
+	given := make(map[string]map[int]bool)
+	for _, v := range history {
+		if given[v.VaccineCode] == nil {
+			given[v.VaccineCode] = make(map[int]bool)
+		}
+		given[v.VaccineCode][v.DoseNumber] = true
+	}
+
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	born := time.Date(birthDate.Year(), birthDate.Month(), birthDate.Day(), 0, 0, 0, 0, at.Location())
+	horizon := today.AddDate(0, 0, horizonDays)
+
+	forecast := &VaccinationForecast{PatientID: patientID, Schedule: s.Name, At: at}
+	for _, d := range s.Doses {
+		if given[d.VaccineCode][d.DoseNumber] {
+			continue
+		}
+		if d.MaxAgeMonths != 0 && !today.Before(born.AddDate(0, d.MaxAgeMonths, 0)) {
+			continue
+		}
+		due := DueDose{ScheduledDose: d, DueDate: born.AddDate(0, d.AgeMonths, 0)}
+		switch {
+		case due.DueDate.Before(today):
+			due.Status = DoseStatusOverdue
+			forecast.Overdue = append(forecast.Overdue, due)
+		case !due.DueDate.After(horizon):
+			due.Status = DoseStatusUpcoming
+			forecast.Upcoming = append(forecast.Upcoming, due)
+		}
+	}
+
+	byDueDate := func(a, b DueDose) int { return a.DueDate.Compare(b.DueDate) }
+	slices.SortStableFunc(forecast.Overdue, byDueDate)
+	slices.SortStableFunc(forecast.Upcoming, byDueDate)
+	return forecast
+}
+
+// ValidateVaccinationHorizon ensures upcoming doses are looked for within a
+// sensible number of days
+func ValidateVaccinationHorizon(days int) error {
+	if days < 1 || days > MaxVaccinationHorizonDays {
+		return ErrInvalidVaccinationHorizon
+	}
+	return nil
+}
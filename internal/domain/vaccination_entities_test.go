@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVaccination_Validate(t *testing.T) {
+	now := time.Now()
+	valid := Vaccination{ID: "v1", PatientID: "p1", VaccineCode: "HEXA", DoseNumber: 1, Lot: "A12B345",
+		AdministeredAt: now.Add(-time.Hour), AdministeredBy: "nurse"}
+
+	tests := []struct {
+		name    string
+		modify  func(v *Vaccination)
+		wantErr error
+	}{
+		{"valid vaccination", func(v *Vaccination) {}, nil},
+		{"missing ID", func(v *Vaccination) { v.ID = "" }, ErrEmptyVaccinationID},
+		{"missing patient", func(v *Vaccination) { v.PatientID = "" }, ErrEmptyPatientFK},
+		{"missing vaccine code", func(v *Vaccination) { v.VaccineCode = "" }, ErrEmptyVaccineCode},
+		{"dose zero", func(v *Vaccination) { v.DoseNumber = 0 }, ErrInvalidDoseNumber},
+		{"missing lot", func(v *Vaccination) { v.Lot = "" }, ErrEmptyVaccineLot},
+		{"missing practitioner", func(v *Vaccination) { v.AdministeredBy = "" }, ErrEmptyVaccinator},
+		{"missing date", func(v *Vaccination) { v.AdministeredAt = time.Time{} }, ErrEmptyVaccinationTime},
+		{"given in the future", func(v *Vaccination) { v.AdministeredAt = now.Add(time.Hour) }, ErrFutureVaccination},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vaccination := valid
+			tt.modify(&vaccination)
+			if err := vaccination.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVaccinationSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		doses   []ScheduledDose
+		wantErr error
+	}{
+		{"valid schedule", []ScheduledDose{{VaccineCode: "TV", DoseNumber: 1, AgeMonths: 12}, {VaccineCode: "TV", DoseNumber: 2, AgeMonths: 36}}, nil},
+		{"repeated dose", []ScheduledDose{{VaccineCode: "TV", DoseNumber: 1, AgeMonths: 12}, {VaccineCode: "TV", DoseNumber: 1, AgeMonths: 36}}, ErrInvalidVaccinationSchedule},
+		{"missing vaccine code", []ScheduledDose{{DoseNumber: 1, AgeMonths: 12}}, ErrInvalidVaccinationSchedule},
+		{"maximum age before due age", []ScheduledDose{{VaccineCode: "RV", DoseNumber: 1, AgeMonths: 4, MaxAgeMonths: 2}}, ErrInvalidVaccinationSchedule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := VaccinationSchedule{Name: "Test", Doses: tt.doses}
+			if err := schedule.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVaccinationSchedule_Forecast(t *testing.T) {
+	schedule := VaccinationSchedule{Name: "Test", Doses: []ScheduledDose{
+		{VaccineCode: "HEXA", DoseNumber: 1, AgeMonths: 2},
+		{VaccineCode: "RV", DoseNumber: 1, AgeMonths: 2, MaxAgeMonths: 4},
+		{VaccineCode: "MENC", DoseNumber: 1, AgeMonths: 4},
+		{VaccineCode: "HEXA", DoseNumber: 2, AgeMonths: 4},
+		{VaccineCode: "TV", DoseNumber: 1, AgeMonths: 12},
+	}}
+	birth := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC) // Almost five months old
+	history := []Vaccination{{VaccineCode: "HEXA", DoseNumber: 1}}
+
+	forecast := schedule.Forecast("p1", birth, history, at, 365)
+
+	t.Run("Overdue doses not given yet, by due date", func(t *testing.T) {
+		if len(forecast.Overdue) != 2 {
+			t.Fatalf("Overdue = %+v, want MENC 1 and HEXA 2", forecast.Overdue)
+		}
+		for _, d := range forecast.Overdue {
+			if d.Status != DoseStatusOverdue || !d.DueDate.Equal(time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("unexpected overdue dose %+v", d)
+			}
+		}
+	})
+
+	t.Run("Skips doses the patient is too old for", func(t *testing.T) {
+		for _, d := range append(forecast.Overdue, forecast.Upcoming...) {
+			if d.VaccineCode == "RV" {
+				t.Errorf("expected the rotavirus dose to be skipped, got %+v", d)
+			}
+		}
+	})
+
+	t.Run("Upcoming doses within the horizon", func(t *testing.T) {
+		if len(forecast.Upcoming) != 1 || forecast.Upcoming[0].VaccineCode != "TV" || forecast.Upcoming[0].Status != DoseStatusUpcoming {
+			t.Errorf("Upcoming = %+v, want TV 1", forecast.Upcoming)
+		}
+		if soon := schedule.Forecast("p1", birth, history, at, 30); len(soon.Upcoming) != 0 {
+			t.Errorf("expected nothing due in 30 days, got %+v", soon.Upcoming)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Vaccination Domain - Repository Interfaces (Driven Ports - Outbound)
+
+// VaccinationRepository defines operations for vaccination persistence
+type VaccinationRepository interface {
+	CreateVaccination(vaccination *Vaccination) error
+	// GetVaccinationsByPatientID returns the vaccinations of a patient in the
+	// order they were given
+	GetVaccinationsByPatientID(patientID string) ([]Vaccination, error)
+}
+
+// VaccinationScheduleSource provides the vaccination calendar in force
+type VaccinationScheduleSource interface {
+	GetVaccinationSchedule() (*VaccinationSchedule, error)
+}
+
+// Vaccination Domain - Service Interfaces (Driving Ports - Inbound)
+
+// VaccinationService defines vaccination record and schedule operations
+type VaccinationService interface {
+	RecordVaccination(caller Caller, vaccination *Vaccination) error
+	GetVaccinations(caller Caller, patientID string) ([]Vaccination, error)
+	// GetVaccinationForecast returns the overdue doses of the patient and the
+	// ones due within the horizon, as of the given time
+	GetVaccinationForecast(caller Caller, patientID string, at time.Time, horizonDays int) (*VaccinationForecast, error)
+}
@@ -12,9 +12,12 @@ import (
 )
 
 type Config struct {
-	Logs     LogsConfig     `mapstructure:"logs" validate:"required"`
-	Database DatabaseConfig `mapstructure:"database" validate:"required"`
-	Api      ApiConfig      `mapstructure:"api" validate:"required"`
+	Logs        LogsConfig        `mapstructure:"logs" validate:"required"`
+	Database    DatabaseConfig    `mapstructure:"database" validate:"required"`
+	Api         ApiConfig         `mapstructure:"api" validate:"required"`
+	Encryption  EncryptionConfig  `mapstructure:"encryption" validate:"required"`
+	Storage     StorageConfig     `mapstructure:"storage" validate:"required"`
+	Vaccination VaccinationConfig `mapstructure:"vaccination" validate:"required"`
 }
 
 type LogsConfig struct {
@@ -35,6 +38,26 @@ type ApiConfig struct {
 	JWTSecret string `mapstructure:"jwt_secret" validate:"required,min=10,max=100"`
 }
 
+// EncryptionConfig holds the master key protecting the data keys of encrypted
+// columns. The key file takes precedence when both are set. The key never goes
+// in a config file: it comes from ENCRYPTION_MASTER_KEY or the key file.
+type EncryptionConfig struct {
+	MasterKey     string `mapstructure:"master_key" validate:"required_without=MasterKeyFile"`
+	MasterKeyFile string `mapstructure:"master_key_file" validate:"required_without=MasterKey"`
+}
+
+// StorageConfig sets where the content of uploaded files and the files of bulk
+// exports are kept
+type StorageConfig struct {
+	Root string `mapstructure:"root" validate:"required"`
+}
+
+// VaccinationConfig sets the file with the vaccination schedule doses are
+// tracked against
+type VaccinationConfig struct {
+	Schedule string `mapstructure:"schedule" validate:"required"`
+}
+
 const defaultTestConfigPath = "configs/config.test.yml"
 
 func LoadConfig() (*Config, error) {
@@ -63,6 +86,10 @@ func LoadConfig() (*Config, error) {
 	// Environment variables
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv() // This will look for environment variables like LOGS_LEVEL, DATABASE_USER, etc.
+	// AutomaticEnv only fills keys present in the file, and the master key is
+	// kept out of config files
+	v.BindEnv("encryption.master_key")
+	v.BindEnv("encryption.master_key_file")
 
 	// Load from file if exists
 	var fileConfigExist bool
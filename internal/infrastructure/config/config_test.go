@@ -1,11 +1,24 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"os"
+	"strings"
 	"testing"
 )
 
+// setThrowawayMasterKey provides the master key through the environment, as
+// config files never hold one
+func setThrowawayMasterKey(t *testing.T) {
+	t.Helper()
+	key := make([]byte, 32)
+	rand.Read(key)
+	t.Setenv("ENCRYPTION_MASTER_KEY", base64.StdEncoding.EncodeToString(key))
+}
+
 func TestLoadConfig_FromFile(t *testing.T) {
+	setThrowawayMasterKey(t)
 	// Set test environment
 	os.Setenv("APP_ENV", "test")
 	defer os.Unsetenv("APP_ENV")
@@ -22,6 +35,7 @@ func TestLoadConfig_FromFile(t *testing.T) {
 }
 
 func TestLoadConfig_FromEnv(t *testing.T) {
+	setThrowawayMasterKey(t)
 
 	// Set environment variable to override config file
 	expectedPort := "9000"
@@ -37,3 +51,14 @@ func TestLoadConfig_FromEnv(t *testing.T) {
 		t.Errorf("Expected Api.Port to be overridden to %s, got %s", expectedPort, cfg.Api.Port)
 	}
 }
+
+func TestLoadConfig_RequiresMasterKey(t *testing.T) {
+	// The committed config files hold no master key
+	t.Setenv("ENCRYPTION_MASTER_KEY", "")
+	os.Unsetenv("ENCRYPTION_MASTER_KEY")
+
+	_, err := LoadConfig()
+	if err == nil || !strings.Contains(err.Error(), "MasterKey") {
+		t.Errorf("LoadConfig() expected a missing master key error, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package fhir
+
+// Asynchronous export of the FHIR Bulk Data Access specification
+// (https://hl7.org/fhir/uv/bulkdata/STU2/export.html)
+
+const (
+	// NDJSONContentType is the media type of the exported files, a resource per line
+	NDJSONContentType = "application/fhir+ndjson"
+
+	// PreferAsync is the Prefer header an export kick-off requires
+	PreferAsync = "respond-async"
+)
+
+// ExportManifest lists the files of a completed export
+type ExportManifest struct {
+	TransactionTime     string         `json:"transactionTime" example:"2026-02-13T02:00:00Z"`
+	Request             string         `json:"request" example:"https://api.example.com/fhir/r4/$export?_since=2026-02-12T02:00:00Z"`
+	RequiresAccessToken bool           `json:"requiresAccessToken" example:"true"`
+	Output              []ExportOutput `json:"output"`
+	Error               []ExportOutput `json:"error"`
+}
+
+// ExportOutput is an exported file and the number of resources it holds
+type ExportOutput struct {
+	Type  string `json:"type" example:"Patient"`
+	URL   string `json:"url" example:"https://api.example.com/fhir/r4/bulk-files/01HMGNBPJNX0G2BZXJ7XW1RHPR/Patient.ndjson"`
+	Count int    `json:"count,omitempty" example:"1250"`
+}
+
+// SupportsOutputFormat reports whether an export can be written in the
+// _outputFormat requested, NDJSON being the only one. Empty means the default.
+func SupportsOutputFormat(format string) bool {
+	switch format {
+	case "", NDJSONContentType, "application/ndjson", "ndjson":
+		return true
+	}
+	return false
+}
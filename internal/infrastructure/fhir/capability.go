@@ -0,0 +1,61 @@
+package fhir
+
+import "time"
+
+// NewCapabilityStatement describes the resources served under baseURL, their
+// interactions and search parameters
+func NewCapabilityStatement(baseURL string, now time.Time) CapabilityStatement {
+	readSearch := []CapabilityInteraction{{Code: "read"}, {Code: "search-type"}}
+	readSearchCreate := append(readSearch, CapabilityInteraction{Code: "create"})
+
+	return CapabilityStatement{
+		ResourceType: "CapabilityStatement",
+		Status:       "active",
+		Date:         now.UTC().Format(dateLayout),
+		Kind:         "instance",
+		Software:     CapabilitySoftware{Name: "TopDoctors"},
+		Implementation: &CapabilityImplementation{
+			Description: "TopDoctors FHIR R4 facade",
+			URL:         baseURL,
+		},
+		FHIRVersion: Version,
+		Format:      []string{ContentType, "json"},
+		Rest: []CapabilityRest{{
+			Mode: "server",
+			Resource: []CapabilityResource{
+				{
+					Type:        ResourcePatient,
+					Interaction: readSearchCreate,
+					SearchParam: []CapabilitySearchParam{
+						{Name: "name", Type: "string"},
+						{Name: "identifier", Type: "token"},
+						{Name: "_count", Type: "number"},
+					},
+				},
+				{
+					Type:        ResourceCondition,
+					Interaction: readSearchCreate,
+					SearchParam: []CapabilitySearchParam{
+						{Name: "subject", Type: "reference"},
+						{Name: "patient", Type: "reference"},
+						{Name: "recorded-date", Type: "date"},
+						{Name: "_count", Type: "number"},
+					},
+				},
+				{
+					Type:        ResourceMedicationRequest,
+					Interaction: readSearch,
+					SearchParam: []CapabilitySearchParam{
+						{Name: "subject", Type: "reference"},
+						{Name: "patient", Type: "reference"},
+						{Name: "authoredon", Type: "date"},
+						{Name: "_count", Type: "number"},
+					},
+				},
+			},
+			Operation: []CapabilityOperation{
+				{Name: "export", Definition: "http://hl7.org/fhir/uv/bulkdata/OperationDefinition/export"},
+			},
+		}},
+	}
+}
@@ -0,0 +1,276 @@
+package fhir
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+var (
+	ErrUnexpectedResourceType = errors.New("unexpected resourceType")
+	ErrInvalidReference       = errors.New("invalid reference")
+	ErrInvalidDate            = errors.New("invalid date, use YYYY-MM-DD or a full date-time")
+	ErrEmptyConditionCode     = errors.New("condition code requires a text")
+	ErrPrescriptionNotFound   = errors.New("prescription not found")
+)
+
+const (
+	ResourcePatient   = "Patient"
+	ResourceCondition = "Condition"
+	ResourceEncounter = "Encounter"
+
+	ResourceMedicationRequest = "MedicationRequest"
+
+	dateLayout = "2006-01-02"
+)
+
+// NewReference returns the relative reference to a resource, e.g. "Patient/123"
+func NewReference(resourceType, id string) string {
+	return resourceType + "/" + id
+}
+
+// ParseReference returns the ID of the resource a reference points to. Both
+// relative ("Patient/123") and absolute references are accepted.
+func ParseReference(reference, resourceType string) (string, error) {
+	parts := strings.Split(strings.TrimSuffix(reference, "/"), "/")
+	if len(parts) < 2 || parts[len(parts)-2] != resourceType || parts[len(parts)-1] == "" {
+		return "", ErrInvalidReference
+	}
+	return parts[len(parts)-1], nil
+}
+
+// Mappers: Domain -> FHIR
+
+// FromPatient maps a patient to a Patient resource. Merged records are
+// inactive and link to the surviving one.
+func FromPatient(p domain.Patient) Patient {
+	resource := Patient{
+		ResourceType: ResourcePatient,
+		ID:           p.ID,
+		Gender:       p.Sex,
+	}
+	active := !p.IsMerged() && !p.IsErased()
+	resource.Active = &active
+	if p.IsMerged() {
+		resource.Link = []PatientLink{{Other: Reference{Reference: NewReference(ResourcePatient, p.MergedInto)}, Type: "replaced-by"}}
+	}
+
+	if p.DNI != "" && !p.IsErased() {
+		resource.Identifier = []Identifier{{Use: "official", System: DNISystem, Value: p.DNI}}
+	}
+	name := HumanName{
+		Use:    "official",
+		Text:   p.DisplayName(),
+		Family: strings.TrimSpace(p.FirstSurname + " " + p.SecondSurname),
+	}
+	if p.GivenName != "" {
+		name.Given = []string{p.GivenName}
+	}
+	resource.Name = []HumanName{name}
+
+	if p.Phone != "" {
+		resource.Telecom = append(resource.Telecom, ContactPoint{System: "phone", Value: p.Phone, Use: "mobile"})
+	}
+	if p.Email != "" {
+		resource.Telecom = append(resource.Telecom, ContactPoint{System: "email", Value: p.Email, Use: "home"})
+	}
+	if p.BirthDate != nil {
+		resource.BirthDate = p.BirthDate.Format(dateLayout)
+	}
+	if !p.Address.IsEmpty() {
+		resource.Address = []Address{fromAddress(p.Address)}
+	}
+	if p.PreferredLanguage != "" {
+		resource.Communication = []Communication{{
+			Language:  CodeableConcept{Coding: []Coding{{System: languageSystem, Code: p.PreferredLanguage}}},
+			Preferred: true,
+		}}
+	}
+	return resource
+}
+
+func fromAddress(a domain.Address) Address {
+	address := Address{
+		Text:       a.String(),
+		City:       a.City,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+	if street := strings.TrimSpace(a.Street + " " + a.Number); street != "" {
+		address.Line = append(address.Line, street)
+	}
+	if a.Floor != "" {
+		address.Line = append(address.Line, a.Floor)
+	}
+	address.State, _ = domain.ProvinceName(a.Province)
+	return address
+}
+
+// FromDiagnosis maps a diagnosis to a Condition resource
+func FromDiagnosis(d domain.Diagnosis) Condition {
+	resource := Condition{
+		ResourceType: ResourceCondition,
+		ID:           d.ID,
+		Category: []CodeableConcept{{Coding: []Coding{{
+			System:  conditionCategorySystem,
+			Code:    "encounter-diagnosis",
+			Display: "Encounter Diagnosis",
+		}}}},
+		Code:         &CodeableConcept{Text: d.Diagnosis},
+		Subject:      Reference{Reference: NewReference(ResourcePatient, d.PatientID)},
+		RecordedDate: d.Date.UTC().Format(time.RFC3339),
+	}
+	if d.Patient.ID != "" && !d.Patient.IsErased() {
+		resource.Subject.Display = d.Patient.DisplayName()
+	}
+	if d.EncounterID != "" {
+		resource.Encounter = &Reference{Reference: NewReference(ResourceEncounter, d.EncounterID)}
+	}
+	return resource
+}
+
+// FromPrescription maps the prescription of a diagnosis to a MedicationRequest
+// sharing the diagnosis ID. Diagnoses without a prescription have none.
+func FromPrescription(d domain.Diagnosis) (MedicationRequest, error) {
+	if d.Prescription == "" {
+		return MedicationRequest{}, ErrPrescriptionNotFound
+	}
+	condition := FromDiagnosis(d)
+	return MedicationRequest{
+		ResourceType:              ResourceMedicationRequest,
+		ID:                        d.ID,
+		Status:                    "active",
+		Intent:                    "order",
+		MedicationCodeableConcept: &CodeableConcept{Text: d.Prescription},
+		Subject:                   condition.Subject,
+		Encounter:                 condition.Encounter,
+		AuthoredOn:                condition.RecordedDate,
+		ReasonReference:           []Reference{{Reference: NewReference(ResourceCondition, d.ID), Display: d.Diagnosis}},
+	}, nil
+}
+
+// Mappers: FHIR -> Domain
+
+// ToPatient maps a Patient resource to a new patient. Only the DNI identifier
+// is kept, and the first official name (or the first one).
+func ToPatient(resource Patient) (domain.Patient, error) {
+	if resource.ResourceType != ResourcePatient {
+		return domain.Patient{}, ErrUnexpectedResourceType
+	}
+	patient := domain.Patient{Sex: resource.Gender}
+
+	for _, identifier := range resource.Identifier {
+		if identifier.System == DNISystem {
+			patient.DNI = identifier.Value
+			break
+		}
+	}
+	if name, ok := officialName(resource.Name); ok {
+		patient.GivenName = strings.Join(name.Given, " ")
+		patient.FirstSurname, patient.SecondSurname = splitFamily(name.Family)
+		if patient.GivenName == "" && name.Family == "" {
+			patient.SetName(name.Text)
+		}
+	}
+	for _, contact := range resource.Telecom {
+		switch {
+		case contact.System == "phone" && patient.Phone == "":
+			patient.Phone = contact.Value
+		case contact.System == "email" && patient.Email == "":
+			patient.Email = contact.Value
+		}
+	}
+	if resource.BirthDate != "" {
+		birthDate, err := time.Parse(dateLayout, resource.BirthDate)
+		if err != nil {
+			return domain.Patient{}, ErrInvalidDate
+		}
+		patient.BirthDate = &birthDate
+	}
+	if len(resource.Address) > 0 {
+		a := resource.Address[0]
+		patient.Address = domain.Address{City: a.City, PostalCode: a.PostalCode, Country: a.Country}
+		if len(a.Line) > 0 {
+			patient.Address.Street = a.Line[0]
+			patient.Address.Floor = strings.Join(a.Line[1:], " ")
+		} else {
+			patient.Address.Street = a.Text
+		}
+	}
+	for _, communication := range resource.Communication {
+		if len(communication.Language.Coding) > 0 && (communication.Preferred || patient.PreferredLanguage == "") {
+			patient.PreferredLanguage = communication.Language.Coding[0].Code
+		}
+	}
+	return patient, nil
+}
+
+func officialName(names []HumanName) (HumanName, bool) {
+	for _, name := range names {
+		if name.Use == "official" {
+			return name, true
+		}
+	}
+	if len(names) == 0 {
+		return HumanName{}, false
+	}
+	return names[0], true
+}
+
+// splitFamily splits the surnames held in a family name. Read as a full name
+// by domain.SplitName, the given name part holds the first surname when there
+// are more than two.
+func splitFamily(family string) (first, second string) {
+	given, first, second := domain.SplitName(family)
+	if second == "" {
+		return given, first
+	}
+	return given + " " + first, second
+}
+
+// ToDiagnosis maps a Condition resource to a new diagnosis. The recorded
+// date defaults to now.
+func ToDiagnosis(resource Condition) (domain.Diagnosis, error) {
+	if resource.ResourceType != ResourceCondition {
+		return domain.Diagnosis{}, ErrUnexpectedResourceType
+	}
+	patientID, err := ParseReference(resource.Subject.Reference, ResourcePatient)
+	if err != nil {
+		return domain.Diagnosis{}, err
+	}
+	diagnosis := domain.Diagnosis{PatientID: patientID, Date: time.Now()}
+
+	if resource.Code != nil {
+		diagnosis.Diagnosis = resource.Code.Text
+		if diagnosis.Diagnosis == "" && len(resource.Code.Coding) > 0 {
+			diagnosis.Diagnosis = resource.Code.Coding[0].Display
+		}
+	}
+	if strings.TrimSpace(diagnosis.Diagnosis) == "" {
+		return domain.Diagnosis{}, ErrEmptyConditionCode
+	}
+	if resource.RecordedDate != "" {
+		if diagnosis.Date, err = ParseDateTime(resource.RecordedDate); err != nil {
+			return domain.Diagnosis{}, err
+		}
+	}
+	if resource.Encounter != nil {
+		if diagnosis.EncounterID, err = ParseReference(resource.Encounter.Reference, ResourceEncounter); err != nil {
+			return domain.Diagnosis{}, err
+		}
+	}
+	return diagnosis, nil
+}
+
+// ParseDateTime reads a FHIR date or dateTime. Dates are taken at midnight UTC.
+func ParseDateTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	return t, nil
+}
@@ -0,0 +1,109 @@
+package fhir
+
+import (
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestPatient_RoundTrip(t *testing.T) {
+	birthDate := time.Date(1980, 5, 17, 0, 0, 0, 0, time.UTC)
+	patient := domain.Patient{ID: "p1", GivenName: "María José", FirstSurname: "García", SecondSurname: "de la Fuente",
+		DNI: "12345678Z", BirthDate: &birthDate, Sex: domain.SexFemale, PreferredLanguage: "es", Email: "maria@example.com",
+		Phone: "+34600123456", Address: domain.Address{Street: "Calle Mayor", Number: "1", Floor: "3º B", PostalCode: "28013", City: "Madrid", Province: "28", Country: "ES"}}
+
+	resource := FromPatient(patient)
+	if resource.ResourceType != ResourcePatient || *resource.Active != true || len(resource.Identifier) != 1 || resource.Identifier[0].System != DNISystem {
+		t.Fatalf("FromPatient() = %+v", resource)
+	}
+	if resource.Name[0].Family != "García de la Fuente" || resource.Address[0].State != "Madrid" || resource.BirthDate != "1980-05-17" {
+		t.Errorf("FromPatient() = %+v", resource)
+	}
+
+	got, err := ToPatient(resource)
+	if err != nil {
+		t.Fatalf("ToPatient() error = %v", err)
+	}
+	if got.GivenName != patient.GivenName || got.FirstSurname != "García" || got.SecondSurname != "de la Fuente" ||
+		got.DNI != patient.DNI || got.Phone != patient.Phone || got.Email != patient.Email || !got.BirthDate.Equal(birthDate) ||
+		got.Sex != patient.Sex || got.PreferredLanguage != "es" || got.Address.Street != "Calle Mayor 1" || got.Address.Floor != "3º B" {
+		t.Errorf("ToPatient() = %+v", got)
+	}
+}
+
+func TestFromPatient_Merged(t *testing.T) {
+	resource := FromPatient(domain.Patient{ID: "p2", GivenName: "Maria", FirstSurname: "Garcia", MergedInto: "p1"})
+	if *resource.Active || len(resource.Link) != 1 || resource.Link[0].Other.Reference != "Patient/p1" || resource.Link[0].Type != "replaced-by" {
+		t.Errorf("FromPatient() = %+v", resource)
+	}
+}
+
+func TestSplitFamily(t *testing.T) {
+	tests := []struct {
+		family, first, second string
+	}{
+		{"García", "García", ""},
+		{"García López", "García", "López"},
+		{"de la Fuente Ruiz", "de la Fuente", "Ruiz"},
+		{"Pérez García López", "Pérez García", "López"},
+	}
+	for _, tt := range tests {
+		if first, second := splitFamily(tt.family); first != tt.first || second != tt.second {
+			t.Errorf("splitFamily(%q) = %q, %q, want %q, %q", tt.family, first, second, tt.first, tt.second)
+		}
+	}
+}
+
+func TestToDiagnosis(t *testing.T) {
+	valid := Condition{ResourceType: ResourceCondition, Code: &CodeableConcept{Text: "Faringitis"},
+		Subject: Reference{Reference: "Patient/p1"}, Encounter: &Reference{Reference: "Encounter/e1"}, RecordedDate: "2026-03-02T09:30:00Z"}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Condition)
+		wantErr error
+	}{
+		{"valid condition", func(c *Condition) {}, nil},
+		{"absolute subject", func(c *Condition) { c.Subject.Reference = "https://example.com/fhir/r4/Patient/p1" }, nil},
+		{"code from its coding", func(c *Condition) { c.Code = &CodeableConcept{Coding: []Coding{{Display: "Faringitis"}}} }, nil},
+		{"another resource", func(c *Condition) { c.ResourceType = ResourcePatient }, ErrUnexpectedResourceType},
+		{"subject of another type", func(c *Condition) { c.Subject.Reference = "Group/g1" }, ErrInvalidReference},
+		{"missing code", func(c *Condition) { c.Code = nil }, ErrEmptyConditionCode},
+		{"invalid date", func(c *Condition) { c.RecordedDate = "02/03/2026" }, ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := valid
+			tt.modify(&condition)
+			diagnosis, err := ToDiagnosis(condition)
+			if err != tt.wantErr {
+				t.Fatalf("ToDiagnosis() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (diagnosis.PatientID != "p1" || diagnosis.Diagnosis != "Faringitis" || diagnosis.EncounterID != "e1") {
+				t.Errorf("ToDiagnosis() = %+v", diagnosis)
+			}
+		})
+	}
+}
+
+func TestFromPrescription(t *testing.T) {
+	diagnosis := domain.Diagnosis{ID: "d1", PatientID: "p1", Diagnosis: "Faringitis", Prescription: "Amoxicilina 500 mg cada 8 horas",
+		Date: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC), EncounterID: "e1"}
+
+	resource, err := FromPrescription(diagnosis)
+	if err != nil {
+		t.Fatalf("FromPrescription() error = %v", err)
+	}
+	if resource.ID != "d1" || resource.MedicationCodeableConcept.Text != diagnosis.Prescription || resource.Subject.Reference != "Patient/p1" ||
+		resource.Encounter.Reference != "Encounter/e1" || resource.AuthoredOn != "2026-03-02T09:30:00Z" {
+		t.Errorf("FromPrescription() = %+v", resource)
+	}
+	if len(resource.ReasonReference) != 1 || resource.ReasonReference[0].Reference != "Condition/d1" {
+		t.Errorf("FromPrescription() reasonReference = %+v", resource.ReasonReference)
+	}
+
+	diagnosis.Prescription = ""
+	if _, err := FromPrescription(diagnosis); err != ErrPrescriptionNotFound {
+		t.Errorf("FromPrescription() error = %v, want %v", err, ErrPrescriptionNotFound)
+	}
+}
@@ -0,0 +1,205 @@
+package fhir
+
+import "encoding/json"
+
+// Resources and data types of HL7 FHIR R4 (https://hl7.org/fhir/R4), limited
+// to the elements this system holds. Every resource is serialized with its
+// resourceType, as FHIR JSON requires.
+
+const (
+	// Version is the FHIR release the resources conform to
+	Version = "4.0.1"
+
+	// ContentType is the media type of FHIR JSON
+	ContentType = "application/fhir+json"
+
+	// DNISystem identifies Spanish national identity documents (DNI and NIE)
+	DNISystem = "urn:oid:1.3.6.1.4.1.19126.3"
+
+	conditionCategorySystem = "http://terminology.hl7.org/CodeSystem/condition-category"
+	languageSystem          = "urn:ietf:bcp:47"
+)
+
+// Data types
+
+type Identifier struct {
+	Use    string `json:"use,omitempty" example:"official"`
+	System string `json:"system,omitempty" example:"urn:oid:1.3.6.1.4.1.19126.3"`
+	Value  string `json:"value,omitempty" example:"12345678Z"`
+}
+
+// HumanName holds both Spanish surnames in family, first surname first
+type HumanName struct {
+	Use    string   `json:"use,omitempty" example:"official"`
+	Text   string   `json:"text,omitempty" example:"María García López"`
+	Family string   `json:"family,omitempty" example:"García López"`
+	Given  []string `json:"given,omitempty"`
+}
+
+type ContactPoint struct {
+	System string `json:"system,omitempty" example:"phone" enums:"phone,email"`
+	Value  string `json:"value,omitempty" example:"+34600123456"`
+	Use    string `json:"use,omitempty" example:"home"`
+}
+
+type Address struct {
+	Text       string   `json:"text,omitempty" example:"Calle Mayor 1, 3º B, 28013 Madrid"`
+	Line       []string `json:"line,omitempty"`
+	City       string   `json:"city,omitempty" example:"Madrid"`
+	State      string   `json:"state,omitempty" example:"Madrid"` // Province name
+	PostalCode string   `json:"postalCode,omitempty" example:"28013"`
+	Country    string   `json:"country,omitempty" example:"ES"`
+}
+
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+type Reference struct {
+	Reference string `json:"reference,omitempty" example:"Patient/01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Display   string `json:"display,omitempty"`
+}
+
+type Communication struct {
+	Language  CodeableConcept `json:"language"`
+	Preferred bool            `json:"preferred,omitempty"`
+}
+
+type PatientLink struct {
+	Other Reference `json:"other"`
+	Type  string    `json:"type" example:"replaced-by"`
+}
+
+// Resources
+
+type Patient struct {
+	ResourceType  string          `json:"resourceType" example:"Patient"`
+	ID            string          `json:"id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Identifier    []Identifier    `json:"identifier,omitempty"`
+	Active        *bool           `json:"active,omitempty"`
+	Name          []HumanName     `json:"name,omitempty"`
+	Telecom       []ContactPoint  `json:"telecom,omitempty"`
+	Gender        string          `json:"gender,omitempty" example:"female" enums:"male,female,other,unknown"`
+	BirthDate     string          `json:"birthDate,omitempty" example:"1980-05-17"`
+	Address       []Address       `json:"address,omitempty"`
+	Communication []Communication `json:"communication,omitempty"`
+	Link          []PatientLink   `json:"link,omitempty"` // Set on records merged into another
+}
+
+type Condition struct {
+	ResourceType string            `json:"resourceType" example:"Condition"`
+	ID           string            `json:"id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Category     []CodeableConcept `json:"category,omitempty"`
+	Code         *CodeableConcept  `json:"code,omitempty"`
+	Subject      Reference         `json:"subject"`
+	Encounter    *Reference        `json:"encounter,omitempty"`
+	RecordedDate string            `json:"recordedDate,omitempty" example:"2026-02-13T18:23:00Z"`
+}
+
+// MedicationRequest is the prescription issued along with a diagnosis. The
+// free-text prescription is the medication text, and the Condition of the
+// diagnosis is the reason.
+type MedicationRequest struct {
+	ResourceType              string           `json:"resourceType" example:"MedicationRequest"`
+	ID                        string           `json:"id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Status                    string           `json:"status" example:"active"`
+	Intent                    string           `json:"intent" example:"order"`
+	MedicationCodeableConcept *CodeableConcept `json:"medicationCodeableConcept,omitempty"`
+	Subject                   Reference        `json:"subject"`
+	Encounter                 *Reference       `json:"encounter,omitempty"`
+	AuthoredOn                string           `json:"authoredOn,omitempty" example:"2026-02-13T18:23:00Z"`
+	ReasonReference           []Reference      `json:"reasonReference,omitempty"`
+}
+
+// Bundle is a searchset of the resources matching a search. Total is only
+// given when the bundle holds every match.
+type Bundle struct {
+	ResourceType string        `json:"resourceType" example:"Bundle"`
+	Type         string        `json:"type" example:"searchset"`
+	Total        *int          `json:"total,omitempty" example:"1"`
+	Link         []BundleLink  `json:"link,omitempty"`
+	Entry        []BundleEntry `json:"entry,omitempty"`
+}
+
+type BundleLink struct {
+	Relation string `json:"relation" example:"self"`
+	URL      string `json:"url"`
+}
+
+type BundleEntry struct {
+	FullURL  string          `json:"fullUrl"`
+	Resource json.RawMessage `json:"resource" swaggertype:"object"`
+	Search   *BundleSearch   `json:"search,omitempty"`
+}
+
+type BundleSearch struct {
+	Mode  string   `json:"mode" example:"match"`
+	Score *float64 `json:"score,omitempty"`
+}
+
+// OperationOutcome reports why an interaction failed
+type OperationOutcome struct {
+	ResourceType string  `json:"resourceType" example:"OperationOutcome"`
+	Issue        []Issue `json:"issue"`
+}
+
+type Issue struct {
+	Severity    string `json:"severity" example:"error"`
+	Code        string `json:"code" example:"not-found"`
+	Diagnostics string `json:"diagnostics,omitempty" example:"patient not found"`
+}
+
+// CapabilityStatement describes the interactions the server supports
+type CapabilityStatement struct {
+	ResourceType   string                    `json:"resourceType" example:"CapabilityStatement"`
+	Status         string                    `json:"status" example:"active"`
+	Date           string                    `json:"date" example:"2026-02-13"`
+	Kind           string                    `json:"kind" example:"instance"`
+	Software       CapabilitySoftware        `json:"software"`
+	FHIRVersion    string                    `json:"fhirVersion" example:"4.0.1"`
+	Format         []string                  `json:"format"`
+	Rest           []CapabilityRest          `json:"rest"`
+	Implementation *CapabilityImplementation `json:"implementation,omitempty"`
+}
+
+type CapabilitySoftware struct {
+	Name string `json:"name" example:"TopDoctors"`
+}
+
+type CapabilityImplementation struct {
+	Description string `json:"description"`
+	URL         string `json:"url,omitempty"`
+}
+
+type CapabilityRest struct {
+	Mode      string                `json:"mode" example:"server"`
+	Resource  []CapabilityResource  `json:"resource"`
+	Operation []CapabilityOperation `json:"operation,omitempty"`
+}
+
+type CapabilityResource struct {
+	Type        string                  `json:"type" example:"Patient"`
+	Interaction []CapabilityInteraction `json:"interaction"`
+	SearchParam []CapabilitySearchParam `json:"searchParam,omitempty"`
+}
+
+type CapabilityInteraction struct {
+	Code string `json:"code" example:"read"`
+}
+
+type CapabilityOperation struct {
+	Name       string `json:"name" example:"export"`
+	Definition string `json:"definition" example:"http://hl7.org/fhir/uv/bulkdata/OperationDefinition/export"`
+}
+
+type CapabilitySearchParam struct {
+	Name string `json:"name" example:"name"`
+	Type string `json:"type" example:"string"`
+}
@@ -0,0 +1,121 @@
+package fhir
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnsupportedPrefix   = errors.New("unsupported date prefix, use eq, ge, gt, le or lt")
+	ErrConflictingPatients = errors.New("subject and patient refer to different patients")
+)
+
+// Search prefixes of date parameters (https://hl7.org/fhir/R4/search.html#prefix)
+const (
+	prefixEqual          = "eq"
+	prefixGreaterOrEqual = "ge"
+	prefixGreater        = "gt"
+	prefixLessOrEqual    = "le"
+	prefixLess           = "lt"
+)
+
+// DateRange is the range of days a date search parameter selects, both ends
+// inclusive. A nil end is open.
+type DateRange struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// ParseDateRange reads the values of a repeated date search parameter, e.g.
+// recorded-date=ge2026-01-01&recorded-date=lt2026-02-01. Searches are at day
+// precision, the time of a dateTime value is ignored.
+func ParseDateRange(values []string) (DateRange, error) {
+	var r DateRange
+	for _, value := range values {
+		prefix := prefixEqual
+		if len(value) > 2 && value[0] >= 'a' && value[0] <= 'z' {
+			prefix, value = value[:2], value[2:]
+		}
+		t, err := ParseDateTime(value)
+		if err != nil {
+			return DateRange{}, err
+		}
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+		switch prefix {
+		case prefixEqual:
+			r.Start, r.End = &day, &day
+		case prefixGreaterOrEqual:
+			r.Start = &day
+		case prefixGreater:
+			next := day.AddDate(0, 0, 1)
+			r.Start = &next
+		case prefixLessOrEqual:
+			r.End = &day
+		case prefixLess:
+			previous := day.AddDate(0, 0, -1)
+			r.End = &previous
+		default:
+			return DateRange{}, ErrUnsupportedPrefix
+		}
+	}
+	return r, nil
+}
+
+// ParseToken splits a token search parameter into its system and code, e.g.
+// "urn:oid:1.3.6.1.4.1.19126.3|12345678Z". hasSystem is false when the value
+// has no "|", so any system matches.
+func ParseToken(value string) (system, code string, hasSystem bool) {
+	system, code, hasSystem = strings.Cut(value, "|")
+	if !hasSystem {
+		return "", value, false
+	}
+	return system, code, true
+}
+
+// NewSearchBundle returns the searchset of the given entries, all the matches
+// of the search
+func NewSearchBundle(selfURL string, entries []BundleEntry) Bundle {
+	total := len(entries)
+	return Bundle{
+		ResourceType: "Bundle",
+		Type:         "searchset",
+		Total:        &total,
+		Link:         []BundleLink{{Relation: "self", URL: selfURL}},
+		Entry:        entries,
+	}
+}
+
+// Paged marks the bundle as one page of the matches, linking to the next one
+// when nextURL is not empty. The total is dropped, as it is not counted.
+func (b *Bundle) Paged(nextURL string) {
+	b.Total = nil
+	if nextURL != "" {
+		b.Link = append(b.Link, BundleLink{Relation: "next", URL: nextURL})
+	}
+}
+
+// NewSearchEntry returns the entry of a resource matching a search, the
+// resource being served under baseURL
+func NewSearchEntry(baseURL, resourceType, id string, resource any) (BundleEntry, error) {
+	content, err := json.Marshal(resource)
+	if err != nil {
+		return BundleEntry{}, err
+	}
+	return BundleEntry{
+		FullURL:  baseURL + "/" + NewReference(resourceType, id),
+		Resource: content,
+		Search:   &BundleSearch{Mode: "match"},
+	}, nil
+}
+
+// NewOperationOutcome returns an outcome with a single error issue. The code
+// is one of the FHIR IssueType codes, e.g. "not-found".
+func NewOperationOutcome(code, diagnostics string) OperationOutcome {
+	return OperationOutcome{
+		ResourceType: "OperationOutcome",
+		Issue:        []Issue{{Severity: "error", Code: code, Diagnostics: diagnostics}},
+	}
+}
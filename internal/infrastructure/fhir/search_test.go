@@ -0,0 +1,73 @@
+package fhir
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name      string
+		values    []string
+		wantStart *time.Time
+		wantEnd   *time.Time
+		wantErr   error
+	}{
+		{"no value", nil, nil, nil, nil},
+		{"a day", []string{"2026-03-02"}, day(2), day(2), nil},
+		{"a day with prefix", []string{"eq2026-03-02"}, day(2), day(2), nil},
+		{"from a day", []string{"ge2026-03-02"}, day(2), nil, nil},
+		{"after a day", []string{"gt2026-03-02"}, day(3), nil, nil},
+		{"range", []string{"ge2026-03-02", "lt2026-03-10"}, day(2), day(9), nil},
+		{"time ignored", []string{"le2026-03-02T18:00:00Z"}, nil, day(2), nil},
+		{"unsupported prefix", []string{"ne2026-03-02"}, nil, nil, ErrUnsupportedPrefix},
+		{"invalid date", []string{"ge02/03/2026"}, nil, nil, ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateRange(tt.values)
+			if err != tt.wantErr {
+				t.Fatalf("ParseDateRange() error = %v, want %v", err, tt.wantErr)
+			}
+			if !sameDay(got.Start, tt.wantStart) || !sameDay(got.End, tt.wantEnd) {
+				t.Errorf("ParseDateRange() = %v - %v, want %v - %v", got.Start, got.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func sameDay(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestParseToken(t *testing.T) {
+	if system, code, ok := ParseToken(DNISystem + "|12345678Z"); !ok || system != DNISystem || code != "12345678Z" {
+		t.Errorf("ParseToken() with system = %q, %q, %v", system, code, ok)
+	}
+	if system, code, ok := ParseToken("12345678Z"); ok || system != "" || code != "12345678Z" {
+		t.Errorf("ParseToken() without system = %q, %q, %v", system, code, ok)
+	}
+}
+
+func TestBundlePaged(t *testing.T) {
+	bundle := NewSearchBundle("http://localhost/fhir/r4/Patient?_count=1", []BundleEntry{{FullURL: "http://localhost/fhir/r4/Patient/1"}})
+	if bundle.Total == nil || *bundle.Total != 1 {
+		t.Fatalf("NewSearchBundle() total = %v, want 1", bundle.Total)
+	}
+
+	bundle.Paged("http://localhost/fhir/r4/Patient?_count=1&_cursor=next")
+	if bundle.Total != nil {
+		t.Errorf("Paged() total = %v, want none", *bundle.Total)
+	}
+	if len(bundle.Link) != 2 || bundle.Link[1].Relation != "next" {
+		t.Errorf("Paged() links = %+v, want self and next", bundle.Link)
+	}
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type AppointmentRequest struct {
+	PatientID      string `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	PractitionerID string `json:"practitioner_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"` // The caller when omitted
+	Start          string `json:"start" example:"2026-03-02T09:00:00Z"`                           // ISO 8601 format
+	End            string `json:"end" example:"2026-03-02T09:20:00Z"`                             // ISO 8601 format
+	Reason         string `json:"reason,omitempty" example:"Revisión"`
+	DiagnosisID    string `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPV"` // Diagnosis the visit follows up
+}
+
+type RescheduleAppointmentRequest struct {
+	Start string `json:"start" example:"2026-03-03T10:00:00Z"` // ISO 8601 format
+	End   string `json:"end" example:"2026-03-03T10:20:00Z"`   // ISO 8601 format
+}
+
+// Response DTOs
+
+type AppointmentResponse struct {
+	ID             string     `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPW"`
+	PatientID      string     `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	PractitionerID string     `json:"practitioner_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	Status         string     `json:"status" example:"booked" enums:"pending,booked,cancelled"`
+	Start          *time.Time `json:"start,omitempty" example:"2026-03-02T09:00:00Z"`
+	End            *time.Time `json:"end,omitempty" example:"2026-03-02T09:20:00Z"`
+	DueDate        string     `json:"due_date,omitempty" example:"2026-03-15"` // Pending follow-ups only
+	Reason         string     `json:"reason,omitempty" example:"Revisión"`
+	DiagnosisID    string     `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPV"`
+	CreatedBy      string     `json:"created_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	CreatedAt      time.Time  `json:"created_at" example:"2026-02-13T10:00:00Z"`
+	CancelledAt    *time.Time `json:"cancelled_at,omitempty" example:"2026-02-20T08:00:00Z"`
+}
+
+type CalendarFeedResponse struct {
+	URL       string    `json:"url" example:"https://api.example.com/practitioners/01HMGNBPJNX0G2BZXJ7XW1RHPS/calendar.ics?token=..."`
+	CreatedAt time.Time `json:"created_at" example:"2026-02-13T10:00:00Z"`
+}
+
+// CreateDiagnosisResponse is the created diagnosis and its follow-up, when
+// one was requested
+type CreateDiagnosisResponse struct {
+	DiagnosisResponse
+	FollowUp *AppointmentResponse `json:"follow_up,omitempty"`
+}
+
+// Mappers: Domain -> DTO
+
+func toAppointmentResponse(a domain.Appointment) AppointmentResponse {
+	response := AppointmentResponse{
+		ID:             a.ID,
+		PatientID:      a.PatientID,
+		PractitionerID: a.PractitionerID,
+		Status:         a.Status,
+		Reason:         a.Reason,
+		DiagnosisID:    a.DiagnosisID,
+		CreatedBy:      a.CreatedBy,
+		CreatedAt:      a.CreatedAt,
+		CancelledAt:    a.CancelledAt,
+	}
+	if !a.Start.IsZero() {
+		start, end := a.Start, a.End
+		response.Start = &start
+		response.End = &end
+	}
+	if a.DueDate != nil {
+		response.DueDate = a.DueDate.Format("2006-01-02")
+	}
+	return response
+}
+
+func toAppointmentResponseList(appointments []domain.Appointment) []AppointmentResponse {
+	result := make([]AppointmentResponse, len(appointments))
+	for i, a := range appointments {
+		result[i] = toAppointmentResponse(a)
+	}
+	return result
+}
+
+// Mappers: DTO -> Domain
+
+func toAppointmentDomain(req AppointmentRequest, start, end time.Time) domain.Appointment {
+	// Time parsing is handled in the handler
+	return domain.Appointment{
+		PatientID:      req.PatientID,
+		PractitionerID: req.PractitionerID,
+		Start:          start,
+		End:            end,
+		Reason:         req.Reason,
+		DiagnosisID:    req.DiagnosisID,
+	}
+}
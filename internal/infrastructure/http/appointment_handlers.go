@@ -0,0 +1,206 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// BookAppointment books an appointment in a practitioner's agenda
+// @Summary Book appointment
+// @Description Book a patient's appointment with a practitioner, the caller when practitioner_id is omitted.
+// @Description Fails with 409 when the practitioner already has an appointment overlapping the time slot.
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param appointment body AppointmentRequest true "Appointment Info"
+// @Success 201 {object} AppointmentResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /appointments [post]
+func (h *HttpHandler) BookAppointment(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("Book appointment request received")
+
+	var req AppointmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode book appointment request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	start, end, ok := parseAppointmentTimes(w, req.Start, req.End)
+	if !ok {
+		return
+	}
+
+	appointment := toAppointmentDomain(req, start, end)
+	if err := h.app.Appointment().BookAppointment(callerFromRequest(r), &appointment); err != nil {
+		slog.Error("Failed to book appointment", "patient_id", req.PatientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toAppointmentResponse(appointment))
+}
+
+// RescheduleAppointment moves an appointment to a new time slot
+// @Summary Reschedule appointment
+// @Description Move an appointment to a new time slot. Rescheduling a pending follow-up books it.
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Appointment ID"
+// @Param slot body RescheduleAppointmentRequest true "New time slot"
+// @Success 200 {object} AppointmentResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /appointments/{id}/reschedule [post]
+func (h *HttpHandler) RescheduleAppointment(w http.ResponseWriter, r *http.Request) {
+	appointmentID := r.PathValue("id")
+	slog.Debug("Reschedule appointment request received", "appointment_id", appointmentID)
+
+	var req RescheduleAppointmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode reschedule appointment request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	start, end, ok := parseAppointmentTimes(w, req.Start, req.End)
+	if !ok {
+		return
+	}
+
+	appointment, err := h.app.Appointment().RescheduleAppointment(callerFromRequest(r), appointmentID, start, end)
+	if err != nil {
+		slog.Error("Failed to reschedule appointment", "appointment_id", appointmentID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toAppointmentResponse(*appointment))
+}
+
+// CancelAppointment cancels an appointment
+// @Summary Cancel appointment
+// @Description Cancel an appointment, freeing its time slot in the practitioner's agenda
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Appointment ID"
+// @Success 200 {object} AppointmentResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /appointments/{id}/cancel [post]
+func (h *HttpHandler) CancelAppointment(w http.ResponseWriter, r *http.Request) {
+	appointmentID := r.PathValue("id")
+	slog.Debug("Cancel appointment request received", "appointment_id", appointmentID)
+
+	appointment, err := h.app.Appointment().CancelAppointment(callerFromRequest(r), appointmentID)
+	if err != nil {
+		slog.Error("Failed to cancel appointment", "appointment_id", appointmentID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toAppointmentResponse(*appointment))
+}
+
+// GetPatientAppointments lists the appointments of a patient
+// @Summary List patient appointments
+// @Description List the booked, pending and cancelled appointments of a patient
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} AppointmentResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/appointments [get]
+func (h *HttpHandler) GetPatientAppointments(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get patient appointments request received", "patient_id", patientID)
+
+	appointments, err := h.app.Appointment().GetPatientAppointments(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get patient appointments", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toAppointmentResponseList(appointments))
+}
+
+// GetAgenda returns a practitioner's agenda
+// @Summary Practitioner agenda
+// @Description List a practitioner's booked appointments and the pending follow-ups due in the day or week
+// @Description (Monday to Sunday) containing the date. Practitioners can only see their own agenda, admins any.
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Practitioner ID"
+// @Param date query string false "Day to show (YYYY-MM-DD), today when omitted"
+// @Param view query string false "Agenda view, day when omitted" Enums(day, week)
+// @Success 200 {array} AppointmentResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /practitioners/{id}/agenda [get]
+func (h *HttpHandler) GetAgenda(w http.ResponseWriter, r *http.Request) {
+	practitionerID := r.PathValue("id")
+	dateParam := r.URL.Query().Get("date")
+	view := r.URL.Query().Get("view")
+	slog.Debug("Get agenda request received", "practitioner_id", practitionerID, "date", dateParam, "view", view)
+
+	date := time.Now().UTC()
+	if dateParam != "" {
+		d, err := time.Parse("2006-01-02", dateParam)
+		if err != nil {
+			slog.Warn("Invalid agenda date format", "date", dateParam)
+			http.Error(w, "Invalid date format, use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		date = d
+	}
+
+	appointments, err := h.app.Appointment().GetAgenda(callerFromRequest(r), practitionerID, date, view)
+	if err != nil {
+		slog.Error("Failed to get agenda", "practitioner_id", practitionerID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toAppointmentResponseList(appointments))
+}
+
+// parseAppointmentTimes parses the ISO 8601 bounds of a time slot, writing a
+// bad request response when either is invalid
+func parseAppointmentTimes(w http.ResponseWriter, startParam, endParam string) (start, end time.Time, ok bool) {
+	start, errStart := time.Parse(time.RFC3339, startParam)
+	end, errEnd := time.Parse(time.RFC3339, endParam)
+	if errStart != nil || errEnd != nil {
+		slog.Warn("Invalid appointment time format", "start", startParam, "end", endParam)
+		http.Error(w, "Invalid start or end format, use ISO 8601", http.StatusBadRequest)
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
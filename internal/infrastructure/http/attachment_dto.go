@@ -0,0 +1,45 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Response DTOs
+
+type AttachmentResponse struct {
+	ID          string    `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPA"`
+	FileName    string    `json:"file_name" example:"rx-torax.png"`
+	ContentType string    `json:"content_type" example:"image/png"`
+	Size        int64     `json:"size" example:"482133"`
+	SHA256      string    `json:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	UploadedBy  string    `json:"uploaded_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPU"`
+	CreatedAt   time.Time `json:"created_at" example:"2026-02-13T18:23:00Z"`
+	URL         string    `json:"url" example:"/diagnostics/01HMGNBPJNX0G2BZXJ7XW1RHPR/attachments/01HMGNBPJNX0G2BZXJ7XW1RHPA"`
+}
+
+// Mappers: Domain -> DTO
+
+func toAttachmentResponse(a domain.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.Checksum,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt,
+		URL:         "/diagnostics/" + a.DiagnosisID + "/attachments/" + a.ID,
+	}
+}
+
+func toAttachmentResponseList(attachments []domain.Attachment) []AttachmentResponse {
+	if len(attachments) == 0 {
+		return nil
+	}
+	result := make([]AttachmentResponse, len(attachments))
+	for i, a := range attachments {
+		result[i] = toAttachmentResponse(a)
+	}
+	return result
+}
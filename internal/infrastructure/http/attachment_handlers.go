@@ -0,0 +1,170 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"topdoctors/internal/domain"
+)
+
+// multipartOverhead leaves room in the request body for the part headers and
+// boundaries around a file of the maximum size
+const multipartOverhead = 1 << 20
+
+// sniffLength is what content type detection looks at, it also covers the
+// "DICM" marker DICOM files carry at offset 128
+const sniffLength = 512
+
+// UploadAttachment attaches a file to a diagnosis
+// @Summary Upload attachment
+// @Description Attach a file, such as a scanned report or an image, to a diagnosis. The file is sent as the "file"
+// @Description part of a multipart form. Its type is detected from the content, only PDF, JPEG, PNG, WebP and DICOM
+// @Description files up to 20 MiB are accepted. When the X-Content-SHA256 header is given the upload is rejected
+// @Description unless the content matches it.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Diagnosis ID"
+// @Param file formData file true "File to attach"
+// @Param X-Content-SHA256 header string false "Hex encoded SHA-256 of the file"
+// @Success 201 {object} AttachmentResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 413 {string} string "Request Entity Too Large"
+// @Failure 415 {string} string "Unsupported Media Type"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /diagnostics/{id}/attachments [post]
+func (h *HttpHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	diagnosisID := r.PathValue("id")
+	slog.Debug("Upload attachment request received", "diagnosis_id", diagnosisID)
+
+	r.Body = http.MaxBytesReader(w, r.Body, domain.MaxAttachmentSize+multipartOverhead)
+	parts, err := r.MultipartReader()
+	if err != nil {
+		slog.Warn("Attachment upload is not a multipart form", "diagnosis_id", diagnosisID, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Stream the file part, the content is never held in memory whole
+	var part io.ReadCloser
+	var fileName string
+	for {
+		p, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			slog.Warn("Failed to read attachment upload", "diagnosis_id", diagnosisID, "error", err)
+			http.Error(w, err.Error(), uploadErrorStatus(err))
+			return
+		}
+		if p.FormName() == "file" {
+			part, fileName = p, p.FileName()
+			break
+		}
+	}
+	if part == nil {
+		http.Error(w, "Missing file part", http.StatusBadRequest)
+		return
+	}
+	defer part.Close()
+
+	content := bufio.NewReaderSize(part, sniffLength)
+	head, err := content.Peek(sniffLength)
+	if err != nil && err != io.EOF {
+		slog.Warn("Failed to read attachment content", "diagnosis_id", diagnosisID, "error", err)
+		http.Error(w, err.Error(), uploadErrorStatus(err))
+		return
+	}
+
+	attachment := &domain.Attachment{
+		DiagnosisID: diagnosisID,
+		FileName:    fileName,
+		ContentType: detectAttachmentType(head),
+	}
+	checksum := strings.ToLower(r.Header.Get("X-Content-SHA256"))
+	if err := h.app.Attachment().AddAttachment(callerFromRequest(r), attachment, content, checksum); err != nil {
+		slog.Error("Failed to add attachment", "diagnosis_id", diagnosisID, "error", err)
+		http.Error(w, err.Error(), uploadErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toAttachmentResponse(*attachment))
+}
+
+// DownloadAttachment returns the content of an attachment
+// @Summary Download attachment
+// @Description Download a file attached to a diagnosis with the type detected on upload. Its SHA-256 is sent as the
+// @Description ETag, and range requests are supported.
+// @Tags Attachments
+// @Produce application/pdf,image/jpeg,image/png,image/webp,application/dicom
+// @Security BearerAuth
+// @Param id path string true "Diagnosis ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {file} file "Attachment content"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /diagnostics/{id}/attachments/{attachmentId} [get]
+func (h *HttpHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	diagnosisID, attachmentID := r.PathValue("id"), r.PathValue("attachmentId")
+	slog.Debug("Download attachment request received", "diagnosis_id", diagnosisID, "attachment_id", attachmentID)
+
+	attachment, content, err := h.app.Attachment().OpenAttachment(callerFromRequest(r), diagnosisID, attachmentID)
+	if err != nil {
+		slog.Error("Failed to open attachment", "attachment_id", attachmentID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("ETag", `"`+attachment.Checksum+`"`)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if seeker, ok := content.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", attachment.CreatedAt, seeker)
+		return
+	}
+	if _, err := io.Copy(w, content); err != nil {
+		slog.Error("Failed to write attachment", "attachment_id", attachmentID, "error", err)
+	}
+}
+
+// detectAttachmentType sniffs the content type from the first bytes of a
+// file, the type the client declares is never trusted
+func detectAttachmentType(head []byte) string {
+	if len(head) >= 132 && string(head[128:132]) == "DICM" {
+		return domain.AttachmentTypeDICOM
+	}
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return ""
+	}
+	return contentType
+}
+
+// uploadErrorStatus is statusForError, also telling bodies over the request
+// limit apart
+func uploadErrorStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return statusForError(err)
+}
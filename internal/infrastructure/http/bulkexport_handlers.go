@@ -0,0 +1,188 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/infrastructure/fhir"
+)
+
+// bulkExportRetryAfter is how long clients are asked to wait between polls of
+// a running export, in seconds
+const bulkExportRetryAfter = "10"
+
+// FHIRStartExport kicks off an asynchronous bulk export
+// @Summary FHIR bulk export kick-off
+// @Description Start an export of every patient and condition the caller may access, following the FHIR Bulk Data
+// @Description Access specification. Restricted to integration clients, and limited to what each patient consented to
+// @Description share with third parties. Answers 202 with the status URL in Content-Location; poll it until the export completes.
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param Prefer header string true "Must be respond-async"
+// @Param _type query string false "Comma separated resource types, Patient and Condition by default"
+// @Param _since query string false "Only resources updated after this instant, e.g. 2026-02-12T02:00:00Z"
+// @Param _outputFormat query string false "application/fhir+ndjson, the only format"
+// @Success 202 {string} string "Accepted, status URL in Content-Location"
+// @Failure 400 {object} fhir.OperationOutcome
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/$export [get]
+func (h *HttpHandler) FHIRStartExport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	slog.Debug("FHIR bulk export kick-off received", "type", query.Get("_type"), "since", query.Get("_since"))
+
+	if !strings.Contains(r.Header.Get("Prefer"), fhir.PreferAsync) {
+		writeOperationOutcomeStatus(w, http.StatusBadRequest, "Bulk export requires the header Prefer: "+fhir.PreferAsync)
+		return
+	}
+	if format := query.Get("_outputFormat"); !fhir.SupportsOutputFormat(format) {
+		writeOperationOutcomeStatus(w, http.StatusBadRequest, "Unsupported _outputFormat "+format+", use "+fhir.NDJSONContentType)
+		return
+	}
+	types, err := domain.ParseBulkExportTypes(query.Get("_type"))
+	if err != nil {
+		writeOperationOutcome(w, err)
+		return
+	}
+	var since *time.Time
+	if value := query.Get("_since"); value != "" {
+		t, err := fhir.ParseDateTime(value)
+		if err != nil {
+			writeOperationOutcome(w, err)
+			return
+		}
+		since = &t
+	}
+
+	job, err := h.app.BulkExport().StartExport(callerFromRequest(r), types, since)
+	if err != nil {
+		slog.Error("Failed to start FHIR bulk export", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Location", fhirBaseURL(r)+"/bulk-status/"+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// FHIRExportStatus reports the progress of a bulk export
+// @Summary FHIR bulk export status
+// @Description Poll a bulk export. Answers 202 while it runs, 200 with the manifest of the NDJSON files once completed
+// @Description and 500 with an OperationOutcome if it failed. Only the client that kicked it off can see it. The files are
+// @Description kept until the time in the Expires header of the manifest, after which the export is gone.
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export job ID"
+// @Success 200 {object} fhir.ExportManifest
+// @Header 200 {string} Expires "When the files are removed"
+// @Success 202 {string} string "In progress, see X-Progress and Retry-After"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/bulk-status/{id} [get]
+func (h *HttpHandler) FHIRExportStatus(w http.ResponseWriter, r *http.Request) {
+	jobID := r.PathValue("id")
+	slog.Debug("FHIR bulk export status request received", "job_id", jobID)
+
+	job, err := h.app.BulkExport().GetExportJob(callerFromRequest(r), jobID)
+	if err != nil {
+		slog.Error("Failed to get FHIR bulk export status", "job_id", jobID, "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	switch job.Status {
+	case domain.BulkExportStatusInProgress:
+		w.Header().Set("X-Progress", job.Status)
+		w.Header().Set("Retry-After", bulkExportRetryAfter)
+		w.WriteHeader(http.StatusAccepted)
+	case domain.BulkExportStatusFailed:
+		writeOperationOutcomeStatus(w, http.StatusInternalServerError, "Bulk export failed: "+job.Error)
+	default:
+		// The manifest is plain JSON, not a FHIR resource
+		w.Header().Set("Content-Type", "application/json")
+		if job.ExpiresAt != nil {
+			w.Header().Set("Expires", job.ExpiresAt.UTC().Format(http.TimeFormat))
+		}
+		json.NewEncoder(w).Encode(exportManifest(fhirBaseURL(r), job))
+	}
+}
+
+// FHIRDownloadExportFile returns a file of a completed bulk export
+// @Summary FHIR bulk export file
+// @Description Download the NDJSON file of a resource type of a completed bulk export, as listed in its manifest
+// @Tags FHIR
+// @Produce application/fhir+ndjson
+// @Security BearerAuth
+// @Param id path string true "Export job ID"
+// @Param file path string true "File name, e.g. Patient.ndjson"
+// @Success 200 {string} string "One resource per line"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {object} fhir.OperationOutcome
+// @Failure 409 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/bulk-files/{id}/{file} [get]
+func (h *HttpHandler) FHIRDownloadExportFile(w http.ResponseWriter, r *http.Request) {
+	jobID, file := r.PathValue("id"), r.PathValue("file")
+	slog.Debug("FHIR bulk export file request received", "job_id", jobID, "file", file)
+
+	resourceType, ok := strings.CutSuffix(file, ".ndjson")
+	if !ok {
+		writeOperationOutcome(w, domain.ErrBulkExportFileNotFound)
+		return
+	}
+	content, err := h.app.BulkExport().OpenExportFile(callerFromRequest(r), jobID, resourceType)
+	if err != nil {
+		slog.Error("Failed to open FHIR bulk export file", "job_id", jobID, "file", file, "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", fhir.NDJSONContentType)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if seeker, ok := content.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", time.Time{}, seeker)
+		return
+	}
+	if _, err := io.Copy(w, content); err != nil {
+		slog.Error("Failed to write FHIR bulk export file", "job_id", jobID, "file", file, "error", err)
+	}
+}
+
+// exportManifest lists the files of a completed export, served under baseURL
+func exportManifest(baseURL string, job *domain.BulkExportJob) fhir.ExportManifest {
+	manifest := fhir.ExportManifest{
+		TransactionTime:     job.RequestedAt.UTC().Format(time.RFC3339Nano),
+		Request:             exportRequestURL(baseURL, job),
+		RequiresAccessToken: true,
+		Output:              make([]fhir.ExportOutput, 0, len(job.Output)),
+		Error:               []fhir.ExportOutput{},
+	}
+	for _, f := range job.Output {
+		manifest.Output = append(manifest.Output, fhir.ExportOutput{
+			Type:  f.Type,
+			URL:   baseURL + "/bulk-files/" + job.ID + "/" + f.Type + ".ndjson",
+			Count: f.Count,
+		})
+	}
+	return manifest
+}
+
+// exportRequestURL rebuilds the kick-off URL of a job
+func exportRequestURL(baseURL string, job *domain.BulkExportJob) string {
+	query := url.Values{}
+	query.Set("_type", strings.Join(job.Types, ","))
+	if job.Since != nil {
+		query.Set("_since", job.Since.UTC().Format(time.RFC3339Nano))
+	}
+	return baseURL + "/$export?" + query.Encode()
+}
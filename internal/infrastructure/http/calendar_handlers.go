@@ -0,0 +1,190 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+const (
+	icsDateTimeLayout = "20060102T150405Z"
+	icsDateLayout     = "20060102"
+)
+
+// CreateCalendarFeed issues the iCalendar subscription URL of a practitioner
+// @Summary Create calendar feed
+// @Description Issue the signed URL to subscribe to a practitioner's agenda from a calendar app. The URL carries its
+// @Description own token, so keep it private; issuing a new one revokes the previous. Own agenda only, admins any.
+// @Tags Appointments
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Practitioner ID"
+// @Success 201 {object} CalendarFeedResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /practitioners/{id}/calendar-feed [post]
+func (h *HttpHandler) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	practitionerID := r.PathValue("id")
+	slog.Debug("Create calendar feed request received", "practitioner_id", practitionerID)
+
+	token, feed, err := h.app.Calendar().CreateFeed(callerFromRequest(r), practitionerID)
+	if err != nil {
+		slog.Error("Failed to create calendar feed", "practitioner_id", practitionerID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	feedURL := fmt.Sprintf("%s://%s/practitioners/%s/calendar.ics?token=%s", scheme, r.Host, url.PathEscape(practitionerID), url.QueryEscape(token))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(CalendarFeedResponse{URL: feedURL, CreatedAt: feed.CreatedAt})
+}
+
+// RevokeCalendarFeed revokes the iCalendar subscription of a practitioner
+// @Summary Revoke calendar feed
+// @Description Revoke the calendar subscription URL of a practitioner
+// @Tags Appointments
+// @Security BearerAuth
+// @Param id path string true "Practitioner ID"
+// @Success 204 "No Content"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /practitioners/{id}/calendar-feed [delete]
+func (h *HttpHandler) RevokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	practitionerID := r.PathValue("id")
+	slog.Debug("Revoke calendar feed request received", "practitioner_id", practitionerID)
+
+	if err := h.app.Calendar().RevokeFeed(callerFromRequest(r), practitionerID); err != nil {
+		slog.Error("Failed to revoke calendar feed", "practitioner_id", practitionerID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCalendarFeed serves a practitioner's agenda as an iCalendar feed
+// @Summary Calendar feed
+// @Description RFC 5545 feed of a practitioner's appointments from 30 days ago to 180 days ahead, for calendar apps.
+// @Description Authenticated by the token of the URL issued by POST /practitioners/{id}/calendar-feed instead of a
+// @Description bearer token. Events only carry times and generic summaries, never patient or diagnosis details;
+// @Description pending follow-ups show as all-day events on their due date.
+// @Tags Appointments
+// @Produce text/calendar
+// @Param id path string true "Practitioner ID"
+// @Param token query string true "Feed token"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /practitioners/{id}/calendar.ics [get]
+func (h *HttpHandler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	practitionerID := r.PathValue("id")
+	slog.Debug("Calendar feed request received", "practitioner_id", practitionerID)
+
+	appointments, err := h.app.Calendar().GetFeedAppointments(practitionerID, r.URL.Query().Get("token"))
+	if err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := writeICalendar(w, appointments, time.Now()); err != nil {
+		slog.Error("Failed to write calendar feed", "practitioner_id", practitionerID, "error", err)
+	}
+}
+
+// GetAppointmentCalendar returns an appointment as an iCalendar attachment
+// @Summary Appointment calendar file
+// @Description Download an appointment as an RFC 5545 .ics file to add it to a calendar app. Like the feed, the event
+// @Description carries no patient or diagnosis details.
+// @Tags Appointments
+// @Produce text/calendar
+// @Security BearerAuth
+// @Param id path string true "Appointment ID"
+// @Success 200 {string} string "iCalendar file"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /appointments/{id}/calendar.ics [get]
+func (h *HttpHandler) GetAppointmentCalendar(w http.ResponseWriter, r *http.Request) {
+	appointmentID := r.PathValue("id")
+	slog.Debug("Appointment calendar request received", "appointment_id", appointmentID)
+
+	appointment, err := h.app.Appointment().GetAppointment(callerFromRequest(r), appointmentID)
+	if err != nil {
+		slog.Error("Failed to get appointment", "appointment_id", appointmentID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "appointment-"+appointmentID+".ics"))
+	if err := writeICalendar(w, []domain.Appointment{*appointment}, time.Now()); err != nil {
+		slog.Error("Failed to write appointment calendar", "appointment_id", appointmentID, "error", err)
+	}
+}
+
+// writeICalendar writes the appointments as an RFC 5545 calendar. Only fixed
+// summaries are written, which keeps patient data out of calendar apps and
+// means no value needs escaping or folding.
+func writeICalendar(w io.Writer, appointments []domain.Appointment, stamp time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//TopDoctors//Agenda//ES",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:TopDoctors",
+	}
+	for _, a := range appointments {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+a.ID+"@topdoctors",
+			"DTSTAMP:"+stamp.UTC().Format(icsDateTimeLayout),
+		)
+		status := "CONFIRMED"
+		switch a.Status {
+		case domain.AppointmentStatusPending:
+			status = "TENTATIVE"
+		case domain.AppointmentStatusCancelled:
+			status = "CANCELLED"
+		}
+		// Follow-ups without a time slot are all-day events on their due date
+		if a.Start.IsZero() && a.DueDate != nil {
+			lines = append(lines,
+				"DTSTART;VALUE=DATE:"+a.DueDate.Format(icsDateLayout),
+				"DTEND;VALUE=DATE:"+a.DueDate.AddDate(0, 0, 1).Format(icsDateLayout),
+				"SUMMARY:Follow-up due",
+				"STATUS:"+status,
+				"TRANSP:TRANSPARENT",
+			)
+		} else {
+			lines = append(lines,
+				"DTSTART:"+a.Start.UTC().Format(icsDateTimeLayout),
+				"DTEND:"+a.End.UTC().Format(icsDateTimeLayout),
+				"SUMMARY:Appointment",
+				"STATUS:"+status,
+				"TRANSP:OPAQUE",
+			)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\r\n")+"\r\n")
+	return err
+}
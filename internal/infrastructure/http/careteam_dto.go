@@ -0,0 +1,58 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type AddCareTeamMemberRequest struct {
+	UserID string `json:"user_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+}
+
+type BreakGlassRequest struct {
+	Justification string `json:"justification" example:"Paciente inconsciente en urgencias"`
+}
+
+// Response DTOs
+
+type CareTeamMemberResponse struct {
+	UserID  string    `json:"user_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	AddedBy string    `json:"added_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	AddedAt time.Time `json:"added_at" example:"2026-02-13T18:23:00Z"`
+}
+
+type BreakGlassResponse struct {
+	ID               string    `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	PatientID        string    `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Justification    string    `json:"justification" example:"Paciente inconsciente en urgencias"`
+	GrantedAt        time.Time `json:"granted_at" example:"2026-02-13T18:23:00Z"`
+	ExpiresAt        time.Time `json:"expires_at" example:"2026-02-13T22:23:00Z"`
+	FlaggedForReview bool      `json:"flagged_for_review" example:"true"`
+}
+
+// Mappers: Domain -> DTO
+
+func toCareTeamResponse(members []domain.CareTeamMember) []CareTeamMemberResponse {
+	result := make([]CareTeamMemberResponse, len(members))
+	for i, m := range members {
+		result[i] = CareTeamMemberResponse{
+			UserID:  m.UserID,
+			AddedBy: m.AddedBy,
+			AddedAt: m.AddedAt,
+		}
+	}
+	return result
+}
+
+func toBreakGlassResponse(b domain.BreakGlassAccess) BreakGlassResponse {
+	return BreakGlassResponse{
+		ID:               b.ID,
+		PatientID:        b.PatientID,
+		Justification:    b.Justification,
+		GrantedAt:        b.GrantedAt,
+		ExpiresAt:        b.ExpiresAt,
+		FlaggedForReview: b.FlaggedForReview,
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// GetCareTeam lists the care team of a patient
+// @Summary Get care team
+// @Description List the users in a patient's care team
+// @Tags Care Team
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} CareTeamMemberResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/care-team [get]
+func (h *HttpHandler) GetCareTeam(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get care team request received", "patient_id", patientID)
+
+	members, err := h.app.CareTeam().GetCareTeam(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get care team", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toCareTeamResponse(members))
+}
+
+// AddCareTeamMember adds a user to a patient's care team
+// @Summary Add care team member
+// @Description Add a user to the care team of a patient the caller has access to
+// @Tags Care Team
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param member body AddCareTeamMemberRequest true "Member Info"
+// @Success 201 {string} string "Created"
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/care-team [post]
+func (h *HttpHandler) AddCareTeamMember(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Add care team member request received", "patient_id", patientID)
+
+	var req AddCareTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode add care team member request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := h.app.CareTeam().AddMember(callerFromRequest(r), patientID, req.UserID)
+	if err != nil {
+		slog.Error("Failed to add care team member", "patient_id", patientID, "user_id", req.UserID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// RemoveCareTeamMember removes a user from a patient's care team
+// @Summary Remove care team member
+// @Description Remove a user from the care team of a patient. The last member cannot be removed.
+// @Tags Care Team
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param userId path string true "User ID"
+// @Success 204 {string} string "No Content"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/care-team/{userId} [delete]
+func (h *HttpHandler) RemoveCareTeamMember(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	userID := r.PathValue("userId")
+	slog.Debug("Remove care team member request received", "patient_id", patientID, "user_id", userID)
+
+	err := h.app.CareTeam().RemoveMember(callerFromRequest(r), patientID, userID)
+	if err != nil {
+		slog.Error("Failed to remove care team member", "patient_id", patientID, "user_id", userID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BreakGlass grants temporary access to a patient outside the caller's care team
+// @Summary Break-glass access
+// @Description Grant the caller temporary access to a patient outside their care team.
+// @Description A justification is mandatory and the access is flagged for review.
+// @Tags Care Team
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param request body BreakGlassRequest true "Justification"
+// @Success 201 {object} BreakGlassResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/break-glass [post]
+func (h *HttpHandler) BreakGlass(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Break-glass request received", "patient_id", patientID)
+
+	var req BreakGlassRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode break-glass request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	access, err := h.app.CareTeam().BreakGlass(callerFromRequest(r), patientID, req.Justification)
+	if err != nil {
+		slog.Error("Failed to grant break-glass access", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toBreakGlassResponse(*access))
+}
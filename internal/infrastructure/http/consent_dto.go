@@ -0,0 +1,69 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type GrantConsentRequest struct {
+	Purpose          string `json:"purpose" example:"third_party_sharing" enums:"third_party_sharing,research"`
+	Scope            string `json:"scope" example:"diagnoses" enums:"all,demographics,diagnoses,prescriptions"`
+	Evidence         string `json:"evidence" example:"Formulario firmado CI-2026-0042"`
+	GrantedAt        string `json:"granted_at" example:"2026-02-13T10:00:00Z"`                        // ISO 8601 format, defaults to now
+	RepresentativeID string `json:"representative_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPT"` // Contact consenting on behalf of a patient under 16
+}
+
+// Response DTOs
+
+type ConsentResponse struct {
+	ID               string     `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	PatientID        string     `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Purpose          string     `json:"purpose" example:"third_party_sharing"`
+	Scope            string     `json:"scope" example:"diagnoses"`
+	Granted          bool       `json:"granted" example:"true"`
+	GrantedAt        time.Time  `json:"granted_at" example:"2026-02-13T10:00:00Z"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" example:"2026-03-01T09:30:00Z"`
+	Evidence         string     `json:"evidence" example:"Formulario firmado CI-2026-0042"`
+	RecordedBy       string     `json:"recorded_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	RepresentativeID string     `json:"representative_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPT"` // Legal representative who consented
+}
+
+// Mappers: Domain -> DTO
+
+func toConsentResponse(c domain.Consent) ConsentResponse {
+	return ConsentResponse{
+		ID:               c.ID,
+		PatientID:        c.PatientID,
+		Purpose:          c.Purpose,
+		Scope:            c.Scope,
+		Granted:          c.IsActive(time.Now()),
+		GrantedAt:        c.GrantedAt,
+		RevokedAt:        c.RevokedAt,
+		Evidence:         c.Evidence,
+		RecordedBy:       c.RecordedBy,
+		RepresentativeID: c.RepresentativeID,
+	}
+}
+
+func toConsentResponseList(consents []domain.Consent) []ConsentResponse {
+	result := make([]ConsentResponse, len(consents))
+	for i, c := range consents {
+		result[i] = toConsentResponse(c)
+	}
+	return result
+}
+
+// Mappers: DTO -> Domain
+
+func toConsentDomain(patientID string, req GrantConsentRequest) domain.Consent {
+	// Date parsing will be handled in the handler
+	return domain.Consent{
+		PatientID:        patientID,
+		Purpose:          req.Purpose,
+		Scope:            req.Scope,
+		Evidence:         req.Evidence,
+		RepresentativeID: req.RepresentativeID,
+	}
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// GetConsents lists the consent records of a patient
+// @Summary List consents
+// @Description List every consent record of a patient, including revoked ones
+// @Tags Consents
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} ConsentResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/consents [get]
+func (h *HttpHandler) GetConsents(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get consents request received", "patient_id", patientID)
+
+	consents, err := h.app.Consent().GetConsents(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get consents", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toConsentResponseList(consents))
+}
+
+// GrantConsent records a patient's consent
+// @Summary Grant consent
+// @Description Record a patient's consent for a purpose (third-party sharing or research) and scope, with its evidence.
+// @Description Patients under 16 consent through a contact who is their legal representative (representative_id).
+// @Tags Consents
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param consent body GrantConsentRequest true "Consent Info"
+// @Success 201 {object} ConsentResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/consents [post]
+func (h *HttpHandler) GrantConsent(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Grant consent request received", "patient_id", patientID)
+
+	var req GrantConsentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode grant consent request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	consent := toConsentDomain(patientID, req)
+	if req.GrantedAt != "" {
+		grantedAt, err := time.Parse(time.RFC3339, req.GrantedAt)
+		if err != nil {
+			slog.Warn("Invalid date format in grant consent request", "date", req.GrantedAt)
+			http.Error(w, "Invalid granted_at format, use ISO 8601", http.StatusBadRequest)
+			return
+		}
+		consent.GrantedAt = grantedAt
+	}
+
+	err := h.app.Consent().GrantConsent(callerFromRequest(r), &consent)
+	if err != nil {
+		slog.Error("Failed to grant consent", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toConsentResponse(consent))
+}
+
+// RevokeConsent revokes a patient's consent
+// @Summary Revoke consent
+// @Description Revoke a previously granted consent. The record is kept for traceability.
+// @Tags Consents
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param consentId path string true "Consent ID"
+// @Success 200 {object} ConsentResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/consents/{consentId}/revoke [post]
+func (h *HttpHandler) RevokeConsent(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	consentID := r.PathValue("consentId")
+	slog.Debug("Revoke consent request received", "patient_id", patientID, "consent_id", consentID)
+
+	consent, err := h.app.Consent().RevokeConsent(callerFromRequest(r), patientID, consentID)
+	if err != nil {
+		slog.Error("Failed to revoke consent", "patient_id", patientID, "consent_id", consentID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toConsentResponse(*consent))
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type ContactRequest struct {
+	Relationship        string `json:"relationship" example:"parent" enums:"parent,guardian,spouse,partner,child,sibling,relative,caregiver,friend,other"`
+	Name                string `json:"name" example:"Carmen López Ruiz"`
+	Phone               string `json:"phone,omitempty" example:"600 65 43 21"` // Spanish, or international with its country code
+	Email               string `json:"email,omitempty" example:"carmen@example.com"`
+	LegalRepresentative bool   `json:"legal_representative" example:"true"` // Can consent on behalf of the patient
+}
+
+// Response DTOs
+
+type ContactResponse struct {
+	ID                  string    `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPT"`
+	PatientID           string    `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Relationship        string    `json:"relationship" example:"parent"`
+	Name                string    `json:"name" example:"Carmen López Ruiz"`
+	Phone               string    `json:"phone,omitempty" example:"+34600654321"` // E.164
+	PhoneDisplay        string    `json:"phone_display,omitempty" example:"+34 600 65 43 21"`
+	Email               string    `json:"email,omitempty" example:"carmen@example.com"`
+	LegalRepresentative bool      `json:"legal_representative" example:"true"`
+	CreatedBy           string    `json:"created_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	CreatedAt           time.Time `json:"created_at" example:"2026-02-13T10:00:00Z"`
+}
+
+// Mappers: Domain -> DTO
+
+func toContactResponse(c domain.Contact) ContactResponse {
+	return ContactResponse{
+		ID:                  c.ID,
+		PatientID:           c.PatientID,
+		Relationship:        c.Relationship,
+		Name:                c.Name,
+		Phone:               c.Phone,
+		PhoneDisplay:        domain.FormatPhone(c.Phone),
+		Email:               c.Email,
+		LegalRepresentative: c.LegalRepresentative,
+		CreatedBy:           c.CreatedBy,
+		CreatedAt:           c.CreatedAt,
+	}
+}
+
+func toContactResponseList(contacts []domain.Contact) []ContactResponse {
+	result := make([]ContactResponse, len(contacts))
+	for i, c := range contacts {
+		result[i] = toContactResponse(c)
+	}
+	return result
+}
+
+// Mappers: DTO -> Domain
+
+func toContactDomain(patientID string, req ContactRequest) domain.Contact {
+	return domain.Contact{
+		PatientID:           patientID,
+		Relationship:        req.Relationship,
+		Name:                req.Name,
+		Phone:               req.Phone,
+		Email:               req.Email,
+		LegalRepresentative: req.LegalRepresentative,
+	}
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// GetContacts lists the contacts of a patient
+// @Summary List contacts
+// @Description List the emergency contacts and legal representatives of a patient
+// @Tags Contacts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} ContactResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/contacts [get]
+func (h *HttpHandler) GetContacts(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get contacts request received", "patient_id", patientID)
+
+	contacts, err := h.app.Contact().GetContacts(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get contacts", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toContactResponseList(contacts))
+}
+
+// AddContact adds a contact to a patient
+// @Summary Add contact
+// @Description Add an emergency contact to a patient. Legal representatives (parents, guardians) can consent on behalf
+// @Description of patients under 16.
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param contact body ContactRequest true "Contact Info"
+// @Success 201 {object} ContactResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/contacts [post]
+func (h *HttpHandler) AddContact(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Add contact request received", "patient_id", patientID)
+
+	var req ContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode add contact request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contact := toContactDomain(patientID, req)
+	if err := h.app.Contact().AddContact(callerFromRequest(r), &contact); err != nil {
+		slog.Error("Failed to add contact", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toContactResponse(contact))
+}
+
+// UpdateContact replaces a contact of a patient
+// @Summary Update contact
+// @Description Replace the details of a patient's contact. Unmarking a legal representative revokes the active consents
+// @Description they gave on the patient's behalf.
+// @Tags Contacts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param contactId path string true "Contact ID"
+// @Param contact body ContactRequest true "Contact Info"
+// @Success 200 {object} ContactResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/contacts/{contactId} [put]
+func (h *HttpHandler) UpdateContact(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	contactID := r.PathValue("contactId")
+	slog.Debug("Update contact request received", "patient_id", patientID, "contact_id", contactID)
+
+	var req ContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode update contact request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contact := toContactDomain(patientID, req)
+	contact.ID = contactID
+	if err := h.app.Contact().UpdateContact(callerFromRequest(r), &contact); err != nil {
+		slog.Error("Failed to update contact", "patient_id", patientID, "contact_id", contactID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toContactResponse(contact))
+}
+
+// DeleteContact removes a contact from a patient
+// @Summary Delete contact
+// @Description Remove a contact from a patient. Active consents the contact gave on the patient's behalf are revoked.
+// @Tags Contacts
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param contactId path string true "Contact ID"
+// @Success 204 {string} string "No Content"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/contacts/{contactId} [delete]
+func (h *HttpHandler) DeleteContact(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	contactID := r.PathValue("contactId")
+	slog.Debug("Delete contact request received", "patient_id", patientID, "contact_id", contactID)
+
+	if err := h.app.Contact().DeleteContact(callerFromRequest(r), patientID, contactID); err != nil {
+		slog.Error("Failed to delete contact", "patient_id", patientID, "contact_id", contactID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -17,19 +17,45 @@ type RegisterRequest struct {
 	Password string `json:"password" example:"secure_password"`
 }
 
+// CreatePatientRequest takes the structured name. Name is still accepted
+// for older clients and split into given name and surnames when the
+// structured fields are missing.
 type CreatePatientRequest struct {
-	Name    string `json:"name" example:"Maria Garcia"`
-	DNI     string `json:"dni" example:"12345678Z"`
-	Email   string `json:"email" example:"maria@example.com"`
-	Phone   string `json:"phone" example:"+34600123456"`
-	Address string `json:"address" example:"Calle Mayor 1, Madrid"`
+	GivenName         string      `json:"given_name" example:"María"`
+	FirstSurname      string      `json:"first_surname" example:"García"`
+	SecondSurname     string      `json:"second_surname,omitempty" example:"López"`
+	Name              string      `json:"name,omitempty" example:"María García López"` // Deprecated: use the structured fields
+	DNI               string      `json:"dni" example:"12345678Z"`
+	BirthDate         string      `json:"birth_date,omitempty" example:"1980-05-17"` // YYYY-MM-DD
+	Sex               string      `json:"sex,omitempty" example:"female" enums:"male,female,other,unknown"`
+	Nationality       string      `json:"nationality,omitempty" example:"ES"`        // ISO 3166-1 alpha-2
+	PreferredLanguage string      `json:"preferred_language,omitempty" example:"es"` // ISO 639-1
+	Email             string      `json:"email" example:"maria@example.com"`
+	Phone             string      `json:"phone" example:"600 12 34 56"`                      // Spanish, or international with its country code
+	Address           string      `json:"address,omitempty" example:"Calle Mayor 1, Madrid"` // Deprecated: use postal_address
+	PostalAddress     *AddressDTO `json:"postal_address,omitempty"`
+}
+
+// AddressDTO is a structured postal address. The province of Spanish
+// addresses is filled from the postal code when omitted.
+type AddressDTO struct {
+	Street       string `json:"street,omitempty" example:"Calle Mayor"`
+	Number       string `json:"number,omitempty" example:"1"`
+	Floor        string `json:"floor,omitempty" example:"3º B"`
+	PostalCode   string `json:"postal_code,omitempty" example:"28013"`
+	City         string `json:"city,omitempty" example:"Madrid"`
+	Province     string `json:"province,omitempty" example:"28"`                          // INE province code
+	ProvinceName string `json:"province_name,omitempty" example:"Madrid" readonly:"true"` // Set in responses only
+	Country      string `json:"country,omitempty" example:"ES"`                           // ISO 3166-1 alpha-2, ES when omitted
 }
 
 type CreateDiagnosisRequest struct {
 	PatientID    string `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
 	Diagnosis    string `json:"diagnosis" example:"Gripe común"`
 	Prescription string `json:"prescription" example:"Ibuprofeno 600mg cada 8h"`
-	Date         string `json:"date" example:"2026-02-13T10:00:00Z"` // ISO 8601 format
+	Date         string `json:"date" example:"2026-02-13T10:00:00Z"`                         // ISO 8601 format
+	FollowUpDays *int   `json:"follow_up_in_days,omitempty" example:"14"`                    // Creates a pending follow-up due that many days later
+	EncounterID  string `json:"encounter_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPE"` // Open encounter the diagnosis is made in
 }
 
 // Response DTOs
@@ -39,34 +65,94 @@ type LoginResponse struct {
 }
 
 type PatientResponse struct {
-	ID      string `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
-	Name    string `json:"name" example:"Maria Garcia"`
-	DNI     string `json:"dni" example:"12345678X"`
-	Email   string `json:"email" example:"maria@example.com"`
-	Phone   string `json:"phone" example:"+34600123456"`
-	Address string `json:"address" example:"Calle Mayor 1, Madrid"`
+	ID                string      `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Name              string      `json:"name" example:"María García López"` // Display name, kept for older clients
+	GivenName         string      `json:"given_name" example:"María"`
+	FirstSurname      string      `json:"first_surname" example:"García"`
+	SecondSurname     string      `json:"second_surname,omitempty" example:"López"`
+	DNI               string      `json:"dni" example:"12345678X"`
+	BirthDate         string      `json:"birth_date,omitempty" example:"1980-05-17"`
+	Age               *int        `json:"age,omitempty" example:"45"`
+	Sex               string      `json:"sex,omitempty" example:"female"`
+	Nationality       string      `json:"nationality,omitempty" example:"ES"`
+	PreferredLanguage string      `json:"preferred_language,omitempty" example:"es"`
+	Email             string      `json:"email" example:"maria@example.com"`
+	Phone             string      `json:"phone" example:"+34600123456"` // E.164
+	PhoneDisplay      string      `json:"phone_display,omitempty" example:"+34 600 12 34 56"`
+	Address           string      `json:"address" example:"Calle Mayor 1, 3º B, 28013 Madrid"` // Single line, kept for older clients
+	PostalAddress     *AddressDTO `json:"postal_address,omitempty"`
+}
+
+type PatientSearchResponse struct {
+	PatientResponse
+	Score float64 `json:"score,omitempty" example:"0.83"`
 }
 
 type DiagnosisResponse struct {
-	ID           string          `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
-	PatientID    string          `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
-	Patient      PatientResponse `json:"patient,omitempty"`
-	Diagnosis    string          `json:"diagnosis" example:"Fiebre alta y tos persistente"`
-	Prescription string          `json:"prescription" example:"Paracetamol 1g cada 8 horas"`
-	Date         time.Time       `json:"date" example:"2026-02-13T18:23:00Z"`
+	ID           string               `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	PatientID    string               `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Patient      PatientResponse      `json:"patient,omitempty"`
+	Diagnosis    string               `json:"diagnosis" example:"Fiebre alta y tos persistente"`
+	Prescription string               `json:"prescription" example:"Paracetamol 1g cada 8 horas"`
+	Date         time.Time            `json:"date" example:"2026-02-13T18:23:00Z"`
+	EncounterID  string               `json:"encounter_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPE"`
+	Attachments  []AttachmentResponse `json:"attachments,omitempty"`
+	Match        *SearchMatchResponse `json:"match,omitempty"`
+}
+
+// SearchMatchResponse is only present in full-text and name search results
+type SearchMatchResponse struct {
+	Score               float64 `json:"score,omitempty" example:"3.2"`
+	NameScore           float64 `json:"name_score,omitempty" example:"0.83"`
+	DiagnosisSnippet    string  `json:"diagnosis_snippet,omitempty" example:"Fiebre alta y <mark>neumonía</mark> bilateral"`
+	PrescriptionSnippet string  `json:"prescription_snippet,omitempty" example:"Amoxicilina 1g cada 8 horas"`
 }
 
 // Mappers: Domain -> DTO
 
 func toPatientResponse(p domain.Patient) PatientResponse {
-	return PatientResponse{
-		ID:      p.ID,
-		Name:    p.Name,
-		DNI:     p.DNI,
-		Email:   p.Email,
-		Phone:   p.Phone,
-		Address: p.Address,
+	response := PatientResponse{
+		ID:                p.ID,
+		Name:              p.DisplayName(),
+		GivenName:         p.GivenName,
+		FirstSurname:      p.FirstSurname,
+		SecondSurname:     p.SecondSurname,
+		DNI:               p.DNI,
+		Sex:               p.Sex,
+		Nationality:       p.Nationality,
+		PreferredLanguage: p.PreferredLanguage,
+		Email:             p.Email,
+		Phone:             p.Phone,
+		PhoneDisplay:      p.FormattedPhone(),
+		Address:           p.Address.String(),
+	}
+	if !p.Address.IsEmpty() {
+		provinceName, _ := domain.ProvinceName(p.Address.Province)
+		response.PostalAddress = &AddressDTO{
+			Street:       p.Address.Street,
+			Number:       p.Address.Number,
+			Floor:        p.Address.Floor,
+			PostalCode:   p.Address.PostalCode,
+			City:         p.Address.City,
+			Province:     p.Address.Province,
+			ProvinceName: provinceName,
+			Country:      p.Address.Country,
+		}
+	}
+	if p.BirthDate != nil {
+		response.BirthDate = p.BirthDate.Format("2006-01-02")
+		age, _ := p.AgeAt(time.Now())
+		response.Age = &age
+	}
+	return response
+}
+
+func toPatientSearchResponseList(patients []domain.PatientSearchResult) []PatientSearchResponse {
+	result := make([]PatientSearchResponse, len(patients))
+	for i, p := range patients {
+		result[i] = PatientSearchResponse{PatientResponse: toPatientResponse(p.Patient), Score: p.Score}
 	}
+	return result
 }
 
 func toDiagnosisResponse(d domain.Diagnosis) DiagnosisResponse {
@@ -77,6 +163,21 @@ func toDiagnosisResponse(d domain.Diagnosis) DiagnosisResponse {
 		Diagnosis:    d.Diagnosis,
 		Prescription: d.Prescription,
 		Date:         d.Date,
+		EncounterID:  d.EncounterID,
+		Attachments:  toAttachmentResponseList(d.Attachments),
+		Match:        toSearchMatchResponse(d.Match),
+	}
+}
+
+func toSearchMatchResponse(m *domain.SearchMatch) *SearchMatchResponse {
+	if m == nil {
+		return nil
+	}
+	return &SearchMatchResponse{
+		Score:               m.Score,
+		NameScore:           m.NameScore,
+		DiagnosisSnippet:    m.DiagnosisSnippet,
+		PrescriptionSnippet: m.PrescriptionSnippet,
 	}
 }
 
@@ -91,13 +192,34 @@ func toDiagnosisResponseList(diagnostics []domain.Diagnosis) []DiagnosisResponse
 // Mappers: DTO -> Domain
 
 func toPatientDomain(req CreatePatientRequest) domain.Patient {
-	return domain.Patient{
-		Name:    req.Name,
-		DNI:     req.DNI,
-		Email:   req.Email,
-		Phone:   req.Phone,
-		Address: req.Address,
+	patient := domain.Patient{
+		GivenName:         req.GivenName,
+		FirstSurname:      req.FirstSurname,
+		SecondSurname:     req.SecondSurname,
+		DNI:               req.DNI,
+		Sex:               req.Sex,
+		Nationality:       req.Nationality,
+		PreferredLanguage: req.PreferredLanguage,
+		Email:             req.Email,
+		Phone:             req.Phone,
+	}
+	if req.GivenName == "" && req.FirstSurname == "" && req.SecondSurname == "" {
+		patient.SetName(req.Name)
+	}
+	if req.PostalAddress != nil {
+		patient.Address = domain.Address{
+			Street:     req.PostalAddress.Street,
+			Number:     req.PostalAddress.Number,
+			Floor:      req.PostalAddress.Floor,
+			PostalCode: req.PostalAddress.PostalCode,
+			City:       req.PostalAddress.City,
+			Province:   req.PostalAddress.Province,
+			Country:    req.PostalAddress.Country,
+		}
+	} else {
+		patient.Address.Street = req.Address
 	}
+	return patient
 }
 
 func toDiagnosisDomain(req CreateDiagnosisRequest) domain.Diagnosis {
@@ -106,5 +228,6 @@ func toDiagnosisDomain(req CreateDiagnosisRequest) domain.Diagnosis {
 		PatientID:    req.PatientID,
 		Diagnosis:    req.Diagnosis,
 		Prescription: req.Prescription,
+		EncounterID:  req.EncounterID,
 	}
 }
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type SOAPNoteRequest struct {
+	Subjective string `json:"subjective,omitempty" example:"Dolor torácico opresivo de 2 horas de evolución"`
+	Objective  string `json:"objective,omitempty" example:"TA 150/95, FC 98 lpm. ECG sin alteraciones agudas"`
+	Assessment string `json:"assessment,omitempty" example:"Dolor torácico atípico, probable origen musculoesquelético"`
+	Plan       string `json:"plan,omitempty" example:"Analgesia y control en 48 horas"`
+}
+
+type OpenEncounterRequest struct {
+	PatientID string           `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	StartedAt string           `json:"started_at,omitempty" example:"2026-02-13T10:00:00Z"` // ISO 8601 format, now when omitted
+	Note      *SOAPNoteRequest `json:"note,omitempty"`
+}
+
+type CloseEncounterRequest struct {
+	Note *SOAPNoteRequest `json:"note,omitempty"` // Final version of the note, the current one is kept when omitted
+}
+
+// Response DTOs
+
+type SOAPNoteResponse struct {
+	Subjective string `json:"subjective" example:"Dolor torácico opresivo de 2 horas de evolución"`
+	Objective  string `json:"objective" example:"TA 150/95, FC 98 lpm. ECG sin alteraciones agudas"`
+	Assessment string `json:"assessment" example:"Dolor torácico atípico, probable origen musculoesquelético"`
+	Plan       string `json:"plan" example:"Analgesia y control en 48 horas"`
+}
+
+type EncounterResponse struct {
+	ID             string              `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPE"`
+	PatientID      string              `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	PractitionerID string              `json:"practitioner_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPN"`
+	Status         string              `json:"status" example:"open" enums:"open,closed"`
+	Note           SOAPNoteResponse    `json:"note"`
+	StartedAt      time.Time           `json:"started_at" example:"2026-02-13T10:00:00Z"`
+	ClosedAt       *time.Time          `json:"closed_at,omitempty" example:"2026-02-13T10:25:00Z"`
+	Diagnoses      []DiagnosisResponse `json:"diagnoses,omitempty"` // Only when reading a single encounter
+}
+
+// Mappers: Domain -> DTO
+
+func toEncounterResponse(e domain.Encounter) EncounterResponse {
+	response := EncounterResponse{
+		ID:             e.ID,
+		PatientID:      e.PatientID,
+		PractitionerID: e.PractitionerID,
+		Status:         e.Status,
+		Note: SOAPNoteResponse{
+			Subjective: e.Note.Subjective,
+			Objective:  e.Note.Objective,
+			Assessment: e.Note.Assessment,
+			Plan:       e.Note.Plan,
+		},
+		StartedAt: e.StartedAt,
+		ClosedAt:  e.ClosedAt,
+	}
+	if len(e.Diagnoses) > 0 {
+		response.Diagnoses = toDiagnosisResponseList(e.Diagnoses)
+	}
+	return response
+}
+
+func toEncounterResponseList(encounters []domain.Encounter) []EncounterResponse {
+	result := make([]EncounterResponse, len(encounters))
+	for i, e := range encounters {
+		result[i] = toEncounterResponse(e)
+	}
+	return result
+}
+
+// Mappers: DTO -> Domain
+
+func toSOAPNoteDomain(req SOAPNoteRequest) domain.SOAPNote {
+	return domain.SOAPNote{
+		Subjective: req.Subjective,
+		Objective:  req.Objective,
+		Assessment: req.Assessment,
+		Plan:       req.Plan,
+	}
+}
+
+func toEncounterDomain(req OpenEncounterRequest, startedAt time.Time) domain.Encounter {
+	// Time parsing is handled in the handler
+	encounter := domain.Encounter{PatientID: req.PatientID, StartedAt: startedAt}
+	if req.Note != nil {
+		encounter.Note = toSOAPNoteDomain(*req.Note)
+	}
+	return encounter
+}
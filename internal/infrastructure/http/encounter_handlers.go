@@ -0,0 +1,201 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// OpenEncounter opens an encounter for a patient's visit
+// @Summary Open encounter
+// @Description Open an encounter for a visit of a patient to the caller, optionally with a first version of its SOAP
+// @Description note. Diagnoses made during the visit reference the encounter through their encounter_id.
+// @Tags Encounters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param encounter body OpenEncounterRequest true "Encounter"
+// @Success 201 {object} EncounterResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /encounters [post]
+func (h *HttpHandler) OpenEncounter(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("Open encounter request received")
+
+	var req OpenEncounterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode open encounter request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var startedAt time.Time
+	if req.StartedAt != "" {
+		var err error
+		startedAt, err = time.Parse(time.RFC3339, req.StartedAt)
+		if err != nil {
+			slog.Warn("Invalid started_at format in encounter request", "started_at", req.StartedAt)
+			http.Error(w, "Invalid started_at format, use ISO 8601", http.StatusBadRequest)
+			return
+		}
+	}
+
+	encounter := toEncounterDomain(req, startedAt)
+	if err := h.app.Encounter().OpenEncounter(callerFromRequest(r), &encounter); err != nil {
+		slog.Error("Failed to open encounter", "patient_id", req.PatientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toEncounterResponse(encounter))
+}
+
+// GetEncounter returns an encounter with its diagnoses
+// @Summary Get encounter
+// @Description Get an encounter with its SOAP note and the diagnoses, with their prescriptions, made during it
+// @Tags Encounters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Encounter ID"
+// @Success 200 {object} EncounterResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /encounters/{id} [get]
+func (h *HttpHandler) GetEncounter(w http.ResponseWriter, r *http.Request) {
+	encounterID := r.PathValue("id")
+	slog.Debug("Get encounter request received", "encounter_id", encounterID)
+
+	encounter, err := h.app.Encounter().GetEncounter(callerFromRequest(r), encounterID)
+	if err != nil {
+		slog.Error("Failed to get encounter", "encounter_id", encounterID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toEncounterResponse(*encounter))
+}
+
+// UpdateEncounterNote replaces the SOAP note of an open encounter
+// @Summary Update encounter note
+// @Description Replace the SOAP note of an encounter while it is open
+// @Tags Encounters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Encounter ID"
+// @Param note body SOAPNoteRequest true "SOAP note"
+// @Success 200 {object} EncounterResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /encounters/{id}/note [put]
+func (h *HttpHandler) UpdateEncounterNote(w http.ResponseWriter, r *http.Request) {
+	encounterID := r.PathValue("id")
+	slog.Debug("Update encounter note request received", "encounter_id", encounterID)
+
+	var req SOAPNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode encounter note request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	encounter, err := h.app.Encounter().UpdateEncounterNote(callerFromRequest(r), encounterID, toSOAPNoteDomain(req))
+	if err != nil {
+		slog.Error("Failed to update encounter note", "encounter_id", encounterID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toEncounterResponse(*encounter))
+}
+
+// CloseEncounter closes an encounter
+// @Summary Close encounter
+// @Description Close an encounter, optionally with the final version of its SOAP note. An encounter cannot be closed
+// @Description without a note, and no diagnosis can be added to it once closed.
+// @Tags Encounters
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Encounter ID"
+// @Param closing body CloseEncounterRequest false "Final note"
+// @Success 200 {object} EncounterResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /encounters/{id}/close [post]
+func (h *HttpHandler) CloseEncounter(w http.ResponseWriter, r *http.Request) {
+	encounterID := r.PathValue("id")
+	slog.Debug("Close encounter request received", "encounter_id", encounterID)
+
+	// The body is optional, the current note is kept without one
+	var req CloseEncounterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		slog.Error("Failed to decode close encounter request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var note *domain.SOAPNote
+	if req.Note != nil {
+		final := toSOAPNoteDomain(*req.Note)
+		note = &final
+	}
+
+	encounter, err := h.app.Encounter().CloseEncounter(callerFromRequest(r), encounterID, note)
+	if err != nil {
+		slog.Error("Failed to close encounter", "encounter_id", encounterID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toEncounterResponse(*encounter))
+}
+
+// GetPatientEncounters lists the encounters of a patient
+// @Summary List patient encounters
+// @Description List the encounters of a patient with their SOAP notes, the oldest first
+// @Tags Encounters
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} EncounterResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/encounters [get]
+func (h *HttpHandler) GetPatientEncounters(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get patient encounters request received", "patient_id", patientID)
+
+	encounters, err := h.app.Encounter().GetPatientEncounters(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get patient encounters", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toEncounterResponseList(encounters))
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type ErasePatientRequest struct {
+	Reason string `json:"reason" example:"Solicitud de supresión recibida el 2026-02-10"`
+}
+
+type ErasureResponse struct {
+	ID          string    `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	PatientID   string    `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Reason      string    `json:"reason" example:"Solicitud de supresión recibida el 2026-02-10"`
+	ErasedAt    time.Time `json:"erased_at" example:"2026-02-13T18:23:00Z"`
+	RetainUntil time.Time `json:"retain_until" example:"2031-02-12T18:23:00Z"`
+}
+
+func toErasureResponse(e domain.Erasure) ErasureResponse {
+	return ErasureResponse{
+		ID:          e.ID,
+		PatientID:   e.PatientID,
+		Reason:      e.Reason,
+		ErasedAt:    e.ErasedAt,
+		RetainUntil: e.RetainUntil,
+	}
+}
+
+// ErasePatient applies a GDPR erasure request to a patient
+// @Summary Erase patient
+// @Description Anonymize the identifying data of a patient (name, DNI, email, phone, address).
+// @Description Clinical records are kept until the legal retention period ends. Restricted to administrators.
+// @Tags Patients
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param request body ErasePatientRequest true "Erasure reason"
+// @Success 200 {object} ErasureResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/erasure [post]
+func (h *HttpHandler) ErasePatient(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Erase patient request received", "patient_id", patientID)
+
+	var req ErasePatientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode erase patient request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	erasure, err := h.app.Erasure().ErasePatient(callerFromRequest(r), patientID, req.Reason)
+	if err != nil {
+		slog.Error("Failed to erase patient", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toErasureResponse(*erasure))
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Response DTOs
+
+type PatientExportResponse struct {
+	GeneratedAt   time.Time                `json:"generated_at" example:"2026-02-13T18:23:00Z"`
+	GeneratedBy   string                   `json:"generated_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Patient       PatientResponse          `json:"patient"`
+	Diagnoses     []ExportedDiagnosis      `json:"diagnoses"`
+	Prescriptions []PrescriptionResponse   `json:"prescriptions"`
+	Consents      []ConsentResponse        `json:"consents"`
+	Contacts      []ContactResponse        `json:"contacts"`
+	Appointments  []AppointmentResponse    `json:"appointments"`
+	Observations  []ObservationResponse    `json:"observations"`
+	LabResults    []LabResultResponse      `json:"lab_results"`
+	Attachments   []AttachmentResponse     `json:"attachments"`
+	Vaccinations  []VaccinationResponse    `json:"vaccinations"`
+	Referrals     []ReferralResponse       `json:"referrals"`
+	Encounters    []EncounterResponse      `json:"encounters"`
+	AccessLog     []AccessLogEntryResponse `json:"access_log"`
+}
+
+type ExportedDiagnosis struct {
+	ID           string    `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Diagnosis    string    `json:"diagnosis" example:"Fiebre alta y tos persistente"`
+	Prescription string    `json:"prescription" example:"Paracetamol 1g cada 8 horas"`
+	Date         time.Time `json:"date" example:"2026-02-13T18:23:00Z"`
+}
+
+type PrescriptionResponse struct {
+	DiagnosisID  string    `json:"diagnosis_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Prescription string    `json:"prescription" example:"Paracetamol 1g cada 8 horas"`
+	Date         time.Time `json:"date" example:"2026-02-13T18:23:00Z"`
+}
+
+type AccessLogEntryResponse struct {
+	UserID     string    `json:"user_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Action     string    `json:"action" example:"read"`
+	BreakGlass bool      `json:"break_glass" example:"false"`
+	At         time.Time `json:"at" example:"2026-02-13T18:23:00Z"`
+}
+
+// Mappers: Domain -> DTO
+
+func toPatientExportResponse(e domain.PatientExport) PatientExportResponse {
+	diagnoses := make([]ExportedDiagnosis, len(e.Diagnoses))
+	for i, d := range e.Diagnoses {
+		diagnoses[i] = ExportedDiagnosis{
+			ID:           d.ID,
+			Diagnosis:    d.Diagnosis,
+			Prescription: d.Prescription,
+			Date:         d.Date,
+		}
+	}
+
+	prescriptions := make([]PrescriptionResponse, len(e.Prescriptions))
+	for i, p := range e.Prescriptions {
+		prescriptions[i] = PrescriptionResponse{
+			DiagnosisID:  p.DiagnosisID,
+			Prescription: p.Prescription,
+			Date:         p.Date,
+		}
+	}
+
+	accessLog := make([]AccessLogEntryResponse, len(e.AccessLog))
+	for i, a := range e.AccessLog {
+		accessLog[i] = AccessLogEntryResponse{
+			UserID:     a.UserID,
+			Action:     a.Action,
+			BreakGlass: a.BreakGlass,
+			At:         a.At,
+		}
+	}
+
+	return PatientExportResponse{
+		GeneratedAt:   e.GeneratedAt,
+		GeneratedBy:   e.GeneratedBy,
+		Patient:       toPatientResponse(e.Patient),
+		Diagnoses:     diagnoses,
+		Prescriptions: prescriptions,
+		Consents:      toConsentResponseList(e.Consents),
+		Contacts:      toContactResponseList(e.Contacts),
+		Appointments:  toAppointmentResponseList(e.Appointments),
+		Observations:  toObservationResponseList(e.Observations),
+		LabResults:    toLabResultResponseList(e.LabResults),
+		Attachments:   toAttachmentResponseList(e.Attachments),
+		Vaccinations:  toVaccinationResponseList(e.Vaccinations),
+		Referrals:     toReferralResponseList(e.Referrals),
+		Encounters:    toEncounterResponseList(e.Encounters),
+		AccessLog:     accessLog,
+	}
+}
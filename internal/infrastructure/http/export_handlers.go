@@ -0,0 +1,226 @@
+package http
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	exportFormatJSON = "json"
+	exportFormatZip  = "zip"
+)
+
+// ExportPatient returns every piece of data held about a patient
+// @Summary Export patient data
+// @Description GDPR right-of-access export: patient record, diagnoses, prescriptions, consents, contacts, appointments, observations, lab results, attachments, vaccinations, referrals, encounters and access log.
+// @Description Use format=zip to get the JSON bundle together with a human-readable summary and a copy of the attached files. Restricted to administrators.
+// @Tags Patients
+// @Produce json
+// @Produce application/zip
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param format query string false "Bundle format" Enums(json, zip)
+// @Success 200 {object} PatientExportResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/export [get]
+func (h *HttpHandler) ExportPatient(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatJSON
+	}
+	slog.Debug("Export patient request received", "patient_id", patientID, "format", format)
+
+	if format != exportFormatJSON && format != exportFormatZip {
+		slog.Warn("Invalid export format", "format", format)
+		http.Error(w, "Invalid format, use json or zip", http.StatusBadRequest)
+		return
+	}
+
+	export, err := h.app.Export().ExportPatient(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to export patient", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+	response := toPatientExportResponse(*export)
+
+	if format == exportFormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	filename := fmt.Sprintf("patient-%s-%s.zip", patientID, export.GeneratedAt.Format("20060102"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	openAttachment := func(attachmentID string) (io.ReadCloser, error) {
+		return h.app.Export().OpenExportedAttachment(callerFromRequest(r), patientID, attachmentID)
+	}
+	if err := writeExportZip(w, response, openAttachment); err != nil {
+		slog.Error("Failed to write export archive", "patient_id", patientID, "error", err)
+	}
+}
+
+// writeExportZip writes the export bundle as a zip archive with the JSON data,
+// a plain text summary and a copy of the attached files
+func writeExportZip(w io.Writer, export PatientExportResponse, openAttachment func(id string) (io.ReadCloser, error)) error {
+	zw := zip.NewWriter(w)
+
+	data, err := zw.Create("export.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(data)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(export); err != nil {
+		return err
+	}
+
+	summary, err := zw.Create("summary.txt")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(summary, exportSummary(export)); err != nil {
+		return err
+	}
+
+	for _, a := range export.Attachments {
+		if err := writeExportAttachment(zw, a, openAttachment); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeExportAttachment(zw *zip.Writer, a AttachmentResponse, openAttachment func(id string) (io.ReadCloser, error)) error {
+	content, err := openAttachment(a.ID)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	file, err := zw.Create(exportAttachmentPath(a))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, content)
+	return err
+}
+
+// exportAttachmentPath names an attached file in the export archive. The ID
+// keeps names unique and the uploaded name is reduced to its last element,
+// so it cannot point outside the attachments directory.
+func exportAttachmentPath(a AttachmentResponse) string {
+	return "attachments/" + a.ID + "-" + path.Base(strings.ReplaceAll(a.FileName, "\\", "/"))
+}
+
+// exportSummary renders a human-readable overview of the export bundle
+func exportSummary(e PatientExportResponse) string {
+	var b strings.Builder
+	p := e.Patient
+
+	fmt.Fprintf(&b, "PERSONAL DATA EXPORT\n")
+	fmt.Fprintf(&b, "Generated at: %s\n\n", e.GeneratedAt.Format(time.RFC3339))
+
+	fmt.Fprintf(&b, "Patient\n")
+	fmt.Fprintf(&b, "  Name:    %s\n", p.Name)
+	fmt.Fprintf(&b, "  DNI:     %s\n", p.DNI)
+	fmt.Fprintf(&b, "  Born:    %s\n", p.BirthDate)
+	fmt.Fprintf(&b, "  Sex:     %s\n", p.Sex)
+	fmt.Fprintf(&b, "  Email:   %s\n", p.Email)
+	fmt.Fprintf(&b, "  Phone:   %s\n", p.PhoneDisplay)
+	fmt.Fprintf(&b, "  Address: %s\n\n", p.Address)
+
+	fmt.Fprintf(&b, "Diagnoses (%d)\n", len(e.Diagnoses))
+	for _, d := range e.Diagnoses {
+		fmt.Fprintf(&b, "  %s  %s\n", d.Date.Format("2006-01-02"), d.Diagnosis)
+	}
+
+	fmt.Fprintf(&b, "\nPrescriptions (%d)\n", len(e.Prescriptions))
+	for _, pr := range e.Prescriptions {
+		fmt.Fprintf(&b, "  %s  %s\n", pr.Date.Format("2006-01-02"), pr.Prescription)
+	}
+
+	fmt.Fprintf(&b, "\nConsents (%d)\n", len(e.Consents))
+	for _, c := range e.Consents {
+		status := "granted"
+		if !c.Granted {
+			status = "revoked"
+		}
+		fmt.Fprintf(&b, "  %s  %s / %s: %s\n", c.GrantedAt.Format("2006-01-02"), c.Purpose, c.Scope, status)
+	}
+
+	fmt.Fprintf(&b, "\nContacts (%d)\n", len(e.Contacts))
+	for _, c := range e.Contacts {
+		note := ""
+		if c.LegalRepresentative {
+			note = " (legal representative)"
+		}
+		fmt.Fprintf(&b, "  %s, %s  %s %s%s\n", c.Name, c.Relationship, c.PhoneDisplay, c.Email, note)
+	}
+
+	fmt.Fprintf(&b, "\nAppointments (%d)\n", len(e.Appointments))
+	for _, a := range e.Appointments {
+		when := a.DueDate
+		if a.Start != nil {
+			when = a.Start.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(&b, "  %s  %s: %s\n", when, a.Reason, a.Status)
+	}
+
+	fmt.Fprintf(&b, "\nObservations (%d)\n", len(e.Observations))
+	for _, o := range e.Observations {
+		fmt.Fprintf(&b, "  %s  %s: %g %s\n", o.EffectiveAt.Format("2006-01-02"), o.Display, o.Value, o.Unit)
+	}
+
+	fmt.Fprintf(&b, "\nLab results (%d)\n", len(e.LabResults))
+	for _, l := range e.LabResults {
+		fmt.Fprintf(&b, "  %s  %s, %s: %g %s %s\n", l.CollectedAt.Format("2006-01-02"), l.Panel, l.Analyte, l.Value, l.Unit, l.Flag)
+	}
+
+	fmt.Fprintf(&b, "\nAttachments (%d)\n", len(e.Attachments))
+	for _, a := range e.Attachments {
+		fmt.Fprintf(&b, "  %s  %s, %s: %s\n", a.CreatedAt.Format("2006-01-02"), a.FileName, a.ContentType, exportAttachmentPath(a))
+	}
+
+	fmt.Fprintf(&b, "\nVaccinations (%d)\n", len(e.Vaccinations))
+	for _, v := range e.Vaccinations {
+		fmt.Fprintf(&b, "  %s  %s, dose %d, lot %s\n", v.AdministeredAt.Format("2006-01-02"), v.VaccineCode, v.DoseNumber, v.Lot)
+	}
+
+	fmt.Fprintf(&b, "\nReferrals (%d)\n", len(e.Referrals))
+	for _, rf := range e.Referrals {
+		to := rf.ToSpecialty
+		if rf.ToPractitionerID != "" {
+			to = "practitioner " + rf.ToPractitionerID
+		}
+		fmt.Fprintf(&b, "  %s  to %s: %s (%s)\n", rf.CreatedAt.Format("2006-01-02"), to, rf.Reason, rf.Status)
+	}
+
+	fmt.Fprintf(&b, "\nEncounters (%d)\n", len(e.Encounters))
+	for _, en := range e.Encounters {
+		fmt.Fprintf(&b, "  %s  %s: %s\n", en.StartedAt.Format("2006-01-02"), en.Status, en.Note.Assessment)
+	}
+
+	fmt.Fprintf(&b, "\nAccesses to your data (%d)\n", len(e.AccessLog))
+	for _, a := range e.AccessLog {
+		note := ""
+		if a.BreakGlass {
+			note = " (emergency access)"
+		}
+		fmt.Fprintf(&b, "  %s  %s by user %s%s\n", a.At.Format(time.RFC3339), a.Action, a.UserID, note)
+	}
+
+	return b.String()
+}
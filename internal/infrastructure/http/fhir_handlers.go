@@ -0,0 +1,475 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/infrastructure/fhir"
+)
+
+// fhirBasePath is where the FHIR R4 facade is served
+const fhirBasePath = "/fhir/r4"
+
+// fhirOpenEnd closes the date range of a diagnosis search started with ge or
+// gt only, a start alone selecting a single day there
+var fhirOpenEnd = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// FHIRMetadata returns the capability statement of the FHIR facade
+// @Summary FHIR capability statement
+// @Description Describe the FHIR R4 resources, interactions and search parameters served under /fhir/r4
+// @Tags FHIR
+// @Produce json
+// @Success 200 {object} fhir.CapabilityStatement
+// @Router /fhir/r4/metadata [get]
+func (h *HttpHandler) FHIRMetadata(w http.ResponseWriter, r *http.Request) {
+	writeFHIR(w, http.StatusOK, fhir.NewCapabilityStatement(fhirBaseURL(r), time.Now()))
+}
+
+// FHIRReadPatient returns a patient as a FHIR Patient
+// @Summary FHIR read Patient
+// @Description Read a patient as a FHIR Patient, with the DNI as identifier. Records merged as duplicates are inactive
+// @Description and link to the surviving one. Same access rules as GET /patients/{id}.
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {object} fhir.Patient
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/Patient/{id} [get]
+func (h *HttpHandler) FHIRReadPatient(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("FHIR read Patient request received", "patient_id", patientID)
+
+	patient, err := h.app.Patient().GetPatient(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to read FHIR Patient", "patient_id", patientID, "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+	writeFHIR(w, http.StatusOK, fhir.FromPatient(*patient))
+}
+
+// FHIRSearchPatients searches patients and returns them in a FHIR Bundle
+// @Summary FHIR search Patient
+// @Description Search the patients the caller can access. name matches any part of the name ignoring case and accents;
+// @Description identifier matches the DNI, with or without the system (urn:oid:1.3.6.1.4.1.19126.3|12345678Z).
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param name query string false "Any part of the name"
+// @Param identifier query string false "DNI, optionally preceded by its system and |"
+// @Param _count query int false "Page size, 50 by default and at most 200"
+// @Param _cursor query string false "Cursor of the page, from the next link of the previous one"
+// @Success 200 {object} fhir.Bundle
+// @Failure 400 {object} fhir.OperationOutcome
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/Patient [get]
+func (h *HttpHandler) FHIRSearchPatients(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	slog.Debug("FHIR search Patient request received", "has_name", query.Has("name"), "has_identifier", query.Has("identifier"))
+
+	page, err := pageFromQuery(query, "_count", "_cursor")
+	if err != nil {
+		slog.Warn("Invalid FHIR Patient search", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	filter := domain.PatientFilter{Page: page}
+	if name := query.Get("name"); name != "" {
+		filter.Name.Name = &name
+	}
+	if identifier := query.Get("identifier"); identifier != "" {
+		system, dni, hasSystem := fhir.ParseToken(identifier)
+		if hasSystem && system != fhir.DNISystem {
+			// No other identifier is held
+			writeSearchBundle(w, r, nil, "")
+			return
+		}
+		filter.DNI = &dni
+	}
+
+	patients, err := h.app.Patient().ListPatients(callerFromRequest(r), filter)
+	if err != nil {
+		slog.Error("Failed to search FHIR Patient", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	base := fhirBaseURL(r)
+	entries := make([]fhir.BundleEntry, 0, len(patients.Patients))
+	for _, p := range patients.Patients {
+		entry, err := fhir.NewSearchEntry(base, fhir.ResourcePatient, p.Patient.ID, fhir.FromPatient(p.Patient))
+		if err != nil {
+			writeOperationOutcome(w, err)
+			return
+		}
+		if filter.Name.Name != nil {
+			score := p.Score
+			entry.Search.Score = &score
+		}
+		entries = append(entries, entry)
+	}
+
+	slog.Info("FHIR Patient search completed", "count", len(entries))
+	writeSearchBundle(w, r, entries, patients.NextCursor)
+}
+
+// FHIRCreatePatient records a patient sent as a FHIR Patient
+// @Summary FHIR create Patient
+// @Description Record a new patient from a FHIR Patient. The DNI is read from the identifier with system
+// @Description urn:oid:1.3.6.1.4.1.19126.3 and the surnames from the family name. The creator joins the care team.
+// @Description Integration clients cannot create patients.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param patient body fhir.Patient true "Patient resource"
+// @Success 201 {object} fhir.Patient
+// @Failure 400 {object} fhir.OperationOutcome
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/Patient [post]
+func (h *HttpHandler) FHIRCreatePatient(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("FHIR create Patient request received")
+	var resource fhir.Patient
+	if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+		slog.Error("Failed to decode FHIR Patient", "error", err)
+		writeOperationOutcomeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	patient, err := fhir.ToPatient(resource)
+	if err == nil {
+		err = h.app.Patient().CreatePatient(callerFromRequest(r), &patient)
+	}
+	if err != nil {
+		slog.Error("Failed to create FHIR Patient", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	slog.Info("FHIR Patient created", "patient_id", patient.ID)
+	w.Header().Set("Location", fhirBaseURL(r)+"/"+fhir.NewReference(fhir.ResourcePatient, patient.ID))
+	writeFHIR(w, http.StatusCreated, fhir.FromPatient(patient))
+}
+
+// FHIRReadCondition returns a diagnosis as a FHIR Condition
+// @Summary FHIR read Condition
+// @Description Read a diagnosis as a FHIR Condition. Integration clients need the patient's consent to share diagnoses.
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Diagnosis ID"
+// @Success 200 {object} fhir.Condition
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} fhir.OperationOutcome
+// @Failure 404 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/Condition/{id} [get]
+func (h *HttpHandler) FHIRReadCondition(w http.ResponseWriter, r *http.Request) {
+	diagnosisID := r.PathValue("id")
+	slog.Debug("FHIR read Condition request received", "diagnosis_id", diagnosisID)
+
+	diagnosis, err := h.app.Patient().GetDiagnosis(callerFromRequest(r), diagnosisID)
+	if err != nil {
+		slog.Error("Failed to read FHIR Condition", "diagnosis_id", diagnosisID, "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+	writeFHIR(w, http.StatusOK, fhir.FromDiagnosis(*diagnosis))
+}
+
+// FHIRSearchConditions searches diagnoses and returns them in a FHIR Bundle
+// @Summary FHIR search Condition
+// @Description Search the diagnoses of the patients the caller can access, by patient and recorded date. recorded-date
+// @Description takes the eq, ge, gt, le and lt prefixes at day precision and can be repeated to give a range.
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param subject query string false "Patient reference, e.g. Patient/01HMGNBPJNX0G2BZXJ7XW1RHPR"
+// @Param patient query string false "Patient ID or reference, the same patient as subject when both are given"
+// @Param recorded-date query []string false "Recorded date with prefix, e.g. ge2026-01-01" collectionFormat(multi)
+// @Param _count query int false "Page size, 50 by default and at most 200"
+// @Param _cursor query string false "Cursor of the page, from the next link of the previous one"
+// @Success 200 {object} fhir.Bundle
+// @Failure 400 {object} fhir.OperationOutcome
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/Condition [get]
+func (h *HttpHandler) FHIRSearchConditions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	slog.Debug("FHIR search Condition request received", "subject", query.Get("subject"), "recorded_date", query["recorded-date"])
+
+	filter, err := diagnosisFilterFromFHIR(query.Get("subject"), query.Get("patient"), query["recorded-date"])
+	if err != nil {
+		slog.Warn("Invalid FHIR Condition search", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+	if filter.IsEmpty() {
+		writeOperationOutcomeStatus(w, http.StatusBadRequest, "At least one search parameter is required")
+		return
+	}
+	if filter.Page, err = pageFromQuery(query, "_count", "_cursor"); err != nil {
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	diagnostics, err := h.app.Patient().GetDiagnostics(callerFromRequest(r), filter)
+	if err != nil {
+		slog.Error("Failed to search FHIR Condition", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	base := fhirBaseURL(r)
+	entries := make([]fhir.BundleEntry, 0, len(diagnostics.Diagnoses))
+	for _, d := range diagnostics.Diagnoses {
+		entry, err := fhir.NewSearchEntry(base, fhir.ResourceCondition, d.ID, fhir.FromDiagnosis(d))
+		if err != nil {
+			writeOperationOutcome(w, err)
+			return
+		}
+		entries = append(entries, entry)
+	}
+
+	slog.Info("FHIR Condition search completed", "count", len(entries))
+	writeSearchBundle(w, r, entries, diagnostics.NextCursor)
+}
+
+// FHIRCreateCondition records a diagnosis sent as a FHIR Condition
+// @Summary FHIR create Condition
+// @Description Record a diagnosis from a FHIR Condition: code.text is the diagnosis, subject the patient and
+// @Description recordedDate the date (now when omitted). An encounter reference adds it to that open encounter.
+// @Tags FHIR
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param condition body fhir.Condition true "Condition resource"
+// @Success 201 {object} fhir.Condition
+// @Failure 400 {object} fhir.OperationOutcome
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} fhir.OperationOutcome
+// @Failure 409 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/Condition [post]
+func (h *HttpHandler) FHIRCreateCondition(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("FHIR create Condition request received")
+	var resource fhir.Condition
+	if err := json.NewDecoder(r.Body).Decode(&resource); err != nil {
+		slog.Error("Failed to decode FHIR Condition", "error", err)
+		writeOperationOutcomeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	diagnosis, err := fhir.ToDiagnosis(resource)
+	if err == nil {
+		_, err = h.app.Patient().CreateDiagnosis(callerFromRequest(r), &diagnosis, nil)
+	}
+	if err != nil {
+		slog.Error("Failed to create FHIR Condition", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	slog.Info("FHIR Condition created", "diagnosis_id", diagnosis.ID, "patient_id", diagnosis.PatientID)
+	w.Header().Set("Location", fhirBaseURL(r)+"/"+fhir.NewReference(fhir.ResourceCondition, diagnosis.ID))
+	writeFHIR(w, http.StatusCreated, fhir.FromDiagnosis(diagnosis))
+}
+
+// FHIRReadMedicationRequest returns the prescription of a diagnosis as a FHIR MedicationRequest
+// @Summary FHIR read MedicationRequest
+// @Description Read the prescription issued along with a diagnosis as a FHIR MedicationRequest, which shares the
+// @Description diagnosis ID and references its Condition as reason. Integration clients need the patient's consent to
+// @Description share prescriptions, otherwise the prescription is not found.
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Diagnosis ID"
+// @Success 200 {object} fhir.MedicationRequest
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} fhir.OperationOutcome
+// @Failure 404 {object} fhir.OperationOutcome
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/MedicationRequest/{id} [get]
+func (h *HttpHandler) FHIRReadMedicationRequest(w http.ResponseWriter, r *http.Request) {
+	diagnosisID := r.PathValue("id")
+	slog.Debug("FHIR read MedicationRequest request received", "diagnosis_id", diagnosisID)
+
+	diagnosis, err := h.app.Patient().GetDiagnosis(callerFromRequest(r), diagnosisID)
+	if err != nil {
+		slog.Error("Failed to read FHIR MedicationRequest", "diagnosis_id", diagnosisID, "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+	resource, err := fhir.FromPrescription(*diagnosis)
+	if err != nil {
+		writeOperationOutcome(w, err)
+		return
+	}
+	writeFHIR(w, http.StatusOK, resource)
+}
+
+// FHIRSearchMedicationRequests searches prescriptions and returns them in a FHIR Bundle
+// @Summary FHIR search MedicationRequest
+// @Description Search the prescriptions of the patients the caller can access, by patient and date of issue. authoredon
+// @Description takes the eq, ge, gt, le and lt prefixes at day precision, so a dispensing system can poll the new ones
+// @Description with authoredon=ge<last poll day>.
+// @Tags FHIR
+// @Produce json
+// @Security BearerAuth
+// @Param subject query string false "Patient reference, e.g. Patient/01HMGNBPJNX0G2BZXJ7XW1RHPR"
+// @Param patient query string false "Patient ID or reference, the same patient as subject when both are given"
+// @Param authoredon query []string false "Date of issue with prefix, e.g. ge2026-01-01" collectionFormat(multi)
+// @Param _count query int false "Page size, 50 by default and at most 200"
+// @Param _cursor query string false "Cursor of the page, from the next link of the previous one"
+// @Success 200 {object} fhir.Bundle
+// @Failure 400 {object} fhir.OperationOutcome
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {object} fhir.OperationOutcome
+// @Router /fhir/r4/MedicationRequest [get]
+func (h *HttpHandler) FHIRSearchMedicationRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	slog.Debug("FHIR search MedicationRequest request received", "subject", query.Get("subject"), "authoredon", query["authoredon"])
+
+	filter, err := diagnosisFilterFromFHIR(query.Get("subject"), query.Get("patient"), query["authoredon"])
+	if err != nil {
+		slog.Warn("Invalid FHIR MedicationRequest search", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+	if filter.IsEmpty() {
+		writeOperationOutcomeStatus(w, http.StatusBadRequest, "At least one search parameter is required")
+		return
+	}
+	if filter.Page, err = pageFromQuery(query, "_count", "_cursor"); err != nil {
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	diagnostics, err := h.app.Patient().GetDiagnostics(callerFromRequest(r), filter)
+	if err != nil {
+		slog.Error("Failed to search FHIR MedicationRequest", "error", err)
+		writeOperationOutcome(w, err)
+		return
+	}
+
+	base := fhirBaseURL(r)
+	entries := make([]fhir.BundleEntry, 0, len(diagnostics.Diagnoses))
+	for _, d := range diagnostics.Diagnoses {
+		// Diagnoses without a prescription, or whose prescription was
+		// redacted for lack of consent, have no MedicationRequest
+		resource, err := fhir.FromPrescription(d)
+		if err != nil {
+			continue
+		}
+		entry, err := fhir.NewSearchEntry(base, fhir.ResourceMedicationRequest, d.ID, resource)
+		if err != nil {
+			writeOperationOutcome(w, err)
+			return
+		}
+		entries = append(entries, entry)
+	}
+
+	slog.Info("FHIR MedicationRequest search completed", "count", len(entries))
+	writeSearchBundle(w, r, entries, diagnostics.NextCursor)
+}
+
+// diagnosisFilterFromFHIR builds a diagnosis search from the patient and date
+// search parameters. Subject and patient may both be given, but must refer to
+// the same patient.
+func diagnosisFilterFromFHIR(subject, patient string, dateValues []string) (domain.DiagnosisFilter, error) {
+	var filter domain.DiagnosisFilter
+	for _, reference := range []string{subject, patient} {
+		if reference == "" {
+			continue
+		}
+		patientID := reference
+		if strings.Contains(reference, "/") {
+			var err error
+			if patientID, err = fhir.ParseReference(reference, fhir.ResourcePatient); err != nil {
+				return filter, err
+			}
+		}
+		if filter.PatientID != nil && *filter.PatientID != patientID {
+			return filter, fhir.ErrConflictingPatients
+		}
+		filter.PatientID = &patientID
+	}
+
+	dates, err := fhir.ParseDateRange(dateValues)
+	if err != nil {
+		return filter, err
+	}
+	filter.DateStart, filter.DateEnd = dates.Start, dates.End
+	if dates.Start != nil && dates.End == nil {
+		filter.DateEnd = &fhirOpenEnd
+	}
+	return filter, nil
+}
+
+// fhirBaseURL returns the absolute URL the FHIR facade is served at
+func fhirBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + fhirBasePath
+}
+
+func writeFHIR(w http.ResponseWriter, status int, resource any) {
+	w.Header().Set("Content-Type", fhir.ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resource)
+}
+
+// writeSearchBundle writes the searchset of a page of matches, linking to the
+// next page when nextCursor is not empty
+func writeSearchBundle(w http.ResponseWriter, r *http.Request, entries []fhir.BundleEntry, nextCursor string) {
+	self := fhirBaseURL(r) + strings.TrimPrefix(r.URL.RequestURI(), fhirBasePath)
+	bundle := fhir.NewSearchBundle(self, entries)
+	if nextCursor != "" || r.URL.Query().Has("_cursor") {
+		next := ""
+		if nextCursor != "" {
+			next = fhirBaseURL(r) + strings.TrimPrefix(nextPageURL(r, "_cursor", nextCursor), fhirBasePath)
+		}
+		bundle.Paged(next)
+	}
+	writeFHIR(w, http.StatusOK, bundle)
+}
+
+// writeOperationOutcome reports an error as an OperationOutcome, with the
+// status of the REST API
+func writeOperationOutcome(w http.ResponseWriter, err error) {
+	writeOperationOutcomeStatus(w, statusForError(err), err.Error())
+}
+
+func writeOperationOutcomeStatus(w http.ResponseWriter, status int, diagnostics string) {
+	writeFHIR(w, status, fhir.NewOperationOutcome(issueCodeForStatus(status), diagnostics))
+}
+
+// issueCodeForStatus maps HTTP status codes to FHIR issue types
+func issueCodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid"
+	case http.StatusUnauthorized:
+		return "login"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not-found"
+	case http.StatusConflict:
+		return "conflict"
+	default:
+		return "exception"
+	}
+}
@@ -3,12 +3,17 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 	"topdoctors/internal/application"
+	"topdoctors/internal/domain"
 	"topdoctors/internal/infrastructure/config"
+	"topdoctors/internal/infrastructure/fhir"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -17,6 +22,7 @@ type contextKey string
 
 const (
 	userIDKey contextKey = "user_id"
+	roleKey   contextKey = "role"
 )
 
 type HttpHandler struct {
@@ -96,15 +102,17 @@ func (h *HttpHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 // CreateDiagnosis handles the creation of a new medical diagnosis
 // @Summary Create diagnosis
-// @Description Add a new diagnosis to a patient
+// @Description Add a new diagnosis to a patient. With follow_up_in_days (1 to 365) a pending follow-up with the caller
+// @Description is created, due that many days after the diagnosis date, to be booked later in the caller's agenda.
 // @Tags Diagnostics
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param diagnosis body CreateDiagnosisRequest true "Diagnosis Info"
-// @Success 201 {string} string "Created"
+// @Success 201 {object} CreateDiagnosisResponse
 // @Failure 400 {string} string "Bad Request"
 // @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
 // @Failure 500 {string} string "Internal Server Error"
 // @Router /diagnostics [post]
 func (h *HttpHandler) CreateDiagnosis(w http.ResponseWriter, r *http.Request) {
@@ -134,28 +142,56 @@ func (h *HttpHandler) CreateDiagnosis(w http.ResponseWriter, r *http.Request) {
 	diagnosis := toDiagnosisDomain(req)
 	diagnosis.Date = diagnosisDate
 
-	err := h.app.Patient().CreateDiagnosis(&diagnosis)
+	followUp, err := h.app.Patient().CreateDiagnosis(callerFromRequest(r), &diagnosis, req.FollowUpDays)
 	if err != nil {
 		slog.Error("Failed to create diagnosis", "patient_id", req.PatientID, "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusForError(err))
 		return
 	}
 
+	response := CreateDiagnosisResponse{DiagnosisResponse: toDiagnosisResponse(diagnosis)}
+	if followUp != nil {
+		followUpResponse := toAppointmentResponse(*followUp)
+		response.FollowUp = &followUpResponse
+	}
+
 	slog.Info("Diagnosis created successfully", "patient_id", req.PatientID)
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
 }
 
 // GetDiagnostics searches for diagnostics based on filters
 // @Summary Search diagnostics
-// @Description Retrieve a list of diagnostics filtering by patient name and/or date range
+// @Description Retrieve a list of diagnostics filtering by patient name, date range, patient age and sex and/or full-text query.
+// @Description Name filters ignore case and accents; with fuzzy=true close spellings match too and results are
+// @Description ordered by name similarity (match.name_score).
+// @Description Name similarity is only known once every candidate is decrypted, so searches by name only page the best 1000
+// @Description results.
+// @Description The full-text query is accent-insensitive, matches every term against diagnosis and prescription text,
+// @Description orders results by relevance and highlights the matching terms in the returned snippets.
+// @Description The age range applies to the patient's age on the diagnosis date; patients without a birth date are excluded.
+// @Description Only patients in the caller's care team or under an active break-glass grant are returned.
 // @Tags Diagnostics
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param patient_name query string false "Filter by patient name"
+// @Param given_name query string false "Filter by patient given name"
+// @Param surname query string false "Filter by either patient surname"
+// @Param fuzzy query bool false "Also match close spellings of the patient name"
 // @Param date_start query string false "Filter by start date (YYYY-MM-DD)"
 // @Param date_end query string false "Filter by end date (YYYY-MM-DD)"
+// @Param q query string false "Full-text search over diagnosis and prescription text"
+// @Param age_min query int false "Minimum patient age at the diagnosis date, inclusive"
+// @Param age_max query int false "Maximum patient age at the diagnosis date, inclusive"
+// @Param sex query string false "Patient sex" Enums(male, female, other, unknown)
+// @Param province query string false "INE province code of the patient's address" example(28)
+// @Param postal_code query string false "Postal code of the patient's address" example(28013)
+// @Param limit query int false "Page size, 50 by default and at most 200"
+// @Param cursor query string false "Cursor of the page, from the next link of the previous one"
 // @Success 200 {array} DiagnosisResponse
+// @Header 200 {string} Link "Next page, as <url>; rel=\"next\""
 // @Failure 400 {string} string "Bad Request"
 // @Failure 401 {string} string "Unauthorized"
 // @Failure 500 {string} string "Internal Server Error"
@@ -164,19 +200,33 @@ func (h *HttpHandler) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
 	patientName := r.URL.Query().Get("patient_name")
 	dateStart := r.URL.Query().Get("date_start")
 	dateEnd := r.URL.Query().Get("date_end")
+	text := r.URL.Query().Get("q")
 
-	slog.Debug("Get diagnostics request received", "patient_name", patientName, "date_start", dateStart, "date_end", dateEnd)
+	slog.Debug("Get diagnostics request received", "patient_name", patientName, "date_start", dateStart, "date_end", dateEnd, "has_query", text != "")
+
+	nameFilter, err := nameFilterFromQuery(r.URL.Query(), "patient_name")
+	if err != nil {
+		slog.Warn("Invalid fuzzy parameter", "error", err)
+		http.Error(w, "Invalid fuzzy parameter", http.StatusBadRequest)
+		return
+	}
+
+	page, err := pageFromQuery(r.URL.Query(), "limit", "cursor")
+	if err != nil {
+		slog.Warn("Invalid page parameters", "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
 
-	var parsedPatientName *string
-	if patientName != "" {
-		parsedPatientName = &patientName
+	filter := domain.DiagnosisFilter{Patient: nameFilter, Page: page}
+	if text != "" {
+		filter.Text = &text
 	}
 
-	var parsedDateStart *time.Time
 	if dateStart != "" {
 		d, err := time.Parse("2006-01-02", dateStart)
 		if err == nil {
-			parsedDateStart = &d
+			filter.DateStart = &d
 		} else {
 			slog.Warn("Invalid date_start format", "date", dateStart)
 			http.Error(w, "Invalid date_start format", http.StatusBadRequest)
@@ -184,11 +234,10 @@ func (h *HttpHandler) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var parsedDateEnd *time.Time
 	if dateEnd != "" {
 		d, err := time.Parse("2006-01-02", dateEnd)
 		if err == nil {
-			parsedDateEnd = &d
+			filter.DateEnd = &d
 		} else {
 			slog.Warn("Invalid date_end format", "date", dateEnd)
 			http.Error(w, "Invalid date_end format", http.StatusBadRequest)
@@ -196,30 +245,55 @@ func (h *HttpHandler) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if parsedPatientName == nil && parsedDateStart == nil && parsedDateEnd == nil {
+	for param, dst := range map[string]**int{"age_min": &filter.AgeMin, "age_max": &filter.AgeMax} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		age, err := strconv.Atoi(value)
+		if err != nil {
+			slog.Warn("Invalid age parameter", "param", param, "value", value)
+			http.Error(w, "Invalid "+param+" parameter", http.StatusBadRequest)
+			return
+		}
+		*dst = &age
+	}
+	if sex := r.URL.Query().Get("sex"); sex != "" {
+		filter.Sex = &sex
+	}
+	if province := r.URL.Query().Get("province"); province != "" {
+		filter.Province = &province
+	}
+	if postalCode := r.URL.Query().Get("postal_code"); postalCode != "" {
+		filter.PostalCode = &postalCode
+	}
+
+	if filter.IsEmpty() {
 		slog.Warn("Get diagnostics request missing parameters")
 		http.Error(w, "At least one parameter is required", http.StatusBadRequest)
 		return
 	}
 
-	diagnostics, err := h.app.Patient().GetDiagnostics(parsedPatientName, parsedDateStart, parsedDateEnd)
+	diagnostics, err := h.app.Patient().GetDiagnostics(callerFromRequest(r), filter)
 	if err != nil {
 		slog.Error("Failed to get diagnostics", "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), statusForError(err))
 		return
 	}
 
 	// Map to DTOs
-	response := toDiagnosisResponseList(diagnostics)
+	response := toDiagnosisResponseList(diagnostics.Diagnoses)
 
 	slog.Info("Diagnostics retrieved successfully", "count", len(response))
+	setNextPageLink(w, r, "cursor", diagnostics.NextCursor)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
 // CreatePatient handles the registration of a new patient
 // @Summary Create patient
-// @Description Record a new patient in the system
+// @Description Record a new patient in the system. The creator becomes the first member of the patient's care team.
+// @Description Integration clients cannot create patients.
 // @Tags Patients
 // @Accept json
 // @Produce json
@@ -227,6 +301,7 @@ func (h *HttpHandler) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
 // @Param patient body CreatePatientRequest true "Patient Info"
 // @Success 201 {object} PatientResponse
 // @Failure 400 {string} string "Bad Request"
+// @Failure 403 {string} string "Forbidden"
 // @Failure 500 {string} string "Internal Server Error"
 // @Router /patients [post]
 func (h *HttpHandler) CreatePatient(w http.ResponseWriter, r *http.Request) {
@@ -241,19 +316,306 @@ func (h *HttpHandler) CreatePatient(w http.ResponseWriter, r *http.Request) {
 	// Map to domain
 	patient := toPatientDomain(req)
 
-	err := h.app.Patient().CreatePatient(&patient)
+	if req.BirthDate != "" {
+		birthDate, err := time.Parse("2006-01-02", req.BirthDate)
+		if err != nil {
+			slog.Warn("Invalid birth_date format", "birth_date", req.BirthDate)
+			http.Error(w, "Invalid birth_date format. Use YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		patient.BirthDate = &birthDate
+	}
+
+	err := h.app.Patient().CreatePatient(callerFromRequest(r), &patient)
 	if err != nil {
-		slog.Error("Failed to create patient", "name", req.Name, "error", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		slog.Error("Failed to create patient", "name", patient.DisplayName(), "error", err)
+		http.Error(w, err.Error(), statusForError(err))
 		return
 	}
 
-	slog.Info("Patient created successfully", "patient_id", patient.ID, "name", patient.Name)
+	slog.Info("Patient created successfully", "patient_id", patient.ID, "name", patient.DisplayName())
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(toPatientResponse(patient))
 }
 
+// GetPatient returns a single patient
+// @Summary Get patient
+// @Description Retrieve a patient by ID. Restricted to the patient's care team or an active break-glass grant.
+// @Description Records merged as duplicates redirect to the surviving patient.
+// @Tags Patients
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {object} PatientResponse
+// @Success 308 {string} string "Permanent Redirect to the surviving patient"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id} [get]
+func (h *HttpHandler) GetPatient(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get patient request received", "patient_id", patientID)
+
+	patient, err := h.app.Patient().GetPatient(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get patient", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+	if patient.IsMerged() {
+		slog.Info("Redirecting merged patient", "patient_id", patientID, "merged_into", patient.MergedInto)
+		http.Redirect(w, r, "/patients/"+patient.MergedInto, http.StatusPermanentRedirect)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPatientResponse(*patient))
+}
+
+// ListPatients lists the patients the caller can access
+// @Summary List patients
+// @Description List the patients in the caller's care teams or under an active break-glass grant, optionally filtered by name.
+// @Description Name filters ignore case and accents; with fuzzy=true close spellings match too. When filtering by name,
+// @Description results are ordered by name similarity (score); sort=surname orders them alphabetically by surnames instead.
+// @Description Results are paged, the Link header points to the next page.
+// @Tags Patients
+// @Produce json
+// @Security BearerAuth
+// @Param name query string false "Filter by any part of the name"
+// @Param given_name query string false "Filter by given name"
+// @Param surname query string false "Filter by either surname"
+// @Param fuzzy query bool false "Also match close spellings"
+// @Param sort query string false "Sort order" Enums(surname)
+// @Param limit query int false "Page size, 50 by default and at most 200"
+// @Param cursor query string false "Cursor of the page, from the next link of the previous one"
+// @Success 200 {array} PatientSearchResponse
+// @Header 200 {string} Link "Next page, as <url>; rel=\"next\""
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients [get]
+func (h *HttpHandler) ListPatients(w http.ResponseWriter, r *http.Request) {
+	nameFilter, err := nameFilterFromQuery(r.URL.Query(), "name")
+	if err != nil {
+		slog.Warn("Invalid fuzzy parameter", "error", err)
+		http.Error(w, "Invalid fuzzy parameter", http.StatusBadRequest)
+		return
+	}
+
+	page, err := pageFromQuery(r.URL.Query(), "limit", "cursor")
+	if err != nil {
+		slog.Warn("Invalid page parameters", "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	patients, err := h.app.Patient().ListPatients(callerFromRequest(r), domain.PatientFilter{
+		Name: nameFilter,
+		Sort: r.URL.Query().Get("sort"),
+		Page: page,
+	})
+	if err != nil {
+		slog.Error("Failed to list patients", "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	slog.Info("Patients listed successfully", "count", len(patients.Patients))
+	setNextPageLink(w, r, "cursor", patients.NextCursor)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPatientSearchResponseList(patients.Patients))
+}
+
+// callerFromRequest builds the domain caller from the authenticated request context
+func callerFromRequest(r *http.Request) domain.Caller {
+	userID, _ := r.Context().Value(userIDKey).(string)
+	role, _ := r.Context().Value(roleKey).(string)
+	return domain.Caller{UserID: userID, Role: role}
+}
+
+// nameFilterFromQuery reads the name filter parameters of a search, the full
+// name one being named nameParam
+func nameFilterFromQuery(query url.Values, nameParam string) (domain.NameFilter, error) {
+	var filter domain.NameFilter
+	if name := query.Get(nameParam); name != "" {
+		filter.Name = &name
+	}
+	if givenName := query.Get("given_name"); givenName != "" {
+		filter.GivenName = &givenName
+	}
+	if surname := query.Get("surname"); surname != "" {
+		filter.Surname = &surname
+	}
+	if fuzzy := query.Get("fuzzy"); fuzzy != "" {
+		parsed, err := strconv.ParseBool(fuzzy)
+		if err != nil {
+			return filter, err
+		}
+		filter.Fuzzy = parsed
+	}
+	return filter, nil
+}
+
+// pageFromQuery reads the page size and cursor parameters of a listing
+func pageFromQuery(query url.Values, limitParam, cursorParam string) (domain.Page, error) {
+	page := domain.Page{Cursor: query.Get(cursorParam)}
+	if limit := query.Get(limitParam); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return page, domain.ErrInvalidPageLimit
+		}
+		page.Limit = parsed
+	}
+	return page, nil
+}
+
+// nextPageURL returns the request URL, path and query, with the cursor of the
+// next page
+func nextPageURL(r *http.Request, cursorParam, cursor string) string {
+	query := r.URL.Query()
+	query.Set(cursorParam, cursor)
+	return r.URL.Path + "?" + query.Encode()
+}
+
+// setNextPageLink links a listing to its next page, if any
+func setNextPageLink(w http.ResponseWriter, r *http.Request, cursorParam, cursor string) {
+	if cursor == "" {
+		return
+	}
+	w.Header().Set("Link", "<"+nextPageURL(r, cursorParam, cursor)+`>; rel="next"`)
+}
+
+// statusForError maps domain errors to HTTP status codes
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidFeedToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrAccessDenied),
+		errors.Is(err, domain.ErrConsentRequired),
+		errors.Is(err, domain.ErrConsentManagementDenied),
+		errors.Is(err, domain.ErrAdminRequired),
+		errors.Is(err, domain.ErrReferralNotRecipient),
+		errors.Is(err, domain.ErrIntegrationRequired):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrConsentPatientMismatch),
+		errors.Is(err, domain.ErrContactPatientMismatch),
+		errors.Is(err, domain.ErrDiagnosisNotFound),
+		errors.Is(err, domain.ErrAttachmentNotFound),
+		errors.Is(err, domain.ErrBlobNotFound),
+		errors.Is(err, domain.ErrReferralNotFound),
+		errors.Is(err, domain.ErrEncounterNotFound),
+		errors.Is(err, fhir.ErrPrescriptionNotFound),
+		errors.Is(err, domain.ErrBulkExportJobNotFound),
+		errors.Is(err, domain.ErrBulkExportFileNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrUnsupportedAttachmentType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, domain.ErrAlreadyCareTeamMember),
+		errors.Is(err, domain.ErrLastCareTeamMember),
+		errors.Is(err, domain.ErrConsentAlreadyRevoked),
+		errors.Is(err, domain.ErrPatientAlreadyErased),
+		errors.Is(err, domain.ErrPatientMerged),
+		errors.Is(err, domain.ErrMergeConflict),
+		errors.Is(err, domain.ErrAppointmentOverlap),
+		errors.Is(err, domain.ErrAppointmentCancelled),
+		errors.Is(err, domain.ErrDuplicateVaccinationDose),
+		errors.Is(err, domain.ErrReferralNotPending),
+		errors.Is(err, domain.ErrReferralNotAccepted),
+		errors.Is(err, domain.ErrEncounterClosed),
+		errors.Is(err, domain.ErrBulkExportNotCompleted):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrEmptyJustification),
+		errors.Is(err, domain.ErrEmptyCareTeamUserID),
+		errors.Is(err, domain.ErrInvalidConsentPurpose),
+		errors.Is(err, domain.ErrInvalidConsentScope),
+		errors.Is(err, domain.ErrEmptyConsentEvidence),
+		errors.Is(err, domain.ErrRepresentativeRequired),
+		errors.Is(err, domain.ErrNotLegalRepresentative),
+		errors.Is(err, domain.ErrInvalidRelationship),
+		errors.Is(err, domain.ErrEmptyContactName),
+		errors.Is(err, domain.ErrContactUnreachable),
+		errors.Is(err, domain.ErrConsentGrantedInFuture),
+		errors.Is(err, domain.ErrEmptyErasureReason),
+		errors.Is(err, domain.ErrEmptySearchText),
+		errors.Is(err, domain.ErrInvalidPatientSort),
+		errors.Is(err, domain.ErrInvalidPageLimit),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrMergeSamePatient),
+		errors.Is(err, domain.ErrEmptyPatientFK),
+		errors.Is(err, domain.ErrInvalidAgeRange),
+		errors.Is(err, domain.ErrEmptyName),
+		errors.Is(err, domain.ErrEmptySurname),
+		errors.Is(err, domain.ErrEmptyDNI),
+		errors.Is(err, domain.ErrInvalidDNI),
+		errors.Is(err, domain.ErrEmptyEmail),
+		errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidPhone),
+		errors.Is(err, domain.ErrFutureBirthDate),
+		errors.Is(err, domain.ErrInvalidSex),
+		errors.Is(err, domain.ErrInvalidNationality),
+		errors.Is(err, domain.ErrInvalidLanguage),
+		errors.Is(err, domain.ErrInvalidCountry),
+		errors.Is(err, domain.ErrInvalidPostalCode),
+		errors.Is(err, domain.ErrInvalidProvince),
+		errors.Is(err, domain.ErrPostalCodeProvinceMismatch),
+		errors.Is(err, domain.ErrEmptyPractitionerID),
+		errors.Is(err, domain.ErrInvalidAppointmentTime),
+		errors.Is(err, domain.ErrAppointmentTooLong),
+		errors.Is(err, domain.ErrInvalidPractitioner),
+		errors.Is(err, domain.ErrDiagnosisPatientMismatch),
+		errors.Is(err, domain.ErrInvalidFollowUpDays),
+		errors.Is(err, domain.ErrInvalidAgendaView),
+		errors.Is(err, domain.ErrUnknownObservationCode),
+		errors.Is(err, domain.ErrInvalidObservationUnit),
+		errors.Is(err, domain.ErrImplausibleObservation),
+		errors.Is(err, domain.ErrInvalidReferenceRange),
+		errors.Is(err, domain.ErrFutureObservation),
+		errors.Is(err, domain.ErrEmptyLabPanel),
+		errors.Is(err, domain.ErrEmptyLabAnalyte),
+		errors.Is(err, domain.ErrEmptyLabUnit),
+		errors.Is(err, domain.ErrFutureLabCollection),
+		errors.Is(err, domain.ErrEmptyLabBatch),
+		errors.Is(err, domain.ErrLabBatchTooLarge),
+		errors.Is(err, domain.ErrInvalidLabRange),
+		errors.Is(err, domain.ErrEmptyAttachmentName),
+		errors.Is(err, domain.ErrEmptyAttachment),
+		errors.Is(err, domain.ErrInvalidChecksum),
+		errors.Is(err, domain.ErrAttachmentChecksumMismatch),
+		errors.Is(err, domain.ErrEmptyVaccineCode),
+		errors.Is(err, domain.ErrInvalidDoseNumber),
+		errors.Is(err, domain.ErrEmptyVaccineLot),
+		errors.Is(err, domain.ErrFutureVaccination),
+		errors.Is(err, domain.ErrVaccinationBeforeBirth),
+		errors.Is(err, domain.ErrMissingBirthDate),
+		errors.Is(err, domain.ErrInvalidVaccinationHorizon),
+		errors.Is(err, domain.ErrReferralRecipientRequired),
+		errors.Is(err, domain.ErrInvalidReferralRecipient),
+		errors.Is(err, domain.ErrSelfReferral),
+		errors.Is(err, domain.ErrUnknownSpecialty),
+		errors.Is(err, domain.ErrEmptyReferralReason),
+		errors.Is(err, domain.ErrInvalidReferralUrgency),
+		errors.Is(err, domain.ErrInvalidReferralStatus),
+		errors.Is(err, domain.ErrEmptyRejectionReason),
+		errors.Is(err, domain.ErrFutureEncounter),
+		errors.Is(err, domain.ErrSOAPSectionTooLong),
+		errors.Is(err, domain.ErrEmptySOAPNote),
+		errors.Is(err, domain.ErrEncounterPatientMismatch),
+		errors.Is(err, fhir.ErrUnexpectedResourceType),
+		errors.Is(err, fhir.ErrInvalidReference),
+		errors.Is(err, fhir.ErrInvalidDate),
+		errors.Is(err, fhir.ErrEmptyConditionCode),
+		errors.Is(err, fhir.ErrUnsupportedPrefix),
+		errors.Is(err, fhir.ErrConflictingPatients),
+		errors.Is(err, domain.ErrUnsupportedBulkExportType):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // Auth Middleware
 func (h *HttpHandler) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -299,11 +661,21 @@ func (h *HttpHandler) AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Inject user_id into context
+		// The role claim is only informative: demotions must apply to tokens
+		// already issued, so the role is read from the user
+		role, err := h.app.Auth().CurrentRole(userID)
+		if err != nil {
+			slog.Warn("Unauthorized request: unknown user", "path", r.URL.Path, "user_id", userID)
+			http.Error(w, "Invalid token subject", http.StatusUnauthorized)
+			return
+		}
+
+		// Inject user_id and role into context
 		ctx := context.WithValue(r.Context(), userIDKey, userID)
+		ctx = context.WithValue(ctx, roleKey, role)
 		r = r.WithContext(ctx)
 
-		slog.Debug("Authorized request", "path", r.URL.Path, "user_id", userID)
+		slog.Debug("Authorized request", "path", r.URL.Path, "user_id", userID, "role", role)
 		next.ServeHTTP(w, r)
 	})
 }
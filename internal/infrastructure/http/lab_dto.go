@@ -0,0 +1,113 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type LabResultRequest struct {
+	Panel          string             `json:"panel" example:"Complete blood count"`
+	Analyte        string             `json:"analyte" example:"Hemoglobin"`
+	Code           string             `json:"code,omitempty" example:"718-7"` // LOINC, if the lab sent it
+	Value          float64            `json:"value" example:"10.9"`
+	Unit           string             `json:"unit" example:"g/dL"` // UCUM
+	ReferenceRange *ReferenceRangeDTO `json:"reference_range,omitempty"`
+}
+
+// LabResultBatchRequest carries the results of one lab report
+type LabResultBatchRequest struct {
+	CollectedAt string             `json:"collected_at,omitempty" example:"2026-02-13T08:30:00Z"` // ISO 8601 format, now when omitted
+	DiagnosisID string             `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPV"`
+	Results     []LabResultRequest `json:"results"`
+}
+
+// Response DTOs
+
+type LabResultResponse struct {
+	ID             string             `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPX"`
+	PatientID      string             `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	BatchID        string             `json:"batch_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPB"`
+	Panel          string             `json:"panel" example:"Complete blood count"`
+	Analyte        string             `json:"analyte" example:"Hemoglobin"`
+	Code           string             `json:"code,omitempty" example:"718-7"`
+	Value          float64            `json:"value" example:"10.9"`
+	Unit           string             `json:"unit" example:"g/dL"`
+	ReferenceRange *ReferenceRangeDTO `json:"reference_range,omitempty"`
+	Flag           string             `json:"flag,omitempty" example:"L" enums:"L,N,H"`
+	Abnormal       bool               `json:"abnormal" example:"true"`
+	CollectedAt    time.Time          `json:"collected_at" example:"2026-02-13T08:30:00Z"`
+	DiagnosisID    string             `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPV"`
+	RecordedBy     string             `json:"recorded_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	CreatedAt      time.Time          `json:"created_at" example:"2026-02-13T10:00:00Z"`
+}
+
+type LabResultBatchResponse struct {
+	BatchID  string              `json:"batch_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPB"`
+	Abnormal int                 `json:"abnormal" example:"1"` // Number of results out of range
+	Results  []LabResultResponse `json:"results"`
+}
+
+// Mappers: Domain -> DTO
+
+func toLabResultResponse(r domain.LabResult) LabResultResponse {
+	return LabResultResponse{
+		ID:             r.ID,
+		PatientID:      r.PatientID,
+		BatchID:        r.BatchID,
+		Panel:          r.Panel,
+		Analyte:        r.Analyte,
+		Code:           r.Code,
+		Value:          r.Value,
+		Unit:           r.Unit,
+		ReferenceRange: toReferenceRangeDTO(r.Reference),
+		Flag:           r.Flag(),
+		Abnormal:       r.IsAbnormal(),
+		CollectedAt:    r.CollectedAt,
+		DiagnosisID:    r.DiagnosisID,
+		RecordedBy:     r.RecordedBy,
+		CreatedAt:      r.CreatedAt,
+	}
+}
+
+func toLabResultResponseList(results []domain.LabResult) []LabResultResponse {
+	list := make([]LabResultResponse, len(results))
+	for i, r := range results {
+		list[i] = toLabResultResponse(r)
+	}
+	return list
+}
+
+func toLabResultBatchResponse(results []domain.LabResult) LabResultBatchResponse {
+	response := LabResultBatchResponse{Results: toLabResultResponseList(results)}
+	for i := range results {
+		response.BatchID = results[i].BatchID
+		if results[i].IsAbnormal() {
+			response.Abnormal++
+		}
+	}
+	return response
+}
+
+// Mappers: DTO -> Domain
+
+func toLabResultDomainList(req LabResultBatchRequest, collectedAt time.Time) []domain.LabResult {
+	// Time parsing is handled in the handler
+	results := make([]domain.LabResult, len(req.Results))
+	for i, r := range req.Results {
+		results[i] = domain.LabResult{
+			Panel:       r.Panel,
+			Analyte:     r.Analyte,
+			Code:        r.Code,
+			Value:       r.Value,
+			Unit:        r.Unit,
+			CollectedAt: collectedAt,
+			DiagnosisID: req.DiagnosisID,
+		}
+		if r.ReferenceRange != nil {
+			results[i].Reference = domain.ReferenceRange{Low: r.ReferenceRange.Low, High: r.ReferenceRange.High}
+		}
+	}
+	return results
+}
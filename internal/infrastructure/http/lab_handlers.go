@@ -0,0 +1,148 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// IngestLabResults ingests the results of a lab report
+// @Summary Ingest lab results
+// @Description Store a batch of lab results of a patient, usually one report, with the reference range the lab gave
+// @Description for each analyte. Values out of range are flagged L (low) or H (high). The batch is rejected as a
+// @Description whole when any result is invalid.
+// @Tags Lab Results
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param batch body LabResultBatchRequest true "Lab results"
+// @Success 201 {object} LabResultBatchResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/lab-results [post]
+func (h *HttpHandler) IngestLabResults(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Ingest lab results request received", "patient_id", patientID)
+
+	var req LabResultBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode ingest lab results request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var collectedAt time.Time
+	if req.CollectedAt != "" {
+		var err error
+		collectedAt, err = time.Parse(time.RFC3339, req.CollectedAt)
+		if err != nil {
+			slog.Warn("Invalid collected_at format in lab results request", "collected_at", req.CollectedAt)
+			http.Error(w, "Invalid collected_at format, use ISO 8601", http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := h.app.Lab().IngestLabResults(callerFromRequest(r), patientID, toLabResultDomainList(req, collectedAt))
+	if err != nil {
+		slog.Error("Failed to ingest lab results", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toLabResultBatchResponse(results))
+}
+
+// GetLabResults lists the lab results of a patient
+// @Summary List lab results
+// @Description List the lab results of a patient in order of collection, optionally of one panel or analyte, only
+// @Description the abnormal ones or in a date range
+// @Tags Lab Results
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param panel query string false "Panel name"
+// @Param analyte query string false "Analyte name"
+// @Param abnormal query bool false "Only results out of range"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Success 200 {array} LabResultResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/lab-results [get]
+func (h *HttpHandler) GetLabResults(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get lab results request received", "patient_id", patientID)
+
+	query := r.URL.Query()
+	from, to, ok := dayRangeFromQuery(w, query)
+	if !ok {
+		return
+	}
+	filter := domain.LabResultFilter{From: from, To: to, AbnormalOnly: query.Get("abnormal") == "true"}
+	if panel := query.Get("panel"); panel != "" {
+		filter.Panel = &panel
+	}
+	if analyte := query.Get("analyte"); analyte != "" {
+		filter.Analyte = &analyte
+	}
+
+	results, err := h.app.Lab().GetLabResults(callerFromRequest(r), patientID, filter)
+	if err != nil {
+		slog.Error("Failed to get lab results", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toLabResultResponseList(results))
+}
+
+// GetAbnormalLabResults lists abnormal lab results across the caller's patients
+// @Summary Abnormal lab results
+// @Description List the lab results out of range collected in a date range, of every patient in the caller's care
+// @Description teams or under an active break-glass grant. The range cannot exceed a year. Not available to
+// @Description integration clients.
+// @Tags Lab Results
+// @Produce json
+// @Security BearerAuth
+// @Param from query string true "First day (YYYY-MM-DD)"
+// @Param to query string true "Last day, inclusive (YYYY-MM-DD)"
+// @Success 200 {array} LabResultResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /lab-results/abnormal [get]
+func (h *HttpHandler) GetAbnormalLabResults(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("Get abnormal lab results request received")
+
+	from, to, ok := dayRangeFromQuery(w, r.URL.Query())
+	if !ok {
+		return
+	}
+	if from == nil || to == nil {
+		slog.Warn("Missing date range in abnormal lab results request")
+		http.Error(w, "from and to are required", http.StatusBadRequest)
+		return
+	}
+
+	results, err := h.app.Lab().GetAbnormalLabResults(callerFromRequest(r), *from, *to)
+	if err != nil {
+		slog.Error("Failed to get abnormal lab results", "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toLabResultResponseList(results))
+}
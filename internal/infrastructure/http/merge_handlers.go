@@ -0,0 +1,121 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type MergePatientRequest struct {
+	DuplicateID string `json:"duplicate_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	Reason      string `json:"reason,omitempty" example:"Registrada sin documentación el 2026-02-10"`
+}
+
+type DuplicateCandidateResponse struct {
+	Patient PatientResponse `json:"patient"`
+	Score   float64         `json:"score" example:"0.75"`
+	Matches []string        `json:"matches" example:"name,phone"`
+}
+
+type PatientMergeResponse struct {
+	ID             string    `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	SurvivorID     string    `json:"survivor_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	DuplicateID    string    `json:"duplicate_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	Reason         string    `json:"reason,omitempty" example:"Registrada sin documentación el 2026-02-10"`
+	DiagnosesMoved int       `json:"diagnoses_moved" example:"2"`
+	MergedAt       time.Time `json:"merged_at" example:"2026-02-13T18:23:00Z"`
+}
+
+func toDuplicateCandidateResponseList(candidates []domain.DuplicateCandidate) []DuplicateCandidateResponse {
+	result := make([]DuplicateCandidateResponse, len(candidates))
+	for i, c := range candidates {
+		result[i] = DuplicateCandidateResponse{
+			Patient: toPatientResponse(c.Patient),
+			Score:   c.Score,
+			Matches: c.Matches,
+		}
+	}
+	return result
+}
+
+func toPatientMergeResponse(m domain.PatientMerge) PatientMergeResponse {
+	return PatientMergeResponse{
+		ID:             m.ID,
+		SurvivorID:     m.SurvivorID,
+		DuplicateID:    m.DuplicateID,
+		Reason:         m.Reason,
+		DiagnosesMoved: m.DiagnosesMoved,
+		MergedAt:       m.MergedAt,
+	}
+}
+
+// FindDuplicates lists the likely duplicates of a patient
+// @Summary Find duplicate patients
+// @Description List the patients the caller can access that are likely the same person, scored between 0 and 1
+// @Description by name similarity (accent-insensitive, tolerating typos and a missing surname), email, phone and birth date.
+// @Tags Patients
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} DuplicateCandidateResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/duplicates [get]
+func (h *HttpHandler) FindDuplicates(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Find duplicates request received", "patient_id", patientID)
+
+	candidates, err := h.app.Merge().FindDuplicates(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to find duplicates", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toDuplicateCandidateResponseList(candidates))
+}
+
+// MergePatients merges a duplicate record into a patient
+// @Summary Merge duplicate patient
+// @Description Move the diagnoses and care team of the duplicate patient to this one, in a single transaction.
+// @Description The duplicate is kept for audit and GET /patients/{duplicate_id} redirects here. Requires access to both patients.
+// @Description Fails with 409 when either patient is merged or erased, or the duplicate gains records, while the merge runs.
+// @Tags Patients
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Surviving patient ID"
+// @Param request body MergePatientRequest true "Duplicate to merge"
+// @Success 200 {object} PatientMergeResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/merge [post]
+func (h *HttpHandler) MergePatients(w http.ResponseWriter, r *http.Request) {
+	survivorID := r.PathValue("id")
+	slog.Debug("Merge patients request received", "survivor_id", survivorID)
+
+	var req MergePatientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode merge patients request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	merge, err := h.app.Merge().MergePatients(callerFromRequest(r), survivorID, req.DuplicateID, req.Reason)
+	if err != nil {
+		slog.Error("Failed to merge patients", "survivor_id", survivorID, "duplicate_id", req.DuplicateID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPatientMergeResponse(*merge))
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type ReferenceRangeDTO struct {
+	Low  *float64 `json:"low,omitempty" example:"60"`
+	High *float64 `json:"high,omitempty" example:"100"`
+}
+
+type ObservationRequest struct {
+	Code           string             `json:"code" example:"8867-4" enums:"8480-6,8462-4,8867-4,8310-5,29463-7,2339-0"` // LOINC
+	Value          float64            `json:"value" example:"72"`
+	Unit           string             `json:"unit" example:"/min"`                                   // UCUM
+	ReferenceRange *ReferenceRangeDTO `json:"reference_range,omitempty"`                             // Adult range of the type when omitted
+	EffectiveAt    string             `json:"effective_at,omitempty" example:"2026-02-13T10:00:00Z"` // ISO 8601 format, now when omitted
+	DiagnosisID    string             `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPV"`
+}
+
+// Response DTOs
+
+type ObservationResponse struct {
+	ID             string             `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPX"`
+	PatientID      string             `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Code           string             `json:"code" example:"8867-4"`
+	Display        string             `json:"display" example:"Heart rate"`
+	Value          float64            `json:"value" example:"72"`
+	Unit           string             `json:"unit" example:"/min"`
+	ReferenceRange *ReferenceRangeDTO `json:"reference_range,omitempty"`
+	Interpretation string             `json:"interpretation,omitempty" example:"N" enums:"L,N,H"`
+	EffectiveAt    time.Time          `json:"effective_at" example:"2026-02-13T10:00:00Z"`
+	DiagnosisID    string             `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPV"`
+	RecordedBy     string             `json:"recorded_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	CreatedAt      time.Time          `json:"created_at" example:"2026-02-13T10:00:00Z"`
+}
+
+type ObservationPointResponse struct {
+	At             time.Time `json:"at" example:"2026-02-13T10:00:00Z"`
+	Value          float64   `json:"value" example:"72"`
+	Interpretation string    `json:"interpretation,omitempty" example:"N"`
+}
+
+// ObservationSeriesResponse has every value in the canonical unit of the type
+type ObservationSeriesResponse struct {
+	Code           string                     `json:"code" example:"8867-4"`
+	Display        string                     `json:"display" example:"Heart rate"`
+	Unit           string                     `json:"unit" example:"/min"`
+	ReferenceRange *ReferenceRangeDTO         `json:"reference_range,omitempty"`
+	Points         []ObservationPointResponse `json:"points"`
+}
+
+// Mappers: Domain -> DTO
+
+func toReferenceRangeDTO(r domain.ReferenceRange) *ReferenceRangeDTO {
+	if r.IsEmpty() {
+		return nil
+	}
+	return &ReferenceRangeDTO{Low: r.Low, High: r.High}
+}
+
+func toObservationResponse(o domain.Observation) ObservationResponse {
+	t, _ := domain.LookupObservationType(o.Code)
+	return ObservationResponse{
+		ID:             o.ID,
+		PatientID:      o.PatientID,
+		Code:           o.Code,
+		Display:        t.Display,
+		Value:          o.Value,
+		Unit:           o.Unit,
+		ReferenceRange: toReferenceRangeDTO(o.Reference),
+		Interpretation: o.Interpretation(),
+		EffectiveAt:    o.EffectiveAt,
+		DiagnosisID:    o.DiagnosisID,
+		RecordedBy:     o.RecordedBy,
+		CreatedAt:      o.CreatedAt,
+	}
+}
+
+func toObservationResponseList(observations []domain.Observation) []ObservationResponse {
+	result := make([]ObservationResponse, len(observations))
+	for i, o := range observations {
+		result[i] = toObservationResponse(o)
+	}
+	return result
+}
+
+func toObservationSeriesResponse(s domain.ObservationSeries) ObservationSeriesResponse {
+	points := make([]ObservationPointResponse, len(s.Points))
+	for i, p := range s.Points {
+		points[i] = ObservationPointResponse{At: p.At, Value: p.Value, Interpretation: p.Interpretation}
+	}
+	return ObservationSeriesResponse{
+		Code:           s.Code,
+		Display:        s.Display,
+		Unit:           s.Unit,
+		ReferenceRange: toReferenceRangeDTO(s.Reference),
+		Points:         points,
+	}
+}
+
+// Mappers: DTO -> Domain
+
+func toObservationDomain(patientID string, req ObservationRequest) domain.Observation {
+	// Time parsing is handled in the handler
+	observation := domain.Observation{
+		PatientID:   patientID,
+		Code:        req.Code,
+		Value:       req.Value,
+		Unit:        req.Unit,
+		DiagnosisID: req.DiagnosisID,
+	}
+	if req.ReferenceRange != nil {
+		observation.Reference = domain.ReferenceRange{Low: req.ReferenceRange.Low, High: req.ReferenceRange.High}
+	}
+	return observation
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// RecordObservation records a vital sign or clinical observation
+// @Summary Record observation
+// @Description Record a measurement of a patient, identified by its LOINC code: systolic (8480-6) and diastolic (8462-4)
+// @Description blood pressure in mm[Hg], heart rate (8867-4) in /min, body temperature (8310-5) in Cel or [degF], body
+// @Description weight (29463-7) in kg, g or [lb_av] and blood glucose (2339-0) in mg/dL or mmol/L. Values outside the
+// @Description plausible range of the type are rejected. Without reference_range the adult range of the type applies,
+// @Description and the value is interpreted against it (L, N or H).
+// @Tags Observations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param observation body ObservationRequest true "Observation"
+// @Success 201 {object} ObservationResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/observations [post]
+func (h *HttpHandler) RecordObservation(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Record observation request received", "patient_id", patientID)
+
+	var req ObservationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode record observation request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	observation := toObservationDomain(patientID, req)
+	if req.EffectiveAt != "" {
+		effectiveAt, err := time.Parse(time.RFC3339, req.EffectiveAt)
+		if err != nil {
+			slog.Warn("Invalid effective_at format in observation request", "effective_at", req.EffectiveAt)
+			http.Error(w, "Invalid effective_at format, use ISO 8601", http.StatusBadRequest)
+			return
+		}
+		observation.EffectiveAt = effectiveAt
+	}
+
+	if err := h.app.Observation().RecordObservation(callerFromRequest(r), &observation); err != nil {
+		slog.Error("Failed to record observation", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toObservationResponse(observation))
+}
+
+// GetObservations lists the observations of a patient
+// @Summary List observations
+// @Description List the observations of a patient in chronological order, optionally of one type and date range
+// @Tags Observations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param code query string false "LOINC code of the observation type" example(8867-4)
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Success 200 {array} ObservationResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/observations [get]
+func (h *HttpHandler) GetObservations(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get observations request received", "patient_id", patientID)
+
+	from, to, ok := dayRangeFromQuery(w, r.URL.Query())
+	if !ok {
+		return
+	}
+	filter := domain.ObservationFilter{From: from, To: to}
+	if code := r.URL.Query().Get("code"); code != "" {
+		filter.Code = &code
+	}
+
+	observations, err := h.app.Observation().GetObservations(callerFromRequest(r), patientID, filter)
+	if err != nil {
+		slog.Error("Failed to get observations", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toObservationResponseList(observations))
+}
+
+// GetObservationSeries returns the time series of an observation type
+// @Summary Observation time series
+// @Description Time series of one observation type of a patient for charting, with every value converted to the
+// @Description canonical unit of the type (e.g. Cel for temperatures recorded in [degF])
+// @Tags Observations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param code query string true "LOINC code of the observation type" example(8867-4)
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day, inclusive (YYYY-MM-DD)"
+// @Success 200 {object} ObservationSeriesResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/observations/series [get]
+func (h *HttpHandler) GetObservationSeries(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	code := r.URL.Query().Get("code")
+	slog.Debug("Get observation series request received", "patient_id", patientID, "code", code)
+
+	from, to, ok := dayRangeFromQuery(w, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	series, err := h.app.Observation().GetObservationSeries(callerFromRequest(r), patientID, code, from, to)
+	if err != nil {
+		slog.Error("Failed to get observation series", "patient_id", patientID, "code", code, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toObservationSeriesResponse(*series))
+}
+
+// dayRangeFromQuery reads the from and to days of a query, writing a bad
+// request response when either is invalid. The to day is inclusive, so the
+// returned end is the start of the following day.
+func dayRangeFromQuery(w http.ResponseWriter, query url.Values) (from, to *time.Time, ok bool) {
+	if value := query.Get("from"); value != "" {
+		d, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			slog.Warn("Invalid from format", "date", value)
+			http.Error(w, "Invalid from format", http.StatusBadRequest)
+			return nil, nil, false
+		}
+		from = &d
+	}
+	if value := query.Get("to"); value != "" {
+		d, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			slog.Warn("Invalid to format", "date", value)
+			http.Error(w, "Invalid to format", http.StatusBadRequest)
+			return nil, nil, false
+		}
+		end := d.AddDate(0, 0, 1)
+		to = &end
+	}
+	return from, to, true
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type CreateReferralRequest struct {
+	PatientID        string `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	DiagnosisID      string `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPD"`       // Diagnosis the referral is about, optional
+	ToPractitionerID string `json:"to_practitioner_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPN"` // Either the practitioner or the specialty
+	ToSpecialty      string `json:"to_specialty,omitempty" example:"cardiology"`
+	Reason           string `json:"reason" example:"Soplo sistólico a estudio"`
+	Urgency          string `json:"urgency,omitempty" example:"urgent" enums:"routine,urgent,asap,stat"` // routine when omitted
+}
+
+type RejectReferralRequest struct {
+	Reason string `json:"reason" example:"Derivar a cardiología infantil"`
+}
+
+type CompleteReferralRequest struct {
+	Note string `json:"note,omitempty" example:"Ecocardiograma normal, alta"`
+}
+
+// Response DTOs
+
+type ReferralResponse struct {
+	ID                 string     `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPF"`
+	PatientID          string     `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	DiagnosisID        string     `json:"diagnosis_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPD"`
+	FromPractitionerID string     `json:"from_practitioner_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	ToPractitionerID   string     `json:"to_practitioner_id,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPN"`
+	ToSpecialty        string     `json:"to_specialty,omitempty" example:"cardiology"`
+	ToSpecialtyName    string     `json:"to_specialty_name,omitempty" example:"Cardiología"`
+	Reason             string     `json:"reason" example:"Soplo sistólico a estudio"`
+	Urgency            string     `json:"urgency" example:"urgent" enums:"routine,urgent,asap,stat"`
+	Status             string     `json:"status" example:"pending" enums:"pending,accepted,rejected,completed"`
+	RespondedBy        string     `json:"responded_by,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPN"`
+	RejectionReason    string     `json:"rejection_reason,omitempty" example:"Derivar a cardiología infantil"`
+	CompletionNote     string     `json:"completion_note,omitempty" example:"Ecocardiograma normal, alta"`
+	CreatedAt          time.Time  `json:"created_at" example:"2026-02-13T10:35:00Z"`
+	RespondedAt        *time.Time `json:"responded_at,omitempty" example:"2026-02-14T09:00:00Z"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty" example:"2026-02-20T12:00:00Z"`
+}
+
+// Mappers: Domain -> DTO
+
+func toReferralResponse(rf domain.Referral) ReferralResponse {
+	return ReferralResponse{
+		ID:                 rf.ID,
+		PatientID:          rf.PatientID,
+		DiagnosisID:        rf.DiagnosisID,
+		FromPractitionerID: rf.FromPractitionerID,
+		ToPractitionerID:   rf.ToPractitionerID,
+		ToSpecialty:        rf.ToSpecialty,
+		ToSpecialtyName:    domain.SpecialtyName(rf.ToSpecialty),
+		Reason:             rf.Reason,
+		Urgency:            rf.Urgency,
+		Status:             rf.Status,
+		RespondedBy:        rf.RespondedBy,
+		RejectionReason:    rf.RejectionReason,
+		CompletionNote:     rf.CompletionNote,
+		CreatedAt:          rf.CreatedAt,
+		RespondedAt:        rf.RespondedAt,
+		CompletedAt:        rf.CompletedAt,
+	}
+}
+
+func toReferralResponseList(referrals []domain.Referral) []ReferralResponse {
+	result := make([]ReferralResponse, len(referrals))
+	for i, rf := range referrals {
+		result[i] = toReferralResponse(rf)
+	}
+	return result
+}
+
+// Mappers: DTO -> Domain
+
+func toReferralDomain(req CreateReferralRequest) domain.Referral {
+	return domain.Referral{
+		PatientID:        req.PatientID,
+		DiagnosisID:      req.DiagnosisID,
+		ToPractitionerID: req.ToPractitionerID,
+		ToSpecialty:      req.ToSpecialty,
+		Reason:           req.Reason,
+		Urgency:          req.Urgency,
+	}
+}
@@ -0,0 +1,219 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"topdoctors/internal/domain"
+)
+
+// CreateReferral refers a patient to another practitioner or to a specialty
+// @Summary Create referral
+// @Description Refer a patient to a practitioner, or to any practitioner of a specialty, optionally about one of the
+// @Description patient's diagnoses. The referral stays pending until a recipient accepts or rejects it.
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param referral body CreateReferralRequest true "Referral"
+// @Success 201 {object} ReferralResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /referrals [post]
+func (h *HttpHandler) CreateReferral(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("Create referral request received")
+
+	var req CreateReferralRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode create referral request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	referral := toReferralDomain(req)
+	if err := h.app.Referral().CreateReferral(callerFromRequest(r), &referral); err != nil {
+		slog.Error("Failed to create referral", "patient_id", req.PatientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toReferralResponse(referral))
+}
+
+// AcceptReferral accepts a referral
+// @Summary Accept referral
+// @Description Accept a pending referral addressed to the caller or to their specialty. The caller joins the
+// @Description patient's care team.
+// @Tags Referrals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Referral ID"
+// @Success 200 {object} ReferralResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /referrals/{id}/accept [post]
+func (h *HttpHandler) AcceptReferral(w http.ResponseWriter, r *http.Request) {
+	referralID := r.PathValue("id")
+	slog.Debug("Accept referral request received", "referral_id", referralID)
+
+	referral, err := h.app.Referral().AcceptReferral(callerFromRequest(r), referralID)
+	if err != nil {
+		slog.Error("Failed to accept referral", "referral_id", referralID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toReferralResponse(*referral))
+}
+
+// RejectReferral rejects a referral
+// @Summary Reject referral
+// @Description Reject a pending referral addressed to the caller or to their specialty, telling the referring
+// @Description practitioner why
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Referral ID"
+// @Param rejection body RejectReferralRequest true "Rejection"
+// @Success 200 {object} ReferralResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /referrals/{id}/reject [post]
+func (h *HttpHandler) RejectReferral(w http.ResponseWriter, r *http.Request) {
+	referralID := r.PathValue("id")
+	slog.Debug("Reject referral request received", "referral_id", referralID)
+
+	var req RejectReferralRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode reject referral request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	referral, err := h.app.Referral().RejectReferral(callerFromRequest(r), referralID, req.Reason)
+	if err != nil {
+		slog.Error("Failed to reject referral", "referral_id", referralID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toReferralResponse(*referral))
+}
+
+// CompleteReferral completes a referral
+// @Summary Complete referral
+// @Description Close a referral the caller accepted once the patient was seen, with an optional note for the
+// @Description referring practitioner
+// @Tags Referrals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Referral ID"
+// @Param completion body CompleteReferralRequest false "Completion"
+// @Success 200 {object} ReferralResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Not Found"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /referrals/{id}/complete [post]
+func (h *HttpHandler) CompleteReferral(w http.ResponseWriter, r *http.Request) {
+	referralID := r.PathValue("id")
+	slog.Debug("Complete referral request received", "referral_id", referralID)
+
+	// The body is optional, a referral can be completed without a note
+	var req CompleteReferralRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		slog.Error("Failed to decode complete referral request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	referral, err := h.app.Referral().CompleteReferral(callerFromRequest(r), referralID, req.Note)
+	if err != nil {
+		slog.Error("Failed to complete referral", "referral_id", referralID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toReferralResponse(*referral))
+}
+
+// GetReferralInbox lists the referrals of the caller
+// @Summary Referral inbox
+// @Description List the pending referrals addressed to the caller or to their specialty, and the ones they
+// @Description answered, the most urgent first
+// @Tags Referrals
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Only referrals with this status" Enums(pending, accepted, rejected, completed)
+// @Success 200 {array} ReferralResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /referrals/inbox [get]
+func (h *HttpHandler) GetReferralInbox(w http.ResponseWriter, r *http.Request) {
+	slog.Debug("Get referral inbox request received")
+
+	var filter domain.ReferralInboxFilter
+	if status := r.URL.Query().Get("status"); status != "" {
+		filter.Status = &status
+	}
+
+	referrals, err := h.app.Referral().GetInbox(callerFromRequest(r), filter)
+	if err != nil {
+		slog.Error("Failed to get referral inbox", "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toReferralResponseList(referrals))
+}
+
+// GetPatientReferrals lists the referrals of a patient
+// @Summary List patient referrals
+// @Description List the referrals made for a patient in the order they were made
+// @Tags Referrals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} ReferralResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/referrals [get]
+func (h *HttpHandler) GetPatientReferrals(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get patient referrals request received", "patient_id", patientID)
+
+	referrals, err := h.app.Referral().GetPatientReferrals(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get patient referrals", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toReferralResponseList(referrals))
+}
@@ -41,11 +41,75 @@ func (s *Server) setupRouter() *http.ServeMux {
 	// Public Routes
 	mux.HandleFunc("POST /login", h.Login)
 	mux.HandleFunc("POST /register", h.Register)
+	// Calendar apps cannot send bearer tokens, the feed URL carries its own
+	mux.HandleFunc("GET /practitioners/{id}/calendar.ics", h.GetCalendarFeed)
+	// FHIR clients discover the server before authenticating
+	mux.HandleFunc("GET /fhir/r4/metadata", h.FHIRMetadata)
 
 	// Protected Routes
 	mux.Handle("GET /diagnostics", h.AuthMiddleware(http.HandlerFunc(h.GetDiagnostics)))
 	mux.Handle("POST /diagnostics", h.AuthMiddleware(http.HandlerFunc(h.CreateDiagnosis)))
+	mux.Handle("POST /diagnostics/{id}/attachments", h.AuthMiddleware(http.HandlerFunc(h.UploadAttachment)))
+	mux.Handle("GET /diagnostics/{id}/attachments/{attachmentId}", h.AuthMiddleware(http.HandlerFunc(h.DownloadAttachment)))
+	mux.Handle("GET /patients", h.AuthMiddleware(http.HandlerFunc(h.ListPatients)))
 	mux.Handle("POST /patients", h.AuthMiddleware(http.HandlerFunc(h.CreatePatient)))
+	mux.Handle("GET /patients/{id}", h.AuthMiddleware(http.HandlerFunc(h.GetPatient)))
+	mux.Handle("GET /patients/{id}/care-team", h.AuthMiddleware(http.HandlerFunc(h.GetCareTeam)))
+	mux.Handle("POST /patients/{id}/care-team", h.AuthMiddleware(http.HandlerFunc(h.AddCareTeamMember)))
+	mux.Handle("DELETE /patients/{id}/care-team/{userId}", h.AuthMiddleware(http.HandlerFunc(h.RemoveCareTeamMember)))
+	mux.Handle("POST /patients/{id}/break-glass", h.AuthMiddleware(http.HandlerFunc(h.BreakGlass)))
+	mux.Handle("GET /patients/{id}/consents", h.AuthMiddleware(http.HandlerFunc(h.GetConsents)))
+	mux.Handle("POST /patients/{id}/consents", h.AuthMiddleware(http.HandlerFunc(h.GrantConsent)))
+	mux.Handle("POST /patients/{id}/consents/{consentId}/revoke", h.AuthMiddleware(http.HandlerFunc(h.RevokeConsent)))
+	mux.Handle("GET /patients/{id}/export", h.AuthMiddleware(http.HandlerFunc(h.ExportPatient)))
+	mux.Handle("POST /patients/{id}/erasure", h.AuthMiddleware(http.HandlerFunc(h.ErasePatient)))
+	mux.Handle("GET /patients/{id}/duplicates", h.AuthMiddleware(http.HandlerFunc(h.FindDuplicates)))
+	mux.Handle("POST /patients/{id}/merge", h.AuthMiddleware(http.HandlerFunc(h.MergePatients)))
+	mux.Handle("GET /patients/{id}/contacts", h.AuthMiddleware(http.HandlerFunc(h.GetContacts)))
+	mux.Handle("POST /patients/{id}/contacts", h.AuthMiddleware(http.HandlerFunc(h.AddContact)))
+	mux.Handle("PUT /patients/{id}/contacts/{contactId}", h.AuthMiddleware(http.HandlerFunc(h.UpdateContact)))
+	mux.Handle("DELETE /patients/{id}/contacts/{contactId}", h.AuthMiddleware(http.HandlerFunc(h.DeleteContact)))
+	mux.Handle("POST /appointments", h.AuthMiddleware(http.HandlerFunc(h.BookAppointment)))
+	mux.Handle("POST /appointments/{id}/reschedule", h.AuthMiddleware(http.HandlerFunc(h.RescheduleAppointment)))
+	mux.Handle("POST /appointments/{id}/cancel", h.AuthMiddleware(http.HandlerFunc(h.CancelAppointment)))
+	mux.Handle("GET /patients/{id}/appointments", h.AuthMiddleware(http.HandlerFunc(h.GetPatientAppointments)))
+	mux.Handle("GET /practitioners/{id}/agenda", h.AuthMiddleware(http.HandlerFunc(h.GetAgenda)))
+	mux.Handle("GET /appointments/{id}/calendar.ics", h.AuthMiddleware(http.HandlerFunc(h.GetAppointmentCalendar)))
+	mux.Handle("POST /practitioners/{id}/calendar-feed", h.AuthMiddleware(http.HandlerFunc(h.CreateCalendarFeed)))
+	mux.Handle("DELETE /practitioners/{id}/calendar-feed", h.AuthMiddleware(http.HandlerFunc(h.RevokeCalendarFeed)))
+	mux.Handle("GET /patients/{id}/observations", h.AuthMiddleware(http.HandlerFunc(h.GetObservations)))
+	mux.Handle("POST /patients/{id}/observations", h.AuthMiddleware(http.HandlerFunc(h.RecordObservation)))
+	mux.Handle("GET /patients/{id}/observations/series", h.AuthMiddleware(http.HandlerFunc(h.GetObservationSeries)))
+	mux.Handle("GET /patients/{id}/lab-results", h.AuthMiddleware(http.HandlerFunc(h.GetLabResults)))
+	mux.Handle("POST /patients/{id}/lab-results", h.AuthMiddleware(http.HandlerFunc(h.IngestLabResults)))
+	mux.Handle("GET /lab-results/abnormal", h.AuthMiddleware(http.HandlerFunc(h.GetAbnormalLabResults)))
+	mux.Handle("GET /patients/{id}/vaccinations", h.AuthMiddleware(http.HandlerFunc(h.GetVaccinations)))
+	mux.Handle("POST /patients/{id}/vaccinations", h.AuthMiddleware(http.HandlerFunc(h.RecordVaccination)))
+	mux.Handle("GET /patients/{id}/vaccinations/forecast", h.AuthMiddleware(http.HandlerFunc(h.GetVaccinationForecast)))
+	mux.Handle("POST /referrals", h.AuthMiddleware(http.HandlerFunc(h.CreateReferral)))
+	mux.Handle("GET /referrals/inbox", h.AuthMiddleware(http.HandlerFunc(h.GetReferralInbox)))
+	mux.Handle("POST /referrals/{id}/accept", h.AuthMiddleware(http.HandlerFunc(h.AcceptReferral)))
+	mux.Handle("POST /referrals/{id}/reject", h.AuthMiddleware(http.HandlerFunc(h.RejectReferral)))
+	mux.Handle("POST /referrals/{id}/complete", h.AuthMiddleware(http.HandlerFunc(h.CompleteReferral)))
+	mux.Handle("GET /patients/{id}/referrals", h.AuthMiddleware(http.HandlerFunc(h.GetPatientReferrals)))
+	mux.Handle("POST /encounters", h.AuthMiddleware(http.HandlerFunc(h.OpenEncounter)))
+	mux.Handle("GET /encounters/{id}", h.AuthMiddleware(http.HandlerFunc(h.GetEncounter)))
+	mux.Handle("PUT /encounters/{id}/note", h.AuthMiddleware(http.HandlerFunc(h.UpdateEncounterNote)))
+	mux.Handle("POST /encounters/{id}/close", h.AuthMiddleware(http.HandlerFunc(h.CloseEncounter)))
+	mux.Handle("GET /patients/{id}/encounters", h.AuthMiddleware(http.HandlerFunc(h.GetPatientEncounters)))
+
+	// FHIR R4 facade
+	mux.Handle("GET /fhir/r4/Patient", h.AuthMiddleware(http.HandlerFunc(h.FHIRSearchPatients)))
+	mux.Handle("POST /fhir/r4/Patient", h.AuthMiddleware(http.HandlerFunc(h.FHIRCreatePatient)))
+	mux.Handle("GET /fhir/r4/Patient/{id}", h.AuthMiddleware(http.HandlerFunc(h.FHIRReadPatient)))
+	mux.Handle("GET /fhir/r4/Condition", h.AuthMiddleware(http.HandlerFunc(h.FHIRSearchConditions)))
+	mux.Handle("POST /fhir/r4/Condition", h.AuthMiddleware(http.HandlerFunc(h.FHIRCreateCondition)))
+	mux.Handle("GET /fhir/r4/Condition/{id}", h.AuthMiddleware(http.HandlerFunc(h.FHIRReadCondition)))
+	mux.Handle("GET /fhir/r4/MedicationRequest", h.AuthMiddleware(http.HandlerFunc(h.FHIRSearchMedicationRequests)))
+	mux.Handle("GET /fhir/r4/MedicationRequest/{id}", h.AuthMiddleware(http.HandlerFunc(h.FHIRReadMedicationRequest)))
+	mux.Handle("GET /fhir/r4/$export", h.AuthMiddleware(http.HandlerFunc(h.FHIRStartExport)))
+	mux.Handle("GET /fhir/r4/bulk-status/{id}", h.AuthMiddleware(http.HandlerFunc(h.FHIRExportStatus)))
+	mux.Handle("GET /fhir/r4/bulk-files/{id}/{file}", h.AuthMiddleware(http.HandlerFunc(h.FHIRDownloadExportFile)))
 
 	// Swagger UI
 	mux.Handle("GET /swagger/", httpSwagger.WrapHandler)
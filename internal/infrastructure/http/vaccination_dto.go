@@ -0,0 +1,112 @@
+package http
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// Request DTOs
+
+type VaccinationRequest struct {
+	VaccineCode    string `json:"vaccine_code" example:"HEXA"` // Code of the vaccine in the schedule
+	DoseNumber     int    `json:"dose_number" example:"1"`
+	Lot            string `json:"lot" example:"A12B345"`
+	AdministeredAt string `json:"administered_at,omitempty" example:"2026-02-13T10:30:00Z"`       // ISO 8601 format, now when omitted
+	AdministeredBy string `json:"administered_by,omitempty" example:"01HMGNBPJNX0G2BZXJ7XW1RHPN"` // The caller when omitted
+}
+
+// Response DTOs
+
+type VaccinationResponse struct {
+	ID             string    `json:"id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPV"`
+	PatientID      string    `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	VaccineCode    string    `json:"vaccine_code" example:"HEXA"`
+	DoseNumber     int       `json:"dose_number" example:"1"`
+	Lot            string    `json:"lot" example:"A12B345"`
+	AdministeredAt time.Time `json:"administered_at" example:"2026-02-13T10:30:00Z"`
+	AdministeredBy string    `json:"administered_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPN"`
+	RecordedBy     string    `json:"recorded_by" example:"01HMGNBPJNX0G2BZXJ7XW1RHPS"`
+	CreatedAt      time.Time `json:"created_at" example:"2026-02-13T10:35:00Z"`
+}
+
+type DueDoseResponse struct {
+	VaccineCode  string `json:"vaccine_code" example:"TV"`
+	VaccineName  string `json:"vaccine_name" example:"Triple vírica (SRP)"`
+	DoseNumber   int    `json:"dose_number" example:"1"`
+	AgeMonths    int    `json:"age_months" example:"12"`
+	MaxAgeMonths int    `json:"max_age_months,omitempty" example:"0"`
+	DueDate      string `json:"due_date" example:"2026-05-04"` // YYYY-MM-DD
+	Status       string `json:"status" example:"overdue" enums:"overdue,upcoming"`
+}
+
+type VaccinationForecastResponse struct {
+	PatientID string            `json:"patient_id" example:"01HMGNBPJNX0G2BZXJ7XW1RHPR"`
+	Schedule  string            `json:"schedule" example:"Calendario común de vacunación infantil (CISNS)"`
+	AsOf      time.Time         `json:"as_of" example:"2026-02-13T10:35:00Z"`
+	Overdue   []DueDoseResponse `json:"overdue"`
+	Upcoming  []DueDoseResponse `json:"upcoming"`
+}
+
+// Mappers: Domain -> DTO
+
+func toVaccinationResponse(v domain.Vaccination) VaccinationResponse {
+	return VaccinationResponse{
+		ID:             v.ID,
+		PatientID:      v.PatientID,
+		VaccineCode:    v.VaccineCode,
+		DoseNumber:     v.DoseNumber,
+		Lot:            v.Lot,
+		AdministeredAt: v.AdministeredAt,
+		AdministeredBy: v.AdministeredBy,
+		RecordedBy:     v.RecordedBy,
+		CreatedAt:      v.CreatedAt,
+	}
+}
+
+func toVaccinationResponseList(vaccinations []domain.Vaccination) []VaccinationResponse {
+	result := make([]VaccinationResponse, len(vaccinations))
+	for i, v := range vaccinations {
+		result[i] = toVaccinationResponse(v)
+	}
+	return result
+}
+
+func toDueDoseResponseList(doses []domain.DueDose) []DueDoseResponse {
+	result := make([]DueDoseResponse, len(doses))
+	for i, d := range doses {
+		result[i] = DueDoseResponse{
+			VaccineCode:  d.VaccineCode,
+			VaccineName:  d.VaccineName,
+			DoseNumber:   d.DoseNumber,
+			AgeMonths:    d.AgeMonths,
+			MaxAgeMonths: d.MaxAgeMonths,
+			DueDate:      d.DueDate.Format("2006-01-02"),
+			Status:       d.Status,
+		}
+	}
+	return result
+}
+
+func toVaccinationForecastResponse(f *domain.VaccinationForecast) VaccinationForecastResponse {
+	return VaccinationForecastResponse{
+		PatientID: f.PatientID,
+		Schedule:  f.Schedule,
+		AsOf:      f.At,
+		Overdue:   toDueDoseResponseList(f.Overdue),
+		Upcoming:  toDueDoseResponseList(f.Upcoming),
+	}
+}
+
+// Mappers: DTO -> Domain
+
+func toVaccinationDomain(patientID string, req VaccinationRequest, administeredAt time.Time) domain.Vaccination {
+	// Time parsing is handled in the handler
+	return domain.Vaccination{
+		PatientID:      patientID,
+		VaccineCode:    req.VaccineCode,
+		DoseNumber:     req.DoseNumber,
+		Lot:            req.Lot,
+		AdministeredAt: administeredAt,
+		AdministeredBy: req.AdministeredBy,
+	}
+}
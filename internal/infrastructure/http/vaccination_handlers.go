@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// RecordVaccination records a vaccine dose given to a patient
+// @Summary Record vaccination
+// @Description Record a dose of a vaccine given to a patient with its lot. Doses count towards the vaccination
+// @Description schedule when recorded with the vaccine code the schedule uses. Each dose of a vaccine can only be
+// @Description recorded once.
+// @Tags Vaccinations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param vaccination body VaccinationRequest true "Vaccination"
+// @Success 201 {object} VaccinationResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 409 {string} string "Conflict"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/vaccinations [post]
+func (h *HttpHandler) RecordVaccination(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Record vaccination request received", "patient_id", patientID)
+
+	var req VaccinationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode record vaccination request", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var administeredAt time.Time
+	if req.AdministeredAt != "" {
+		var err error
+		administeredAt, err = time.Parse(time.RFC3339, req.AdministeredAt)
+		if err != nil {
+			slog.Warn("Invalid administered_at format in vaccination request", "administered_at", req.AdministeredAt)
+			http.Error(w, "Invalid administered_at format, use ISO 8601", http.StatusBadRequest)
+			return
+		}
+	}
+
+	vaccination := toVaccinationDomain(patientID, req, administeredAt)
+	if err := h.app.Vaccination().RecordVaccination(callerFromRequest(r), &vaccination); err != nil {
+		slog.Error("Failed to record vaccination", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toVaccinationResponse(vaccination))
+}
+
+// GetVaccinations lists the vaccinations of a patient
+// @Summary List vaccinations
+// @Description List the vaccine doses given to a patient in the order they were given
+// @Tags Vaccinations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Success 200 {array} VaccinationResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/vaccinations [get]
+func (h *HttpHandler) GetVaccinations(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get vaccinations request received", "patient_id", patientID)
+
+	vaccinations, err := h.app.Vaccination().GetVaccinations(callerFromRequest(r), patientID)
+	if err != nil {
+		slog.Error("Failed to get vaccinations", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toVaccinationResponseList(vaccinations))
+}
+
+// GetVaccinationForecast lists the overdue and upcoming doses of a patient
+// @Summary Overdue and upcoming vaccine doses
+// @Description Compare the patient's vaccinations with the vaccination schedule from the birth date. Doses past their
+// @Description due date are overdue, unless the patient is already too old for them, and doses due in the next days
+// @Description are upcoming. Requires the patient's birth date.
+// @Tags Vaccinations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Patient ID"
+// @Param days query int false "Days ahead to look for upcoming doses (1-365)" default(90)
+// @Success 200 {object} VaccinationForecastResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /patients/{id}/vaccinations/forecast [get]
+func (h *HttpHandler) GetVaccinationForecast(w http.ResponseWriter, r *http.Request) {
+	patientID := r.PathValue("id")
+	slog.Debug("Get vaccination forecast request received", "patient_id", patientID)
+
+	days := domain.DefaultVaccinationHorizonDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		if days, err = strconv.Atoi(value); err != nil {
+			slog.Warn("Invalid days in vaccination forecast request", "days", value)
+			http.Error(w, "Invalid days, use a number of days", http.StatusBadRequest)
+			return
+		}
+	}
+
+	forecast, err := h.app.Vaccination().GetVaccinationForecast(callerFromRequest(r), patientID, time.Now(), days)
+	if err != nil {
+		slog.Error("Failed to get vaccination forecast", "patient_id", patientID, "error", err)
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toVaccinationForecastResponse(forecast))
+}
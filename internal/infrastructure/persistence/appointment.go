@@ -0,0 +1,209 @@
+package persistence
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type AppointmentDB struct {
+	ID               uint       `gorm:"primaryKey,autoIncrement"`
+	ULID             string     `gorm:"column:ulid;unique"`
+	PatientULID      string     `gorm:"column:patient_ulid;index"`
+	PractitionerULID string     `gorm:"column:practitioner_ulid;index"`
+	DiagnosisULID    *string    `gorm:"column:diagnosis_ulid"`
+	Status           string     `gorm:"index"`
+	StartAt          *time.Time `gorm:"column:start_at"`
+	EndAt            *time.Time `gorm:"column:end_at"`
+	DueDate          *time.Time `gorm:"column:due_date"`
+	Reason           string     // Encrypted
+	CreatedByULID    string     `gorm:"column:created_by_ulid"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+	CancelledAt      *time.Time
+}
+
+func (AppointmentDB) TableName() string {
+	return "appointments"
+}
+
+// Appointment Repository Implementation
+func (r *GormRepository) CreateAppointment(appointment *domain.Appointment) error {
+	dbAppointment, err := toAppointmentDB(appointment, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkAppointmentOverlap(tx, appointment); err != nil {
+			return err
+		}
+		return tx.Create(dbAppointment).Error
+	})
+}
+
+func (r *GormRepository) UpdateAppointment(appointment *domain.Appointment) error {
+	dbAppointment, err := toAppointmentDB(appointment, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkAppointmentOverlap(tx, appointment); err != nil {
+			return err
+		}
+		return tx.Model(&AppointmentDB{}).Where("ulid = ?", appointment.ID).Updates(map[string]interface{}{
+			"practitioner_ulid": dbAppointment.PractitionerULID,
+			"status":            dbAppointment.Status,
+			"start_at":          dbAppointment.StartAt,
+			"end_at":            dbAppointment.EndAt,
+			"due_date":          dbAppointment.DueDate,
+			"reason":            dbAppointment.Reason,
+			"cancelled_at":      dbAppointment.CancelledAt,
+		}).Error
+	})
+}
+
+func (r *GormRepository) GetAppointmentByID(id string) (*domain.Appointment, error) {
+	var appointment AppointmentDB
+	if err := r.db.Where("ulid = ?", id).First(&appointment).Error; err != nil {
+		return nil, err
+	}
+	return toAppointmentDomain(&appointment, r.cipher)
+}
+
+func (r *GormRepository) GetAppointmentsByPatientID(patientID string) ([]domain.Appointment, error) {
+	var appointments []AppointmentDB
+	err := r.db.Where("patient_ulid = ?", patientID).
+		Order("COALESCE(start_at, due_date), id").
+		Find(&appointments).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.toAppointmentsDomain(appointments)
+}
+
+func (r *GormRepository) GetAgenda(practitionerID string, from, to time.Time) ([]domain.Appointment, error) {
+	var appointments []AppointmentDB
+	err := r.db.Where("practitioner_ulid = ?", practitionerID).
+		Where(r.db.Where("status = ? AND start_at >= ? AND start_at < ?", domain.AppointmentStatusBooked, from, to).
+			Or("status = ? AND due_date >= ? AND due_date < ?", domain.AppointmentStatusPending, from, to)).
+		Order("COALESCE(start_at, due_date), id").
+		Find(&appointments).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.toAppointmentsDomain(appointments)
+}
+
+// checkAppointmentOverlap fails when a booked appointment would overlap
+// another of the practitioner's. It runs in the transaction that writes the
+// appointment so two concurrent bookings cannot both take the slot.
+func checkAppointmentOverlap(tx *gorm.DB, appointment *domain.Appointment) error {
+	if appointment.Status != domain.AppointmentStatusBooked {
+		return nil
+	}
+	var count int64
+	err := tx.Model(&AppointmentDB{}).
+		Where("practitioner_ulid = ? AND ulid <> ? AND status = ?", appointment.PractitionerID, appointment.ID, domain.AppointmentStatusBooked).
+		Where("start_at < ? AND end_at > ?", appointment.End, appointment.Start).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrAppointmentOverlap
+	}
+	return nil
+}
+
+// reencryptAppointments re-encrypts every appointment reason with the cipher
+// next, as part of a key rotation
+func (r *GormRepository) reencryptAppointments(tx *gorm.DB, next *fieldCipher) (int, error) {
+	var appointments []AppointmentDB
+	if err := tx.Find(&appointments).Error; err != nil {
+		return 0, err
+	}
+	for _, a := range appointments {
+		reason, err := r.cipher.decrypt(a.Reason)
+		if err != nil {
+			return 0, err
+		}
+		encrypted, err := next.encrypt(reason)
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Model(&AppointmentDB{}).Where("ulid = ?", a.ULID).Update("reason", encrypted).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(appointments), nil
+}
+
+func (r *GormRepository) toAppointmentsDomain(appointments []AppointmentDB) ([]domain.Appointment, error) {
+	result := make([]domain.Appointment, len(appointments))
+	for i, a := range appointments {
+		appointment, err := toAppointmentDomain(&a, r.cipher)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *appointment
+	}
+	return result, nil
+}
+
+// Mappers
+func toAppointmentDB(a *domain.Appointment, cipher *fieldCipher) (*AppointmentDB, error) {
+	reason, err := cipher.encrypt(a.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	dbAppointment := &AppointmentDB{
+		ULID:             a.ID,
+		PatientULID:      a.PatientID,
+		PractitionerULID: a.PractitionerID,
+		Status:           a.Status,
+		DueDate:          a.DueDate,
+		Reason:           reason,
+		CreatedByULID:    a.CreatedBy,
+		CreatedAt:        a.CreatedAt,
+		CancelledAt:      a.CancelledAt,
+	}
+	if a.DiagnosisID != "" {
+		dbAppointment.DiagnosisULID = &a.DiagnosisID
+	}
+	if !a.Start.IsZero() {
+		start, end := a.Start, a.End
+		dbAppointment.StartAt = &start
+		dbAppointment.EndAt = &end
+	}
+	return dbAppointment, nil
+}
+
+func toAppointmentDomain(a *AppointmentDB, cipher *fieldCipher) (*domain.Appointment, error) {
+	reason, err := cipher.decrypt(a.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment := &domain.Appointment{
+		ID:             a.ULID,
+		PatientID:      a.PatientULID,
+		PractitionerID: a.PractitionerULID,
+		DueDate:        a.DueDate,
+		Status:         a.Status,
+		Reason:         reason,
+		CreatedBy:      a.CreatedByULID,
+		CreatedAt:      a.CreatedAt,
+		CancelledAt:    a.CancelledAt,
+	}
+	if a.DiagnosisULID != nil {
+		appointment.DiagnosisID = *a.DiagnosisULID
+	}
+	if a.StartAt != nil {
+		appointment.Start = *a.StartAt
+	}
+	if a.EndAt != nil {
+		appointment.End = *a.EndAt
+	}
+	return appointment, nil
+}
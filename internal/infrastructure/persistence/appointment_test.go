@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestAppointments(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z"}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+
+	nine := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	booked := func(id string, start time.Time) *domain.Appointment {
+		return &domain.Appointment{ID: id, PatientID: patient.ID, PractitionerID: "doctor", Start: start, End: start.Add(30 * time.Minute),
+			Status: domain.AppointmentStatusBooked, Reason: "Revisión", CreatedBy: "doctor", CreatedAt: time.Now()}
+	}
+	first := booked("01HZY0000000000000000000A1", nine)
+	if err := repo.CreateAppointment(first); err != nil {
+		t.Fatalf("CreateAppointment() error = %v", err)
+	}
+
+	t.Run("Encrypts the reason", func(t *testing.T) {
+		var stored AppointmentDB
+		repo.db.Where("ulid = ?", first.ID).First(&stored)
+		if !strings.HasPrefix(stored.Reason, encryptedPrefix) {
+			t.Errorf("expected encrypted reason, got %q", stored.Reason)
+		}
+	})
+
+	t.Run("Rejects overlapping bookings", func(t *testing.T) {
+		if err := repo.CreateAppointment(booked("01HZY0000000000000000000A2", nine.Add(15*time.Minute))); err != domain.ErrAppointmentOverlap {
+			t.Errorf("CreateAppointment() = %v, want %v", err, domain.ErrAppointmentOverlap)
+		}
+		back := booked("01HZY0000000000000000000A3", nine.Add(30*time.Minute))
+		if err := repo.CreateAppointment(back); err != nil {
+			t.Fatalf("CreateAppointment() back to back error = %v", err)
+		}
+		if err := back.Reschedule(nine, nine.Add(10*time.Minute)); err != nil {
+			t.Fatalf("Reschedule() error = %v", err)
+		}
+		if err := repo.UpdateAppointment(back); err != domain.ErrAppointmentOverlap {
+			t.Errorf("UpdateAppointment() = %v, want %v", err, domain.ErrAppointmentOverlap)
+		}
+	})
+
+	t.Run("Cancelled appointments free the slot", func(t *testing.T) {
+		if err := first.Cancel(time.Now()); err != nil {
+			t.Fatalf("Cancel() error = %v", err)
+		}
+		if err := repo.UpdateAppointment(first); err != nil {
+			t.Fatalf("UpdateAppointment() error = %v", err)
+		}
+		if err := repo.CreateAppointment(booked("01HZY0000000000000000000A4", nine)); err != nil {
+			t.Errorf("CreateAppointment() in freed slot error = %v", err)
+		}
+	})
+
+	t.Run("Agenda lists booked appointments and due follow-ups", func(t *testing.T) {
+		due := nine.Truncate(24 * time.Hour)
+		followUp := &domain.Appointment{ID: "01HZY0000000000000000000A5", PatientID: patient.ID, PractitionerID: "doctor", DueDate: &due,
+			Status: domain.AppointmentStatusPending, Reason: "Follow-up", CreatedBy: "doctor", CreatedAt: time.Now()}
+		if err := repo.CreateAppointment(followUp); err != nil {
+			t.Fatalf("CreateAppointment() follow-up error = %v", err)
+		}
+
+		from, to, _ := domain.AgendaRange(nine, domain.AgendaViewDay)
+		agenda, err := repo.GetAgenda("doctor", from, to)
+		if err != nil {
+			t.Fatalf("GetAgenda() error = %v", err)
+		}
+		var ids []string
+		for _, a := range agenda {
+			ids = append(ids, a.ID)
+		}
+		// The cancelled first appointment is left out
+		want := "01HZY0000000000000000000A5,01HZY0000000000000000000A4,01HZY0000000000000000000A3"
+		if strings.Join(ids, ",") != want {
+			t.Errorf("GetAgenda() = %v, want %v", ids, want)
+		}
+
+		nextDay, err := repo.GetAgenda("doctor", to, to.AddDate(0, 0, 1))
+		if err != nil || len(nextDay) != 0 {
+			t.Errorf("GetAgenda() next day = %+v, %v", nextDay, err)
+		}
+	})
+
+	t.Run("Stores a follow-up with its diagnosis", func(t *testing.T) {
+		diagnosis := &domain.Diagnosis{ID: "01HZY0000000000000000000D1", PatientID: patient.ID, Diagnosis: "Hipertensión", Date: nine}
+		followUp, _ := domain.NewFollowUp(diagnosis, "doctor", 14)
+		followUp.ID, followUp.CreatedAt = "01HZY0000000000000000000A6", time.Now()
+		if err := repo.CreateDiagnosis(diagnosis, followUp); err != nil {
+			t.Fatalf("CreateDiagnosis() error = %v", err)
+		}
+		got, err := repo.GetAppointmentByID(followUp.ID)
+		if err != nil || got.DiagnosisID != diagnosis.ID || got.Status != domain.AppointmentStatusPending {
+			t.Errorf("GetAppointmentByID() = %+v, %v", got, err)
+		}
+
+		// A follow-up that cannot be stored leaves no diagnosis behind
+		again := &domain.Diagnosis{ID: "01HZY0000000000000000000D2", PatientID: patient.ID, Diagnosis: "Hipertensión", Date: nine}
+		if err := repo.CreateDiagnosis(again, followUp); err == nil {
+			t.Fatal("CreateDiagnosis() with a clashing follow-up expected error, got nil")
+		}
+		if _, err := repo.GetDiagnosisByID(again.ID); err == nil {
+			t.Error("expected the diagnosis to be rolled back with its follow-up")
+		}
+	})
+
+	t.Run("Survives key rotation", func(t *testing.T) {
+		if _, err := repo.RotateKeys(nil); err != nil {
+			t.Fatalf("RotateKeys() error = %v", err)
+		}
+		got, err := repo.GetAppointmentByID(first.ID)
+		if err != nil || got.Reason != "Revisión" || got.Status != domain.AppointmentStatusCancelled || got.CancelledAt == nil {
+			t.Errorf("GetAppointmentByID() = %+v, %v", got, err)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"errors"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentDB struct {
+	ID             uint   `gorm:"primaryKey,autoIncrement"`
+	ULID           string `gorm:"column:ulid;unique"`
+	DiagnosisULID  string `gorm:"column:diagnosis_ulid;index"`
+	PatientULID    string `gorm:"column:patient_ulid;index"`
+	FileName       string // Encrypted, names often carry the patient's name
+	ContentType    string
+	Size           int64
+	Checksum       string
+	ContentKey     string    // Wrapped by the patient's key
+	UploadedByULID string    `gorm:"column:uploaded_by_ulid"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (AttachmentDB) TableName() string {
+	return "attachments"
+}
+
+// Attachment Repository Implementation
+func (r *GormRepository) CreateAttachment(attachment *domain.Attachment) error {
+	dbAttachment, err := toAttachmentDB(attachment, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(dbAttachment).Error
+}
+
+func (r *GormRepository) GetAttachmentByID(id string) (*domain.Attachment, error) {
+	var attachment AttachmentDB
+	err := r.db.Where("ulid = ?", id).First(&attachment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toAttachmentDomain(&attachment, r.cipher)
+}
+
+func (r *GormRepository) GetAttachmentsByPatientID(patientID string) ([]domain.Attachment, error) {
+	var attachments []AttachmentDB
+	if err := r.db.Where("patient_ulid = ?", patientID).Order("created_at, id").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return toAttachmentDomainList(attachments, r.cipher)
+}
+
+func (r *GormRepository) reencryptAttachments(tx *gorm.DB, next *fieldCipher) (int, error) {
+	var attachments []AttachmentDB
+	if err := tx.Find(&attachments).Error; err != nil {
+		return 0, err
+	}
+	for _, a := range attachments {
+		name, err := r.cipher.decrypt(a.FileName)
+		if err != nil {
+			return 0, err
+		}
+		encrypted, err := next.encrypt(name)
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Model(&AttachmentDB{}).Where("ulid = ?", a.ULID).Update("file_name", encrypted).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(attachments), nil
+}
+
+// rewrapAttachmentKeys wraps the content keys of a patient's attachments
+// with another patient's key, for a merge. It runs before the transaction
+// moving them, patient keys cannot be created inside it.
+func (r *GormRepository) rewrapAttachmentKeys(fromPatientID, toPatientID string) ([]*AttachmentDB, error) {
+	var stored []AttachmentDB
+	if err := r.db.Where("patient_ulid = ?", fromPatientID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	attachments, err := toAttachmentDomainList(stored, r.cipher)
+	if err != nil {
+		return nil, err
+	}
+	moved := make([]*AttachmentDB, len(attachments))
+	for i := range attachments {
+		attachments[i].PatientID = toPatientID
+		if moved[i], err = toAttachmentDB(&attachments[i], r.cipher); err != nil {
+			return nil, err
+		}
+	}
+	return moved, nil
+}
+
+// moveAttachments reassigns the attachments of a merged duplicate to the
+// survivor. Their keys must have been wrapped with the survivor's key already.
+func moveAttachments(tx *gorm.DB, moved []*AttachmentDB) error {
+	for _, a := range moved {
+		err := tx.Model(&AttachmentDB{}).Where("ulid = ?", a.ULID).Updates(map[string]interface{}{
+			"patient_ulid": a.PatientULID,
+			"content_key":  a.ContentKey,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachmentOrder lists the attachments preloaded with a diagnosis in upload order
+func attachmentOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("attachments.created_at, attachments.id")
+}
+
+// Mappers
+func toAttachmentDB(a *domain.Attachment, cipher *fieldCipher) (*AttachmentDB, error) {
+	name, err := cipher.encrypt(a.FileName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := cipher.encryptForPatient(a.PatientID, base64.StdEncoding.EncodeToString(a.ContentKey))
+	if err != nil {
+		return nil, err
+	}
+	return &AttachmentDB{
+		ULID:           a.ID,
+		DiagnosisULID:  a.DiagnosisID,
+		PatientULID:    a.PatientID,
+		FileName:       name,
+		ContentType:    a.ContentType,
+		Size:           a.Size,
+		Checksum:       a.Checksum,
+		ContentKey:     key,
+		UploadedByULID: a.UploadedBy,
+		CreatedAt:      a.CreatedAt,
+	}, nil
+}
+
+func toAttachmentDomain(a *AttachmentDB, cipher *fieldCipher) (*domain.Attachment, error) {
+	name, err := cipher.decrypt(a.FileName)
+	if err != nil {
+		return nil, err
+	}
+	encodedKey, err := cipher.decryptForPatient(a.PatientULID, a.ContentKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Attachment{
+		ID:          a.ULID,
+		DiagnosisID: a.DiagnosisULID,
+		PatientID:   a.PatientULID,
+		FileName:    name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		ContentKey:  key,
+		UploadedBy:  a.UploadedByULID,
+		CreatedAt:   a.CreatedAt,
+	}, nil
+}
+
+func toAttachmentDomainList(attachments []AttachmentDB, cipher *fieldCipher) ([]domain.Attachment, error) {
+	result := make([]domain.Attachment, len(attachments))
+	for i, a := range attachments {
+		attachment, err := toAttachmentDomain(&a, cipher)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *attachment
+	}
+	return result, nil
+}
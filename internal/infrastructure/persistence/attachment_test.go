@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestAttachments(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z"}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+	diagnosis := &domain.Diagnosis{ID: "01HZY0000000000000000000D1", PatientID: patient.ID, Diagnosis: "Esguince de tobillo", Date: time.Now()}
+	if err := repo.CreateDiagnosis(diagnosis, nil); err != nil {
+		t.Fatalf("CreateDiagnosis() error = %v", err)
+	}
+
+	checksum := strings.Repeat("ab", 32)
+	attachment := &domain.Attachment{
+		ID: "01HZY0000000000000000000A1", DiagnosisID: diagnosis.ID, PatientID: patient.ID, FileName: "rx-lucia-ruiz.png",
+		ContentType: domain.AttachmentTypePNG, Size: 2048, Checksum: checksum, ContentKey: []byte("content-key"), UploadedBy: "doctor", CreatedAt: time.Now(),
+	}
+	if err := repo.CreateAttachment(attachment); err != nil {
+		t.Fatalf("CreateAttachment() error = %v", err)
+	}
+
+	t.Run("Encrypts the file name and wraps the content key", func(t *testing.T) {
+		var stored AttachmentDB
+		repo.db.Where("ulid = ?", attachment.ID).First(&stored)
+		if !strings.HasPrefix(stored.FileName, encryptedPrefix) {
+			t.Errorf("expected file name encrypted, got %q", stored.FileName)
+		}
+		if !strings.HasPrefix(stored.ContentKey, patientEncryptedPrefix) {
+			t.Errorf("expected content key wrapped by the patient's key, got %q", stored.ContentKey)
+		}
+	})
+
+	t.Run("Lists the attachments with their diagnosis", func(t *testing.T) {
+		diagnoses, err := repo.GetDiagnosisByPatientID(patient.ID)
+		if err != nil || len(diagnoses) != 1 {
+			t.Fatalf("GetDiagnosisByPatientID() = %+v, %v", diagnoses, err)
+		}
+		got := diagnoses[0].Attachments
+		if len(got) != 1 || got[0].FileName != attachment.FileName || got[0].Checksum != checksum {
+			t.Errorf("Attachments = %+v", got)
+		}
+	})
+
+	t.Run("Finds the diagnosis and the attachment by ID", func(t *testing.T) {
+		found, err := repo.GetDiagnosisByID(diagnosis.ID)
+		if err != nil || found.PatientID != patient.ID {
+			t.Errorf("GetDiagnosisByID() = %+v, %v", found, err)
+		}
+		if _, err := repo.GetDiagnosisByID("unknown"); err != domain.ErrDiagnosisNotFound {
+			t.Errorf("GetDiagnosisByID() unknown = %v, want %v", err, domain.ErrDiagnosisNotFound)
+		}
+		if _, err := repo.GetAttachmentByID("unknown"); err != domain.ErrAttachmentNotFound {
+			t.Errorf("GetAttachmentByID() unknown = %v, want %v", err, domain.ErrAttachmentNotFound)
+		}
+	})
+
+	t.Run("Keeps file names readable after key rotation", func(t *testing.T) {
+		if _, err := repo.RotateKeys(nil); err != nil {
+			t.Fatalf("RotateKeys() error = %v", err)
+		}
+		got, err := repo.GetAttachmentByID(attachment.ID)
+		if err != nil || got.FileName != attachment.FileName || string(got.ContentKey) != "content-key" {
+			t.Errorf("GetAttachmentByID() = %+v, %v", got, err)
+		}
+	})
+}
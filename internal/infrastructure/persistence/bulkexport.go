@@ -0,0 +1,320 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// bulkExportBatchSize bounds the records held in memory while a bulk export
+// runs
+const bulkExportBatchSize = 500
+
+type BulkExportJobDB struct {
+	ID              uint   `gorm:"primaryKey,autoIncrement"`
+	ULID            string `gorm:"column:ulid;unique"`
+	RequestedByULID string `gorm:"column:requested_by_ulid;index"`
+	Types           string // Comma separated resource types
+	Since           *time.Time
+	Status          string
+	Error           string
+	Output          []BulkExportFileDB `gorm:"foreignKey:JobULID;references:ULID;constraint:OnDelete:CASCADE"`
+	RequestedAt     time.Time
+	CompletedAt     *time.Time
+	ExpiresAt       *time.Time `gorm:"index"`
+	ContentKey      string     // Encrypted with the field key
+}
+
+func (BulkExportJobDB) TableName() string {
+	return "bulk_export_jobs"
+}
+
+type BulkExportFileDB struct {
+	ID      uint   `gorm:"primaryKey,autoIncrement"`
+	JobULID string `gorm:"column:job_ulid;index"`
+	Type    string
+	Count   int
+}
+
+func (BulkExportFileDB) TableName() string {
+	return "bulk_export_files"
+}
+
+// BulkExportPatientDB records a patient whose data a job holds, to withdraw
+// the job if they are erased
+type BulkExportPatientDB struct {
+	ID          uint   `gorm:"primaryKey,autoIncrement"`
+	JobULID     string `gorm:"column:job_ulid;index"`
+	PatientULID string `gorm:"column:patient_ulid;index"`
+}
+
+func (BulkExportPatientDB) TableName() string {
+	return "bulk_export_patients"
+}
+
+// Bulk Export Repository Implementation
+func (r *GormRepository) CreateBulkExportJob(job *domain.BulkExportJob) error {
+	dbJob, err := toBulkExportJobDB(job, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(dbJob).Error
+}
+
+func (r *GormRepository) UpdateBulkExportJob(job *domain.BulkExportJob) error {
+	dbJob, err := toBulkExportJobDB(job, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&BulkExportJobDB{}).Where("ulid = ?", job.ID).Updates(map[string]interface{}{
+			"status":       dbJob.Status,
+			"error":        dbJob.Error,
+			"completed_at": dbJob.CompletedAt,
+			"expires_at":   dbJob.ExpiresAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("job_ulid = ?", job.ID).Delete(&BulkExportFileDB{}).Error; err != nil {
+			return err
+		}
+		if len(dbJob.Output) == 0 {
+			return nil
+		}
+		return tx.Create(&dbJob.Output).Error
+	})
+}
+
+func (r *GormRepository) GetBulkExportJobByID(id string) (*domain.BulkExportJob, error) {
+	var job BulkExportJobDB
+	err := r.db.Preload("Output", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Where("ulid = ?", id).First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrBulkExportJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toBulkExportJobDomain(&job, r.cipher)
+}
+
+func (r *GormRepository) GetBulkExportJobsByStatus(status string) ([]domain.BulkExportJob, error) {
+	return r.findBulkExportJobs(r.db.Where("status = ?", status))
+}
+
+func (r *GormRepository) GetExpiredBulkExportJobs(at time.Time) ([]domain.BulkExportJob, error) {
+	return r.findBulkExportJobs(r.db.Where("expires_at <= ? OR (expires_at IS NULL AND completed_at <= ?)",
+		at, at.Add(-domain.BulkExportRetention)))
+}
+
+func (r *GormRepository) DeleteBulkExportJob(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_ulid = ?", id).Delete(&BulkExportFileDB{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("job_ulid = ?", id).Delete(&BulkExportPatientDB{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("ulid = ?", id).Delete(&BulkExportJobDB{}).Error
+	})
+}
+
+func (r *GormRepository) AddBulkExportPatients(jobID string, patientIDs []string) error {
+	rows := make([]BulkExportPatientDB, len(patientIDs))
+	for i, id := range patientIDs {
+		rows[i] = BulkExportPatientDB{JobULID: jobID, PatientULID: id}
+	}
+	return r.db.CreateInBatches(rows, bulkExportBatchSize).Error
+}
+
+func (r *GormRepository) GetBulkExportJobsWithPatient(patientID, status string) ([]domain.BulkExportJob, error) {
+	held := r.db.Model(&BulkExportPatientDB{}).Select("job_ulid").
+		Where("patient_ulid = ? OR patient_ulid IN (?)", patientID,
+			r.db.Model(&PatientDB{}).Select("ulid").Where("merged_into_ulid = ?", patientID))
+	return r.findBulkExportJobs(r.db.Where("status = ? AND ulid IN (?)", status, held))
+}
+
+func (r *GormRepository) HasErasedBulkExportPatients(jobID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&BulkExportPatientDB{}).
+		Joins("JOIN patients ON patients.ulid = bulk_export_patients.patient_ulid").
+		Where("bulk_export_patients.job_ulid = ? AND patients.erased_at IS NOT NULL", jobID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormRepository) findBulkExportJobs(query *gorm.DB) ([]domain.BulkExportJob, error) {
+	var jobs []BulkExportJobDB
+	err := query.Preload("Output", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Order("id").Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.BulkExportJob, len(jobs))
+	for i, j := range jobs {
+		job, err := toBulkExportJobDomain(&j, r.cipher)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *job
+	}
+	return result, nil
+}
+
+// reencryptBulkExportJobs encrypts the content keys of the jobs with the next
+// field key
+func (r *GormRepository) reencryptBulkExportJobs(tx *gorm.DB, next *fieldCipher) (int, error) {
+	var jobs []BulkExportJobDB
+	if err := tx.Find(&jobs).Error; err != nil {
+		return 0, err
+	}
+	for _, j := range jobs {
+		key, err := r.cipher.decrypt(j.ContentKey)
+		if err != nil {
+			return 0, err
+		}
+		encrypted, err := next.encrypt(key)
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Model(&BulkExportJobDB{}).Where("ulid = ?", j.ULID).Update("content_key", encrypted).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(jobs), nil
+}
+
+// ExportPatients walks the patients in order of creation. Merged and erased
+// records are left out, their data is either held by another record or gone.
+func (r *GormRepository) ExportPatients(caller domain.Caller, since *time.Time, fn func([]domain.Patient) error) error {
+	lastID := uint(0)
+	for {
+		query := r.db.Table("patients AS Patient").
+			Where("Patient.merged_into_ulid IS NULL AND Patient.erased_at IS NULL AND Patient.id > ?", lastID)
+		query = r.exportableBy(query, caller, domain.ConsentScopeDemographics)
+		if since != nil {
+			query = query.Where("Patient.updated_at > ?", *since)
+		}
+
+		var patients []PatientDB
+		if err := query.Order("Patient.id").Limit(bulkExportBatchSize).Find(&patients).Error; err != nil {
+			return err
+		}
+		if len(patients) == 0 {
+			return nil
+		}
+
+		batch := make([]domain.Patient, len(patients))
+		for i, p := range patients {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			batch[i] = *patient
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		lastID = patients[len(patients)-1].ID
+	}
+}
+
+// ExportDiagnoses walks the diagnoses in order of creation, with their
+// patient. Those of erased patients are left out.
+func (r *GormRepository) ExportDiagnoses(caller domain.Caller, since *time.Time, fn func([]domain.Diagnosis) error) error {
+	lastID := uint(0)
+	for {
+		query := r.db.Model(&DiagnosisDB{}).Joins("Patient").
+			Where("Patient.erased_at IS NULL AND diagnoses.id > ?", lastID)
+		query = r.exportableBy(query, caller, domain.ConsentScopeDiagnoses)
+		if since != nil {
+			query = query.Where("diagnoses.updated_at > ?", *since)
+		}
+
+		var diagnoses []DiagnosisDB
+		if err := query.Order("diagnoses.id").Limit(bulkExportBatchSize).Find(&diagnoses).Error; err != nil {
+			return err
+		}
+		if len(diagnoses) == 0 {
+			return nil
+		}
+
+		batch, err := toDiagnosisDomainList(diagnoses, r.cipher)
+		if err != nil {
+			return err
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		lastID = diagnoses[len(diagnoses)-1].ID
+	}
+}
+
+// exportableBy restricts a query joined with Patient to the patients whose
+// data the caller may export: with consent to share the scope for integration
+// clients, their own patients for anyone else
+func (r *GormRepository) exportableBy(query *gorm.DB, caller domain.Caller, scope string) *gorm.DB {
+	if caller.IsIntegration() {
+		return r.consentedTo(query, domain.ConsentPurposeThirdPartySharing, scope, time.Now())
+	}
+	return r.accessibleBy(query, caller.UserID, time.Now())
+}
+
+// Mappers
+func toBulkExportJobDB(j *domain.BulkExportJob, cipher *fieldCipher) (*BulkExportJobDB, error) {
+	key, err := cipher.encrypt(base64.StdEncoding.EncodeToString(j.ContentKey))
+	if err != nil {
+		return nil, err
+	}
+	output := make([]BulkExportFileDB, len(j.Output))
+	for i, f := range j.Output {
+		output[i] = BulkExportFileDB{JobULID: j.ID, Type: f.Type, Count: f.Count}
+	}
+	return &BulkExportJobDB{
+		ULID:            j.ID,
+		RequestedByULID: j.RequestedBy,
+		Types:           strings.Join(j.Types, ","),
+		Since:           j.Since,
+		Status:          j.Status,
+		Error:           j.Error,
+		Output:          output,
+		RequestedAt:     j.RequestedAt,
+		CompletedAt:     j.CompletedAt,
+		ExpiresAt:       j.ExpiresAt,
+		ContentKey:      key,
+	}, nil
+}
+
+func toBulkExportJobDomain(j *BulkExportJobDB, cipher *fieldCipher) (*domain.BulkExportJob, error) {
+	encodedKey, err := cipher.decrypt(j.ContentKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]domain.BulkExportFile, len(j.Output))
+	for i, f := range j.Output {
+		output[i] = domain.BulkExportFile{Type: f.Type, Count: f.Count}
+	}
+	job := &domain.BulkExportJob{
+		ID:          j.ULID,
+		RequestedBy: j.RequestedByULID,
+		Since:       j.Since,
+		Status:      j.Status,
+		Error:       j.Error,
+		Output:      output,
+		RequestedAt: j.RequestedAt,
+		CompletedAt: j.CompletedAt,
+		ExpiresAt:   j.ExpiresAt,
+		ContentKey:  key,
+	}
+	if j.Types != "" {
+		job.Types = strings.Split(j.Types, ",")
+	}
+	return job, nil
+}
@@ -0,0 +1,293 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestBulkExport(t *testing.T) {
+	repo := newTestRepository(t)
+	integration := domain.Caller{UserID: "partner", Role: domain.RoleIntegration}
+
+	consented := []string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}
+	for i, id := range append(consented, "01HZY0000000000000000000P3") {
+		patient := &domain.Patient{ID: id, GivenName: "Ana", FirstSurname: "García", DNI: []string{"12345678Z", "11111111H", "87654321X"}[i]}
+		if err := repo.CreatePatient(patient, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		diagnosis := &domain.Diagnosis{ID: "01HZY0000000000000000000D" + id[len(id)-1:], PatientID: id, Diagnosis: "Faringitis", Date: time.Now()}
+		if err := repo.CreateDiagnosis(diagnosis, nil); err != nil {
+			t.Fatalf("CreateDiagnosis() error = %v", err)
+		}
+	}
+	// P1 shares everything, P2 its diagnoses only and P3 nothing
+	repo.CreateConsent(&domain.Consent{ID: "01HZY0000000000000000000C1", PatientID: consented[0],
+		Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeAll, GrantedAt: time.Now().Add(-time.Hour)})
+	repo.CreateConsent(&domain.Consent{ID: "01HZY0000000000000000000C2", PatientID: consented[1],
+		Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeDiagnoses, GrantedAt: time.Now().Add(-time.Hour)})
+
+	exportedPatients := func(t *testing.T, since *time.Time) []string {
+		t.Helper()
+		var ids []string
+		err := repo.ExportPatients(integration, since, func(patients []domain.Patient) error {
+			for _, p := range patients {
+				ids = append(ids, p.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ExportPatients() error = %v", err)
+		}
+		return ids
+	}
+	exportedDiagnoses := func(t *testing.T, since *time.Time) []string {
+		t.Helper()
+		var ids []string
+		err := repo.ExportDiagnoses(integration, since, func(diagnoses []domain.Diagnosis) error {
+			for _, d := range diagnoses {
+				if d.Diagnosis != "Faringitis" {
+					t.Errorf("expected the diagnosis decrypted, got %q", d.Diagnosis)
+				}
+				ids = append(ids, d.PatientID)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ExportDiagnoses() error = %v", err)
+		}
+		return ids
+	}
+
+	t.Run("Exports what patients consented to share", func(t *testing.T) {
+		if got := exportedPatients(t, nil); !slices.Equal(got, consented[:1]) {
+			t.Errorf("ExportPatients() = %v", got)
+		}
+		if got := exportedDiagnoses(t, nil); !slices.Equal(got, consented) {
+			t.Errorf("ExportDiagnoses() = %v", got)
+		}
+	})
+
+	t.Run("Exports only what was updated since", func(t *testing.T) {
+		lastWeek := time.Now().AddDate(0, 0, -7)
+		repo.db.Model(&DiagnosisDB{}).Where("patient_ulid = ?", consented[0]).UpdateColumn("updated_at", lastWeek)
+
+		since := time.Now().AddDate(0, 0, -1).UTC()
+		if got := exportedDiagnoses(t, &since); !slices.Equal(got, consented[1:]) {
+			t.Errorf("ExportDiagnoses() since yesterday = %v", got)
+		}
+		if got := exportedPatients(t, &since); !slices.Equal(got, consented[:1]) {
+			t.Errorf("ExportPatients() since yesterday = %v", got)
+		}
+		future := time.Now().Add(time.Hour)
+		if got := exportedPatients(t, &future); len(got) != 0 {
+			t.Errorf("ExportPatients() since a future time = %v", got)
+		}
+	})
+
+	t.Run("Stops at the first failing batch", func(t *testing.T) {
+		errStop := errors.New("stop")
+		err := repo.ExportDiagnoses(integration, nil, func([]domain.Diagnosis) error { return errStop })
+		if !errors.Is(err, errStop) {
+			t.Errorf("ExportDiagnoses() error = %v, want %v", err, errStop)
+		}
+	})
+
+	t.Run("Stores the job and its output", func(t *testing.T) {
+		job := &domain.BulkExportJob{ID: "01HZY0000000000000000000J1", RequestedBy: integration.UserID, Types: domain.BulkExportTypes,
+			Status: domain.BulkExportStatusInProgress, RequestedAt: time.Now(), ContentKey: []byte("content-key")}
+		if err := repo.CreateBulkExportJob(job); err != nil {
+			t.Fatalf("CreateBulkExportJob() error = %v", err)
+		}
+		running, err := repo.GetBulkExportJobsByStatus(domain.BulkExportStatusInProgress)
+		if err != nil || len(running) != 1 || running[0].ID != job.ID {
+			t.Errorf("GetBulkExportJobsByStatus() = %+v, %v", running, err)
+		}
+
+		completedAt := time.Now()
+		job.Status, job.CompletedAt = domain.BulkExportStatusCompleted, &completedAt
+		job.Output = []domain.BulkExportFile{{Type: domain.BulkExportTypePatient, Count: 1}, {Type: domain.BulkExportTypeCondition, Count: 2}}
+		if err := repo.UpdateBulkExportJob(job); err != nil {
+			t.Fatalf("UpdateBulkExportJob() error = %v", err)
+		}
+
+		got, err := repo.GetBulkExportJobByID(job.ID)
+		if err != nil {
+			t.Fatalf("GetBulkExportJobByID() error = %v", err)
+		}
+		if got.Status != domain.BulkExportStatusCompleted || got.CompletedAt == nil || !slices.Equal(got.Types, domain.BulkExportTypes) ||
+			!slices.Equal(got.Output, job.Output) || string(got.ContentKey) != "content-key" {
+			t.Errorf("GetBulkExportJobByID() = %+v", got)
+		}
+
+		var stored BulkExportJobDB
+		repo.db.Where("ulid = ?", job.ID).First(&stored)
+		if stored.ContentKey == "" || strings.Contains(stored.ContentKey, base64.StdEncoding.EncodeToString(job.ContentKey)) {
+			t.Errorf("expected the content key to be encrypted, got %q", stored.ContentKey)
+		}
+		if _, err := repo.RotateKeys(nil); err != nil {
+			t.Fatalf("RotateKeys() error = %v", err)
+		}
+		if got, err := repo.GetBulkExportJobByID(job.ID); err != nil || string(got.ContentKey) != "content-key" {
+			t.Errorf("GetBulkExportJobByID() after key rotation = %+v, %v", got, err)
+		}
+
+		if _, err := repo.GetBulkExportJobByID("01HZY0000000000000000000J9"); !errors.Is(err, domain.ErrBulkExportJobNotFound) {
+			t.Errorf("GetBulkExportJobByID() error = %v, want %v", err, domain.ErrBulkExportJobNotFound)
+		}
+	})
+
+	t.Run("Deletes the jobs past their retention", func(t *testing.T) {
+		completedAt := time.Now()
+		expiresAt := completedAt.Add(domain.BulkExportRetention)
+		job := &domain.BulkExportJob{ID: "01HZY0000000000000000000J2", RequestedBy: integration.UserID, Types: domain.BulkExportTypes,
+			Status: domain.BulkExportStatusInProgress, RequestedAt: completedAt}
+		if err := repo.CreateBulkExportJob(job); err != nil {
+			t.Fatalf("CreateBulkExportJob() error = %v", err)
+		}
+		job.Status, job.CompletedAt, job.ExpiresAt = domain.BulkExportStatusCompleted, &completedAt, &expiresAt
+		job.Output = []domain.BulkExportFile{{Type: domain.BulkExportTypePatient, Count: 1}}
+		if err := repo.UpdateBulkExportJob(job); err != nil {
+			t.Fatalf("UpdateBulkExportJob() error = %v", err)
+		}
+
+		if expired, err := repo.GetExpiredBulkExportJobs(completedAt); err != nil || len(expired) != 0 {
+			t.Errorf("GetExpiredBulkExportJobs() before the expiry = %+v, %v", expired, err)
+		}
+		// The job stored above completed without an expiry, it is kept as long
+		// from its completion
+		expired, err := repo.GetExpiredBulkExportJobs(expiresAt)
+		if err != nil || len(expired) != 2 || expired[0].ID != "01HZY0000000000000000000J1" || expired[1].ID != job.ID ||
+			expired[1].ExpiresAt == nil {
+			t.Fatalf("GetExpiredBulkExportJobs() = %+v, %v", expired, err)
+		}
+
+		if err := repo.DeleteBulkExportJob(job.ID); err != nil {
+			t.Fatalf("DeleteBulkExportJob() error = %v", err)
+		}
+		if _, err := repo.GetBulkExportJobByID(job.ID); !errors.Is(err, domain.ErrBulkExportJobNotFound) {
+			t.Errorf("GetBulkExportJobByID() error = %v, want %v", err, domain.ErrBulkExportJobNotFound)
+		}
+		var files int64
+		repo.db.Model(&BulkExportFileDB{}).Where("job_ulid = ?", job.ID).Count(&files)
+		if files != 0 {
+			t.Errorf("expected the output of the deleted job to go, %d rows left", files)
+		}
+	})
+
+	t.Run("Finds the jobs holding a patient", func(t *testing.T) {
+		completedAt := time.Now()
+		job := &domain.BulkExportJob{ID: "01HZY0000000000000000000J3", RequestedBy: integration.UserID, Types: domain.BulkExportTypes,
+			Status: domain.BulkExportStatusInProgress, RequestedAt: completedAt}
+		if err := repo.CreateBulkExportJob(job); err != nil {
+			t.Fatalf("CreateBulkExportJob() error = %v", err)
+		}
+		job.Status, job.CompletedAt = domain.BulkExportStatusCompleted, &completedAt
+		if err := repo.UpdateBulkExportJob(job); err != nil {
+			t.Fatalf("UpdateBulkExportJob() error = %v", err)
+		}
+		if err := repo.AddBulkExportPatients(job.ID, []string{consented[1]}); err != nil {
+			t.Fatalf("AddBulkExportPatients() error = %v", err)
+		}
+		const other = "01HZY0000000000000000000J1"
+		if err := repo.AddBulkExportPatients(other, []string{consented[0], "01HZY0000000000000000000P3"}); err != nil {
+			t.Fatalf("AddBulkExportPatients() error = %v", err)
+		}
+
+		jobIDs := func(patientID string) []string {
+			t.Helper()
+			jobs, err := repo.GetBulkExportJobsWithPatient(patientID, domain.BulkExportStatusCompleted)
+			if err != nil {
+				t.Fatalf("GetBulkExportJobsWithPatient() error = %v", err)
+			}
+			var ids []string
+			for _, j := range jobs {
+				ids = append(ids, j.ID)
+			}
+			return ids
+		}
+		if got := jobIDs(consented[1]); !slices.Equal(got, []string{job.ID}) {
+			t.Errorf("GetBulkExportJobsWithPatient() = %v, want %v", got, []string{job.ID})
+		}
+		if got := jobIDs(consented[0]); !slices.Equal(got, []string{other}) {
+			t.Errorf("GetBulkExportJobsWithPatient() = %v, want %v", got, []string{other})
+		}
+		if got, _ := repo.GetBulkExportJobsWithPatient(consented[1], domain.BulkExportStatusInProgress); len(got) != 0 {
+			t.Errorf("GetBulkExportJobsWithPatient() in progress = %+v", got)
+		}
+
+		// A duplicate merged into a patient is the same person
+		repo.db.Model(&PatientDB{}).Where("ulid = ?", "01HZY0000000000000000000P3").Update("merged_into_ulid", consented[1])
+		if got := jobIDs(consented[1]); !slices.Equal(got, []string{other, job.ID}) {
+			t.Errorf("GetBulkExportJobsWithPatient() with a merged duplicate = %v", got)
+		}
+
+		if erased, err := repo.HasErasedBulkExportPatients(job.ID); err != nil || erased {
+			t.Errorf("HasErasedBulkExportPatients() = %v, %v, want false", erased, err)
+		}
+		repo.db.Model(&PatientDB{}).Where("ulid = ?", consented[1]).Update("erased_at", time.Now())
+		if erased, err := repo.HasErasedBulkExportPatients(job.ID); err != nil || !erased {
+			t.Errorf("HasErasedBulkExportPatients() after the erasure = %v, %v, want true", erased, err)
+		}
+		if erased, err := repo.HasErasedBulkExportPatients(other); err != nil || erased {
+			t.Errorf("HasErasedBulkExportPatients() of another job = %v, %v, want false", erased, err)
+		}
+	})
+}
+
+func TestExportSinceAcrossTimeZones(t *testing.T) {
+	local := time.Local
+	t.Cleanup(func() { time.Local = local })
+	written := time.FixedZone("UTC+2", 2*60*60)
+	time.Local = written
+
+	repo := newTestRepository(t)
+	integration := domain.Caller{UserID: "partner", Role: domain.RoleIntegration}
+	ids := []string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}
+	for i, id := range ids {
+		patient := &domain.Patient{ID: id, GivenName: "Ana", FirstSurname: "García", DNI: []string{"12345678Z", "11111111H"}[i]}
+		if err := repo.CreatePatient(patient, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		repo.CreateConsent(&domain.Consent{ID: "01HZY0000000000000000000C" + id[len(id)-1:], PatientID: id,
+			Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeAll, GrantedAt: time.Now().Add(-time.Hour)})
+	}
+	// P2 was last updated half an hour ago, stored in the local time of the
+	// server as it was before times were bound in UTC
+	legacy := time.Now().Add(-30 * time.Minute).In(written).Format("2006-01-02 15:04:05.999999999-07:00")
+	repo.db.Exec("UPDATE patients SET updated_at = ? WHERE ulid = ?", legacy, ids[1])
+	if err := repo.utcTimestamps(&PatientDB{}); err != nil {
+		t.Fatalf("utcTimestamps() error = %v", err)
+	}
+
+	// The server now runs in another zone
+	time.Local = time.FixedZone("UTC-5", -5*60*60)
+	exported := func(since time.Time) []string {
+		t.Helper()
+		var got []string
+		err := repo.ExportPatients(integration, &since, func(patients []domain.Patient) error {
+			for _, p := range patients {
+				got = append(got, p.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ExportPatients() error = %v", err)
+		}
+		return got
+	}
+
+	if got := exported(time.Now().Add(-time.Hour)); !slices.Equal(got, ids) {
+		t.Errorf("ExportPatients() since an hour ago = %v, want %v", got, ids)
+	}
+	if got := exported(time.Now().Add(-10 * time.Minute).In(written)); !slices.Equal(got, ids[:1]) {
+		t.Errorf("ExportPatients() since ten minutes ago = %v, want %v", got, ids[:1])
+	}
+	if got := exported(time.Now().Add(time.Minute).UTC()); len(got) != 0 {
+		t.Errorf("ExportPatients() since a minute ahead = %v", got)
+	}
+}
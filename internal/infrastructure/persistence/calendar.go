@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm/clause"
+)
+
+type CalendarFeedDB struct {
+	ID               uint      `gorm:"primaryKey,autoIncrement"`
+	PractitionerULID string    `gorm:"column:practitioner_ulid;unique"`
+	Nonce            string    `gorm:"column:nonce"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+}
+
+func (CalendarFeedDB) TableName() string {
+	return "calendar_feeds"
+}
+
+// Calendar Feed Repository Implementation
+func (r *GormRepository) SaveCalendarFeed(feed *domain.CalendarFeed) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "practitioner_ulid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nonce", "created_at"}),
+	}).Create(toCalendarFeedDB(feed)).Error
+}
+
+func (r *GormRepository) GetCalendarFeed(practitionerID string) (*domain.CalendarFeed, error) {
+	var feed CalendarFeedDB
+	if err := r.db.Where("practitioner_ulid = ?", practitionerID).First(&feed).Error; err != nil {
+		return nil, err
+	}
+	return toCalendarFeedDomain(&feed), nil
+}
+
+func (r *GormRepository) DeleteCalendarFeed(practitionerID string) error {
+	return r.db.Where("practitioner_ulid = ?", practitionerID).Delete(&CalendarFeedDB{}).Error
+}
+
+// Mappers
+func toCalendarFeedDB(f *domain.CalendarFeed) *CalendarFeedDB {
+	return &CalendarFeedDB{
+		PractitionerULID: f.PractitionerID,
+		Nonce:            f.Nonce,
+		CreatedAt:        f.CreatedAt,
+	}
+}
+
+func toCalendarFeedDomain(f *CalendarFeedDB) *domain.CalendarFeed {
+	return &domain.CalendarFeed{
+		PractitionerID: f.PractitionerULID,
+		Nonce:          f.Nonce,
+		CreatedAt:      f.CreatedAt,
+	}
+}
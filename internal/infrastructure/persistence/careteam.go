@@ -0,0 +1,189 @@
+package persistence
+
+import (
+	"errors"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type CareTeamMemberDB struct {
+	ID          uint      `gorm:"primaryKey,autoIncrement"`
+	PatientULID string    `gorm:"column:patient_ulid;uniqueIndex:idx_care_team_member"`
+	UserULID    string    `gorm:"column:user_ulid;uniqueIndex:idx_care_team_member;index"`
+	AddedByULID string    `gorm:"column:added_by_ulid"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (CareTeamMemberDB) TableName() string {
+	return "care_team_members"
+}
+
+type BreakGlassAccessDB struct {
+	ID               uint   `gorm:"primaryKey,autoIncrement"`
+	ULID             string `gorm:"column:ulid;unique"`
+	PatientULID      string `gorm:"column:patient_ulid;index"`
+	UserULID         string `gorm:"column:user_ulid;index"`
+	Justification    string
+	GrantedAt        time.Time
+	ExpiresAt        time.Time
+	FlaggedForReview bool
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+}
+
+func (BreakGlassAccessDB) TableName() string {
+	return "break_glass_accesses"
+}
+
+type AccessLogEntryDB struct {
+	ID          uint   `gorm:"primaryKey,autoIncrement"`
+	ULID        string `gorm:"column:ulid;unique"`
+	PatientULID string `gorm:"column:patient_ulid;index"`
+	UserULID    string `gorm:"column:user_ulid"`
+	Action      string
+	BreakGlass  bool
+	At          time.Time
+}
+
+func (AccessLogEntryDB) TableName() string {
+	return "access_log"
+}
+
+// Care Team Repository Implementation
+func (r *GormRepository) AddCareTeamMember(member *domain.CareTeamMember) error {
+	return r.db.Create(toCareTeamMemberDB(member)).Error
+}
+
+func (r *GormRepository) RemoveCareTeamMember(patientID, userID string) error {
+	return r.db.Where("patient_ulid = ? AND user_ulid = ?", patientID, userID).Delete(&CareTeamMemberDB{}).Error
+}
+
+func (r *GormRepository) GetCareTeam(patientID string) ([]domain.CareTeamMember, error) {
+	var members []CareTeamMemberDB
+	err := r.db.Where("patient_ulid = ?", patientID).Order("created_at").Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CareTeamMember, len(members))
+	for i, m := range members {
+		result[i] = *toCareTeamMemberDomain(&m)
+	}
+	return result, nil
+}
+
+func (r *GormRepository) IsCareTeamMember(patientID, userID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&CareTeamMemberDB{}).Where("patient_ulid = ? AND user_ulid = ?", patientID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormRepository) CreateBreakGlassAccess(access *domain.BreakGlassAccess) error {
+	return r.db.Create(toBreakGlassAccessDB(access)).Error
+}
+
+func (r *GormRepository) GetActiveBreakGlassAccess(patientID, userID string, at time.Time) (*domain.BreakGlassAccess, error) {
+	var access BreakGlassAccessDB
+	err := r.db.Where("patient_ulid = ? AND user_ulid = ? AND granted_at <= ? AND expires_at > ?", patientID, userID, at, at).
+		Order("expires_at DESC").First(&access).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toBreakGlassAccessDomain(&access), nil
+}
+
+func (r *GormRepository) CreateAccessLogEntry(entry *domain.AccessLogEntry) error {
+	return r.db.Create(toAccessLogEntryDB(entry)).Error
+}
+
+func (r *GormRepository) GetAccessLogByPatientID(patientID string) ([]domain.AccessLogEntry, error) {
+	var entries []AccessLogEntryDB
+	err := r.db.Where("patient_ulid = ?", patientID).Order("at").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AccessLogEntry, len(entries))
+	for i, e := range entries {
+		result[i] = *toAccessLogEntryDomain(&e)
+	}
+	return result, nil
+}
+
+// accessibleBy restricts a query joined with Patient to the patients a user
+// may access: their care team or an active break-glass grant
+func (r *GormRepository) accessibleBy(query *gorm.DB, userID string, at time.Time) *gorm.DB {
+	careTeam := r.db.Model(&CareTeamMemberDB{}).Select("patient_ulid").Where("user_ulid = ?", userID)
+	breakGlass := r.db.Model(&BreakGlassAccessDB{}).Select("patient_ulid").
+		Where("user_ulid = ? AND granted_at <= ? AND expires_at > ?", userID, at, at)
+	return query.Where("(Patient.ulid IN (?) OR Patient.ulid IN (?))", careTeam, breakGlass)
+}
+
+// Mappers
+func toCareTeamMemberDB(m *domain.CareTeamMember) *CareTeamMemberDB {
+	return &CareTeamMemberDB{
+		PatientULID: m.PatientID,
+		UserULID:    m.UserID,
+		AddedByULID: m.AddedBy,
+		CreatedAt:   m.AddedAt,
+	}
+}
+
+func toCareTeamMemberDomain(m *CareTeamMemberDB) *domain.CareTeamMember {
+	return &domain.CareTeamMember{
+		PatientID: m.PatientULID,
+		UserID:    m.UserULID,
+		AddedBy:   m.AddedByULID,
+		AddedAt:   m.CreatedAt,
+	}
+}
+
+func toBreakGlassAccessDB(b *domain.BreakGlassAccess) *BreakGlassAccessDB {
+	return &BreakGlassAccessDB{
+		ULID:             b.ID,
+		PatientULID:      b.PatientID,
+		UserULID:         b.UserID,
+		Justification:    b.Justification,
+		GrantedAt:        b.GrantedAt,
+		ExpiresAt:        b.ExpiresAt,
+		FlaggedForReview: b.FlaggedForReview,
+	}
+}
+
+func toBreakGlassAccessDomain(b *BreakGlassAccessDB) *domain.BreakGlassAccess {
+	return &domain.BreakGlassAccess{
+		ID:               b.ULID,
+		PatientID:        b.PatientULID,
+		UserID:           b.UserULID,
+		Justification:    b.Justification,
+		GrantedAt:        b.GrantedAt,
+		ExpiresAt:        b.ExpiresAt,
+		FlaggedForReview: b.FlaggedForReview,
+	}
+}
+
+func toAccessLogEntryDB(e *domain.AccessLogEntry) *AccessLogEntryDB {
+	return &AccessLogEntryDB{
+		ULID:        e.ID,
+		PatientULID: e.PatientID,
+		UserULID:    e.UserID,
+		Action:      e.Action,
+		BreakGlass:  e.BreakGlass,
+		At:          e.At,
+	}
+}
+
+func toAccessLogEntryDomain(e *AccessLogEntryDB) *domain.AccessLogEntry {
+	return &domain.AccessLogEntry{
+		ID:         e.ULID,
+		PatientID:  e.PatientULID,
+		UserID:     e.UserULID,
+		Action:     e.Action,
+		BreakGlass: e.BreakGlass,
+		At:         e.At,
+	}
+}
@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+func TestCreatePatientCareTeam(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Fernández", DNI: "12345678Z", Email: "lucia@example.com"}
+	creator := &domain.CareTeamMember{PatientID: patient.ID, UserID: "doctor", AddedBy: "doctor", AddedAt: time.Now()}
+
+	t.Run("Adds the creator to the care team", func(t *testing.T) {
+		if err := repo.CreatePatient(patient, creator); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		member, err := repo.IsCareTeamMember(patient.ID, "doctor")
+		if err != nil || !member {
+			t.Errorf("IsCareTeamMember() = %v, %v, want true", member, err)
+		}
+	})
+
+	t.Run("Rolls back the patient when the care team fails", func(t *testing.T) {
+		// The creator row clashes with the unique care team index
+		other := &domain.Patient{ID: "01HZY0000000000000000000P2", GivenName: "Luis", FirstSurname: "Pérez", DNI: "11111111H", Email: "luis@example.com"}
+		if err := repo.CreatePatient(other, creator); err == nil {
+			t.Fatal("CreatePatient() expected error, got nil")
+		}
+		if _, err := repo.GetPatientByID(other.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("GetPatientByID() error = %v, want %v", err, gorm.ErrRecordNotFound)
+		}
+	})
+}
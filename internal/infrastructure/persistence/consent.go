@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ConsentDB struct {
+	ID                 uint   `gorm:"primaryKey,autoIncrement"`
+	ULID               string `gorm:"column:ulid;unique"`
+	PatientULID        string `gorm:"column:patient_ulid;index"`
+	Purpose            string
+	Scope              string
+	GrantedAt          time.Time
+	RevokedAt          *time.Time
+	Evidence           string
+	RecordedByULID     string    `gorm:"column:recorded_by_ulid"`
+	RepresentativeULID *string   `gorm:"column:representative_ulid"` // Contact who consented on behalf of a minor
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ConsentDB) TableName() string {
+	return "consents"
+}
+
+// Consent Repository Implementation
+func (r *GormRepository) CreateConsent(consent *domain.Consent) error {
+	return r.db.Create(toConsentDB(consent)).Error
+}
+
+func (r *GormRepository) GetConsentByID(id string) (*domain.Consent, error) {
+	var consent ConsentDB
+	err := r.db.Where("ulid = ?", id).First(&consent).Error
+	if err != nil {
+		return nil, err
+	}
+	return toConsentDomain(&consent), nil
+}
+
+func (r *GormRepository) GetConsentsByPatientID(patientID string) ([]domain.Consent, error) {
+	var consents []ConsentDB
+	err := r.db.Where("patient_ulid = ?", patientID).Order("granted_at").Find(&consents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Consent, len(consents))
+	for i, c := range consents {
+		result[i] = *toConsentDomain(&c)
+	}
+	return result, nil
+}
+
+func (r *GormRepository) RevokeConsent(id string, at time.Time) error {
+	return r.db.Model(&ConsentDB{}).Where("ulid = ? AND revoked_at IS NULL", id).Update("revoked_at", at).Error
+}
+
+// consentedTo restricts a query joined with Patient to the patients with an
+// active consent for the given purpose covering the given scope
+func (r *GormRepository) consentedTo(query *gorm.DB, purpose, scope string, at time.Time) *gorm.DB {
+	consented := r.db.Model(&ConsentDB{}).Select("patient_ulid").
+		Where("purpose = ? AND scope IN ? AND granted_at <= ? AND (revoked_at IS NULL OR revoked_at > ?)",
+			purpose, []string{domain.ConsentScopeAll, scope}, at, at)
+	return query.Where("Patient.ulid IN (?)", consented)
+}
+
+// Mappers
+func toConsentDB(c *domain.Consent) *ConsentDB {
+	consent := &ConsentDB{
+		ULID:           c.ID,
+		PatientULID:    c.PatientID,
+		Purpose:        c.Purpose,
+		Scope:          c.Scope,
+		GrantedAt:      c.GrantedAt,
+		RevokedAt:      c.RevokedAt,
+		Evidence:       c.Evidence,
+		RecordedByULID: c.RecordedBy,
+	}
+	if c.RepresentativeID != "" {
+		consent.RepresentativeULID = &c.RepresentativeID
+	}
+	return consent
+}
+
+func toConsentDomain(c *ConsentDB) *domain.Consent {
+	consent := &domain.Consent{
+		ID:         c.ULID,
+		PatientID:  c.PatientULID,
+		Purpose:    c.Purpose,
+		Scope:      c.Scope,
+		GrantedAt:  c.GrantedAt,
+		RevokedAt:  c.RevokedAt,
+		Evidence:   c.Evidence,
+		RecordedBy: c.RecordedByULID,
+	}
+	if c.RepresentativeULID != nil {
+		consent.RepresentativeID = *c.RepresentativeULID
+	}
+	return consent
+}
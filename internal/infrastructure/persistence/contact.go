@@ -0,0 +1,159 @@
+package persistence
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ContactDB struct {
+	ID                  uint   `gorm:"primaryKey,autoIncrement"`
+	ULID                string `gorm:"column:ulid;unique"`
+	PatientULID         string `gorm:"column:patient_ulid;index"`
+	Relationship        string
+	Name                string // Encrypted
+	Phone               string // Encrypted
+	Email               string // Encrypted
+	LegalRepresentative bool
+	CreatedByULID       string    `gorm:"column:created_by_ulid"`
+	CreatedAt           time.Time `gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ContactDB) TableName() string {
+	return "contacts"
+}
+
+// Contact Repository Implementation
+func (r *GormRepository) CreateContact(contact *domain.Contact) error {
+	dbContact, err := toContactDB(contact, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(dbContact).Error
+}
+
+func (r *GormRepository) GetContactByID(id string) (*domain.Contact, error) {
+	var contact ContactDB
+	if err := r.db.Where("ulid = ?", id).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return toContactDomain(&contact, r.cipher)
+}
+
+func (r *GormRepository) GetContactsByPatientID(patientID string) ([]domain.Contact, error) {
+	var contacts []ContactDB
+	if err := r.db.Where("patient_ulid = ?", patientID).Order("id").Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Contact, len(contacts))
+	for i, c := range contacts {
+		contact, err := toContactDomain(&c, r.cipher)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *contact
+	}
+	return result, nil
+}
+
+func (r *GormRepository) UpdateContact(contact *domain.Contact) error {
+	return r.updateContact(r.db, contact, r.cipher)
+}
+
+func (r *GormRepository) DeleteContact(id string) error {
+	return r.db.Where("ulid = ?", id).Delete(&ContactDB{}).Error
+}
+
+// updateContact rewrites a contact with its identifying fields encrypted with c
+func (r *GormRepository) updateContact(tx *gorm.DB, contact *domain.Contact, c *fieldCipher) error {
+	dbContact, err := toContactDB(contact, c)
+	if err != nil {
+		return err
+	}
+	return tx.Model(&ContactDB{}).Where("ulid = ?", contact.ID).Updates(map[string]interface{}{
+		"relationship":         dbContact.Relationship,
+		"name":                 dbContact.Name,
+		"phone":                dbContact.Phone,
+		"email":                dbContact.Email,
+		"legal_representative": dbContact.LegalRepresentative,
+	}).Error
+}
+
+// reencryptContacts re-encrypts every contact with the cipher next, as part
+// of a key rotation
+func (r *GormRepository) reencryptContacts(tx *gorm.DB, next *fieldCipher) (int, error) {
+	var contacts []ContactDB
+	if err := tx.Find(&contacts).Error; err != nil {
+		return 0, err
+	}
+	for _, c := range contacts {
+		contact, err := toContactDomain(&c, r.cipher)
+		if err != nil {
+			return 0, err
+		}
+		if err := r.updateContact(tx, contact, next); err != nil {
+			return 0, err
+		}
+	}
+	return len(contacts), nil
+}
+
+// Mappers
+func toContactDB(c *domain.Contact, cipher *fieldCipher) (*ContactDB, error) {
+	dbContact := &ContactDB{
+		ULID:                c.ID,
+		PatientULID:         c.PatientID,
+		Relationship:        c.Relationship,
+		LegalRepresentative: c.LegalRepresentative,
+		CreatedByULID:       c.CreatedBy,
+		CreatedAt:           c.CreatedAt,
+	}
+
+	fields := []struct {
+		dst *string
+		src string
+	}{
+		{&dbContact.Name, c.Name},
+		{&dbContact.Phone, c.Phone},
+		{&dbContact.Email, c.Email},
+	}
+	for _, f := range fields {
+		encrypted, err := cipher.encrypt(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = encrypted
+	}
+	return dbContact, nil
+}
+
+func toContactDomain(c *ContactDB, cipher *fieldCipher) (*domain.Contact, error) {
+	contact := &domain.Contact{
+		ID:                  c.ULID,
+		PatientID:           c.PatientULID,
+		Relationship:        c.Relationship,
+		LegalRepresentative: c.LegalRepresentative,
+		CreatedBy:           c.CreatedByULID,
+		CreatedAt:           c.CreatedAt,
+	}
+
+	fields := []struct {
+		dst *string
+		src string
+	}{
+		{&contact.Name, c.Name},
+		{&contact.Phone, c.Phone},
+		{&contact.Email, c.Email},
+	}
+	for _, f := range fields {
+		decrypted, err := cipher.decrypt(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = decrypted
+	}
+	return contact, nil
+}
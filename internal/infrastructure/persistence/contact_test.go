@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestContacts(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z"}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+	contact := &domain.Contact{ID: "01HZY0000000000000000000C1", PatientID: patient.ID, Relationship: domain.RelationshipParent,
+		Name: "Carmen López", Phone: "+34600654321", LegalRepresentative: true, CreatedBy: "doctor", CreatedAt: time.Now()}
+	if err := repo.CreateContact(contact); err != nil {
+		t.Fatalf("CreateContact() error = %v", err)
+	}
+
+	t.Run("Encrypts identifying fields", func(t *testing.T) {
+		var stored ContactDB
+		repo.db.Where("ulid = ?", contact.ID).First(&stored)
+		for _, value := range []string{stored.Name, stored.Phone} {
+			if !strings.HasPrefix(value, encryptedPrefix) {
+				t.Errorf("expected encrypted value, got %q", value)
+			}
+		}
+	})
+
+	t.Run("Updates and survives key rotation", func(t *testing.T) {
+		contact.Email = "carmen@example.com"
+		if err := repo.UpdateContact(contact); err != nil {
+			t.Fatalf("UpdateContact() error = %v", err)
+		}
+		if _, err := repo.RotateKeys(nil); err != nil {
+			t.Fatalf("RotateKeys() error = %v", err)
+		}
+		got, err := repo.GetContactsByPatientID(patient.ID)
+		if err != nil || len(got) != 1 || got[0].Name != "Carmen López" || got[0].Email != "carmen@example.com" || !got[0].LegalRepresentative {
+			t.Errorf("GetContactsByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Erasure removes the contacts", func(t *testing.T) {
+		patient.Anonymize("01HZY0000000000000000000E1", time.Now())
+		erasure := &domain.Erasure{ID: "01HZY0000000000000000000E1", PatientID: patient.ID, RequestedBy: "doctor", ErasedAt: time.Now(), RetainUntil: time.Now()}
+		if err := repo.ErasePatient(patient, erasure); err != nil {
+			t.Fatalf("ErasePatient() error = %v", err)
+		}
+		got, err := repo.GetContactsByPatientID(patient.ID)
+		if err != nil || len(got) != 0 {
+			t.Errorf("expected no contacts after erasure, got %+v, %v", got, err)
+		}
+	})
+}
@@ -0,0 +1,405 @@
+package persistence
+
+import (
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// Diagnosis and prescription text is encrypted with per-patient keys, so the
+// database can no longer search it. Each term produced by the Spanish
+// analyzer is stored as a blind index token instead, keyed with the index key
+// and kept apart from the ciphertext, which lets text queries match diagnoses
+// by equality of tokens without revealing their content.
+//
+// The token table is always maintained and answers queries on any build. When
+// SQLite is compiled with FTS5 (build tag sqlite_fts5) the same tokens are
+// also written to a diagnosis_fts virtual table, which ranks results with
+// bm25. A PostgreSQL deployment would keep the tokens in a tsvector column
+// with a GIN index and rank with ts_rank.
+
+const (
+	diagnosisTokenNamespace = "diagnosis_text"
+
+	fieldDiagnosis    = "diagnosis"
+	fieldPrescription = "prescription"
+
+	createDiagnosisFTS = "CREATE VIRTUAL TABLE IF NOT EXISTS diagnosis_fts USING fts5(" +
+		"diagnosis_ulid UNINDEXED, patient_ulid UNINDEXED, diagnosis, prescription)"
+)
+
+type DiagnosisSearchTokenDB struct {
+	ID            uint   `gorm:"primaryKey,autoIncrement"`
+	DiagnosisULID string `gorm:"column:diagnosis_ulid;index"`
+	PatientULID   string `gorm:"column:patient_ulid;index"`
+	Field         string
+	TokenHash     string `gorm:"index"`
+	Frequency     int
+}
+
+func (DiagnosisSearchTokenDB) TableName() string {
+	return "diagnosis_search_tokens"
+}
+
+// textMatchDB is a row of the text match subqueries
+type textMatchDB struct {
+	DiagnosisULID string `gorm:"column:diagnosis_ulid"`
+	Score         float64
+}
+
+// enableFTS5 creates the FTS5 table when SQLite was compiled with it
+func enableFTS5(db *gorm.DB) (bool, error) {
+	var available bool
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available).Error; err != nil {
+		return false, err
+	}
+	if !available {
+		slog.Info("SQLite FTS5 not available, ranking diagnosis text search by term frequency")
+		return false, nil
+	}
+	return true, db.Exec(createDiagnosisFTS).Error
+}
+
+// termHashes returns the blind index of each term
+func (r *GormRepository) termHashes(terms []string) []string {
+	hashes := make([]string, len(terms))
+	for i, term := range terms {
+		hashes[i] = r.cipher.blindIndex(diagnosisTokenNamespace, term)
+	}
+	return hashes
+}
+
+// indexDiagnosisText (re)indexes the text of a diagnosis within tx, keeping
+// the token table and the FTS5 table in sync
+func (r *GormRepository) indexDiagnosisText(tx *gorm.DB, d *domain.Diagnosis) error {
+	if err := tx.Where("diagnosis_ulid = ?", d.ID).Delete(&DiagnosisSearchTokenDB{}).Error; err != nil {
+		return err
+	}
+
+	fields := map[string][]string{
+		fieldDiagnosis:    r.termHashes(analyzeSpanish(d.Diagnosis)),
+		fieldPrescription: r.termHashes(analyzeSpanish(d.Prescription)),
+	}
+
+	var rows []DiagnosisSearchTokenDB
+	for field, hashes := range fields {
+		frequency := make(map[string]int)
+		for _, hash := range hashes {
+			frequency[hash]++
+		}
+		for hash, count := range frequency {
+			rows = append(rows, DiagnosisSearchTokenDB{
+				DiagnosisULID: d.ID,
+				PatientULID:   d.PatientID,
+				Field:         field,
+				TokenHash:     hash,
+				Frequency:     count,
+			})
+		}
+	}
+	if len(rows) > 0 {
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+	}
+
+	if !r.fts5 {
+		return nil
+	}
+	if err := tx.Exec("DELETE FROM diagnosis_fts WHERE diagnosis_ulid = ?", d.ID).Error; err != nil {
+		return err
+	}
+	return tx.Exec("INSERT INTO diagnosis_fts (diagnosis_ulid, patient_ulid, diagnosis, prescription) VALUES (?, ?, ?, ?)",
+		d.ID, d.PatientID, strings.Join(fields[fieldDiagnosis], " "), strings.Join(fields[fieldPrescription], " ")).Error
+}
+
+// removeDiagnosisText drops the text index of every diagnosis of a patient
+func (r *GormRepository) removeDiagnosisText(tx *gorm.DB, patientULID string) error {
+	if err := tx.Where("patient_ulid = ?", patientULID).Delete(&DiagnosisSearchTokenDB{}).Error; err != nil {
+		return err
+	}
+	if !r.fts5 {
+		return nil
+	}
+	return tx.Exec("DELETE FROM diagnosis_fts WHERE patient_ulid = ?", patientULID).Error
+}
+
+// diagnosesMatchingText returns a subquery selecting the diagnosis_ulid and
+// score (higher is better) of the diagnoses containing every term. When
+// diagnosisOnly is set prescriptions are not searched.
+func (r *GormRepository) diagnosesMatchingText(terms []string, diagnosisOnly bool) *gorm.DB {
+	hashes := r.termHashes(uniqueTerms(terms))
+
+	if r.fts5 {
+		quoted := make([]string, len(hashes))
+		for i, hash := range hashes {
+			quoted[i] = `"` + hash + `"`
+		}
+		expression := strings.Join(quoted, " AND ")
+		if diagnosisOnly {
+			expression = fieldDiagnosis + " : (" + expression + ")"
+		}
+		// bm25 is lower for better matches, diagnosis text weighs double
+		return r.db.Table("diagnosis_fts").
+			Select("diagnosis_ulid, -bm25(diagnosis_fts, 0, 0, 2.0, 1.0) AS score").
+			Where("diagnosis_fts MATCH ?", expression)
+	}
+
+	query := r.db.Model(&DiagnosisSearchTokenDB{}).
+		Select("diagnosis_ulid, SUM(CASE WHEN field = ? THEN 2 * frequency ELSE frequency END) AS score", fieldDiagnosis).
+		Where("token_hash IN ?", hashes)
+	if diagnosisOnly {
+		query = query.Where("field = ?", fieldDiagnosis)
+	}
+	return query.Group("diagnosis_ulid").Having("COUNT(DISTINCT token_hash) = ?", len(hashes))
+}
+
+// applyTextMatches sorts the diagnoses by relevance and attaches their score
+// and highlighted snippets
+func (r *GormRepository) applyTextMatches(diagnostics []domain.Diagnosis, matches *gorm.DB, terms []string) error {
+	ulids := make([]string, len(diagnostics))
+	for i, d := range diagnostics {
+		ulids[i] = d.ID
+	}
+
+	var rows []textMatchDB
+	err := r.db.Table("(?) AS text_match", matches).Where("diagnosis_ulid IN ?", ulids).Find(&rows).Error
+	if err != nil {
+		return err
+	}
+	scores := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		scores[row.DiagnosisULID] = row.Score
+	}
+
+	termSet := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		termSet[term] = true
+	}
+	for i := range diagnostics {
+		d := &diagnostics[i]
+		match := domain.SearchMatch{}
+		if d.Match != nil {
+			match = *d.Match
+		}
+		match.Score = scores[d.ID]
+		match.DiagnosisSnippet = highlightSnippet(d.Diagnosis, termSet)
+		match.PrescriptionSnippet = highlightSnippet(d.Prescription, termSet)
+		d.Match = &match
+	}
+
+	// Stable, so results equally relevant keep their name similarity order
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Match.Score > diagnostics[j].Match.Score
+	})
+	return nil
+}
+
+// rankedDiagnosisDB holds the keys a diagnosis search is ordered by
+type rankedDiagnosisDB struct {
+	ID    uint
+	Score float64
+}
+
+// searchDiagnosisInOrder returns a page of a diagnosis search the database
+// orders: by text relevance when searching text, by ID otherwise. Rows are
+// read a page at a time after the cursor, so only those are decrypted, and
+// ages are compared once decrypted.
+func (r *GormRepository) searchDiagnosisInOrder(query *gorm.DB, filter domain.DiagnosisFilter, textMatches *gorm.DB, terms []string) (*domain.DiagnosisPage, error) {
+	keys, order := "diagnoses.id AS id, 0 AS score", "diagnoses.id"
+	if textMatches != nil {
+		query = query.Joins("JOIN (?) AS text_match ON text_match.diagnosis_ulid = diagnoses.ulid", textMatches)
+		keys, order = "diagnoses.id AS id, text_match.score AS score", "text_match.score DESC, diagnoses.id"
+	}
+	query = query.Session(&gorm.Session{})
+
+	var after *rankedDiagnosisDB
+	if filter.Page.Cursor != "" {
+		after = &rankedDiagnosisDB{}
+		var err error
+		if textMatches != nil {
+			after.Score, after.ID, err = decodeScoreCursor(filter.Page.Cursor)
+		} else {
+			after.ID, err = decodeCursor(filter.Page.Cursor, cursorAfterID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	size := filter.Page.Size()
+	var result []domain.Diagnosis
+	var resultKeys []rankedDiagnosisDB
+	for len(result) <= size {
+		batch := query.Select(keys).Order(order).Limit(size + 1)
+		if after != nil && textMatches != nil {
+			batch = batch.Where("text_match.score < ? OR (text_match.score = ? AND diagnoses.id > ?)", after.Score, after.Score, after.ID)
+		} else if after != nil {
+			batch = batch.Where("diagnoses.id > ?", after.ID)
+		}
+		var ranked []rankedDiagnosisDB
+		if err := batch.Scan(&ranked).Error; err != nil {
+			return nil, err
+		}
+
+		diagnostics, err := r.diagnosesInOrder(ranked)
+		if err != nil {
+			return nil, err
+		}
+		for i, d := range diagnostics {
+			// Birth dates are encrypted, ages can only be compared once decrypted
+			if filter.MatchesAge(&d.Patient, d.Date) {
+				result = append(result, d)
+				resultKeys = append(resultKeys, ranked[i])
+			}
+		}
+		if len(ranked) <= size {
+			break
+		}
+		after = &ranked[len(ranked)-1]
+	}
+
+	next := ""
+	if len(result) > size {
+		result = result[:size]
+		last := resultKeys[size-1]
+		if textMatches != nil {
+			next = encodeScoreCursor(last.Score, last.ID)
+		} else {
+			next = encodeCursor(cursorAfterID, last.ID)
+		}
+	}
+	if textMatches != nil {
+		if err := r.applyTextMatches(result, textMatches, terms); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.DiagnosisPage{Diagnoses: result, NextCursor: next}, nil
+}
+
+// diagnosesInOrder reads and decrypts the diagnoses of the given keys, in
+// their order
+func (r *GormRepository) diagnosesInOrder(ranked []rankedDiagnosisDB) ([]domain.Diagnosis, error) {
+	if len(ranked) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint, len(ranked))
+	for i, k := range ranked {
+		ids[i] = k.ID
+	}
+	var rows []DiagnosisDB
+	err := r.db.Preload("Patient").Preload("Attachments", attachmentOrder).Where("id IN ?", ids).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	position := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.Slice(rows, func(i, j int) bool { return position[rows[i].ID] < position[rows[j].ID] })
+	return toDiagnosisDomainList(rows, r.cipher)
+}
+
+// searchDiagnosisByName returns a page of a diagnosis search by patient name.
+// Every candidate the name index narrows the search to is read, decrypted and
+// scored, and only the domain.MaxRankedResults best are paged.
+func (r *GormRepository) searchDiagnosisByName(query *gorm.DB, filter domain.DiagnosisFilter, textMatches *gorm.DB, terms []string) (*domain.DiagnosisPage, error) {
+	if textMatches != nil {
+		query = query.Where("diagnoses.ulid IN (?)", r.db.Table("(?) AS text_match", textMatches).Select("diagnosis_ulid"))
+	}
+	var diagnostics []DiagnosisDB
+	err := query.Preload("Patient").Preload("Attachments", attachmentOrder).Order("diagnoses.id").Find(&diagnostics).Error
+	if err != nil {
+		return nil, err
+	}
+	result, err := toDiagnosisDomainList(diagnostics, r.cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	result = slices.DeleteFunc(result, func(d domain.Diagnosis) bool {
+		return !filter.MatchesAge(&d.Patient, d.Date)
+	})
+	result = applyNameMatches(result, filter.Patient)
+	if textMatches != nil {
+		if err := r.applyTextMatches(result, textMatches, terms); err != nil {
+			return nil, err
+		}
+	}
+	result = result[:min(len(result), domain.MaxRankedResults)]
+
+	result, next, err := pageAtOffset(result, filter.Page)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.DiagnosisPage{Diagnoses: result, NextCursor: next}, nil
+}
+
+// ensureTextIndex rebuilds the text index when it is out of date: after the
+// token table gained its field and frequency columns, or when the FTS5 table
+// does not cover every diagnosis (e.g. on the first start of an FTS5 build)
+func (r *GormRepository) ensureTextIndex(tokensOutdated bool) error {
+	rebuild := tokensOutdated
+	if r.fts5 && !rebuild {
+		var indexed, total int64
+		if err := r.db.Table("diagnosis_fts").Count(&indexed).Error; err != nil {
+			return err
+		}
+		if err := r.db.Model(&DiagnosisDB{}).Count(&total).Error; err != nil {
+			return err
+		}
+		rebuild = indexed != total
+	}
+	if !rebuild {
+		return nil
+	}
+
+	var stored []DiagnosisDB
+	if err := r.db.Find(&stored).Error; err != nil {
+		return err
+	}
+	// Decrypt first, patient keys cannot be created inside the transaction
+	diagnostics, err := toDiagnosisDomainList(stored, r.cipher)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&DiagnosisSearchTokenDB{}).Error; err != nil {
+			return err
+		}
+		if r.fts5 {
+			if err := tx.Exec("DELETE FROM diagnosis_fts").Error; err != nil {
+				return err
+			}
+		}
+		for i := range diagnostics {
+			if err := r.indexDiagnosisText(tx, &diagnostics[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Diagnosis text index rebuilt", "diagnostics", len(diagnostics), "fts5", r.fts5)
+	return nil
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	result := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	return result
+}
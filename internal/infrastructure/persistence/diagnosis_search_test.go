@@ -0,0 +1,237 @@
+package persistence
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestAnalyzeSpanish(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"Folds accents and case", "Neumonía BILATERAL", []string{"neumonia", "bilateral"}},
+		{"Drops stopwords", "Dolor de cabeza con náuseas", []string{"dolor", "cabeza", "nausea"}},
+		{"Reduces plurals", "infecciones dolores luces neumonías", []string{"infeccion", "dolor", "luz", "neumonia"}},
+		{"Keeps repeated terms", "tos y más tos", []string{"tos", "mas", "tos"}},
+		{"Only stopwords", "de la y", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := analyzeSpanish(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("analyzeSpanish(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	terms := map[string]bool{"neumonia": true}
+
+	got := highlightSnippet("Fiebre <alta> y neumonías, tratar", terms)
+	want := "Fiebre &lt;alta&gt; y <mark>neumonías</mark>, tratar"
+	if got != want {
+		t.Errorf("highlightSnippet() = %q, want %q", got, want)
+	}
+
+	if got := highlightSnippet("Gripe común", terms); got != "" {
+		t.Errorf("highlightSnippet() without match = %q, want empty", got)
+	}
+}
+
+func TestSearchDiagnosisText(t *testing.T) {
+	repo := newTestRepository(t)
+	caller := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Ana", FirstSurname: "Ruiz", DNI: "12345678Z", Email: "ana@example.com"}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+	repo.AddCareTeamMember(&domain.CareTeamMember{PatientID: patient.ID, UserID: caller.UserID, AddedAt: time.Now()})
+
+	for _, d := range []domain.Diagnosis{
+		{ID: "01HZY0000000000000000000D1", Diagnosis: "Gripe común", Prescription: "Reposo"},
+		{ID: "01HZY0000000000000000000D2", Diagnosis: "Neumonía leve", Prescription: "Amoxicilina"},
+		{ID: "01HZY0000000000000000000D3", Diagnosis: "Neumonía bilateral, control de la neumonía en 7 días", Prescription: "Levofloxacino"},
+	} {
+		d.PatientID = patient.ID
+		d.Date = time.Now()
+		if err := repo.CreateDiagnosis(&d, nil); err != nil {
+			t.Fatalf("CreateDiagnosis() error = %v", err)
+		}
+	}
+
+	t.Run("Ranks and highlights accent-insensitive matches", func(t *testing.T) {
+		q := "NEUMONIAS"
+		got, err := searchDiagnoses(repo, caller, domain.DiagnosisFilter{Text: &q})
+		if err != nil {
+			t.Fatalf("SearchDiagnosis() error = %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("SearchDiagnosis() returned %d diagnoses, want 2", len(got))
+		}
+		if got[0].ID != "01HZY0000000000000000000D3" {
+			t.Errorf("expected the diagnosis mentioning pneumonia twice first, got %s", got[0].ID)
+		}
+		if got[0].Match == nil || !strings.Contains(got[0].Match.DiagnosisSnippet, "<mark>Neumonía</mark>") {
+			t.Errorf("expected highlighted snippet, got %+v", got[0].Match)
+		}
+	})
+
+	t.Run("Requires every term", func(t *testing.T) {
+		q := "neumonía amoxicilina"
+		got, err := searchDiagnoses(repo, caller, domain.DiagnosisFilter{Text: &q})
+		if err != nil || len(got) != 1 || got[0].ID != "01HZY0000000000000000000D2" {
+			t.Errorf("SearchDiagnosis() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("Pages by relevance", func(t *testing.T) {
+		q := "neumonía"
+		first, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{Text: &q, Page: domain.Page{Limit: 1}})
+		if err != nil || len(first.Diagnoses) != 1 || first.Diagnoses[0].ID != "01HZY0000000000000000000D3" || first.NextCursor == "" {
+			t.Fatalf("SearchDiagnosis() first page = %+v, %v", first, err)
+		}
+		second, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{Text: &q, Page: domain.Page{Limit: 1, Cursor: first.NextCursor}})
+		if err != nil || len(second.Diagnoses) != 1 || second.Diagnoses[0].ID != "01HZY0000000000000000000D2" || second.NextCursor != "" {
+			t.Errorf("SearchDiagnosis() last page = %+v, %v", second, err)
+		}
+		if second.Diagnoses[0].Match == nil || second.Diagnoses[0].Match.DiagnosisSnippet == "" {
+			t.Errorf("expected the match of the second page, got %+v", second.Diagnoses[0].Match)
+		}
+
+		byID, _ := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{PatientID: &patient.ID, Page: domain.Page{Limit: 1}})
+		if _, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{Text: &q, Page: domain.Page{Cursor: byID.NextCursor}}); !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("SearchDiagnosis() with a cursor of another order = %v, want %v", err, domain.ErrInvalidCursor)
+		}
+	})
+
+	t.Run("Rejects queries without terms", func(t *testing.T) {
+		q := "de la"
+		if _, err := searchDiagnoses(repo, caller, domain.DiagnosisFilter{Text: &q}); !errors.Is(err, domain.ErrEmptySearchText) {
+			t.Errorf("expected ErrEmptySearchText, got %v", err)
+		}
+	})
+}
+
+func TestSearchDiagnosisDemographics(t *testing.T) {
+	repo := newTestRepository(t)
+	caller := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+
+	childBirth := time.Date(2015, 3, 10, 0, 0, 0, 0, time.UTC)
+	adultBirth := time.Date(1970, 3, 10, 0, 0, 0, 0, time.UTC)
+	patients := []domain.Patient{
+		{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z", BirthDate: &childBirth, Sex: domain.SexFemale,
+			Address: domain.Address{Street: "Calle Mayor", PostalCode: "28013", Province: "28"}},
+		{ID: "01HZY0000000000000000000P2", GivenName: "Pedro", FirstSurname: "Gil", DNI: "11111111H", BirthDate: &adultBirth, Sex: domain.SexMale,
+			Address: domain.Address{PostalCode: "08001", Province: "08"}},
+		{ID: "01HZY0000000000000000000P3", GivenName: "Ana", FirstSurname: "Sanz", DNI: "87654321X", Sex: domain.SexFemale,
+			Address: domain.Address{PostalCode: "28220", Province: "28"}},
+	}
+	for i, p := range patients {
+		if err := repo.CreatePatient(&p, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		repo.AddCareTeamMember(&domain.CareTeamMember{PatientID: p.ID, UserID: caller.UserID, AddedAt: time.Now()})
+		d := domain.Diagnosis{ID: "01HZY0000000000000000000D" + string(rune('1'+i)), PatientID: p.ID, Diagnosis: "Faringitis",
+			Date: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)}
+		if err := repo.CreateDiagnosis(&d, nil); err != nil {
+			t.Fatalf("CreateDiagnosis() error = %v", err)
+		}
+	}
+
+	patientIDs := func(diagnostics []domain.Diagnosis) []string {
+		var ids []string
+		for _, d := range diagnostics {
+			ids = append(ids, d.PatientID)
+		}
+		return ids
+	}
+	zero, fourteen := 0, 14
+	female := domain.SexFemale
+
+	got, err := searchDiagnoses(repo, caller, domain.DiagnosisFilter{AgeMin: &zero, AgeMax: &fourteen})
+	if err != nil || !reflect.DeepEqual(patientIDs(got), []string{"01HZY0000000000000000000P1"}) {
+		t.Errorf("SearchDiagnosis() by age = %v, %v", patientIDs(got), err)
+	}
+
+	got, err = searchDiagnoses(repo, caller, domain.DiagnosisFilter{Sex: &female})
+	if err != nil || !reflect.DeepEqual(patientIDs(got), []string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P3"}) {
+		t.Errorf("SearchDiagnosis() by sex = %v, %v", patientIDs(got), err)
+	}
+	madrid, postalCode := "28", "28013"
+	got2, err := searchDiagnoses(repo, caller, domain.DiagnosisFilter{Province: &madrid})
+	if err != nil || !reflect.DeepEqual(patientIDs(got2), []string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P3"}) {
+		t.Errorf("SearchDiagnosis() by province = %v, %v", patientIDs(got2), err)
+	}
+	got2, err = searchDiagnoses(repo, caller, domain.DiagnosisFilter{PostalCode: &postalCode})
+	if err != nil || !reflect.DeepEqual(patientIDs(got2), []string{"01HZY0000000000000000000P1"}) {
+		t.Errorf("SearchDiagnosis() by postal code = %v, %v", patientIDs(got2), err)
+	}
+
+	patientID := "01HZY0000000000000000000P2"
+	got2, err = searchDiagnoses(repo, caller, domain.DiagnosisFilter{PatientID: &patientID})
+	if err != nil || !reflect.DeepEqual(patientIDs(got2), []string{patientID}) {
+		t.Errorf("SearchDiagnosis() by patient = %v, %v", patientIDs(got2), err)
+	}
+
+	if got[0].Patient.BirthDate == nil || !got[0].Patient.BirthDate.Equal(childBirth) {
+		t.Errorf("expected the birth date to be decrypted, got %v", got[0].Patient.BirthDate)
+	}
+
+	t.Run("Pages the results", func(t *testing.T) {
+		first, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{Sex: &female, Page: domain.Page{Limit: 1}})
+		if err != nil || !reflect.DeepEqual(patientIDs(first.Diagnoses), []string{"01HZY0000000000000000000P1"}) || first.NextCursor == "" {
+			t.Fatalf("SearchDiagnosis() first page = %+v, %v", first, err)
+		}
+		second, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{Sex: &female, Page: domain.Page{Limit: 1, Cursor: first.NextCursor}})
+		if err != nil || !reflect.DeepEqual(patientIDs(second.Diagnoses), []string{"01HZY0000000000000000000P3"}) || second.NextCursor != "" {
+			t.Errorf("SearchDiagnosis() last page = %+v, %v", second, err)
+		}
+	})
+
+	t.Run("Pages the results filtered by age", func(t *testing.T) {
+		hundred := 100
+		first, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{AgeMin: &zero, AgeMax: &hundred, Page: domain.Page{Limit: 1}})
+		if err != nil || !reflect.DeepEqual(patientIDs(first.Diagnoses), []string{"01HZY0000000000000000000P1"}) || first.NextCursor == "" {
+			t.Fatalf("SearchDiagnosis() first page = %+v, %v", first, err)
+		}
+		second, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{AgeMin: &zero, AgeMax: &hundred, Page: domain.Page{Limit: 1, Cursor: first.NextCursor}})
+		if err != nil || !reflect.DeepEqual(patientIDs(second.Diagnoses), []string{"01HZY0000000000000000000P2"}) || second.NextCursor != "" {
+			t.Errorf("SearchDiagnosis() last page = %+v, %v", second, err)
+		}
+
+		// Rows out of the range are skipped until the page is full
+		fifty, sixty := 50, 60
+		got, err := repo.SearchDiagnosis(caller, domain.DiagnosisFilter{AgeMin: &fifty, AgeMax: &sixty, Page: domain.Page{Limit: 1}})
+		if err != nil || !reflect.DeepEqual(patientIDs(got.Diagnoses), []string{"01HZY0000000000000000000P2"}) || got.NextCursor != "" {
+			t.Errorf("SearchDiagnosis() sparse matches = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Integration clients need consent to filter on demographics", func(t *testing.T) {
+		integration := domain.Caller{UserID: "app", Role: domain.RoleIntegration}
+		repo.CreateConsent(&domain.Consent{ID: "01HZY0000000000000000000C1", PatientID: "01HZY0000000000000000000P1",
+			Purpose: domain.ConsentPurposeThirdPartySharing, Scope: domain.ConsentScopeDiagnoses, GrantedAt: time.Now().Add(-time.Hour)})
+
+		got, err := searchDiagnoses(repo, integration, domain.DiagnosisFilter{Sex: &female})
+		if err != nil || len(got) != 0 {
+			t.Errorf("SearchDiagnosis() without demographics consent = %v, %v", patientIDs(got), err)
+		}
+	})
+}
+
+// searchDiagnoses returns the first page of a diagnosis search
+func searchDiagnoses(repo *GormRepository, caller domain.Caller, filter domain.DiagnosisFilter) ([]domain.Diagnosis, error) {
+	page, err := repo.SearchDiagnosis(caller, filter)
+	if err != nil {
+		return nil, err
+	}
+	return page.Diagnoses, nil
+}
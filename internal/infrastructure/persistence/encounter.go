@@ -0,0 +1,186 @@
+package persistence
+
+import (
+	"errors"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type EncounterDB struct {
+	ID               uint   `gorm:"primaryKey,autoIncrement"`
+	ULID             string `gorm:"column:ulid;unique"`
+	PatientULID      string `gorm:"column:patient_ulid;index"`
+	PractitionerULID string `gorm:"column:practitioner_ulid"`
+	Status           string
+	Subjective       string // The SOAP note is encrypted with the patient key
+	Objective        string
+	Assessment       string
+	Plan             string
+	StartedAt        time.Time
+	ClosedAt         *time.Time
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+func (EncounterDB) TableName() string {
+	return "encounters"
+}
+
+// Encounter Repository Implementation
+func (r *GormRepository) CreateEncounter(encounter *domain.Encounter) error {
+	dbEncounter, err := toEncounterDB(encounter, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(dbEncounter).Error
+}
+
+func (r *GormRepository) UpdateEncounter(encounter *domain.Encounter) error {
+	dbEncounter, err := toEncounterDB(encounter, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&EncounterDB{}).Where("ulid = ?", encounter.ID).Updates(map[string]interface{}{
+		"status":     dbEncounter.Status,
+		"subjective": dbEncounter.Subjective,
+		"objective":  dbEncounter.Objective,
+		"assessment": dbEncounter.Assessment,
+		"plan":       dbEncounter.Plan,
+		"closed_at":  dbEncounter.ClosedAt,
+	}).Error
+}
+
+func (r *GormRepository) GetEncounterByID(id string) (*domain.Encounter, error) {
+	var encounter EncounterDB
+	err := r.db.Where("ulid = ?", id).First(&encounter).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrEncounterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toEncounterDomain(&encounter, r.cipher)
+}
+
+func (r *GormRepository) GetEncountersByPatientID(patientID string) ([]domain.Encounter, error) {
+	var encounters []EncounterDB
+	if err := r.db.Where("patient_ulid = ?", patientID).Order("started_at, id").Find(&encounters).Error; err != nil {
+		return nil, err
+	}
+	return toEncounterDomainList(encounters, r.cipher)
+}
+
+func (r *GormRepository) GetDiagnosesByEncounterID(encounterID string) ([]domain.Diagnosis, error) {
+	var diagnostics []DiagnosisDB
+	err := r.db.Preload("Attachments", attachmentOrder).Where("encounter_ulid = ?", encounterID).Order("date, id").Find(&diagnostics).Error
+	if err != nil {
+		return nil, err
+	}
+	return toDiagnosisDomainList(diagnostics, r.cipher)
+}
+
+// reencryptEncounters returns the encounters of a patient encrypted with the
+// key of another, to move them there
+func (r *GormRepository) reencryptEncounters(fromPatientID, toPatientID string) ([]*EncounterDB, error) {
+	var stored []EncounterDB
+	if err := r.db.Where("patient_ulid = ?", fromPatientID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	encounters, err := toEncounterDomainList(stored, r.cipher)
+	if err != nil {
+		return nil, err
+	}
+	moved := make([]*EncounterDB, len(encounters))
+	for i := range encounters {
+		encounters[i].PatientID = toPatientID
+		if moved[i], err = toEncounterDB(&encounters[i], r.cipher); err != nil {
+			return nil, err
+		}
+	}
+	return moved, nil
+}
+
+// moveEncounters reassigns the encounters of a merged duplicate to the
+// survivor. They must have been re-encrypted with the survivor's key already.
+func moveEncounters(tx *gorm.DB, moved []*EncounterDB) error {
+	for _, e := range moved {
+		err := tx.Model(&EncounterDB{}).Where("ulid = ?", e.ULID).Updates(map[string]interface{}{
+			"patient_ulid": e.PatientULID,
+			"subjective":   e.Subjective,
+			"objective":    e.Objective,
+			"assessment":   e.Assessment,
+			"plan":         e.Plan,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mappers
+func toEncounterDB(e *domain.Encounter, c *fieldCipher) (*EncounterDB, error) {
+	sections := []string{e.Note.Subjective, e.Note.Objective, e.Note.Assessment, e.Note.Plan}
+	for i, section := range sections {
+		encrypted, err := c.encryptForPatient(e.PatientID, section)
+		if err != nil {
+			return nil, err
+		}
+		sections[i] = encrypted
+	}
+
+	return &EncounterDB{
+		ULID:             e.ID,
+		PatientULID:      e.PatientID,
+		PractitionerULID: e.PractitionerID,
+		Status:           e.Status,
+		Subjective:       sections[0],
+		Objective:        sections[1],
+		Assessment:       sections[2],
+		Plan:             sections[3],
+		StartedAt:        e.StartedAt,
+		ClosedAt:         e.ClosedAt,
+		CreatedAt:        e.CreatedAt,
+	}, nil
+}
+
+func toEncounterDomain(e *EncounterDB, c *fieldCipher) (*domain.Encounter, error) {
+	sections := []string{e.Subjective, e.Objective, e.Assessment, e.Plan}
+	for i, section := range sections {
+		decrypted, err := c.decryptForPatient(e.PatientULID, section)
+		if err != nil {
+			return nil, err
+		}
+		sections[i] = decrypted
+	}
+
+	return &domain.Encounter{
+		ID:             e.ULID,
+		PatientID:      e.PatientULID,
+		PractitionerID: e.PractitionerULID,
+		Status:         e.Status,
+		Note: domain.SOAPNote{
+			Subjective: sections[0],
+			Objective:  sections[1],
+			Assessment: sections[2],
+			Plan:       sections[3],
+		},
+		StartedAt: e.StartedAt,
+		ClosedAt:  e.ClosedAt,
+		CreatedAt: e.CreatedAt,
+	}, nil
+}
+
+func toEncounterDomainList(encounters []EncounterDB, c *fieldCipher) ([]domain.Encounter, error) {
+	result := make([]domain.Encounter, len(encounters))
+	for i, e := range encounters {
+		encounter, err := toEncounterDomain(&e, c)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *encounter
+	}
+	return result, nil
+}
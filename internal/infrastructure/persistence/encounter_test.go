@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestEncounters(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z"}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	encounter := &domain.Encounter{ID: "01HZY0000000000000000000E1", PatientID: patient.ID, PractitionerID: "doctor", Status: domain.EncounterStatusOpen,
+		Note: domain.SOAPNote{Subjective: "Dolor de garganta"}, StartedAt: day, CreatedAt: day}
+	if err := repo.CreateEncounter(encounter); err != nil {
+		t.Fatalf("CreateEncounter() error = %v", err)
+	}
+	for _, d := range []*domain.Diagnosis{
+		{ID: "01HZY0000000000000000000D1", PatientID: patient.ID, EncounterID: encounter.ID, Diagnosis: "Faringitis", Prescription: "Ibuprofeno", Date: day},
+		{ID: "01HZY0000000000000000000D2", PatientID: patient.ID, Diagnosis: "Rinitis alérgica", Date: day.Add(-24 * time.Hour)},
+	} {
+		if err := repo.CreateDiagnosis(d, nil); err != nil {
+			t.Fatalf("CreateDiagnosis() error = %v", err)
+		}
+	}
+
+	t.Run("Encrypts the note with the patient key", func(t *testing.T) {
+		var stored EncounterDB
+		repo.db.Where("ulid = ?", encounter.ID).First(&stored)
+		if !strings.HasPrefix(stored.Subjective, patientEncryptedPrefix) {
+			t.Errorf("expected note encrypted with the patient key, got %q", stored.Subjective)
+		}
+	})
+
+	t.Run("Updates the note and status", func(t *testing.T) {
+		encounter.Note.Assessment = "Faringitis aguda"
+		if err := encounter.Close(nil, day.Add(time.Hour)); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if err := repo.UpdateEncounter(encounter); err != nil {
+			t.Fatalf("UpdateEncounter() error = %v", err)
+		}
+		got, err := repo.GetEncounterByID(encounter.ID)
+		if err != nil || got.Status != domain.EncounterStatusClosed || got.ClosedAt == nil || got.Note.Assessment != "Faringitis aguda" {
+			t.Errorf("GetEncounterByID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Lists the diagnoses of the encounter only", func(t *testing.T) {
+		got, err := repo.GetDiagnosesByEncounterID(encounter.ID)
+		if err != nil || len(got) != 1 || got[0].Diagnosis != "Faringitis" || got[0].EncounterID != encounter.ID {
+			t.Errorf("GetDiagnosesByEncounterID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Unknown encounter", func(t *testing.T) {
+		if _, err := repo.GetEncounterByID("01HZY0000000000000000000E9"); err != domain.ErrEncounterNotFound {
+			t.Errorf("GetEncounterByID() error = %v, want %v", err, domain.ErrEncounterNotFound)
+		}
+	})
+}
@@ -0,0 +1,378 @@
+package persistence
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Envelope encryption of sensitive columns.
+//
+// Every encrypted value is sealed with AES-256-GCM under a random data key.
+// Data keys are stored in the data_keys table wrapped (AES-GCM) by a master
+// key that never touches the database. A separate, equally wrapped index key
+// computes deterministic HMAC blind indexes so encrypted columns can still be
+// looked up by equality.
+//
+// Clinical text goes one level deeper: each patient has its own data key,
+// wrapped by the active field data key (see patient_keys.go).
+
+const (
+	encryptedPrefix     = "enc:v"
+	dataKeyPurposeField = "field"
+	dataKeyPurposeIndex = "index"
+	keySize             = 32
+)
+
+var (
+	ErrInvalidMasterKey = errors.New("master key must be 32 bytes encoded in base64")
+	ErrUnknownDataKey   = errors.New("value encrypted with an unknown data key")
+	ErrUnwrapDataKey    = errors.New("cannot unwrap data key, is the master key correct?")
+)
+
+type DataKeyDB struct {
+	ID         uint   `gorm:"primaryKey,autoIncrement"`
+	Purpose    string `gorm:"uniqueIndex:idx_data_key_version"`
+	Version    int    `gorm:"uniqueIndex:idx_data_key_version"`
+	WrappedKey string
+	Active     bool
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (DataKeyDB) TableName() string {
+	return "data_keys"
+}
+
+// fieldCipher holds the unwrapped keys used to protect sensitive columns.
+// Another process (cmd/manage rotate-keys) may add data keys while it is in
+// use, so unknown versions are reloaded from the database.
+type fieldCipher struct {
+	db          *gorm.DB
+	master      []byte
+	mu          sync.RWMutex // Guards dataKeys and active
+	dataKeys    map[int][]byte
+	active      int
+	indexKey    []byte
+	patientKeys *patientKeyCache
+}
+
+// LoadMasterKey reads the base64 encoded master key, either inline or from a
+// key file. The key file takes precedence when both are set.
+func LoadMasterKey(key, keyFile string) ([]byte, error) {
+	if keyFile != "" {
+		content, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading master key file: %w", err)
+		}
+		key = string(content)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(raw) != keySize {
+		return nil, ErrInvalidMasterKey
+	}
+	return raw, nil
+}
+
+// GenerateMasterKey returns a new random master key encoded in base64
+func GenerateMasterKey() (string, error) {
+	key, err := randomKey()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// newFieldCipher unwraps the stored data keys, creating the initial ones on
+// first start
+func newFieldCipher(db *gorm.DB, master []byte) (*fieldCipher, error) {
+	if len(master) != keySize {
+		return nil, ErrInvalidMasterKey
+	}
+
+	c := &fieldCipher{
+		db:          db,
+		master:      master,
+		dataKeys:    make(map[int][]byte),
+		patientKeys: newPatientKeyCache(),
+	}
+
+	var keys []DataKeyDB
+	if err := db.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		key, err := unwrapKey(master, k.WrappedKey)
+		if err != nil {
+			return nil, err
+		}
+		switch k.Purpose {
+		case dataKeyPurposeIndex:
+			c.indexKey = key
+		case dataKeyPurposeField:
+			c.dataKeys[k.Version] = key
+			if k.Active {
+				c.active = k.Version
+			}
+		}
+	}
+
+	if c.indexKey == nil {
+		key, err := createDataKey(db, master, dataKeyPurposeIndex, 1)
+		if err != nil {
+			return nil, err
+		}
+		c.indexKey = key
+	}
+	if c.active == 0 {
+		key, err := createDataKey(db, master, dataKeyPurposeField, 1)
+		if err != nil {
+			return nil, err
+		}
+		c.dataKeys[1] = key
+		c.active = 1
+	}
+
+	return c, nil
+}
+
+// activeKey returns the version and key new values are sealed with
+func (c *fieldCipher) activeKey() (int, []byte) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.active, c.dataKeys[c.active]
+}
+
+// dataKey returns the data key of a version. A version this cipher does not
+// know was created by a rotation in another process: the keys are reloaded.
+func (c *fieldCipher) dataKey(version int) ([]byte, error) {
+	c.mu.RLock()
+	key, ok := c.dataKeys[version]
+	c.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := c.reloadDataKeys(); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if key, ok = c.dataKeys[version]; !ok {
+		return nil, ErrUnknownDataKey
+	}
+	return key, nil
+}
+
+// reloadDataKeys reads the field data keys added since the cipher was built
+// and switches to the active one. Keys wrapped by another master key fail with
+// ErrUnwrapDataKey: rotating the master key requires restarting with it.
+func (c *fieldCipher) reloadDataKeys() error {
+	var keys []DataKeyDB
+	if err := c.db.Where("purpose = ?", dataKeyPurposeField).Find(&keys).Error; err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range keys {
+		if _, ok := c.dataKeys[k.Version]; !ok {
+			key, err := unwrapKey(c.master, k.WrappedKey)
+			if err != nil {
+				return err
+			}
+			c.dataKeys[k.Version] = key
+		}
+		if k.Active {
+			c.active = k.Version
+		}
+	}
+	return nil
+}
+
+// encrypt seals a value with the active data key. Empty values stay empty.
+func (c *fieldCipher) encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	version, key := c.activeKey()
+	sealed, err := seal(key, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return encryptedPrefix + strconv.Itoa(version) + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt opens a value sealed by encrypt. Values without the encryption
+// prefix are legacy plaintext and returned untouched.
+func (c *fieldCipher) decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return value, nil
+	}
+
+	versionPart, payload, ok := strings.Cut(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if !ok {
+		return "", ErrUnknownDataKey
+	}
+	version, err := strconv.Atoi(versionPart)
+	if err != nil {
+		return "", ErrUnknownDataKey
+	}
+	key, err := c.dataKey(version)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(key, sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// blindIndex returns a deterministic keyed hash of a value, namespaced so the
+// same value indexed for different columns produces different hashes
+func (c *fieldCipher) blindIndex(namespace, value string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(namespace))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// optionalBlindIndex is blindIndex for optional fields: empty values get an
+// empty index, so patients missing the field do not match each other
+func (c *fieldCipher) optionalBlindIndex(namespace, value string) string {
+	if value == "" {
+		return ""
+	}
+	return c.blindIndex(namespace, value)
+}
+
+// rotated returns a copy of the cipher with a new active data key and,
+// optionally, a new master key
+func (c *fieldCipher) rotated(newMaster []byte) (*fieldCipher, []byte, error) {
+	key, err := randomKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	next := &fieldCipher{
+		db:          c.db,
+		master:      c.master,
+		dataKeys:    make(map[int][]byte, len(c.dataKeys)+1),
+		active:      c.active + 1,
+		indexKey:    c.indexKey,
+		patientKeys: c.patientKeys,
+	}
+	for version, k := range c.dataKeys {
+		if version >= next.active {
+			next.active = version + 1
+		}
+		next.dataKeys[version] = k
+	}
+	next.dataKeys[next.active] = key
+	if newMaster != nil {
+		next.master = newMaster
+	}
+	return next, key, nil
+}
+
+func createDataKey(db *gorm.DB, master []byte, purpose string, version int) ([]byte, error) {
+	key, err := randomKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := wrapKey(master, key)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Create(&DataKeyDB{
+		Purpose:    purpose,
+		Version:    version,
+		WrappedKey: wrapped,
+		Active:     true,
+	}).Error
+	return key, err
+}
+
+func wrapKey(master, key []byte) (string, error) {
+	sealed, err := seal(master, key)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func unwrapKey(master []byte, wrapped string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, ErrUnwrapDataKey
+	}
+	key, err := open(master, sealed)
+	if err != nil {
+		return nil, ErrUnwrapDataKey
+	}
+	return key, nil
+}
+
+func randomKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// seal encrypts with AES-GCM and prepends the random nonce
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open reverses seal
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,208 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+// newTestRepository opens a repository on a temporary database, encrypted with
+// a throwaway master key
+func newTestRepository(t *testing.T) *GormRepository {
+	t.Helper()
+	masterKey, err := GenerateMasterKey()
+	if err != nil {
+		t.Fatalf("GenerateMasterKey() error = %v", err)
+	}
+	repo, err := NewGormRepository(Config{
+		DSN:       filepath.Join(t.TempDir(), "encryption.db"),
+		MasterKey: masterKey,
+	})
+	if err != nil {
+		t.Fatalf("NewGormRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestPatientEncryption(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{
+		ID:           "01HZY0000000000000000000P1",
+		GivenName:    "Lucía",
+		FirstSurname: "Fernández",
+		DNI:          "12345678Z",
+		Email:        "lucia@example.com",
+		Phone:        "600000000",
+	}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+
+	t.Run("Stores ciphertext", func(t *testing.T) {
+		var stored PatientDB
+		repo.db.Where("ulid = ?", patient.ID).First(&stored)
+		for _, value := range []string{stored.GivenName, stored.FirstSurname, stored.DNI, stored.Email, stored.Phone} {
+			if !strings.HasPrefix(value, encryptedPrefix) {
+				t.Errorf("expected encrypted value, got %q", value)
+			}
+		}
+		if stored.Address != "" {
+			t.Errorf("expected empty address to stay empty, got %q", stored.Address)
+		}
+	})
+
+	t.Run("Finds by DNI", func(t *testing.T) {
+		got, err := repo.GetPatientByDNI(" 12345678z ")
+		if err != nil {
+			t.Fatalf("GetPatientByDNI() error = %v", err)
+		}
+		if got.DisplayName() != patient.DisplayName() || got.Email != patient.Email {
+			t.Errorf("GetPatientByDNI() = %+v, want %+v", got, patient)
+		}
+	})
+
+	t.Run("Rejects duplicated DNI", func(t *testing.T) {
+		duplicate := &domain.Patient{ID: "01HZY0000000000000000000P2", GivenName: "Otra", FirstSurname: "Persona", DNI: "12345678Z"}
+		if err := repo.CreatePatient(duplicate, nil); err == nil {
+			t.Error("expected unique violation on DNI blind index")
+		}
+	})
+
+	t.Run("Matches name prefixes", func(t *testing.T) {
+		matching := func(name string) []string {
+			var ids []string
+			repo.filterByName(repo.db.Table("patients AS Patient"), domain.NameFilter{Name: &name}).Pluck("Patient.ulid", &ids)
+			return ids
+		}
+		if ids := matching("fer LUCÍA"); len(ids) != 1 || ids[0] != patient.ID {
+			t.Errorf("filterByName() = %v, want [%s]", ids, patient.ID)
+		}
+		if ids := matching("ucía"); len(ids) != 0 {
+			t.Errorf("expected no match for an infix, got %v", ids)
+		}
+	})
+
+	diagnosis := &domain.Diagnosis{
+		ID:           "01HZY0000000000000000000D1",
+		PatientID:    patient.ID,
+		Diagnosis:    "Migraña crónica",
+		Prescription: "Ibuprofeno 600mg",
+		Date:         time.Now(),
+	}
+	if err := repo.CreateDiagnosis(diagnosis, nil); err != nil {
+		t.Fatalf("CreateDiagnosis() error = %v", err)
+	}
+
+	t.Run("Encrypts diagnosis text with the patient key", func(t *testing.T) {
+		var stored DiagnosisDB
+		repo.db.Where("ulid = ?", diagnosis.ID).First(&stored)
+		if !strings.HasPrefix(stored.Diagnosis, patientEncryptedPrefix) || !strings.HasPrefix(stored.Prescription, patientEncryptedPrefix) {
+			t.Errorf("expected encrypted clinical text, got %q / %q", stored.Diagnosis, stored.Prescription)
+		}
+
+		var tokens int64
+		repo.db.Model(&DiagnosisSearchTokenDB{}).Where("diagnosis_ulid = ? AND token_hash = ?",
+			diagnosis.ID, repo.cipher.blindIndex(diagnosisTokenNamespace, "ibuprofeno")).Count(&tokens)
+		if tokens != 1 {
+			t.Errorf("expected a search token for the prescription, got %d", tokens)
+		}
+
+		got, err := repo.GetDiagnosisByPatientID(patient.ID)
+		if err != nil || len(got) != 1 || got[0].Diagnosis != diagnosis.Diagnosis {
+			t.Errorf("GetDiagnosisByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Rotates keys", func(t *testing.T) {
+		newMaster, _ := GenerateMasterKey()
+		rawMaster, _ := base64.StdEncoding.DecodeString(newMaster)
+
+		rotated, err := repo.RotateKeys(rawMaster)
+		if err != nil {
+			t.Fatalf("RotateKeys() error = %v", err)
+		}
+		if rotated != 1 {
+			t.Errorf("RotateKeys() rotated %d patients, want 1", rotated)
+		}
+
+		var stored PatientDB
+		repo.db.Where("ulid = ?", patient.ID).First(&stored)
+		if !strings.HasPrefix(stored.GivenName, encryptedPrefix+"2:") {
+			t.Errorf("expected name encrypted with data key v2, got %q", stored.GivenName)
+		}
+
+		// A fresh cipher with the new master key must read everything back
+		reopened, err := newFieldCipher(repo.db, rawMaster)
+		if err != nil {
+			t.Fatalf("newFieldCipher() with new master error = %v", err)
+		}
+		got, err := toPatientDomain(&stored, reopened)
+		if err != nil || got.DNI != patient.DNI {
+			t.Errorf("toPatientDomain() = %+v, %v", got, err)
+		}
+		var storedDiagnosis DiagnosisDB
+		repo.db.Where("ulid = ?", diagnosis.ID).First(&storedDiagnosis)
+		gotDiagnosis, err := toDiagnosisDomain(&storedDiagnosis, reopened)
+		if err != nil || gotDiagnosis.Prescription != diagnosis.Prescription {
+			t.Errorf("toDiagnosisDomain() = %+v, %v", gotDiagnosis, err)
+		}
+
+		oldMaster, _ := LoadMasterKey(repo.cfg.MasterKey, "")
+		if _, err := newFieldCipher(repo.db, oldMaster); err != ErrUnwrapDataKey {
+			t.Errorf("expected old master key to be rejected, got %v", err)
+		}
+	})
+}
+
+func TestKeyRotationByAnotherProcess(t *testing.T) {
+	api := newTestRepository(t)
+	// cmd/manage opens its own repository on the same database
+	manage, err := NewGormRepository(api.cfg)
+	if err != nil {
+		t.Fatalf("NewGormRepository() error = %v", err)
+	}
+	defer manage.Close()
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Fernández", DNI: "12345678Z", Email: "lucia@example.com"}
+	if err := api.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+	diagnosis := &domain.Diagnosis{ID: "01HZY0000000000000000000D1", PatientID: patient.ID, Diagnosis: "Migraña crónica", Date: time.Now()}
+	if err := api.CreateDiagnosis(diagnosis, nil); err != nil {
+		t.Fatalf("CreateDiagnosis() error = %v", err)
+	}
+	if _, err := manage.RotateKeys(nil); err != nil {
+		t.Fatalf("RotateKeys() error = %v", err)
+	}
+
+	t.Run("Reads values re-encrypted by the rotation", func(t *testing.T) {
+		// Forget the patient key so it is unwrapped again with the new data key
+		api.cipher.patientKeys.forget(patient.ID)
+
+		got, err := api.GetPatientByID(patient.ID)
+		if err != nil || got.DNI != patient.DNI {
+			t.Errorf("GetPatientByID() = %+v, %v", got, err)
+		}
+		diagnoses, err := api.GetDiagnosisByPatientID(patient.ID)
+		if err != nil || len(diagnoses) != 1 || diagnoses[0].Diagnosis != diagnosis.Diagnosis {
+			t.Errorf("GetDiagnosisByPatientID() = %+v, %v", diagnoses, err)
+		}
+	})
+
+	t.Run("Encrypts with the new active key once reloaded", func(t *testing.T) {
+		other := &domain.Patient{ID: "01HZY0000000000000000000P2", GivenName: "Luis", FirstSurname: "Pérez", DNI: "11111111H", Email: "luis@example.com"}
+		if err := api.CreatePatient(other, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		var stored PatientDB
+		api.db.Where("ulid = ?", other.ID).First(&stored)
+		if !strings.HasPrefix(stored.GivenName, encryptedPrefix+"2:") {
+			t.Errorf("expected name encrypted with data key v2, got %q", stored.GivenName)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ErasureDB struct {
+	ID              uint   `gorm:"primaryKey,autoIncrement"`
+	ULID            string `gorm:"column:ulid;unique"`
+	PatientULID     string `gorm:"column:patient_ulid;index"`
+	RequestedByULID string `gorm:"column:requested_by_ulid"`
+	Reason          string
+	ErasedAt        time.Time
+	RetainUntil     time.Time `gorm:"index"`
+	PurgedAt        *time.Time
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+}
+
+func (ErasureDB) TableName() string {
+	return "erasures"
+}
+
+// Erasure Repository Implementation
+func (r *GormRepository) ErasePatient(patient *domain.Patient, erasure *domain.Erasure) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.updatePatient(tx, patient, r.cipher); err != nil {
+			return err
+		}
+		// Contacts identify third parties through the patient, they go too
+		if err := tx.Where("patient_ulid = ?", patient.ID).Delete(&ContactDB{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(toErasureDB(erasure)).Error
+	})
+}
+
+func (r *GormRepository) GetErasuresDueForPurge(at time.Time) ([]domain.Erasure, error) {
+	var erasures []ErasureDB
+	err := r.db.Where("retain_until <= ? AND purged_at IS NULL", at).Find(&erasures).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Erasure, len(erasures))
+	for i, e := range erasures {
+		result[i] = *toErasureDomain(&e)
+	}
+	return result, nil
+}
+
+func (r *GormRepository) PurgeClinicalRecords(erasure *domain.Erasure, at time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.removeDiagnosisText(tx, erasure.PatientID); err != nil {
+			return err
+		}
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&DiagnosisDB{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&AppointmentDB{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&ObservationDB{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&LabResultDB{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&VaccinationDB{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&ReferralDB{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&EncounterDB{}).Error; err != nil {
+			return err
+		}
+		// Only the metadata, stored content is released by the caller
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&AttachmentDB{}).Error; err != nil {
+			return err
+		}
+		// Dropping the patient key makes any leftover copy of the records unreadable
+		if err := tx.Where("patient_ulid = ?", erasure.PatientID).Delete(&PatientDataKeyDB{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ErasureDB{}).Where("ulid = ?", erasure.ID).Update("purged_at", at).Error
+	})
+	if err == nil {
+		r.cipher.patientKeys.forget(erasure.PatientID)
+	}
+	return err
+}
+
+// Mappers
+func toErasureDB(e *domain.Erasure) *ErasureDB {
+	return &ErasureDB{
+		ULID:            e.ID,
+		PatientULID:     e.PatientID,
+		RequestedByULID: e.RequestedBy,
+		Reason:          e.Reason,
+		ErasedAt:        e.ErasedAt,
+		RetainUntil:     e.RetainUntil,
+		PurgedAt:        e.PurgedAt,
+	}
+}
+
+func toErasureDomain(e *ErasureDB) *domain.Erasure {
+	return &domain.Erasure{
+		ID:          e.ULID,
+		PatientID:   e.PatientULID,
+		RequestedBy: e.RequestedByULID,
+		Reason:      e.Reason,
+		ErasedAt:    e.ErasedAt,
+		RetainUntil: e.RetainUntil,
+		PurgedAt:    e.PurgedAt,
+	}
+}
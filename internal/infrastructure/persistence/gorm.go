@@ -1,6 +1,7 @@
 package persistence
 
 import (
+	"errors"
 	"log/slog"
 	"time"
 	"topdoctors/internal/domain"
@@ -11,29 +12,104 @@ import (
 
 // GormRepository implements all repository interfaces
 type GormRepository struct {
-	db  *gorm.DB
-	cfg Config
+	db     *gorm.DB
+	cfg    Config
+	cipher *fieldCipher
+	fts5   bool // Whether diagnosis text search is backed by SQLite FTS5
 }
 type Config struct {
-	DSN string
+	DSN           string
+	MasterKey     string
+	MasterKeyFile string
 }
 
 func NewGormRepository(cfg Config) (*GormRepository, error) {
-	db, err := gorm.Open(sqlite.Open(cfg.DSN), &gorm.Config{})
+	masterKey, err := LoadMasterKey(cfg.MasterKey, cfg.MasterKeyFile)
+	if err != nil {
+		slog.Error("Failed to load encryption master key", "error", err)
+		return nil, err
+	}
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{DriverName: sqliteUTCDriver, DSN: cfg.DSN}), &gorm.Config{})
 	if err != nil {
 		slog.Error("Failed to open GORM database", "dsn", cfg.DSN, "error", err)
 		return nil, err
 	}
 
+	// Token rows written before the Spanish analyzer lack field and frequency
+	tokensOutdated := db.Migrator().HasTable(&DiagnosisSearchTokenDB{}) &&
+		!db.Migrator().HasColumn(&DiagnosisSearchTokenDB{}, "Frequency")
+	// Name tokens written before fuzzy search lack field and kind
+	nameTokensOutdated := db.Migrator().HasTable(&PatientSearchTokenDB{}) &&
+		!db.Migrator().HasColumn(&PatientSearchTokenDB{}, "Kind")
+	// Patients stored before duplicate detection lack email and phone indexes
+	contactIndexOutdated := db.Migrator().HasTable(&PatientDB{}) &&
+		!db.Migrator().HasColumn(&PatientDB{}, "EmailIndex")
+
 	// Auto migrate
-	err = db.AutoMigrate(&PatientDB{}, &DiagnosisDB{}, &UserDB{}, &UserTokenDB{})
+	models := []any{
+		&PatientDB{}, &DiagnosisDB{}, &UserDB{}, &UserTokenDB{},
+		&CareTeamMemberDB{}, &BreakGlassAccessDB{}, &AccessLogEntryDB{},
+		&ConsentDB{}, &ErasureDB{}, &DataKeyDB{}, &PatientSearchTokenDB{},
+		&PatientDataKeyDB{}, &DiagnosisSearchTokenDB{}, &PatientMergeDB{},
+		&ContactDB{}, &AppointmentDB{}, &CalendarFeedDB{},
+		&ObservationDB{}, &LabResultDB{}, &AttachmentDB{}, &VaccinationDB{}, &ReferralDB{},
+		&EncounterDB{}, &BulkExportJobDB{}, &BulkExportFileDB{}, &BulkExportPatientDB{},
+	}
+	err = db.AutoMigrate(models...)
 	if err != nil {
 		slog.Error("Database auto-migration failed", "error", err)
 		return nil, err
 	}
 
+	cipher, err := newFieldCipher(db, masterKey)
+	if err != nil {
+		slog.Error("Failed to load data keys", "error", err)
+		return nil, err
+	}
+
+	fts5, err := enableFTS5(db)
+	if err != nil {
+		slog.Error("Failed to create diagnosis full-text index", "error", err)
+		return nil, err
+	}
+
+	repo := &GormRepository{db: db, cfg: cfg, cipher: cipher, fts5: fts5}
+	if err := repo.encryptLegacyPatients(); err != nil {
+		slog.Error("Failed to encrypt legacy patient records", "error", err)
+		return nil, err
+	}
+	if err := repo.splitLegacyNames(); err != nil {
+		slog.Error("Failed to split legacy patient names", "error", err)
+		return nil, err
+	}
+	if err := repo.moveLegacyAddresses(); err != nil {
+		slog.Error("Failed to move legacy patient addresses", "error", err)
+		return nil, err
+	}
+	if err := repo.ensureContactIndex(contactIndexOutdated); err != nil {
+		slog.Error("Failed to build patient contact index", "error", err)
+		return nil, err
+	}
+	if err := repo.encryptLegacyDiagnoses(); err != nil {
+		slog.Error("Failed to encrypt legacy diagnosis records", "error", err)
+		return nil, err
+	}
+	if err := repo.ensurePatientNameIndex(nameTokensOutdated); err != nil {
+		slog.Error("Failed to rebuild patient name index", "error", err)
+		return nil, err
+	}
+	if err := repo.ensureTextIndex(tokensOutdated); err != nil {
+		slog.Error("Failed to rebuild diagnosis text index", "error", err)
+		return nil, err
+	}
+	if err := repo.utcTimestamps(models...); err != nil {
+		slog.Error("Failed to convert stored times to UTC", "error", err)
+		return nil, err
+	}
+
 	slog.Debug("GORM repository initialized and migrated")
-	return &GormRepository{db: db, cfg: cfg}, nil
+	return repo, nil
 }
 
 // Close closes the underlying database connection
@@ -46,10 +122,23 @@ func (r *GormRepository) Close() error {
 }
 
 // Patient Repository Implementation
-func (r *GormRepository) CreatePatient(patient *domain.Patient) error {
+func (r *GormRepository) CreatePatient(patient *domain.Patient, creator *domain.CareTeamMember) error {
+	dbPatient, err := toPatientDB(patient, r.cipher)
+	if err != nil {
+		return err
+	}
 
-	dbPatient := toPatientDB(patient)
-	err := r.db.Create(dbPatient).Error
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbPatient).Error; err != nil {
+			return err
+		}
+		if creator != nil {
+			if err := tx.Create(toCareTeamMemberDB(creator)).Error; err != nil {
+				return err
+			}
+		}
+		return r.replacePatientSearchTokens(tx, dbPatient.ULID, patient)
+	})
 	if err == nil {
 		patient.ID = dbPatient.ULID
 	}
@@ -62,22 +151,129 @@ func (r *GormRepository) GetPatientByID(id string) (*domain.Patient, error) {
 	if err != nil {
 		return nil, err
 	}
-	return toPatientDomain(&patient), nil
+	return toPatientDomain(&patient, r.cipher)
 }
 
 func (r *GormRepository) GetPatientByDNI(dni string) (*domain.Patient, error) {
 	var patient PatientDB
-	err := r.db.Where("dni = ?", dni).First(&patient).Error
+	err := r.db.Where("dni_index = ?", r.cipher.blindIndex(dniIndexNamespace, normalizeDNI(dni))).First(&patient).Error
 	if err != nil {
 		return nil, err
 	}
-	return toPatientDomain(&patient), nil
+	return toPatientDomain(&patient, r.cipher)
 }
 
-// Diagnosis Repository Implementation
-func (r *GormRepository) CreateDiagnosis(diagnosis *domain.Diagnosis) error {
-	dbDiagnosis := toDiagnosisDB(diagnosis)
+// updatePatient rewrites the identifying fields of a patient encrypted with c,
+// keeping the blind indexes and the name search tokens in sync. Erased and
+// merged patients lose their search tokens.
+func (r *GormRepository) updatePatient(tx *gorm.DB, patient *domain.Patient, c *fieldCipher) error {
+	dbPatient, err := toPatientDB(patient, c)
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&PatientDB{}).Where("ulid = ?", patient.ID).Updates(map[string]interface{}{
+		"name":               "",
+		"given_name":         dbPatient.GivenName,
+		"first_surname":      dbPatient.FirstSurname,
+		"second_surname":     dbPatient.SecondSurname,
+		"dni":                dbPatient.DNI,
+		"dni_index":          dbPatient.DNIIndex,
+		"birth_date":         dbPatient.BirthDate,
+		"sex":                dbPatient.Sex,
+		"nationality":        dbPatient.Nationality,
+		"preferred_language": dbPatient.PreferredLanguage,
+		"email":              dbPatient.Email,
+		"email_index":        dbPatient.EmailIndex,
+		"phone":              dbPatient.Phone,
+		"phone_index":        dbPatient.PhoneIndex,
+		"address":            "",
+		"address_street":     dbPatient.AddressStreet,
+		"address_number":     dbPatient.AddressNumber,
+		"address_floor":      dbPatient.AddressFloor,
+		"postal_code":        dbPatient.PostalCode,
+		"city":               dbPatient.City,
+		"province":           dbPatient.Province,
+		"country":            dbPatient.Country,
+		"erased_at":          dbPatient.ErasedAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	if patient.IsErased() || patient.IsMerged() {
+		return r.replacePatientSearchTokens(tx, patient.ID, nil)
+	}
+	return r.replacePatientSearchTokens(tx, patient.ID, patient)
+}
+
+// NormalizePhones rewrites the phones stored before they were normalized to
+// E.164. Phones that cannot be parsed are left as they are and counted as
+// invalid, to be fixed by hand.
+func (r *GormRepository) NormalizePhones() (normalized, invalid int, err error) {
+	var stored []PatientDB
+	if err := r.db.Where("phone <> ''").Find(&stored).Error; err != nil {
+		return 0, 0, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range stored {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			phone, err := domain.NormalizePhone(patient.Phone)
+			if err != nil {
+				slog.Warn("Patient phone cannot be normalized", "patient_id", patient.ID)
+				invalid++
+				continue
+			}
+			if phone == patient.Phone {
+				continue
+			}
+			patient.Phone = phone
+			if err := r.updatePatient(tx, patient, r.cipher); err != nil {
+				return err
+			}
+			normalized++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return normalized, invalid, nil
+}
 
+// moveLegacyAddresses moves the free-text addresses stored before the
+// structured address to its street, where they stay until edited
+func (r *GormRepository) moveLegacyAddresses() error {
+	var legacy []PatientDB
+	if err := r.db.Where("address IS NOT NULL AND address <> ''").Find(&legacy).Error; err != nil || len(legacy) == 0 {
+		return err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range legacy {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			if err := r.updatePatient(tx, patient, r.cipher); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Moved legacy patient addresses", "count", len(legacy))
+	return nil
+}
+
+// Diagnosis Repository Implementation
+func (r *GormRepository) CreateDiagnosis(diagnosis *domain.Diagnosis, followUp *domain.Appointment) error {
 	// Search patient by ULID to get the primary key (ID)
 	var patient PatientDB
 	errGetPatientID := r.db.Where("ulid = ?", diagnosis.PatientID).Select("id", "ulid").First(&patient).Error
@@ -86,9 +282,34 @@ func (r *GormRepository) CreateDiagnosis(diagnosis *domain.Diagnosis) error {
 		return errGetPatientID
 	}
 
+	dbDiagnosis, err := toDiagnosisDB(diagnosis, r.cipher)
+	if err != nil {
+		return err
+	}
 	dbDiagnosis.PatientID = patient.ID
 	dbDiagnosis.PatientULID = patient.ULID
-	errCreateDiagnosis := r.db.Create(dbDiagnosis).Error
+
+	var dbFollowUp *AppointmentDB
+	if followUp != nil {
+		if dbFollowUp, err = toAppointmentDB(followUp, r.cipher); err != nil {
+			return err
+		}
+	}
+
+	errCreateDiagnosis := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbDiagnosis).Error; err != nil {
+			return err
+		}
+		indexed := *diagnosis
+		indexed.ID, indexed.PatientID = dbDiagnosis.ULID, patient.ULID
+		if err := r.indexDiagnosisText(tx, &indexed); err != nil {
+			return err
+		}
+		if dbFollowUp == nil {
+			return nil
+		}
+		return tx.Create(dbFollowUp).Error
+	})
 	if errCreateDiagnosis == nil {
 		diagnosis.ID = dbDiagnosis.ULID
 	}
@@ -97,16 +318,24 @@ func (r *GormRepository) CreateDiagnosis(diagnosis *domain.Diagnosis) error {
 
 func (r *GormRepository) GetDiagnosisByPatientID(patientID string) ([]domain.Diagnosis, error) {
 	var diagnostics []DiagnosisDB
-	err := r.db.Where("patient_ulid = ?", patientID).Find(&diagnostics).Error
+	err := r.db.Preload("Attachments", attachmentOrder).Where("patient_ulid = ?", patientID).Find(&diagnostics).Error
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]domain.Diagnosis, len(diagnostics))
-	for i, d := range diagnostics {
-		result[i] = *toDiagnosisDomain(&d)
+	return toDiagnosisDomainList(diagnostics, r.cipher)
+}
+
+func (r *GormRepository) GetDiagnosisByID(id string) (*domain.Diagnosis, error) {
+	var diagnosis DiagnosisDB
+	err := r.db.Where("ulid = ?", id).First(&diagnosis).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrDiagnosisNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	return toDiagnosisDomain(&diagnosis, r.cipher)
 }
 
 func (r *GormRepository) GetByDiagnosisDateRange(startDate, endDate time.Time) ([]domain.Diagnosis, error) {
@@ -116,34 +345,60 @@ func (r *GormRepository) GetByDiagnosisDateRange(startDate, endDate time.Time) (
 		return nil, err
 	}
 
-	result := make([]domain.Diagnosis, len(diagnostics))
-	for i, d := range diagnostics {
-		result[i] = *toDiagnosisDomain(&d)
-	}
-	return result, nil
+	return toDiagnosisDomainList(diagnostics, r.cipher)
 }
 
 func (r *GormRepository) GetDiagnosisByPatientName(name string) ([]domain.Diagnosis, error) {
 	var diagnostics []DiagnosisDB
-	err := r.db.Joins("Patient").Where("Patient.name LIKE ?", "%"+name+"%").Find(&diagnostics).Error
+	err := r.filterByName(r.db.Joins("Patient"), domain.NameFilter{Name: &name}).Find(&diagnostics).Error
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]domain.Diagnosis, len(diagnostics))
-	for i, d := range diagnostics {
-		result[i] = *toDiagnosisDomain(&d)
-	}
-	return result, nil
+	return toDiagnosisDomainList(diagnostics, r.cipher)
 }
 
-func (r *GormRepository) SearchDiagnosis(patientName *string, dateStart, dateEnd *time.Time) ([]domain.Diagnosis, error) {
-	query := r.db.Model(&DiagnosisDB{}).Preload("Patient").Joins("Patient")
+// SearchDiagnosis returns a page of the diagnoses the caller may access
+// matching the filter
+func (r *GormRepository) SearchDiagnosis(caller domain.Caller, filter domain.DiagnosisFilter) (*domain.DiagnosisPage, error) {
+	query := r.db.Model(&DiagnosisDB{}).Joins("Patient")
+	if caller.IsIntegration() {
+		query = r.consentedTo(query, domain.ConsentPurposeThirdPartySharing, domain.ConsentScopeDiagnoses, time.Now())
+		// Filtering on demographics would reveal them, even when redacted
+		if !filter.Patient.IsEmpty() || filter.HasDemographics() {
+			query = r.consentedTo(query, domain.ConsentPurposeThirdPartySharing, domain.ConsentScopeDemographics, time.Now())
+		}
+	} else {
+		query = r.accessibleBy(query, caller.UserID, time.Now())
+	}
 
-	if patientName != nil && *patientName != "" {
-		query = query.Where("Patient.name LIKE ?", "%"+*patientName+"%")
+	query = r.filterByName(query, filter.Patient)
+	if filter.PatientID != nil {
+		query = query.Where("diagnoses.patient_ulid = ?", *filter.PatientID)
+	}
+	if filter.Sex != nil {
+		query = query.Where("Patient.sex = ?", *filter.Sex)
+	}
+	if filter.Province != nil {
+		query = query.Where("Patient.province = ?", *filter.Province)
+	}
+	if filter.PostalCode != nil {
+		query = query.Where("Patient.postal_code = ?", *filter.PostalCode)
 	}
 
+	var terms []string
+	var textMatches *gorm.DB
+	if filter.Text != nil {
+		terms = analyzeSpanish(*filter.Text)
+		if len(terms) == 0 {
+			return nil, domain.ErrEmptySearchText
+		}
+		// Prescriptions are only searchable by callers allowed to read them,
+		// integration clients may lack consent for that scope
+		textMatches = r.diagnosesMatchingText(terms, caller.IsIntegration())
+	}
+
+	dateStart, dateEnd := filter.DateStart, filter.DateEnd
 	if dateStart != nil {
 		startOfDay := time.Date(dateStart.Year(), dateStart.Month(), dateStart.Day(), 0, 0, 0, 0, dateStart.Location())
 		if dateEnd == nil {
@@ -159,17 +414,12 @@ func (r *GormRepository) SearchDiagnosis(patientName *string, dateStart, dateEnd
 		query = query.Where("diagnoses.date <= ?", endOfDay)
 	}
 
-	var diagnostics []DiagnosisDB
-	err := query.Find(&diagnostics).Error
-	if err != nil {
-		return nil, err
-	}
-
-	result := make([]domain.Diagnosis, len(diagnostics))
-	for i, d := range diagnostics {
-		result[i] = *toDiagnosisDomain(&d)
+	// Name similarity is only known once decrypted, other searches are
+	// ordered by the database
+	if !filter.Patient.IsEmpty() {
+		return r.searchDiagnosisByName(query, filter, textMatches, terms)
 	}
-	return result, nil
+	return r.searchDiagnosisInOrder(query, filter, textMatches, terms)
 }
 
 // User Repository Implementation
@@ -182,6 +432,15 @@ func (r *GormRepository) GetByUsername(username string) (*domain.User, error) {
 	return toUserDomain(&user), nil
 }
 
+func (r *GormRepository) GetByID(id string) (*domain.User, error) {
+	var user UserDB
+	err := r.db.Where("ulid = ?", id).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return toUserDomain(&user), nil
+}
+
 func (r *GormRepository) CreateUser(user *domain.User) error {
 	dbUser := toUserDB(user)
 	err := r.db.Create(dbUser).Error
@@ -190,3 +449,11 @@ func (r *GormRepository) CreateUser(user *domain.User) error {
 	}
 	return err
 }
+
+func (r *GormRepository) UpdateUserRole(id, role string) error {
+	return r.db.Model(&UserDB{}).Where("ulid = ?", id).Update("role", role).Error
+}
+
+func (r *GormRepository) UpdateUserSpecialty(id, specialty string) error {
+	return r.db.Model(&UserDB{}).Where("ulid = ?", id).Update("specialty", specialty).Error
+}
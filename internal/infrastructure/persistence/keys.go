@@ -0,0 +1,186 @@
+package persistence
+
+import (
+	"log/slog"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// encryptLegacyPatients encrypts the rows stored before field encryption was
+// introduced. They are recognised by their missing DNI blind index.
+func (r *GormRepository) encryptLegacyPatients() error {
+	var legacy []PatientDB
+	err := r.db.Where("dni_index IS NULL OR dni_index = ''").Find(&legacy).Error
+	if err != nil || len(legacy) == 0 {
+		return err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range legacy {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			if err := r.updatePatient(tx, patient, r.cipher); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Encrypted legacy patient records", "count", len(legacy))
+	return nil
+}
+
+// encryptLegacyDiagnoses encrypts the clinical text stored before per-patient
+// keys were introduced and builds its search tokens
+func (r *GormRepository) encryptLegacyDiagnoses() error {
+	var legacy []DiagnosisDB
+	err := r.db.Where("(diagnosis <> '' AND diagnosis NOT LIKE ?) OR (prescription <> '' AND prescription NOT LIKE ?)",
+		patientEncryptedPrefix+"%", patientEncryptedPrefix+"%").Find(&legacy).Error
+	if err != nil || len(legacy) == 0 {
+		return err
+	}
+
+	// Encrypt first, patient keys cannot be created inside the transaction
+	encrypted := make([]*DiagnosisDB, len(legacy))
+	plaintexts := make([]*domain.Diagnosis, len(legacy))
+	for i, d := range legacy {
+		diagnosis, err := toDiagnosisDomain(&d, r.cipher)
+		if err != nil {
+			return err
+		}
+		if encrypted[i], err = toDiagnosisDB(diagnosis, r.cipher); err != nil {
+			return err
+		}
+		plaintexts[i] = diagnosis
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for i, d := range encrypted {
+			err := tx.Model(&DiagnosisDB{}).Where("ulid = ?", d.ULID).Updates(map[string]interface{}{
+				"diagnosis":    d.Diagnosis,
+				"prescription": d.Prescription,
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err := r.indexDiagnosisText(tx, plaintexts[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Encrypted legacy diagnosis records", "count", len(legacy))
+	return nil
+}
+
+// RotateKeys creates a new active data key, re-encrypts every patient and
+// contact with it and re-wraps the per-patient keys, which leaves clinical text untouched.
+// When newMaster is not nil all data keys are also re-wrapped with it, and
+// the old master key can be discarded once the rotation succeeds.
+func (r *GormRepository) RotateKeys(newMaster []byte) (int, error) {
+	if newMaster != nil && len(newMaster) != keySize {
+		return 0, ErrInvalidMasterKey
+	}
+
+	next, key, err := r.cipher.rotated(newMaster)
+	if err != nil {
+		return 0, err
+	}
+
+	var patients []PatientDB
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if newMaster != nil {
+			if err := rewrapDataKeys(tx, r.cipher.master, newMaster); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&DataKeyDB{}).Where("purpose = ?", dataKeyPurposeField).Update("active", false).Error; err != nil {
+			return err
+		}
+		wrapped, err := wrapKey(next.master, key)
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&DataKeyDB{
+			Purpose:    dataKeyPurposeField,
+			Version:    next.active,
+			WrappedKey: wrapped,
+			Active:     true,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := r.cipher.rewrapPatientKeys(tx, next); err != nil {
+			return err
+		}
+
+		if err := tx.Find(&patients).Error; err != nil {
+			return err
+		}
+		for _, p := range patients {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			if err := r.updatePatient(tx, patient, next); err != nil {
+				return err
+			}
+		}
+		if _, err := r.reencryptContacts(tx, next); err != nil {
+			return err
+		}
+		if _, err := r.reencryptAppointments(tx, next); err != nil {
+			return err
+		}
+		if _, err := r.reencryptAttachments(tx, next); err != nil {
+			return err
+		}
+		if _, err := r.reencryptReferrals(tx, next); err != nil {
+			return err
+		}
+		_, err = r.reencryptBulkExportJobs(tx, next)
+		return err
+	})
+	if err != nil {
+		slog.Error("Key rotation failed, nothing was changed", "error", err)
+		return 0, err
+	}
+
+	r.cipher = next
+	slog.Info("Data key rotated", "version", next.active, "patients", len(patients), "new_master", newMaster != nil)
+	return len(patients), nil
+}
+
+// rewrapDataKeys wraps every stored data key with a new master key
+func rewrapDataKeys(tx *gorm.DB, oldMaster, newMaster []byte) error {
+	var keys []DataKeyDB
+	if err := tx.Find(&keys).Error; err != nil {
+		return err
+	}
+	for _, k := range keys {
+		key, err := unwrapKey(oldMaster, k.WrappedKey)
+		if err != nil {
+			return err
+		}
+		wrapped, err := wrapKey(newMaster, key)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&DataKeyDB{}).Where("id = ?", k.ID).Update("wrapped_key", wrapped).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
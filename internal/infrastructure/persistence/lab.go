@@ -0,0 +1,193 @@
+package persistence
+
+import (
+	"strconv"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type LabResultDB struct {
+	ID             uint   `gorm:"primaryKey,autoIncrement"`
+	ULID           string `gorm:"column:ulid;unique"`
+	PatientULID    string `gorm:"column:patient_ulid;index"`
+	BatchULID      string `gorm:"column:batch_ulid;index"`
+	Panel          string
+	Analyte        string
+	Code           string
+	Value          string // Encrypted with the patient key
+	Unit           string
+	ReferenceLow   *float64
+	ReferenceHigh  *float64
+	Flag           string    `gorm:"index:idx_lab_results_abnormal,priority:1"` // Kept in clear to find abnormal values without decrypting
+	CollectedAt    time.Time `gorm:"index:idx_lab_results_abnormal,priority:2"`
+	DiagnosisULID  *string   `gorm:"column:diagnosis_ulid"`
+	RecordedByULID string    `gorm:"column:recorded_by_ulid"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (LabResultDB) TableName() string {
+	return "lab_results"
+}
+
+// Lab Result Repository Implementation
+func (r *GormRepository) CreateLabResults(results []domain.LabResult) error {
+	// Encrypt before the transaction, patient keys cannot be created inside it
+	dbResults := make([]*LabResultDB, len(results))
+	for i := range results {
+		dbResult, err := toLabResultDB(&results[i], r.cipher)
+		if err != nil {
+			return err
+		}
+		dbResults[i] = dbResult
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(dbResults).Error
+	})
+}
+
+func (r *GormRepository) GetLabResultsByPatientID(patientID string, filter domain.LabResultFilter) ([]domain.LabResult, error) {
+	query := r.db.Where("patient_ulid = ?", patientID)
+	if filter.Panel != nil {
+		query = query.Where("panel = ?", *filter.Panel)
+	}
+	if filter.Analyte != nil {
+		query = query.Where("analyte = ?", *filter.Analyte)
+	}
+	if filter.AbnormalOnly {
+		query = query.Where("flag IN ?", []string{domain.InterpretationLow, domain.InterpretationHigh})
+	}
+	if filter.From != nil {
+		query = query.Where("collected_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("collected_at < ?", *filter.To)
+	}
+
+	var results []LabResultDB
+	if err := query.Order("collected_at, id").Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return toLabResultDomainList(results, r.cipher)
+}
+
+func (r *GormRepository) GetAbnormalLabResults(caller domain.Caller, from, to time.Time) ([]domain.LabResult, error) {
+	query := r.db.Model(&LabResultDB{}).Joins("JOIN patients AS Patient ON Patient.ulid = lab_results.patient_ulid").
+		Where("lab_results.flag IN ?", []string{domain.InterpretationLow, domain.InterpretationHigh}).
+		Where("lab_results.collected_at >= ? AND lab_results.collected_at < ?", from, to)
+	query = r.accessibleBy(query, caller.UserID, time.Now())
+
+	var results []LabResultDB
+	if err := query.Order("lab_results.collected_at, lab_results.id").Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return toLabResultDomainList(results, r.cipher)
+}
+
+// reencryptLabResults returns the lab results of a patient encrypted with the
+// key of another, to move them there
+func (r *GormRepository) reencryptLabResults(fromPatientID, toPatientID string) ([]*LabResultDB, error) {
+	var stored []LabResultDB
+	if err := r.db.Where("patient_ulid = ?", fromPatientID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	results, err := toLabResultDomainList(stored, r.cipher)
+	if err != nil {
+		return nil, err
+	}
+	moved := make([]*LabResultDB, len(results))
+	for i := range results {
+		results[i].PatientID = toPatientID
+		if moved[i], err = toLabResultDB(&results[i], r.cipher); err != nil {
+			return nil, err
+		}
+	}
+	return moved, nil
+}
+
+// moveLabResults reassigns the lab results of a merged duplicate to the
+// survivor. They must have been re-encrypted with the survivor's key already.
+func moveLabResults(tx *gorm.DB, moved []*LabResultDB) error {
+	for _, l := range moved {
+		err := tx.Model(&LabResultDB{}).Where("ulid = ?", l.ULID).Updates(map[string]interface{}{
+			"patient_ulid": l.PatientULID,
+			"value":        l.Value,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mappers
+func toLabResultDB(l *domain.LabResult, c *fieldCipher) (*LabResultDB, error) {
+	value, err := c.encryptForPatient(l.PatientID, strconv.FormatFloat(l.Value, 'g', -1, 64))
+	if err != nil {
+		return nil, err
+	}
+
+	dbResult := &LabResultDB{
+		ULID:           l.ID,
+		PatientULID:    l.PatientID,
+		BatchULID:      l.BatchID,
+		Panel:          l.Panel,
+		Analyte:        l.Analyte,
+		Code:           l.Code,
+		Value:          value,
+		Unit:           l.Unit,
+		ReferenceLow:   l.Reference.Low,
+		ReferenceHigh:  l.Reference.High,
+		Flag:           l.Flag(),
+		CollectedAt:    l.CollectedAt,
+		RecordedByULID: l.RecordedBy,
+		CreatedAt:      l.CreatedAt,
+	}
+	if l.DiagnosisID != "" {
+		dbResult.DiagnosisULID = &l.DiagnosisID
+	}
+	return dbResult, nil
+}
+
+func toLabResultDomain(l *LabResultDB, c *fieldCipher) (*domain.LabResult, error) {
+	text, err := c.decryptForPatient(l.PatientULID, l.Value)
+	if err != nil {
+		return nil, err
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.LabResult{
+		ID:          l.ULID,
+		PatientID:   l.PatientULID,
+		BatchID:     l.BatchULID,
+		Panel:       l.Panel,
+		Analyte:     l.Analyte,
+		Code:        l.Code,
+		Value:       value,
+		Unit:        l.Unit,
+		Reference:   domain.ReferenceRange{Low: l.ReferenceLow, High: l.ReferenceHigh},
+		CollectedAt: l.CollectedAt,
+		RecordedBy:  l.RecordedByULID,
+		CreatedAt:   l.CreatedAt,
+	}
+	if l.DiagnosisULID != nil {
+		result.DiagnosisID = *l.DiagnosisULID
+	}
+	return result, nil
+}
+
+func toLabResultDomainList(results []LabResultDB, c *fieldCipher) ([]domain.LabResult, error) {
+	list := make([]domain.LabResult, len(results))
+	for i, l := range results {
+		result, err := toLabResultDomain(&l, c)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = *result
+	}
+	return list, nil
+}
@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestLabResults(t *testing.T) {
+	repo := newTestRepository(t)
+	caller := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+
+	mine := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z"}
+	other := &domain.Patient{ID: "01HZY0000000000000000000P2", GivenName: "Juan", FirstSurname: "Pérez", DNI: "87654321X"}
+	for _, p := range []*domain.Patient{mine, other} {
+		if err := repo.CreatePatient(p, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+	}
+	repo.AddCareTeamMember(&domain.CareTeamMember{PatientID: mine.ID, UserID: caller.UserID, AddedAt: time.Now()})
+
+	collected := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	low, high, ceiling := 12.0, 16.0, 200.0
+	batch := func(patientID, prefix string, hemoglobin float64) []domain.LabResult {
+		return []domain.LabResult{
+			{ID: prefix + "1", PatientID: patientID, BatchID: prefix + "B", Panel: "Complete blood count", Analyte: "Hemoglobin", Value: hemoglobin,
+				Unit: "g/dL", Reference: domain.ReferenceRange{Low: &low, High: &high}, CollectedAt: collected, RecordedBy: "doctor", CreatedAt: time.Now()},
+			{ID: prefix + "2", PatientID: patientID, BatchID: prefix + "B", Panel: "Lipid panel", Analyte: "Cholesterol", Value: 180,
+				Unit: "mg/dL", Reference: domain.ReferenceRange{High: &ceiling}, CollectedAt: collected, RecordedBy: "doctor", CreatedAt: time.Now()},
+		}
+	}
+	if err := repo.CreateLabResults(batch(mine.ID, "01HZY00000000000000000L1", 10.9)); err != nil {
+		t.Fatalf("CreateLabResults() error = %v", err)
+	}
+	if err := repo.CreateLabResults(batch(other.ID, "01HZY00000000000000000L2", 9.5)); err != nil {
+		t.Fatalf("CreateLabResults() error = %v", err)
+	}
+
+	t.Run("Encrypts the value and keeps the flag", func(t *testing.T) {
+		var stored LabResultDB
+		repo.db.Where("ulid = ?", "01HZY00000000000000000L11").First(&stored)
+		if !strings.HasPrefix(stored.Value, patientEncryptedPrefix) {
+			t.Errorf("expected value encrypted with the patient key, got %q", stored.Value)
+		}
+		if stored.Flag != domain.InterpretationLow {
+			t.Errorf("expected flag %q, got %q", domain.InterpretationLow, stored.Flag)
+		}
+	})
+
+	t.Run("Filters the results of a patient", func(t *testing.T) {
+		all, err := repo.GetLabResultsByPatientID(mine.ID, domain.LabResultFilter{})
+		if err != nil || len(all) != 2 {
+			t.Fatalf("GetLabResultsByPatientID() = %+v, %v", all, err)
+		}
+		panel := "Lipid panel"
+		lipids, _ := repo.GetLabResultsByPatientID(mine.ID, domain.LabResultFilter{Panel: &panel})
+		if len(lipids) != 1 || lipids[0].Value != 180 {
+			t.Errorf("GetLabResultsByPatientID() by panel = %+v", lipids)
+		}
+		abnormal, _ := repo.GetLabResultsByPatientID(mine.ID, domain.LabResultFilter{AbnormalOnly: true})
+		if len(abnormal) != 1 || abnormal[0].Analyte != "Hemoglobin" || abnormal[0].Value != 10.9 {
+			t.Errorf("GetLabResultsByPatientID() abnormal = %+v", abnormal)
+		}
+	})
+
+	t.Run("Abnormal results only of accessible patients", func(t *testing.T) {
+		got, err := repo.GetAbnormalLabResults(caller, collected.Add(-time.Hour), collected.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("GetAbnormalLabResults() error = %v", err)
+		}
+		if len(got) != 1 || got[0].PatientID != mine.ID {
+			t.Errorf("GetAbnormalLabResults() = %+v", got)
+		}
+		none, _ := repo.GetAbnormalLabResults(caller, collected.Add(time.Hour), collected.Add(2*time.Hour))
+		if len(none) != 0 {
+			t.Errorf("expected no results out of the range, got %+v", none)
+		}
+	})
+}
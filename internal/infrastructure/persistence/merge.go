@@ -0,0 +1,364 @@
+package persistence
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// Duplicate candidates are the accessible patients sharing name trigrams,
+// the email or the phone with the patient. Identifying fields are encrypted,
+// so the candidates are selected through blind indexes and scored in Go,
+// where the birth date is compared as well.
+
+// minSharedTrigrams is how many name trigrams a patient must share with
+// another to be considered a candidate on the name alone
+const minSharedTrigrams = 3
+
+type PatientMergeDB struct {
+	ID             uint   `gorm:"primaryKey,autoIncrement"`
+	ULID           string `gorm:"column:ulid;unique"`
+	SurvivorULID   string `gorm:"column:survivor_ulid;index"`
+	DuplicateULID  string `gorm:"column:duplicate_ulid;unique"`
+	MergedByULID   string `gorm:"column:merged_by_ulid"`
+	Reason         string
+	DiagnosesMoved int
+	MergedAt       time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (PatientMergeDB) TableName() string {
+	return "patient_merges"
+}
+
+// Merge Repository Implementation
+func (r *GormRepository) FindDuplicateCandidates(caller domain.Caller, patient *domain.Patient) ([]domain.DuplicateCandidate, error) {
+	query := r.db.Table("patients AS Patient").
+		Where("Patient.ulid <> ? AND Patient.erased_at IS NULL AND Patient.merged_into_ulid IS NULL", patient.ID)
+	query = r.accessibleBy(query, caller.UserID, time.Now())
+
+	emailIndex := r.cipher.optionalBlindIndex(emailIndexNamespace, normalizeEmail(patient.Email))
+	phoneIndex := r.cipher.optionalBlindIndex(phoneIndexNamespace, normalizePhone(patient.Phone))
+
+	signals := r.db.Where("Patient.ulid IN (?)", r.patientsSharingTrigrams(patient))
+	if emailIndex != "" {
+		signals = signals.Or("Patient.email_index = ?", emailIndex)
+	}
+	if phoneIndex != "" {
+		signals = signals.Or("Patient.phone_index = ?", phoneIndex)
+	}
+
+	var stored []PatientDB
+	if err := query.Where(signals).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+
+	var result []domain.DuplicateCandidate
+	for _, p := range stored {
+		candidate, err := toPatientDomain(&p, r.cipher)
+		if err != nil {
+			return nil, err
+		}
+		score, matches := domain.DuplicateScore(
+			nameSimilarity(patient, candidate),
+			emailIndex != "" && p.EmailIndex == emailIndex,
+			phoneIndex != "" && p.PhoneIndex == phoneIndex,
+			patient.BirthDate != nil && candidate.BirthDate != nil && patient.BirthDate.Equal(*candidate.BirthDate),
+		)
+		if score >= domain.DuplicateThreshold {
+			result = append(result, domain.DuplicateCandidate{Patient: *candidate, Score: score, Matches: matches})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	return result, nil
+}
+
+// patientsSharingTrigrams returns a subquery selecting the patients sharing
+// enough name trigrams with the given one
+func (r *GormRepository) patientsSharingTrigrams(p *domain.Patient) *gorm.DB {
+	seen := make(map[string]bool)
+	var hashes []string
+	for _, words := range nameFields(p) {
+		for _, word := range words {
+			for _, trigram := range trigrams(word) {
+				hash := r.cipher.blindIndex(trigramTokenNamespace, trigram)
+				if !seen[hash] {
+					seen[hash] = true
+					hashes = append(hashes, hash)
+				}
+			}
+		}
+	}
+	return r.db.Model(&PatientSearchTokenDB{}).Select("patient_ulid").
+		Where("kind = ? AND token_hash IN ?", tokenKindTrigram, hashes).
+		Group("patient_ulid").
+		Having("COUNT(DISTINCT token_hash) >= ?", min(minSharedTrigrams, len(hashes)))
+}
+
+// nameSimilarity compares two names word by word, ignoring which part of the
+// name each word belongs to: every word of the shorter name is matched with
+// its most similar word of the other. It returns the average similarity,
+// between 0 and 1, so a name missing its second surname still scores 1.
+func nameSimilarity(a, b *domain.Patient) float64 {
+	words := func(p *domain.Patient) []string {
+		fields := nameFields(p)
+		return append(fields[nameFieldGiven], fields[nameFieldSurname]...)
+	}
+	shorter, longer := words(a), words(b)
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	if len(shorter) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, word := range shorter {
+		best := 0.0
+		for _, other := range longer {
+			best = max(best, wordSimilarity(word, other, true))
+		}
+		total += best
+	}
+	return total / float64(len(shorter))
+}
+
+func (r *GormRepository) MergePatients(merge *domain.PatientMerge) error {
+	var survivor PatientDB
+	if err := r.db.Where("ulid = ?", merge.SurvivorID).Select("id", "ulid").First(&survivor).Error; err != nil {
+		return err
+	}
+
+	var stored []DiagnosisDB
+	if err := r.db.Where("patient_ulid = ?", merge.DuplicateID).Find(&stored).Error; err != nil {
+		return err
+	}
+	// Re-encrypt with the survivor's key first, patient keys cannot be
+	// created inside the transaction
+	diagnoses, err := toDiagnosisDomainList(stored, r.cipher)
+	if err != nil {
+		return err
+	}
+	moved := make([]*DiagnosisDB, len(diagnoses))
+	for i := range diagnoses {
+		diagnoses[i].PatientID = survivor.ULID
+		if moved[i], err = toDiagnosisDB(&diagnoses[i], r.cipher); err != nil {
+			return err
+		}
+	}
+	movedObservations, err := r.reencryptObservations(merge.DuplicateID, survivor.ULID)
+	if err != nil {
+		return err
+	}
+	movedLabResults, err := r.reencryptLabResults(merge.DuplicateID, survivor.ULID)
+	if err != nil {
+		return err
+	}
+	movedEncounters, err := r.reencryptEncounters(merge.DuplicateID, survivor.ULID)
+	if err != nil {
+		return err
+	}
+	movedAttachments, err := r.rewrapAttachmentKeys(merge.DuplicateID, survivor.ULID)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// The checks of the service and the snapshot above ran outside the
+		// transaction. Both patients are claimed with conditional updates, so
+		// of two concurrent merges involving either of them only one goes on.
+		claim := tx.Model(&PatientDB{}).Where("ulid = ?", survivor.ULID).Scopes(activePatient).
+			UpdateColumn("merged_into_ulid", gorm.Expr("merged_into_ulid"))
+		if err := claimed(claim); err != nil {
+			return err
+		}
+		claim = tx.Model(&PatientDB{}).Where("ulid = ?", merge.DuplicateID).Scopes(activePatient).
+			UpdateColumn("merged_into_ulid", survivor.ULID)
+		if err := claimed(claim); err != nil {
+			return err
+		}
+		// Rows written for the duplicate since the snapshot would be left on
+		// the merged record
+		snapshots := []struct {
+			model any
+			ulids []string
+		}{
+			{&DiagnosisDB{}, rowULIDs(moved, func(d *DiagnosisDB) string { return d.ULID })},
+			{&ObservationDB{}, rowULIDs(movedObservations, func(o *ObservationDB) string { return o.ULID })},
+			{&LabResultDB{}, rowULIDs(movedLabResults, func(l *LabResultDB) string { return l.ULID })},
+			{&EncounterDB{}, rowULIDs(movedEncounters, func(e *EncounterDB) string { return e.ULID })},
+			{&AttachmentDB{}, rowULIDs(movedAttachments, func(a *AttachmentDB) string { return a.ULID })},
+		}
+		for _, snapshot := range snapshots {
+			gained, err := gainedRows(tx, snapshot.model, merge.DuplicateID, snapshot.ulids)
+			if err != nil {
+				return err
+			}
+			if gained {
+				return domain.ErrMergeConflict
+			}
+		}
+
+		for i, d := range moved {
+			err := tx.Model(&DiagnosisDB{}).Where("ulid = ?", d.ULID).Updates(map[string]interface{}{
+				"patient_id":   survivor.ID,
+				"patient_ulid": survivor.ULID,
+				"diagnosis":    d.Diagnosis,
+				"prescription": d.Prescription,
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err := r.indexDiagnosisText(tx, &diagnoses[i]); err != nil {
+				return err
+			}
+		}
+
+		// The duplicate's care team keeps access to the records it wrote
+		var members []CareTeamMemberDB
+		if err := tx.Where("patient_ulid = ?", merge.DuplicateID).Find(&members).Error; err != nil {
+			return err
+		}
+		for _, m := range members {
+			var count int64
+			err := tx.Model(&CareTeamMemberDB{}).Where("patient_ulid = ? AND user_ulid = ?", survivor.ULID, m.UserULID).Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+			copied := CareTeamMemberDB{PatientULID: survivor.ULID, UserULID: m.UserULID, AddedByULID: merge.MergedBy, CreatedAt: merge.MergedAt}
+			if err := tx.Create(&copied).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := moveObservations(tx, movedObservations); err != nil {
+			return err
+		}
+		if err := moveLabResults(tx, movedLabResults); err != nil {
+			return err
+		}
+		if err := moveEncounters(tx, movedEncounters); err != nil {
+			return err
+		}
+		if err := moveAttachments(tx, movedAttachments); err != nil {
+			return err
+		}
+
+		err := tx.Model(&ContactDB{}).Where("patient_ulid = ?", merge.DuplicateID).Update("patient_ulid", survivor.ULID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&AppointmentDB{}).Where("patient_ulid = ?", merge.DuplicateID).Update("patient_ulid", survivor.ULID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&VaccinationDB{}).Where("patient_ulid = ?", merge.DuplicateID).Update("patient_ulid", survivor.ULID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&ReferralDB{}).Where("patient_ulid = ?", merge.DuplicateID).Update("patient_ulid", survivor.ULID).Error
+		if err != nil {
+			return err
+		}
+
+		// The duplicate must no longer show up in name searches
+		if err := r.replacePatientSearchTokens(tx, merge.DuplicateID, nil); err != nil {
+			return err
+		}
+
+		merge.DiagnosesMoved = len(moved)
+		return tx.Create(toPatientMergeDB(merge)).Error
+	})
+}
+
+// activePatient selects the patients neither merged nor erased
+func activePatient(query *gorm.DB) *gorm.DB {
+	return query.Where("merged_into_ulid IS NULL AND erased_at IS NULL")
+}
+
+// claimed checks the conditional update claiming a patient for a merge
+func claimed(update *gorm.DB) error {
+	if update.Error != nil {
+		return update.Error
+	}
+	if update.RowsAffected != 1 {
+		return domain.ErrMergeConflict
+	}
+	return nil
+}
+
+// gainedRows reports whether the patient has rows of the model other than
+// the snapshotted ones
+func gainedRows(tx *gorm.DB, model any, patientID string, snapshot []string) (bool, error) {
+	query := tx.Model(model).Where("patient_ulid = ?", patientID)
+	if len(snapshot) > 0 {
+		query = query.Where("ulid NOT IN ?", snapshot)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func rowULIDs[T any](rows []*T, ulid func(*T) string) []string {
+	ulids := make([]string, len(rows))
+	for i, row := range rows {
+		ulids[i] = ulid(row)
+	}
+	return ulids
+}
+
+// ensureContactIndex computes the email and phone blind indexes of the
+// patients stored before they existed
+func (r *GormRepository) ensureContactIndex(outdated bool) error {
+	if !outdated {
+		return nil
+	}
+
+	var stored []PatientDB
+	if err := r.db.Find(&stored).Error; err != nil {
+		return err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range stored {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			if err := r.updatePatient(tx, patient, r.cipher); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Patient contact index built", "patients", len(stored))
+	return nil
+}
+
+// Mappers
+func toPatientMergeDB(m *domain.PatientMerge) *PatientMergeDB {
+	return &PatientMergeDB{
+		ULID:           m.ID,
+		SurvivorULID:   m.SurvivorID,
+		DuplicateULID:  m.DuplicateID,
+		MergedByULID:   m.MergedBy,
+		Reason:         m.Reason,
+		DiagnosesMoved: m.DiagnosesMoved,
+		MergedAt:       m.MergedAt,
+	}
+}
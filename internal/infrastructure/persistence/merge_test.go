@@ -0,0 +1,187 @@
+package persistence
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestMergePatients(t *testing.T) {
+	repo := newTestRepository(t)
+	caller := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+
+	birthDate := time.Date(1980, 5, 17, 0, 0, 0, 0, time.UTC)
+	survivor := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "María", FirstSurname: "García", SecondSurname: "López",
+		DNI: "12345678Z", Email: "maria@example.com", Phone: "600123456", BirthDate: &birthDate}
+	duplicate := &domain.Patient{ID: "01HZY0000000000000000000P2", GivenName: "Maria", FirstSurname: "Garcia",
+		DNI: "11111111H", Phone: "+34 600 12 34 56", BirthDate: &birthDate}
+	unrelated := &domain.Patient{ID: "01HZY0000000000000000000P3", GivenName: "Juan", FirstSurname: "Pérez", DNI: "87654321X"}
+	for _, p := range []*domain.Patient{survivor, duplicate, unrelated} {
+		if err := repo.CreatePatient(p, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		repo.AddCareTeamMember(&domain.CareTeamMember{PatientID: p.ID, UserID: caller.UserID, AddedAt: time.Now()})
+	}
+	repo.AddCareTeamMember(&domain.CareTeamMember{PatientID: duplicate.ID, UserID: "nurse", AddedAt: time.Now()})
+
+	diagnosis := &domain.Diagnosis{ID: "01HZY0000000000000000000D1", PatientID: duplicate.ID, Diagnosis: "Otitis media", Prescription: "Amoxicilina", Date: time.Now()}
+	if err := repo.CreateDiagnosis(diagnosis, nil); err != nil {
+		t.Fatalf("CreateDiagnosis() error = %v", err)
+	}
+
+	repo.CreateContact(&domain.Contact{ID: "01HZY0000000000000000000C1", PatientID: duplicate.ID, Relationship: domain.RelationshipSpouse,
+		Name: "Pedro Gil", Phone: "+34600654321", CreatedAt: time.Now()})
+	repo.CreateObservation(&domain.Observation{ID: "01HZY0000000000000000000O1", PatientID: duplicate.ID, Code: domain.ObservationHeartRate,
+		Value: 72, Unit: "/min", EffectiveAt: time.Now(), RecordedBy: caller.UserID, CreatedAt: time.Now()})
+	repo.CreateLabResults([]domain.LabResult{{ID: "01HZY0000000000000000000L1", PatientID: duplicate.ID, BatchID: "01HZY0000000000000000000B1",
+		Panel: "Lipid panel", Analyte: "Cholesterol", Value: 240, Unit: "mg/dL", CollectedAt: time.Now(), RecordedBy: caller.UserID, CreatedAt: time.Now()}})
+	repo.CreateVaccination(&domain.Vaccination{ID: "01HZY0000000000000000000V1", PatientID: duplicate.ID, VaccineCode: "TV", DoseNumber: 1,
+		Lot: "X1234", AdministeredAt: time.Now(), AdministeredBy: caller.UserID, RecordedBy: caller.UserID, CreatedAt: time.Now()})
+	repo.CreateAttachment(&domain.Attachment{ID: "01HZY0000000000000000000A1", DiagnosisID: diagnosis.ID, PatientID: duplicate.ID, FileName: "audiometria.pdf",
+		ContentType: domain.AttachmentTypePDF, Size: 1024, Checksum: strings.Repeat("ab", 32), ContentKey: []byte("content-key"), UploadedBy: caller.UserID, CreatedAt: time.Now()})
+	repo.CreateReferral(&domain.Referral{ID: "01HZY0000000000000000000R1", PatientID: duplicate.ID, FromPractitionerID: caller.UserID,
+		ToSpecialty: "otolaryngology", Reason: "Otitis de repetición", Urgency: domain.ReferralUrgencyRoutine, Status: domain.ReferralStatusPending, CreatedAt: time.Now()})
+	repo.CreateEncounter(&domain.Encounter{ID: "01HZY0000000000000000000E1", PatientID: duplicate.ID, PractitionerID: caller.UserID,
+		Status: domain.EncounterStatusOpen, Note: domain.SOAPNote{Subjective: "Otalgia"}, StartedAt: time.Now(), CreatedAt: time.Now()})
+
+	t.Run("Finds the duplicate by name, phone and birth date", func(t *testing.T) {
+		candidates, err := repo.FindDuplicateCandidates(caller, survivor)
+		if err != nil {
+			t.Fatalf("FindDuplicateCandidates() error = %v", err)
+		}
+		if len(candidates) != 1 || candidates[0].Patient.ID != duplicate.ID {
+			t.Fatalf("FindDuplicateCandidates() = %+v, want only %s", candidates, duplicate.ID)
+		}
+		want := domain.DuplicateNameWeight + domain.DuplicatePhoneWeight + domain.DuplicateBirthDateWeight
+		if math.Abs(candidates[0].Score-want) > 1e-9 {
+			t.Errorf("expected score %v, got %v (%v)", want, candidates[0].Score, candidates[0].Matches)
+		}
+	})
+
+	merge := &domain.PatientMerge{ID: "01HZY0000000000000000000M1", SurvivorID: survivor.ID, DuplicateID: duplicate.ID,
+		MergedBy: caller.UserID, MergedAt: time.Now()}
+	if err := repo.MergePatients(merge); err != nil {
+		t.Fatalf("MergePatients() error = %v", err)
+	}
+	if merge.DiagnosesMoved != 1 {
+		t.Errorf("expected 1 diagnosis moved, got %d", merge.DiagnosesMoved)
+	}
+
+	t.Run("Moves and re-encrypts the diagnoses", func(t *testing.T) {
+		got, err := repo.GetDiagnosisByPatientID(survivor.ID)
+		if err != nil || len(got) != 1 || got[0].Prescription != "Amoxicilina" {
+			t.Errorf("GetDiagnosisByPatientID() = %+v, %v", got, err)
+		}
+		q := "otitis"
+		found, err := searchDiagnoses(repo, caller, domain.DiagnosisFilter{Text: &q})
+		if err != nil || len(found) != 1 || found[0].PatientID != survivor.ID {
+			t.Errorf("SearchDiagnosis() = %+v, %v", found, err)
+		}
+	})
+
+	t.Run("Leaves a redirect and hides the duplicate", func(t *testing.T) {
+		got, err := repo.GetPatientByID(duplicate.ID)
+		if err != nil || got.MergedInto != survivor.ID {
+			t.Errorf("GetPatientByID() = %+v, %v", got, err)
+		}
+		listed, _ := searchPatients(repo, caller, domain.PatientFilter{})
+		for _, p := range listed {
+			if p.Patient.ID == duplicate.ID {
+				t.Error("expected merged patient to be hidden from listings")
+			}
+		}
+	})
+
+	t.Run("Moves the contacts", func(t *testing.T) {
+		got, err := repo.GetContactsByPatientID(survivor.ID)
+		if err != nil || len(got) != 1 || got[0].Name != "Pedro Gil" {
+			t.Errorf("GetContactsByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Moves the observations", func(t *testing.T) {
+		got, err := repo.GetObservationsByPatientID(survivor.ID, domain.ObservationFilter{})
+		if err != nil || len(got) != 1 || got[0].Value != 72 {
+			t.Errorf("GetObservationsByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Moves the lab results", func(t *testing.T) {
+		got, err := repo.GetLabResultsByPatientID(survivor.ID, domain.LabResultFilter{})
+		if err != nil || len(got) != 1 || got[0].Value != 240 {
+			t.Errorf("GetLabResultsByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Moves the attachments and rewraps their keys", func(t *testing.T) {
+		got, err := repo.GetAttachmentsByPatientID(survivor.ID)
+		if err != nil || len(got) != 1 || got[0].FileName != "audiometria.pdf" || string(got[0].ContentKey) != "content-key" {
+			t.Errorf("GetAttachmentsByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Moves the vaccinations", func(t *testing.T) {
+		got, err := repo.GetVaccinationsByPatientID(survivor.ID)
+		if err != nil || len(got) != 1 || got[0].VaccineCode != "TV" {
+			t.Errorf("GetVaccinationsByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Moves the referrals", func(t *testing.T) {
+		got, err := repo.GetReferralsByPatientID(survivor.ID)
+		if err != nil || len(got) != 1 || got[0].Reason != "Otitis de repetición" {
+			t.Errorf("GetReferralsByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Moves the encounters", func(t *testing.T) {
+		got, err := repo.GetEncountersByPatientID(survivor.ID)
+		if err != nil || len(got) != 1 || got[0].Note.Subjective != "Otalgia" {
+			t.Errorf("GetEncountersByPatientID() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Copies the care team", func(t *testing.T) {
+		member, err := repo.IsCareTeamMember(survivor.ID, "nurse")
+		if err != nil || !member {
+			t.Errorf("expected the duplicate's care team to reach the survivor, got %v, %v", member, err)
+		}
+	})
+
+	t.Run("Rejects merging a merged patient", func(t *testing.T) {
+		// A merge the other way round, checked by the service before this one committed
+		reverse := &domain.PatientMerge{ID: "01HZY0000000000000000000M2", SurvivorID: duplicate.ID, DuplicateID: survivor.ID,
+			MergedBy: caller.UserID, MergedAt: time.Now()}
+		if err := repo.MergePatients(reverse); !errors.Is(err, domain.ErrMergeConflict) {
+			t.Fatalf("MergePatients() error = %v, want %v", err, domain.ErrMergeConflict)
+		}
+		got, err := repo.GetPatientByID(survivor.ID)
+		if err != nil || got.IsMerged() {
+			t.Errorf("GetPatientByID() = %+v, %v, want the survivor unmerged", got, err)
+		}
+	})
+
+	t.Run("Detects rows written after the snapshot", func(t *testing.T) {
+		repo.CreateObservation(&domain.Observation{ID: "01HZY0000000000000000000O2", PatientID: unrelated.ID, Code: domain.ObservationHeartRate,
+			Value: 80, Unit: "/min", EffectiveAt: time.Now(), RecordedBy: caller.UserID, CreatedAt: time.Now()})
+		if gained, err := gainedRows(repo.db, &ObservationDB{}, unrelated.ID, []string{"01HZY0000000000000000000O2"}); err != nil || gained {
+			t.Errorf("gainedRows() = %v, %v, want false", gained, err)
+		}
+		if gained, err := gainedRows(repo.db, &ObservationDB{}, unrelated.ID, nil); err != nil || !gained {
+			t.Errorf("gainedRows() with an empty snapshot = %v, %v, want true", gained, err)
+		}
+	})
+}
+
+func TestNameSimilarity(t *testing.T) {
+	full := &domain.Patient{GivenName: "María", FirstSurname: "García", SecondSurname: "López"}
+	if got := nameSimilarity(full, &domain.Patient{GivenName: "maria", FirstSurname: "GARCIA"}); got != 1 {
+		t.Errorf("expected a missing second surname to keep full similarity, got %v", got)
+	}
+	if got := nameSimilarity(full, &domain.Patient{GivenName: "Juan", FirstSurname: "Pérez"}); got >= domain.FuzzyNameThreshold {
+		t.Errorf("expected different names to score low, got %v", got)
+	}
+}
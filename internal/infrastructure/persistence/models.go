@@ -7,15 +7,34 @@ import (
 
 // GORM models with tags (infrastructure concern)
 type PatientDB struct {
-	ID        uint   `gorm:"primaryKey,autoIncrement"`
-	ULID      string `gorm:"column:ulid;unique"`
-	Name      string
-	DNI       string `gorm:"unique"`
-	Email     string
-	Phone     string
-	Address   string
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID                uint   `gorm:"primaryKey,autoIncrement"`
+	ULID              string `gorm:"column:ulid;unique"`
+	Name              string // Encrypted full name of patients created before the structured name, split on startup
+	GivenName         string // Encrypted
+	FirstSurname      string // Encrypted
+	SecondSurname     string // Encrypted
+	DNI               string // Encrypted
+	DNIIndex          string `gorm:"column:dni_index;uniqueIndex"` // Blind index, keeps lookups and uniqueness working
+	BirthDate         string // Encrypted, formatted as birthDateLayout
+	Sex               string `gorm:"index"` // Coded, filtered on by diagnosis search
+	Nationality       string
+	PreferredLanguage string
+	Email             string // Encrypted
+	EmailIndex        string `gorm:"column:email_index;index"` // Blind index, finds duplicate candidates
+	Phone             string // Encrypted
+	PhoneIndex        string `gorm:"column:phone_index;index"` // Blind index, finds duplicate candidates
+	Address           string // Encrypted free-text address of patients created before the structured address, moved to AddressStreet on startup
+	AddressStreet     string // Encrypted
+	AddressNumber     string // Encrypted
+	AddressFloor      string // Encrypted
+	PostalCode        string `gorm:"index"` // Coarse location, filtered on by diagnosis search
+	City              string
+	Province          string `gorm:"index"` // INE code, filtered on by diagnosis search
+	Country           string
+	ErasedAt          *time.Time
+	MergedIntoULID    *string   `gorm:"column:merged_into_ulid;index"`
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
 }
 
 func (PatientDB) TableName() string {
@@ -23,16 +42,18 @@ func (PatientDB) TableName() string {
 }
 
 type DiagnosisDB struct {
-	ID           uint   `gorm:"primaryKey,autoIncrement"`
-	ULID         string `gorm:"column:ulid;unique"`
-	PatientULID  string `gorm:"column:patient_ulid"`
-	PatientID    uint
-	Patient      PatientDB `gorm:"foreignKey:PatientID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Diagnosis    string
-	Prescription string
-	Date         time.Time
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	ID            uint   `gorm:"primaryKey,autoIncrement"`
+	ULID          string `gorm:"column:ulid;unique"`
+	PatientULID   string `gorm:"column:patient_ulid"`
+	PatientID     uint
+	Patient       PatientDB `gorm:"foreignKey:PatientID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"` // Clinical records outlive patient erasure
+	Diagnosis     string    // Encrypted with the patient's data key
+	Prescription  string    // Encrypted with the patient's data key
+	Date          time.Time
+	EncounterULID *string        `gorm:"column:encounter_ulid;index"`
+	Attachments   []AttachmentDB `gorm:"foreignKey:DiagnosisULID;references:ULID;constraint:false"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
 }
 
 func (DiagnosisDB) TableName() string {
@@ -40,10 +61,12 @@ func (DiagnosisDB) TableName() string {
 }
 
 type UserDB struct {
-	ID       uint   `gorm:"primaryKey,autoIncrement"`
-	ULID     string `gorm:"column:ulid;unique"`
-	Username string `gorm:"unique"`
-	Password string
+	ID        uint   `gorm:"primaryKey,autoIncrement"`
+	ULID      string `gorm:"column:ulid;unique"`
+	Username  string `gorm:"unique"`
+	Password  string
+	Role      string `gorm:"default:practitioner"`
+	Specialty string `gorm:"index"`
 }
 
 func (UserDB) TableName() string {
@@ -64,68 +87,222 @@ func (UserTokenDB) TableName() string {
 }
 
 // Mappers from domain to DB
-func toPatientDB(p *domain.Patient) *PatientDB {
-	return &PatientDB{
-		ULID:    p.ID,
-		Name:    p.Name,
-		DNI:     p.DNI,
-		Email:   p.Email,
-		Phone:   p.Phone,
-		Address: p.Address,
+
+// birthDateLayout is the format of the encrypted birth date
+const birthDateLayout = "2006-01-02"
+
+// toPatientDB encrypts the identifying fields and computes the DNI blind index
+func toPatientDB(p *domain.Patient, c *fieldCipher) (*PatientDB, error) {
+	dbPatient := &PatientDB{
+		ULID:              p.ID,
+		DNIIndex:          c.blindIndex(dniIndexNamespace, normalizeDNI(p.DNI)),
+		EmailIndex:        c.optionalBlindIndex(emailIndexNamespace, normalizeEmail(p.Email)),
+		PhoneIndex:        c.optionalBlindIndex(phoneIndexNamespace, normalizePhone(p.Phone)),
+		Sex:               p.Sex,
+		Nationality:       p.Nationality,
+		PreferredLanguage: p.PreferredLanguage,
+		PostalCode:        p.Address.PostalCode,
+		City:              p.Address.City,
+		Province:          p.Address.Province,
+		Country:           p.Address.Country,
+		ErasedAt:          p.ErasedAt,
+	}
+	birthDate := ""
+	if p.BirthDate != nil {
+		birthDate = p.BirthDate.Format(birthDateLayout)
 	}
+	if p.MergedInto != "" {
+		dbPatient.MergedIntoULID = &p.MergedInto
+	}
+
+	fields := []struct {
+		dst *string
+		src string
+	}{
+		{&dbPatient.GivenName, p.GivenName},
+		{&dbPatient.FirstSurname, p.FirstSurname},
+		{&dbPatient.SecondSurname, p.SecondSurname},
+		{&dbPatient.DNI, p.DNI},
+		{&dbPatient.BirthDate, birthDate},
+		{&dbPatient.Email, p.Email},
+		{&dbPatient.Phone, p.Phone},
+		{&dbPatient.AddressStreet, p.Address.Street},
+		{&dbPatient.AddressNumber, p.Address.Number},
+		{&dbPatient.AddressFloor, p.Address.Floor},
+	}
+	for _, f := range fields {
+		encrypted, err := c.encrypt(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = encrypted
+	}
+	return dbPatient, nil
 }
 
-func toPatientDomain(p *PatientDB) *domain.Patient {
-	return &domain.Patient{
-		ID:      p.ULID,
-		Name:    p.Name,
-		DNI:     p.DNI,
-		Email:   p.Email,
-		Phone:   p.Phone,
-		Address: p.Address,
+// toPatientDomain decrypts the identifying fields. A legacy full name is split
+// into the structured name and a legacy free-text address becomes its street.
+func toPatientDomain(p *PatientDB, c *fieldCipher) (*domain.Patient, error) {
+	patient := &domain.Patient{
+		ID:                p.ULID,
+		Sex:               p.Sex,
+		Nationality:       p.Nationality,
+		PreferredLanguage: p.PreferredLanguage,
+		Address: domain.Address{
+			PostalCode: p.PostalCode,
+			City:       p.City,
+			Province:   p.Province,
+			Country:    p.Country,
+		},
+		ErasedAt: p.ErasedAt,
+	}
+	if p.MergedIntoULID != nil {
+		patient.MergedInto = *p.MergedIntoULID
 	}
+
+	fields := []struct {
+		dst *string
+		src string
+	}{
+		{&patient.GivenName, p.GivenName},
+		{&patient.FirstSurname, p.FirstSurname},
+		{&patient.SecondSurname, p.SecondSurname},
+		{&patient.DNI, p.DNI},
+		{&patient.Email, p.Email},
+		{&patient.Phone, p.Phone},
+		{&patient.Address.Street, p.AddressStreet},
+		{&patient.Address.Number, p.AddressNumber},
+		{&patient.Address.Floor, p.AddressFloor},
+	}
+	for _, f := range fields {
+		decrypted, err := c.decrypt(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = decrypted
+	}
+
+	birthDate, err := c.decrypt(p.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+	if birthDate != "" {
+		parsed, err := time.Parse(birthDateLayout, birthDate)
+		if err != nil {
+			return nil, err
+		}
+		patient.BirthDate = &parsed
+	}
+
+	if p.Name != "" && patient.GivenName == "" && patient.FirstSurname == "" && patient.SecondSurname == "" {
+		name, err := c.decrypt(p.Name)
+		if err != nil {
+			return nil, err
+		}
+		patient.SetName(name)
+	}
+	if p.Address != "" && patient.Address.IsEmpty() {
+		address, err := c.decrypt(p.Address)
+		if err != nil {
+			return nil, err
+		}
+		patient.Address.Street = address
+	}
+	return patient, nil
 }
 
-func toDiagnosisDB(d *domain.Diagnosis) *DiagnosisDB {
-	return &DiagnosisDB{
+// toDiagnosisDB encrypts the clinical text with the patient's data key
+func toDiagnosisDB(d *domain.Diagnosis, c *fieldCipher) (*DiagnosisDB, error) {
+	diagnosis, err := c.encryptForPatient(d.PatientID, d.Diagnosis)
+	if err != nil {
+		return nil, err
+	}
+	prescription, err := c.encryptForPatient(d.PatientID, d.Prescription)
+	if err != nil {
+		return nil, err
+	}
+
+	dbDiagnosis := &DiagnosisDB{
 		ULID:         d.ID,
 		PatientULID:  d.PatientID,
-		Diagnosis:    d.Diagnosis,
-		Prescription: d.Prescription,
+		Diagnosis:    diagnosis,
+		Prescription: prescription,
 		Date:         d.Date,
 	}
+	if d.EncounterID != "" {
+		dbDiagnosis.EncounterULID = &d.EncounterID
+	}
+	return dbDiagnosis, nil
 }
 
-func toDiagnosisDomain(d *DiagnosisDB) *domain.Diagnosis {
+// toDiagnosisDomain decrypts the clinical text and the preloaded patient
+func toDiagnosisDomain(d *DiagnosisDB, c *fieldCipher) (*domain.Diagnosis, error) {
+	text, err := c.decryptForPatient(d.PatientULID, d.Diagnosis)
+	if err != nil {
+		return nil, err
+	}
+	prescription, err := c.decryptForPatient(d.PatientULID, d.Prescription)
+	if err != nil {
+		return nil, err
+	}
+
 	diagnosis := &domain.Diagnosis{
 		ID:           d.ULID,
 		PatientID:    d.PatientULID,
-		Diagnosis:    d.Diagnosis,
-		Prescription: d.Prescription,
+		Diagnosis:    text,
+		Prescription: prescription,
 		Date:         d.Date,
 	}
+	if d.EncounterULID != nil {
+		diagnosis.EncounterID = *d.EncounterULID
+	}
 
 	// Only map patient if it was preloaded
 	if d.Patient.ULID != "" {
-		diagnosis.Patient = *toPatientDomain(&d.Patient)
+		patient, err := toPatientDomain(&d.Patient, c)
+		if err != nil {
+			return nil, err
+		}
+		diagnosis.Patient = *patient
 	}
+	if len(d.Attachments) > 0 {
+		if diagnosis.Attachments, err = toAttachmentDomainList(d.Attachments, c); err != nil {
+			return nil, err
+		}
+	}
+
+	return diagnosis, nil
+}
 
-	return diagnosis
+func toDiagnosisDomainList(diagnostics []DiagnosisDB, c *fieldCipher) ([]domain.Diagnosis, error) {
+	result := make([]domain.Diagnosis, len(diagnostics))
+	for i, d := range diagnostics {
+		diagnosis, err := toDiagnosisDomain(&d, c)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *diagnosis
+	}
+	return result, nil
 }
 
 func toUserDB(u *domain.User) *UserDB {
 	return &UserDB{
-		ULID:     u.ID,
-		Username: u.Username,
-		Password: u.Password,
+		ULID:      u.ID,
+		Username:  u.Username,
+		Password:  u.Password,
+		Role:      u.Role,
+		Specialty: u.Specialty,
 	}
 }
 
 func toUserDomain(u *UserDB) *domain.User {
 	return &domain.User{
-		ID:       u.ULID,
-		Username: u.Username,
-		Password: u.Password,
+		ID:        u.ULID,
+		Username:  u.Username,
+		Password:  u.Password,
+		Role:      u.Role,
+		Specialty: u.Specialty,
 	}
 }
 
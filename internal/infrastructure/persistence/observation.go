@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"strconv"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ObservationDB struct {
+	ID             uint   `gorm:"primaryKey,autoIncrement"`
+	ULID           string `gorm:"column:ulid;unique"`
+	PatientULID    string `gorm:"column:patient_ulid;index:idx_observations_series,priority:1"`
+	Code           string `gorm:"index:idx_observations_series,priority:2"`
+	Value          string // Encrypted with the patient key
+	Unit           string
+	ReferenceLow   *float64
+	ReferenceHigh  *float64
+	Interpretation string    // Kept in clear to find abnormal values without decrypting
+	EffectiveAt    time.Time `gorm:"index:idx_observations_series,priority:3"`
+	DiagnosisULID  *string   `gorm:"column:diagnosis_ulid"`
+	RecordedByULID string    `gorm:"column:recorded_by_ulid"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (ObservationDB) TableName() string {
+	return "observations"
+}
+
+// Observation Repository Implementation
+func (r *GormRepository) CreateObservation(observation *domain.Observation) error {
+	dbObservation, err := toObservationDB(observation, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(dbObservation).Error
+}
+
+func (r *GormRepository) GetObservationsByPatientID(patientID string, filter domain.ObservationFilter) ([]domain.Observation, error) {
+	query := r.db.Where("patient_ulid = ?", patientID)
+	if filter.Code != nil {
+		query = query.Where("code = ?", *filter.Code)
+	}
+	if filter.From != nil {
+		query = query.Where("effective_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("effective_at < ?", *filter.To)
+	}
+
+	var observations []ObservationDB
+	if err := query.Order("effective_at, id").Find(&observations).Error; err != nil {
+		return nil, err
+	}
+	return toObservationDomainList(observations, r.cipher)
+}
+
+// reencryptObservations returns the observations of a patient encrypted with
+// the key of another, to move them there
+func (r *GormRepository) reencryptObservations(fromPatientID, toPatientID string) ([]*ObservationDB, error) {
+	var stored []ObservationDB
+	if err := r.db.Where("patient_ulid = ?", fromPatientID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	observations, err := toObservationDomainList(stored, r.cipher)
+	if err != nil {
+		return nil, err
+	}
+	moved := make([]*ObservationDB, len(observations))
+	for i := range observations {
+		observations[i].PatientID = toPatientID
+		if moved[i], err = toObservationDB(&observations[i], r.cipher); err != nil {
+			return nil, err
+		}
+	}
+	return moved, nil
+}
+
+// moveObservations reassigns the observations of a merged duplicate to the
+// survivor. They must have been re-encrypted with the survivor's key already.
+func moveObservations(tx *gorm.DB, moved []*ObservationDB) error {
+	for _, o := range moved {
+		err := tx.Model(&ObservationDB{}).Where("ulid = ?", o.ULID).Updates(map[string]interface{}{
+			"patient_ulid": o.PatientULID,
+			"value":        o.Value,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Mappers
+func toObservationDB(o *domain.Observation, c *fieldCipher) (*ObservationDB, error) {
+	value, err := c.encryptForPatient(o.PatientID, strconv.FormatFloat(o.Value, 'g', -1, 64))
+	if err != nil {
+		return nil, err
+	}
+
+	dbObservation := &ObservationDB{
+		ULID:           o.ID,
+		PatientULID:    o.PatientID,
+		Code:           o.Code,
+		Value:          value,
+		Unit:           o.Unit,
+		ReferenceLow:   o.Reference.Low,
+		ReferenceHigh:  o.Reference.High,
+		Interpretation: o.Interpretation(),
+		EffectiveAt:    o.EffectiveAt,
+		RecordedByULID: o.RecordedBy,
+		CreatedAt:      o.CreatedAt,
+	}
+	if o.DiagnosisID != "" {
+		dbObservation.DiagnosisULID = &o.DiagnosisID
+	}
+	return dbObservation, nil
+}
+
+func toObservationDomain(o *ObservationDB, c *fieldCipher) (*domain.Observation, error) {
+	text, err := c.decryptForPatient(o.PatientULID, o.Value)
+	if err != nil {
+		return nil, err
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	observation := &domain.Observation{
+		ID:          o.ULID,
+		PatientID:   o.PatientULID,
+		Code:        o.Code,
+		Value:       value,
+		Unit:        o.Unit,
+		Reference:   domain.ReferenceRange{Low: o.ReferenceLow, High: o.ReferenceHigh},
+		EffectiveAt: o.EffectiveAt,
+		RecordedBy:  o.RecordedByULID,
+		CreatedAt:   o.CreatedAt,
+	}
+	if o.DiagnosisULID != nil {
+		observation.DiagnosisID = *o.DiagnosisULID
+	}
+	return observation, nil
+}
+
+func toObservationDomainList(observations []ObservationDB, c *fieldCipher) ([]domain.Observation, error) {
+	result := make([]domain.Observation, len(observations))
+	for i, o := range observations {
+		observation, err := toObservationDomain(&o, c)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *observation
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestObservations(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z"}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+
+	day := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	record := func(id, code string, value float64, unit string, at time.Time) *domain.Observation {
+		o := &domain.Observation{ID: id, PatientID: patient.ID, Code: code, Value: value, Unit: unit, EffectiveAt: at,
+			RecordedBy: "doctor", CreatedAt: time.Now()}
+		o.ApplyDefaultReference()
+		if err := repo.CreateObservation(o); err != nil {
+			t.Fatalf("CreateObservation() error = %v", err)
+		}
+		return o
+	}
+	later := record("01HZY0000000000000000000O1", domain.ObservationTemperature, 38.4, "Cel", day.Add(24*time.Hour))
+	record("01HZY0000000000000000000O2", domain.ObservationTemperature, 36.6, "Cel", day)
+	record("01HZY0000000000000000000O3", domain.ObservationHeartRate, 80, "/min", day)
+
+	t.Run("Encrypts the value and keeps the interpretation", func(t *testing.T) {
+		var stored ObservationDB
+		repo.db.Where("ulid = ?", later.ID).First(&stored)
+		if !strings.HasPrefix(stored.Value, patientEncryptedPrefix) {
+			t.Errorf("expected value encrypted with the patient key, got %q", stored.Value)
+		}
+		if stored.Interpretation != domain.InterpretationHigh {
+			t.Errorf("expected interpretation %q, got %q", domain.InterpretationHigh, stored.Interpretation)
+		}
+	})
+
+	t.Run("Filters by type in chronological order", func(t *testing.T) {
+		code := domain.ObservationTemperature
+		got, err := repo.GetObservationsByPatientID(patient.ID, domain.ObservationFilter{Code: &code})
+		if err != nil {
+			t.Fatalf("GetObservationsByPatientID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].Value != 36.6 || got[1].Value != 38.4 {
+			t.Fatalf("GetObservationsByPatientID() = %+v", got)
+		}
+		if got[1].Reference.High == nil || *got[1].Reference.High != 37.5 {
+			t.Errorf("expected the reference range to round-trip, got %+v", got[1].Reference)
+		}
+	})
+
+	t.Run("Filters by time range", func(t *testing.T) {
+		from, to := day, day.Add(24*time.Hour)
+		got, err := repo.GetObservationsByPatientID(patient.ID, domain.ObservationFilter{From: &from, To: &to})
+		if err != nil || len(got) != 2 {
+			t.Errorf("GetObservationsByPatientID() = %+v, %v", got, err)
+		}
+	})
+}
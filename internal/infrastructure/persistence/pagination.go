@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// Listings are paged with opaque cursors. Results the database orders carry
+// on after the row ID of the last one returned (keyset pagination), or after
+// its text relevance and row ID, so only the rows of the page are read and
+// decrypted. Results ordered once decrypted, by name similarity or surname,
+// must all be read and carry on at an offset.
+
+const (
+	cursorAfterID    = "id"
+	cursorAfterScore = "score"
+	cursorOffset     = "offset"
+)
+
+func encodeCursor(kind string, value uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(kind + ":" + strconv.FormatUint(uint64(value), 10)))
+}
+
+// decodeCursor returns the value of a cursor of the given kind, 0 for the
+// first page
+func decodeCursor(cursor, kind string) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	value, err := cursorValue(cursor, kind)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, domain.ErrInvalidCursor
+	}
+	return uint(parsed), nil
+}
+
+// encodeScoreCursor returns the cursor carrying on after a result of the
+// given relevance and row ID
+func encodeScoreCursor(score float64, id uint) string {
+	value := strconv.FormatFloat(score, 'g', -1, 64) + ":" + strconv.FormatUint(uint64(id), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorAfterScore + ":" + value))
+}
+
+// decodeScoreCursor returns the relevance and row ID of a cursor made by
+// encodeScoreCursor
+func decodeScoreCursor(cursor string) (float64, uint, error) {
+	value, err := cursorValue(cursor, cursorAfterScore)
+	if err != nil {
+		return 0, 0, err
+	}
+	score, id, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, domain.ErrInvalidCursor
+	}
+	parsedScore, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		return 0, 0, domain.ErrInvalidCursor
+	}
+	parsedID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, 0, domain.ErrInvalidCursor
+	}
+	return parsedScore, uint(parsedID), nil
+}
+
+// cursorValue returns the value of a cursor after checking its kind
+func cursorValue(cursor, kind string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", domain.ErrInvalidCursor
+	}
+	cursorKind, value, ok := strings.Cut(string(raw), ":")
+	if !ok || cursorKind != kind {
+		return "", domain.ErrInvalidCursor
+	}
+	return value, nil
+}
+
+// pageAfterID orders a query by idColumn and limits it to the page after the
+// cursor, reading one row more to know whether another page follows
+func pageAfterID(query *gorm.DB, idColumn string, page domain.Page) (*gorm.DB, error) {
+	after, err := decodeCursor(page.Cursor, cursorAfterID)
+	if err != nil {
+		return nil, err
+	}
+	return query.Where(idColumn+" > ?", after).Order(idColumn).Limit(page.Size() + 1), nil
+}
+
+// trimPageAfterID drops the extra row read by pageAfterID and returns the
+// cursor of the following page, if any
+func trimPageAfterID[T any](rows []T, page domain.Page, id func(T) uint) ([]T, string) {
+	if len(rows) <= page.Size() {
+		return rows, ""
+	}
+	rows = rows[:page.Size()]
+	return rows, encodeCursor(cursorAfterID, id(rows[len(rows)-1]))
+}
+
+// pageAtOffset returns the page of results already read and ordered, and the
+// cursor of the following page, if any
+func pageAtOffset[T any](results []T, page domain.Page) ([]T, string, error) {
+	offset, err := decodeCursor(page.Cursor, cursorOffset)
+	if err != nil {
+		return nil, "", err
+	}
+	start := min(int(offset), len(results))
+	end := min(start+page.Size(), len(results))
+	next := ""
+	if end < len(results) {
+		next = encodeCursor(cursorOffset, uint(end))
+	}
+	return results[start:end], next, nil
+}
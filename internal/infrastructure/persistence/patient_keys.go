@@ -0,0 +1,191 @@
+package persistence
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Per-patient data keys protect the clinical text of a patient. Keys are
+// wrapped by a field data key, so a key rotation only re-wraps them instead
+// of re-encrypting every diagnosis, and deleting a patient's key renders
+// whatever copy of their records is left (e.g. in backups) unreadable.
+
+const patientEncryptedPrefix = "enc:p:"
+
+type PatientDataKeyDB struct {
+	ID          uint   `gorm:"primaryKey,autoIncrement"`
+	PatientULID string `gorm:"column:patient_ulid;uniqueIndex"`
+	KeyVersion  int    // Version of the field data key wrapping this key
+	WrappedKey  string
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (PatientDataKeyDB) TableName() string {
+	return "patient_data_keys"
+}
+
+// patientKeyCache keeps unwrapped patient keys in memory. It is shared by
+// the ciphers produced on rotation since patient keys never change.
+type patientKeyCache struct {
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+func newPatientKeyCache() *patientKeyCache {
+	return &patientKeyCache{keys: make(map[string][]byte)}
+}
+
+func (p *patientKeyCache) get(patientULID string) ([]byte, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	key, ok := p.keys[patientULID]
+	return key, ok
+}
+
+func (p *patientKeyCache) set(patientULID string, key []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[patientULID] = key
+}
+
+func (p *patientKeyCache) forget(patientULID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.keys, patientULID)
+}
+
+// patientKey returns the data key of a patient, creating it on first use.
+// It must not be called inside a write transaction.
+func (c *fieldCipher) patientKey(patientULID string) ([]byte, error) {
+	if key, ok := c.patientKeys.get(patientULID); ok {
+		return key, nil
+	}
+
+	var row PatientDataKeyDB
+	err := c.db.Where("patient_ulid = ?", patientULID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		row, err = c.createPatientKey(patientULID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := c.unwrapPatientKey(row)
+	if err != nil {
+		return nil, err
+	}
+	c.patientKeys.set(patientULID, key)
+	return key, nil
+}
+
+// createPatientKey stores a new key for the patient. When another request
+// created one concurrently, that one is returned instead.
+func (c *fieldCipher) createPatientKey(patientULID string) (PatientDataKeyDB, error) {
+	key, err := randomKey()
+	if err != nil {
+		return PatientDataKeyDB{}, err
+	}
+	row, err := c.wrapPatientKey(patientULID, key)
+	if err != nil {
+		return PatientDataKeyDB{}, err
+	}
+
+	err = c.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error
+	if err != nil {
+		return PatientDataKeyDB{}, err
+	}
+	err = c.db.Where("patient_ulid = ?", patientULID).First(&row).Error
+	return row, err
+}
+
+func (c *fieldCipher) wrapPatientKey(patientULID string, key []byte) (PatientDataKeyDB, error) {
+	version, fieldKey := c.activeKey()
+	wrapped, err := wrapKey(fieldKey, key)
+	if err != nil {
+		return PatientDataKeyDB{}, err
+	}
+	return PatientDataKeyDB{
+		PatientULID: patientULID,
+		KeyVersion:  version,
+		WrappedKey:  wrapped,
+	}, nil
+}
+
+func (c *fieldCipher) unwrapPatientKey(row PatientDataKeyDB) ([]byte, error) {
+	fieldKey, err := c.dataKey(row.KeyVersion)
+	if err != nil {
+		return nil, err
+	}
+	return unwrapKey(fieldKey, row.WrappedKey)
+}
+
+// encryptForPatient seals a value with the patient's data key. Empty values
+// stay empty.
+func (c *fieldCipher) encryptForPatient(patientULID, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	key, err := c.patientKey(patientULID)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(key, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return patientEncryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptForPatient opens a value sealed by encryptForPatient. Values without
+// the prefix are legacy plaintext and returned untouched.
+func (c *fieldCipher) decryptForPatient(patientULID, value string) (string, error) {
+	if !strings.HasPrefix(value, patientEncryptedPrefix) {
+		return value, nil
+	}
+	key, err := c.patientKey(patientULID)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, patientEncryptedPrefix))
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(key, sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// rewrapPatientKeys wraps every patient key with the active field data key of
+// next, within the rotation transaction
+func (c *fieldCipher) rewrapPatientKeys(tx *gorm.DB, next *fieldCipher) error {
+	var rows []PatientDataKeyDB
+	if err := tx.Find(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		key, err := c.unwrapPatientKey(row)
+		if err != nil {
+			return err
+		}
+		rewrapped, err := next.wrapPatientKey(row.PatientULID, key)
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&PatientDataKeyDB{}).Where("id = ?", row.ID).Updates(map[string]interface{}{
+			"key_version": rewrapped.KeyVersion,
+			"wrapped_key": rewrapped.WrappedKey,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,486 @@
+package persistence
+
+import (
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"topdoctors/internal/domain"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Patient names are encrypted, so LIKE queries no longer work on them. Each
+// word of the name is stored as blind index tokens instead, tagged with the
+// part of the name it belongs to (given name or surname):
+//
+//   - the accent-folded word and its prefixes, for exact searches, which
+//     match the patients holding the tokens of every searched word;
+//   - the trigrams of the word, for fuzzy searches, which select the patients
+//     sharing trigrams with the searched words as candidates.
+//
+// Candidates are then decrypted and scored in Go, where fuzzy matches below
+// domain.FuzzyNameThreshold are discarded.
+
+const (
+	dniIndexNamespace     = "patient_dni"
+	emailIndexNamespace   = "patient_email"
+	phoneIndexNamespace   = "patient_phone"
+	nameTokenNamespace    = "patient_name"
+	trigramTokenNamespace = "patient_name_trigram"
+	minPrefixLength       = 3
+
+	tokenKindWord    = "word"
+	tokenKindPrefix  = "prefix"
+	tokenKindTrigram = "trigram"
+
+	nameFieldGiven   = "given"
+	nameFieldSurname = "surname"
+)
+
+type PatientSearchTokenDB struct {
+	ID          uint   `gorm:"primaryKey,autoIncrement"`
+	PatientULID string `gorm:"column:patient_ulid;index"`
+	Field       string
+	Kind        string
+	TokenHash   string `gorm:"index"`
+}
+
+func (PatientSearchTokenDB) TableName() string {
+	return "patient_search_tokens"
+}
+
+// normalizeDNI returns the canonical form used to compute the DNI blind index
+func normalizeDNI(dni string) string {
+	return strings.ToUpper(strings.TrimSpace(dni))
+}
+
+// normalizeEmail returns the canonical form used to compute the email blind index
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizePhone returns the canonical form used to compute the phone blind
+// index: its digits, without the Spanish country code. E.164 numbers and the
+// free-form ones stored before phones were normalized get the same index.
+func normalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	digits = strings.TrimPrefix(digits, "00")
+	if len(digits) == 11 && strings.HasPrefix(digits, "34") {
+		digits = digits[2:]
+	}
+	return digits
+}
+
+// nameWords splits a name into lower-cased words
+func nameWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchableNameWords returns the accent-folded words of a name, without
+// particles such as "de" or "la"
+func searchableNameWords(name string) []string {
+	var words []string
+	for _, word := range nameWords(name) {
+		folded := foldAccents(word)
+		if folded != "" && !domain.IsNameParticle(folded) {
+			words = append(words, folded)
+		}
+	}
+	return words
+}
+
+// nameFields returns the searchable words of each part of a patient's name
+func nameFields(p *domain.Patient) map[string][]string {
+	return map[string][]string{
+		nameFieldGiven:   searchableNameWords(p.GivenName),
+		nameFieldSurname: searchableNameWords(p.FirstSurname + " " + p.SecondSurname),
+	}
+}
+
+// trigrams returns the distinct trigrams of a word padded with "$" at both
+// ends, so short words and word boundaries are represented
+func trigrams(word string) []string {
+	runes := []rune("$" + word + "$")
+	seen := make(map[string]bool)
+	var result []string
+	for i := 0; i+3 <= len(runes); i++ {
+		trigram := string(runes[i : i+3])
+		if !seen[trigram] {
+			seen[trigram] = true
+			result = append(result, trigram)
+		}
+	}
+	return result
+}
+
+// patientSearchTokens builds the token rows indexing a patient's name
+func (r *GormRepository) patientSearchTokens(patientULID string, p *domain.Patient) []PatientSearchTokenDB {
+	type token struct{ field, kind, hash string }
+	seen := make(map[token]bool)
+	var rows []PatientSearchTokenDB
+	add := func(field, kind, namespace, value string) {
+		t := token{field, kind, r.cipher.blindIndex(namespace, value)}
+		if seen[t] {
+			return
+		}
+		seen[t] = true
+		rows = append(rows, PatientSearchTokenDB{PatientULID: patientULID, Field: field, Kind: kind, TokenHash: t.hash})
+	}
+
+	for field, words := range nameFields(p) {
+		for _, word := range words {
+			runes := []rune(word)
+			for i := minPrefixLength; i < len(runes); i++ {
+				add(field, tokenKindPrefix, nameTokenNamespace, string(runes[:i]))
+			}
+			add(field, tokenKindWord, nameTokenNamespace, word)
+			for _, trigram := range trigrams(word) {
+				add(field, tokenKindTrigram, trigramTokenNamespace, trigram)
+			}
+		}
+	}
+	return rows
+}
+
+// replacePatientSearchTokens reindexes a patient's name within tx. A nil
+// patient only removes the existing tokens.
+func (r *GormRepository) replacePatientSearchTokens(tx *gorm.DB, patientULID string, p *domain.Patient) error {
+	if err := tx.Where("patient_ulid = ?", patientULID).Delete(&PatientSearchTokenDB{}).Error; err != nil {
+		return err
+	}
+	if p == nil {
+		return nil
+	}
+	rows := r.patientSearchTokens(patientULID, p)
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// nameCriterion is a set of searched words and the parts of the name they
+// are matched against
+type nameCriterion struct {
+	words  []string
+	fields []string
+}
+
+func nameCriteria(f domain.NameFilter) []nameCriterion {
+	var criteria []nameCriterion
+	add := func(value *string, fields ...string) {
+		if value == nil {
+			return
+		}
+		if words := searchableNameWords(*value); len(words) > 0 {
+			criteria = append(criteria, nameCriterion{words: words, fields: fields})
+		}
+	}
+	add(f.Name, nameFieldGiven, nameFieldSurname)
+	add(f.GivenName, nameFieldGiven)
+	add(f.Surname, nameFieldSurname)
+	return criteria
+}
+
+// patientsMatching returns a subquery selecting the ULIDs of the candidate
+// patients for a criterion
+func (r *GormRepository) patientsMatching(c nameCriterion, fuzzy bool) *gorm.DB {
+	query := r.db.Model(&PatientSearchTokenDB{}).Select("patient_ulid").Where("field IN ?", c.fields)
+
+	if fuzzy {
+		var hashes []string
+		for _, word := range c.words {
+			for _, trigram := range trigrams(word) {
+				hashes = append(hashes, r.cipher.blindIndex(trigramTokenNamespace, trigram))
+			}
+		}
+		return query.Where("kind = ? AND token_hash IN ?", tokenKindTrigram, hashes).Distinct()
+	}
+
+	seen := make(map[string]bool)
+	var hashes []string
+	for _, word := range c.words {
+		hash := r.cipher.blindIndex(nameTokenNamespace, word)
+		if !seen[hash] {
+			seen[hash] = true
+			hashes = append(hashes, hash)
+		}
+	}
+	return query.
+		Where("kind IN ? AND token_hash IN ?", []string{tokenKindWord, tokenKindPrefix}, hashes).
+		Group("patient_ulid").
+		Having("COUNT(DISTINCT token_hash) = ?", len(hashes))
+}
+
+// filterByName restricts a query joined with Patient to the candidates of
+// every name criterion. Erased patients must not be findable by name.
+func (r *GormRepository) filterByName(query *gorm.DB, f domain.NameFilter) *gorm.DB {
+	if f.IsEmpty() {
+		return query
+	}
+	criteria := nameCriteria(f)
+	if len(criteria) == 0 {
+		// Only punctuation or particles were searched, nothing can match
+		return query.Where("1 = 0")
+	}
+
+	query = query.Where("Patient.erased_at IS NULL")
+	for _, c := range criteria {
+		query = query.Where("Patient.ulid IN (?)", r.patientsMatching(c, f.Fuzzy))
+	}
+	return query
+}
+
+// nameScore returns how well a decrypted patient name matches the filter,
+// between 0 and 1, and whether it matches at all
+func nameScore(f domain.NameFilter, p *domain.Patient) (float64, bool) {
+	criteria := nameCriteria(f)
+	if len(criteria) == 0 {
+		return 0, false
+	}
+
+	fields := nameFields(p)
+	var total float64
+	var count int
+	for _, c := range criteria {
+		var candidates []string
+		for _, field := range c.fields {
+			candidates = append(candidates, fields[field]...)
+		}
+		for _, word := range c.words {
+			best := 0.0
+			for _, candidate := range candidates {
+				best = max(best, wordSimilarity(word, candidate, f.Fuzzy))
+			}
+			if best == 0 || (f.Fuzzy && best < domain.FuzzyNameThreshold) {
+				return 0, false
+			}
+			total += best
+			count++
+		}
+	}
+	return total / float64(count), true
+}
+
+// wordSimilarity scores a searched word against a name word: 1 for equal
+// words, between 0.5 and 1 for prefixes depending on how much of the word
+// they cover and, in fuzzy mode, the normalized Levenshtein similarity
+func wordSimilarity(searched, word string, fuzzy bool) float64 {
+	if searched == word {
+		return 1
+	}
+	s, w := []rune(searched), []rune(word)
+	score := 0.0
+	if strings.HasPrefix(word, searched) {
+		score = 0.5 + 0.5*float64(len(s))/float64(len(w))
+	}
+	if fuzzy {
+		similarity := 1 - float64(levenshtein(s, w))/float64(max(len(s), len(w)))
+		score = max(score, similarity)
+	}
+	return score
+}
+
+// levenshtein returns the edit distance between two words
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// SearchPatients lists a page of the patients the caller may access, filtered
+// and ordered by name similarity when a name filter is given
+func (r *GormRepository) SearchPatients(caller domain.Caller, filter domain.PatientFilter) (*domain.PatientPage, error) {
+	query := r.db.Table("patients AS Patient").Where("Patient.merged_into_ulid IS NULL")
+	if caller.IsIntegration() {
+		query = r.consentedTo(query, domain.ConsentPurposeThirdPartySharing, domain.ConsentScopeDemographics, time.Now())
+	} else {
+		query = r.accessibleBy(query, caller.UserID, time.Now())
+	}
+	query = r.filterByName(query, filter.Name)
+	if filter.DNI != nil {
+		query = query.Where("Patient.dni_index = ?", r.cipher.blindIndex(dniIndexNamespace, normalizeDNI(*filter.DNI)))
+	}
+
+	// Names are encrypted: ordering by them or by name similarity needs every
+	// candidate decrypted, creation order only the page
+	orderedOnceDecrypted := !filter.Name.IsEmpty() || filter.Sort == domain.PatientSortSurname
+	if orderedOnceDecrypted {
+		query = query.Order("Patient.id")
+	} else {
+		var err error
+		if query, err = pageAfterID(query, "Patient.id", filter.Page); err != nil {
+			return nil, err
+		}
+	}
+
+	var patients []PatientDB
+	if err := query.Find(&patients).Error; err != nil {
+		return nil, err
+	}
+
+	next := ""
+	if !orderedOnceDecrypted {
+		patients, next = trimPageAfterID(patients, filter.Page, func(p PatientDB) uint { return p.ID })
+	}
+
+	result := make([]domain.PatientSearchResult, 0, len(patients))
+	for _, p := range patients {
+		patient, err := toPatientDomain(&p, r.cipher)
+		if err != nil {
+			return nil, err
+		}
+		score, ok := 0.0, true
+		if !filter.Name.IsEmpty() {
+			score, ok = nameScore(filter.Name, patient)
+		}
+		if ok {
+			result = append(result, domain.PatientSearchResult{Patient: *patient, Score: score})
+		}
+	}
+
+	if !orderedOnceDecrypted {
+		return &domain.PatientPage{Patients: result, NextCursor: next}, nil
+	}
+
+	if filter.Sort == domain.PatientSortSurname {
+		sortBySurname(result)
+	} else {
+		sort.SliceStable(result, func(i, j int) bool {
+			return result[i].Score > result[j].Score
+		})
+	}
+	result, next, err := pageAtOffset(result, filter.Page)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PatientPage{Patients: result, NextCursor: next}, nil
+}
+
+// sortBySurname orders patients alphabetically by first surname, second
+// surname and given name, ignoring case and accents. Names are encrypted, so
+// the database cannot sort them.
+func sortBySurname(patients []domain.PatientSearchResult) {
+	keys := make(map[string][]string, len(patients))
+	for _, p := range patients {
+		keys[p.Patient.ID] = []string{
+			foldAccents(p.Patient.FirstSurname),
+			foldAccents(p.Patient.SecondSurname),
+			foldAccents(p.Patient.GivenName),
+		}
+	}
+	sort.SliceStable(patients, func(i, j int) bool {
+		return slices.Compare(keys[patients[i].Patient.ID], keys[patients[j].Patient.ID]) < 0
+	})
+}
+
+// applyNameMatches scores the diagnoses by the name of their patient, drops
+// fuzzy candidates below the threshold and orders the rest by similarity
+func applyNameMatches(diagnostics []domain.Diagnosis, f domain.NameFilter) []domain.Diagnosis {
+	result := diagnostics[:0]
+	for _, d := range diagnostics {
+		score, ok := nameScore(f, &d.Patient)
+		if !ok {
+			continue
+		}
+		match := domain.SearchMatch{}
+		if d.Match != nil {
+			match = *d.Match
+		}
+		match.NameScore = score
+		d.Match = &match
+		result = append(result, d)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Match.NameScore > result[j].Match.NameScore
+	})
+	return result
+}
+
+// ensurePatientNameIndex rebuilds the name tokens written before they were
+// tagged with their field and kind
+func (r *GormRepository) ensurePatientNameIndex(outdated bool) error {
+	if !outdated {
+		return nil
+	}
+
+	var stored []PatientDB
+	if err := r.db.Where("erased_at IS NULL AND merged_into_ulid IS NULL").Find(&stored).Error; err != nil {
+		return err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&PatientSearchTokenDB{}).Error; err != nil {
+			return err
+		}
+		for _, p := range stored {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			if err := r.replacePatientSearchTokens(tx, patient.ID, patient); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Patient name index rebuilt", "patients", len(stored))
+	return nil
+}
+
+// splitLegacyNames moves the full names stored before the structured name
+// into given name and surnames, guessed with domain.SplitName. The legacy
+// column is cleared, so rows are migrated once.
+func (r *GormRepository) splitLegacyNames() error {
+	var legacy []PatientDB
+	if err := r.db.Where("name IS NOT NULL AND name <> ''").Find(&legacy).Error; err != nil || len(legacy) == 0 {
+		return err
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range legacy {
+			patient, err := toPatientDomain(&p, r.cipher)
+			if err != nil {
+				return err
+			}
+			if err := r.updatePatient(tx, patient, r.cipher); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Split legacy patient names", "count", len(legacy))
+	return nil
+}
@@ -0,0 +1,259 @@
+package persistence
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestSearchPatients(t *testing.T) {
+	repo := newTestRepository(t)
+	caller := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+
+	for _, p := range []domain.Patient{
+		{ID: "01HZY0000000000000000000P1", GivenName: "María", FirstSurname: "García", SecondSurname: "López", DNI: "12345678Z"},
+		{ID: "01HZY0000000000000000000P2", GivenName: "García", FirstSurname: "Pérez", DNI: "11111111H"},
+		{ID: "01HZY0000000000000000000P3", GivenName: "Juan", FirstSurname: "de la Fuente", SecondSurname: "Garcés", DNI: "87654321X"},
+	} {
+		if err := repo.CreatePatient(&p, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		repo.AddCareTeamMember(&domain.CareTeamMember{PatientID: p.ID, UserID: caller.UserID, AddedAt: time.Now()})
+	}
+	// Not in the caller's care team
+	outsider := &domain.Patient{ID: "01HZY0000000000000000000P4", GivenName: "Ana", FirstSurname: "García", DNI: "00000000T"}
+	repo.CreatePatient(outsider, nil)
+
+	ids := func(results []domain.PatientSearchResult) []string {
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.Patient.ID)
+		}
+		return ids
+	}
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		filter domain.NameFilter
+		sort   string
+		want   []string
+	}{
+		{"No filter lists every accessible patient", domain.NameFilter{}, "",
+			[]string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P2", "01HZY0000000000000000000P3"}},
+		{"Ignores case and accents", domain.NameFilter{Name: str("GARCIA")}, "",
+			[]string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}},
+		{"Exact search misses typos", domain.NameFilter{Name: str("garsia")}, "", nil},
+		{"Fuzzy search finds typos above the threshold", domain.NameFilter{Name: str("garsia"), Fuzzy: true}, "",
+			[]string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}},
+		{"Fuzzy search ranks closest first", domain.NameFilter{Name: str("garcez"), Fuzzy: true}, "",
+			[]string{"01HZY0000000000000000000P3", "01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}},
+		{"Given name only matches given names", domain.NameFilter{GivenName: str("garcía")}, "",
+			[]string{"01HZY0000000000000000000P2"}},
+		{"Surname matches either surname", domain.NameFilter{Surname: str("lopez")}, "",
+			[]string{"01HZY0000000000000000000P1"}},
+		{"Surname with particles", domain.NameFilter{Surname: str("de la fuente")}, "",
+			[]string{"01HZY0000000000000000000P3"}},
+		{"Sorts by surnames ignoring accents", domain.NameFilter{}, domain.PatientSortSurname,
+			[]string{"01HZY0000000000000000000P3", "01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}},
+		{"Sorts name matches by surname", domain.NameFilter{Name: str("garcia")}, domain.PatientSortSurname,
+			[]string{"01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := searchPatients(repo, caller, domain.PatientFilter{Name: tt.filter, Sort: tt.sort})
+			if err != nil {
+				t.Fatalf("SearchPatients() error = %v", err)
+			}
+			gotIDs := ids(got)
+			if len(gotIDs) != len(tt.want) {
+				t.Fatalf("SearchPatients() = %v, want %v", gotIDs, tt.want)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.want[i] {
+					t.Errorf("SearchPatients() = %v, want %v", gotIDs, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	t.Run("Finds a patient by DNI ignoring case", func(t *testing.T) {
+		got, err := searchPatients(repo, caller, domain.PatientFilter{DNI: str("12345678z")})
+		if err != nil || !slices.Equal(ids(got), []string{"01HZY0000000000000000000P1"}) {
+			t.Errorf("SearchPatients() = %v, %v", ids(got), err)
+		}
+		if got, _ := searchPatients(repo, caller, domain.PatientFilter{DNI: &outsider.DNI}); len(got) != 0 {
+			t.Errorf("SearchPatients() found a patient outside the care team: %v", ids(got))
+		}
+	})
+
+	// Pages through a listing until its last page
+	pages := func(filter domain.PatientFilter) [][]string {
+		var pages [][]string
+		for {
+			page, err := repo.SearchPatients(caller, filter)
+			if err != nil {
+				t.Fatalf("SearchPatients() error = %v", err)
+			}
+			pages = append(pages, ids(page.Patients))
+			if page.NextCursor == "" {
+				return pages
+			}
+			filter.Page.Cursor = page.NextCursor
+		}
+	}
+
+	t.Run("Pages in creation order", func(t *testing.T) {
+		got := pages(domain.PatientFilter{Page: domain.Page{Limit: 2}})
+		want := [][]string{{"01HZY0000000000000000000P1", "01HZY0000000000000000000P2"}, {"01HZY0000000000000000000P3"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("pages = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Pages in surname order", func(t *testing.T) {
+		got := pages(domain.PatientFilter{Sort: domain.PatientSortSurname, Page: domain.Page{Limit: 2}})
+		want := [][]string{{"01HZY0000000000000000000P3", "01HZY0000000000000000000P1"}, {"01HZY0000000000000000000P2"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("pages = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Rejects cursors of another order", func(t *testing.T) {
+		first, _ := repo.SearchPatients(caller, domain.PatientFilter{Page: domain.Page{Limit: 1}})
+		_, err := repo.SearchPatients(caller, domain.PatientFilter{Sort: domain.PatientSortSurname, Page: domain.Page{Cursor: first.NextCursor}})
+		if !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("SearchPatients() error = %v, want %v", err, domain.ErrInvalidCursor)
+		}
+	})
+}
+
+func TestSplitLegacyNames(t *testing.T) {
+	repo := newTestRepository(t)
+
+	name, _ := repo.cipher.encrypt("Juan de la Fuente Garcés")
+	dni, _ := repo.cipher.encrypt("12345678Z")
+	legacy := PatientDB{ULID: "01HZY0000000000000000000P1", Name: name, DNI: dni,
+		DNIIndex: repo.cipher.blindIndex(dniIndexNamespace, "12345678Z")}
+	if err := repo.db.Create(&legacy).Error; err != nil {
+		t.Fatalf("creating legacy patient: %v", err)
+	}
+
+	if err := repo.splitLegacyNames(); err != nil {
+		t.Fatalf("splitLegacyNames() error = %v", err)
+	}
+
+	var stored PatientDB
+	repo.db.Where("ulid = ?", legacy.ULID).First(&stored)
+	if stored.Name != "" {
+		t.Errorf("expected legacy name column to be cleared, got %q", stored.Name)
+	}
+	got, err := repo.GetPatientByID(legacy.ULID)
+	if err != nil {
+		t.Fatalf("GetPatientByID() error = %v", err)
+	}
+	if got.GivenName != "Juan" || got.FirstSurname != "de la Fuente" || got.SecondSurname != "Garcés" {
+		t.Errorf("GetPatientByID() name = %q / %q / %q", got.GivenName, got.FirstSurname, got.SecondSurname)
+	}
+
+	surname := "fuente"
+	var ids []string
+	repo.filterByName(repo.db.Table("patients AS Patient"), domain.NameFilter{Surname: &surname}).Pluck("Patient.ulid", &ids)
+	if len(ids) != 1 {
+		t.Errorf("expected migrated patient to be indexed by surname, got %v", ids)
+	}
+}
+
+func TestMoveLegacyAddresses(t *testing.T) {
+	repo := newTestRepository(t)
+
+	address, _ := repo.cipher.encrypt("Calle Mayor 1, Madrid")
+	given, _ := repo.cipher.encrypt("Juan")
+	dni, _ := repo.cipher.encrypt("12345678Z")
+	legacy := PatientDB{ULID: "01HZY0000000000000000000P1", GivenName: given, DNI: dni, Address: address,
+		DNIIndex: repo.cipher.blindIndex(dniIndexNamespace, "12345678Z")}
+	if err := repo.db.Create(&legacy).Error; err != nil {
+		t.Fatalf("creating legacy patient: %v", err)
+	}
+
+	if err := repo.moveLegacyAddresses(); err != nil {
+		t.Fatalf("moveLegacyAddresses() error = %v", err)
+	}
+
+	var stored PatientDB
+	repo.db.Where("ulid = ?", legacy.ULID).First(&stored)
+	if stored.Address != "" || stored.AddressStreet == "" || stored.AddressStreet == "Calle Mayor 1, Madrid" {
+		t.Errorf("expected the address moved to the encrypted street, got %q / %q", stored.Address, stored.AddressStreet)
+	}
+	got, err := repo.GetPatientByID(legacy.ULID)
+	if err != nil || got.Address.Street != "Calle Mayor 1, Madrid" || got.Address.String() != "Calle Mayor 1, Madrid" {
+		t.Errorf("GetPatientByID() address = %+v, %v", got.Address, err)
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		searched, word string
+		fuzzy          bool
+		want           float64
+	}{
+		{"garcia", "garcia", false, 1},
+		{"gar", "garcia", false, 0.75},
+		{"garsia", "garcia", false, 0},
+		{"garsia", "garcia", true, 1 - 1.0/6},
+		{"perez", "garcia", true, 1 - 5.0/6},
+	}
+	for _, tt := range tests {
+		if got := wordSimilarity(tt.searched, tt.word, tt.fuzzy); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("wordSimilarity(%q, %q, %v) = %v, want %v", tt.searched, tt.word, tt.fuzzy, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePhones(t *testing.T) {
+	repo := newTestRepository(t)
+	caller := domain.Caller{UserID: "doctor", Role: domain.RolePractitioner}
+
+	// Stored before phones were normalized
+	legacy := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "María", FirstSurname: "García", DNI: "12345678Z", Phone: "600 12 34 56"}
+	invalid := &domain.Patient{ID: "01HZY0000000000000000000P2", GivenName: "Juan", FirstSurname: "Pérez", DNI: "87654321X", Phone: "ext. 12"}
+	current := &domain.Patient{ID: "01HZY0000000000000000000P3", GivenName: "Mario", FirstSurname: "García", DNI: "11111111H", Phone: "+34600123456"}
+	for _, p := range []*domain.Patient{legacy, invalid, current} {
+		if err := repo.CreatePatient(p, nil); err != nil {
+			t.Fatalf("CreatePatient() error = %v", err)
+		}
+		repo.AddCareTeamMember(&domain.CareTeamMember{PatientID: p.ID, UserID: caller.UserID, AddedAt: time.Now()})
+	}
+
+	normalized, invalidCount, err := repo.NormalizePhones()
+	if err != nil || normalized != 1 || invalidCount != 1 {
+		t.Fatalf("NormalizePhones() = %d, %d, %v, want 1, 1", normalized, invalidCount, err)
+	}
+	if got, _ := repo.GetPatientByID(legacy.ID); got.Phone != "+34600123456" {
+		t.Errorf("expected the legacy phone in E.164, got %q", got.Phone)
+	}
+	if got, _ := repo.GetPatientByID(invalid.ID); got.Phone != "ext. 12" {
+		t.Errorf("expected the invalid phone untouched, got %q", got.Phone)
+	}
+
+	// The blind index is unchanged, so the duplicate is still found by phone
+	candidates, err := repo.FindDuplicateCandidates(caller, current)
+	if err != nil || len(candidates) != 1 || !slices.Contains(candidates[0].Matches, domain.DuplicateMatchPhone) {
+		t.Errorf("FindDuplicateCandidates() = %+v, %v", candidates, err)
+	}
+}
+
+// searchPatients returns the first page of a patient listing
+func searchPatients(repo *GormRepository, caller domain.Caller, filter domain.PatientFilter) ([]domain.PatientSearchResult, error) {
+	page, err := repo.SearchPatients(caller, filter)
+	if err != nil {
+		return nil, err
+	}
+	return page.Patients, nil
+}
@@ -0,0 +1,234 @@
+package persistence
+
+import (
+	"errors"
+	"time"
+	"topdoctors/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ReferralDB struct {
+	ID                   uint   `gorm:"primaryKey,autoIncrement"`
+	ULID                 string `gorm:"column:ulid;unique"`
+	PatientULID          string `gorm:"column:patient_ulid;index"`
+	DiagnosisULID        string `gorm:"column:diagnosis_ulid"`
+	FromPractitionerULID string `gorm:"column:from_practitioner_ulid;index"`
+	ToPractitionerULID   string `gorm:"column:to_practitioner_ulid;index"`
+	ToSpecialty          string `gorm:"index"`
+	Reason               string // Encrypted, as are the rejection reason and completion note
+	Urgency              string
+	Status               string `gorm:"index"`
+	RespondedByULID      string `gorm:"column:responded_by_ulid;index"`
+	RejectionReason      string
+	CompletionNote       string
+	CreatedAt            time.Time `gorm:"autoCreateTime"`
+	RespondedAt          *time.Time
+	CompletedAt          *time.Time
+}
+
+func (ReferralDB) TableName() string {
+	return "referrals"
+}
+
+// Referral Repository Implementation
+func (r *GormRepository) CreateReferral(referral *domain.Referral) error {
+	dbReferral, err := toReferralDB(referral, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(dbReferral).Error
+}
+
+func (r *GormRepository) UpdateReferral(referral *domain.Referral, fromStatus string) error {
+	dbReferral, err := toReferralDB(referral, r.cipher)
+	if err != nil {
+		return err
+	}
+	return updateReferral(r.db, dbReferral, fromStatus)
+}
+
+func (r *GormRepository) AcceptReferral(referral *domain.Referral, member *domain.CareTeamMember) error {
+	dbReferral, err := toReferralDB(referral, r.cipher)
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateReferral(tx, dbReferral, domain.ReferralStatusPending); err != nil {
+			return err
+		}
+		if member == nil {
+			return nil
+		}
+		return tx.Create(toCareTeamMemberDB(member)).Error
+	})
+}
+
+// updateReferral stores the answer or completion of a referral with a
+// conditional update, which fails when the referral no longer has the status
+// fromStatus
+func updateReferral(db *gorm.DB, referral *ReferralDB, fromStatus string) error {
+	update := db.Model(&ReferralDB{}).Where("ulid = ? AND status = ?", referral.ULID, fromStatus).Updates(map[string]interface{}{
+		"status":            referral.Status,
+		"responded_by_ulid": referral.RespondedByULID,
+		"rejection_reason":  referral.RejectionReason,
+		"completion_note":   referral.CompletionNote,
+		"responded_at":      referral.RespondedAt,
+		"completed_at":      referral.CompletedAt,
+	})
+	if update.Error != nil {
+		return update.Error
+	}
+	if update.RowsAffected == 0 {
+		if fromStatus == domain.ReferralStatusPending {
+			return domain.ErrReferralNotPending
+		}
+		return domain.ErrReferralNotAccepted
+	}
+	return nil
+}
+
+func (r *GormRepository) GetReferralByID(id string) (*domain.Referral, error) {
+	var referral ReferralDB
+	err := r.db.Where("ulid = ?", id).First(&referral).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrReferralNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toReferralDomain(&referral, r.cipher)
+}
+
+func (r *GormRepository) GetReferralsByPatientID(patientID string) ([]domain.Referral, error) {
+	var referrals []ReferralDB
+	if err := r.db.Where("patient_ulid = ?", patientID).Order("created_at, id").Find(&referrals).Error; err != nil {
+		return nil, err
+	}
+	return toReferralDomainList(referrals, r.cipher)
+}
+
+func (r *GormRepository) GetReferralInbox(userID, specialty string, filter domain.ReferralInboxFilter) ([]domain.Referral, error) {
+	// Pending referrals any recipient can answer, and the ones this one did
+	addressed := r.db.Where("to_practitioner_ulid = ?", userID)
+	if specialty != "" {
+		addressed = addressed.Or("to_specialty = ?", specialty)
+	}
+	query := r.db.Where(r.db.Where("status = ?", domain.ReferralStatusPending).Where(addressed)).
+		Or("responded_by_ulid = ?", userID)
+
+	var referrals []ReferralDB
+	db := r.db.Where(query)
+	if filter.Status != nil {
+		db = db.Where("status = ?", *filter.Status)
+	}
+	if err := db.Order("created_at, id").Find(&referrals).Error; err != nil {
+		return nil, err
+	}
+	return toReferralDomainList(referrals, r.cipher)
+}
+
+// reencryptReferrals re-encrypts the free text of every referral with the
+// cipher next, as part of a key rotation
+func (r *GormRepository) reencryptReferrals(tx *gorm.DB, next *fieldCipher) (int, error) {
+	var referrals []ReferralDB
+	if err := tx.Find(&referrals).Error; err != nil {
+		return 0, err
+	}
+	for _, dbReferral := range referrals {
+		referral, err := toReferralDomain(&dbReferral, r.cipher)
+		if err != nil {
+			return 0, err
+		}
+		encrypted, err := toReferralDB(referral, next)
+		if err != nil {
+			return 0, err
+		}
+		err = tx.Model(&ReferralDB{}).Where("ulid = ?", referral.ID).Updates(map[string]interface{}{
+			"reason":           encrypted.Reason,
+			"rejection_reason": encrypted.RejectionReason,
+			"completion_note":  encrypted.CompletionNote,
+		}).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(referrals), nil
+}
+
+// Mappers
+func toReferralDB(rf *domain.Referral, cipher *fieldCipher) (*ReferralDB, error) {
+	reason, err := cipher.encrypt(rf.Reason)
+	if err != nil {
+		return nil, err
+	}
+	rejection, err := cipher.encrypt(rf.RejectionReason)
+	if err != nil {
+		return nil, err
+	}
+	note, err := cipher.encrypt(rf.CompletionNote)
+	if err != nil {
+		return nil, err
+	}
+	return &ReferralDB{
+		ULID:                 rf.ID,
+		PatientULID:          rf.PatientID,
+		DiagnosisULID:        rf.DiagnosisID,
+		FromPractitionerULID: rf.FromPractitionerID,
+		ToPractitionerULID:   rf.ToPractitionerID,
+		ToSpecialty:          rf.ToSpecialty,
+		Reason:               reason,
+		Urgency:              rf.Urgency,
+		Status:               rf.Status,
+		RespondedByULID:      rf.RespondedBy,
+		RejectionReason:      rejection,
+		CompletionNote:       note,
+		CreatedAt:            rf.CreatedAt,
+		RespondedAt:          rf.RespondedAt,
+		CompletedAt:          rf.CompletedAt,
+	}, nil
+}
+
+func toReferralDomain(rf *ReferralDB, cipher *fieldCipher) (*domain.Referral, error) {
+	reason, err := cipher.decrypt(rf.Reason)
+	if err != nil {
+		return nil, err
+	}
+	rejection, err := cipher.decrypt(rf.RejectionReason)
+	if err != nil {
+		return nil, err
+	}
+	note, err := cipher.decrypt(rf.CompletionNote)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Referral{
+		ID:                 rf.ULID,
+		PatientID:          rf.PatientULID,
+		DiagnosisID:        rf.DiagnosisULID,
+		FromPractitionerID: rf.FromPractitionerULID,
+		ToPractitionerID:   rf.ToPractitionerULID,
+		ToSpecialty:        rf.ToSpecialty,
+		Reason:             reason,
+		Urgency:            rf.Urgency,
+		Status:             rf.Status,
+		RespondedBy:        rf.RespondedByULID,
+		RejectionReason:    rejection,
+		CompletionNote:     note,
+		CreatedAt:          rf.CreatedAt,
+		RespondedAt:        rf.RespondedAt,
+		CompletedAt:        rf.CompletedAt,
+	}, nil
+}
+
+func toReferralDomainList(referrals []ReferralDB, cipher *fieldCipher) ([]domain.Referral, error) {
+	result := make([]domain.Referral, len(referrals))
+	for i, rf := range referrals {
+		referral, err := toReferralDomain(&rf, cipher)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *referral
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestReferrals(t *testing.T) {
+	repo := newTestRepository(t)
+
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	newReferral := func(id, to, specialty string) *domain.Referral {
+		return &domain.Referral{ID: id, PatientID: "01HZY0000000000000000000P1", FromPractitionerID: "gp", ToPractitionerID: to, ToSpecialty: specialty,
+			Reason: "Soplo sistólico", Urgency: domain.ReferralUrgencyRoutine, Status: domain.ReferralStatusPending, CreatedAt: day}
+	}
+	toCardiologist := newReferral("01HZY0000000000000000000R1", "cardiologist", "")
+	toCardiology := newReferral("01HZY0000000000000000000R2", "", "cardiology")
+	toDermatology := newReferral("01HZY0000000000000000000R3", "", "dermatology")
+	toOther := newReferral("01HZY0000000000000000000R4", "other", "")
+	for _, r := range []*domain.Referral{toCardiologist, toCardiology, toDermatology, toOther} {
+		if err := repo.CreateReferral(r); err != nil {
+			t.Fatalf("CreateReferral() error = %v", err)
+		}
+	}
+
+	inboxIDs := func(t *testing.T, userID, specialty string, filter domain.ReferralInboxFilter) []string {
+		t.Helper()
+		referrals, err := repo.GetReferralInbox(userID, specialty, filter)
+		if err != nil {
+			t.Fatalf("GetReferralInbox() error = %v", err)
+		}
+		var ids []string
+		for _, r := range referrals {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	t.Run("Encrypts the reason", func(t *testing.T) {
+		var stored ReferralDB
+		repo.db.Where("ulid = ?", toCardiologist.ID).First(&stored)
+		if !strings.HasPrefix(stored.Reason, encryptedPrefix) {
+			t.Errorf("expected reason encrypted, got %q", stored.Reason)
+		}
+	})
+
+	t.Run("Inbox holds the referrals to the practitioner and their specialty", func(t *testing.T) {
+		got := inboxIDs(t, "cardiologist", "cardiology", domain.ReferralInboxFilter{})
+		if len(got) != 2 || got[0] != toCardiologist.ID || got[1] != toCardiology.ID {
+			t.Errorf("inbox = %v", got)
+		}
+		if got := inboxIDs(t, "cardiologist", "", domain.ReferralInboxFilter{}); len(got) != 1 {
+			t.Errorf("inbox without specialty = %v", got)
+		}
+	})
+
+	t.Run("Answered referrals stay in the inbox of who answered them only", func(t *testing.T) {
+		if err := toCardiology.Accept("cardiologist", day.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		member := &domain.CareTeamMember{PatientID: toCardiology.PatientID, UserID: "cardiologist", AddedBy: "gp", AddedAt: day}
+		if err := repo.AcceptReferral(toCardiology, member); err != nil {
+			t.Fatalf("AcceptReferral() error = %v", err)
+		}
+		if joined, _ := repo.IsCareTeamMember(toCardiology.PatientID, "cardiologist"); !joined {
+			t.Error("expected the practitioner who accepted to join the care team")
+		}
+
+		if got := inboxIDs(t, "another-cardiologist", "cardiology", domain.ReferralInboxFilter{}); len(got) != 0 {
+			t.Errorf("inbox of another cardiologist = %v", got)
+		}
+		accepted := domain.ReferralStatusAccepted
+		got := inboxIDs(t, "cardiologist", "cardiology", domain.ReferralInboxFilter{Status: &accepted})
+		if len(got) != 1 || got[0] != toCardiology.ID {
+			t.Errorf("accepted inbox = %v", got)
+		}
+	})
+
+	t.Run("Only the first of two acceptances is stored", func(t *testing.T) {
+		late := newReferral(toCardiology.ID, "", "cardiology")
+		if err := late.Accept("another-cardiologist", day.Add(2*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		member := &domain.CareTeamMember{PatientID: late.PatientID, UserID: "another-cardiologist", AddedBy: "gp", AddedAt: day}
+		if err := repo.AcceptReferral(late, member); err != domain.ErrReferralNotPending {
+			t.Errorf("AcceptReferral() = %v, want %v", err, domain.ErrReferralNotPending)
+		}
+		if joined, _ := repo.IsCareTeamMember(late.PatientID, "another-cardiologist"); joined {
+			t.Error("expected the late practitioner not to join the care team")
+		}
+		got, _ := repo.GetReferralByID(toCardiology.ID)
+		if got.RespondedBy != "cardiologist" {
+			t.Errorf("GetReferralByID() responded by %q", got.RespondedBy)
+		}
+	})
+
+	t.Run("Keeps the free text readable after key rotation", func(t *testing.T) {
+		if err := toCardiology.Complete("cardiologist", "Ecocardiograma normal", day.Add(24*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.UpdateReferral(toCardiology, domain.ReferralStatusAccepted); err != nil {
+			t.Fatalf("UpdateReferral() error = %v", err)
+		}
+		if _, err := repo.RotateKeys(nil); err != nil {
+			t.Fatalf("RotateKeys() error = %v", err)
+		}
+		got, err := repo.GetReferralByID(toCardiology.ID)
+		if err != nil || got.Reason != "Soplo sistólico" || got.CompletionNote != "Ecocardiograma normal" || got.Status != domain.ReferralStatusCompleted {
+			t.Errorf("GetReferralByID() = %+v, %v", got, err)
+		}
+		if _, err := repo.GetReferralByID("unknown"); err != domain.ErrReferralNotFound {
+			t.Errorf("GetReferralByID() unknown = %v, want %v", err, domain.ErrReferralNotFound)
+		}
+	})
+}
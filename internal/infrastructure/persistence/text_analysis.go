@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Spanish text analysis shared by indexing and querying, so both sides agree
+// on the terms: words are lower-cased, stripped of accents (including ñ, as
+// PostgreSQL's unaccent does), stopwords are dropped and plurals reduced with
+// a light stemmer.
+
+const (
+	markOpen       = "<mark>"
+	markClose      = "</mark>"
+	snippetContext = 6 // Words shown around the first match
+)
+
+var spanishStopwords = map[string]bool{
+	"a": true, "al": true, "con": true, "como": true, "de": true, "del": true,
+	"e": true, "el": true, "en": true, "es": true, "la": true, "las": true,
+	"lo": true, "los": true, "o": true, "para": true, "por": true, "que": true,
+	"se": true, "sin": true, "su": true, "sus": true, "u": true, "un": true,
+	"una": true, "y": true, "cada": true, "sobre": true, "tras": true,
+}
+
+// foldAccents lower-cases a word and removes its diacritics
+func foldAccents(word string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(word))
+	if err != nil {
+		return strings.ToLower(word)
+	}
+	return folded
+}
+
+// stemSpanish reduces plural forms to their singular, e.g. "neumonias" to
+// "neumonia", "infecciones" to "infeccion" and "luces" to "luz"
+func stemSpanish(word string) string {
+	n := len(word)
+	switch {
+	case n > 4 && strings.HasSuffix(word, "ces"):
+		return word[:n-3] + "z"
+	case n > 4 && strings.HasSuffix(word, "es") && !isVowel(word[n-3]):
+		return word[:n-2]
+	case n > 3 && strings.HasSuffix(word, "s") && isVowel(word[n-2]):
+		return word[:n-1]
+	}
+	return word
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// analyzeTerm returns the indexed form of a single word, or "" for stopwords
+func analyzeTerm(word string) string {
+	folded := foldAccents(word)
+	if folded == "" || spanishStopwords[folded] {
+		return ""
+	}
+	return stemSpanish(folded)
+}
+
+// analyzeSpanish returns the terms of a text in order, repeated terms
+// included so ranking can weigh their frequency
+func analyzeSpanish(text string) []string {
+	var terms []string
+	for _, word := range nameWords(norm.NFC.String(text)) {
+		if term := analyzeTerm(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// highlightSnippet returns an excerpt of text around the first word matching
+// one of the terms, with every matching word wrapped in <mark> tags. The text
+// is HTML escaped so the snippet can be rendered as is. It returns "" when
+// nothing matches.
+func highlightSnippet(text string, terms map[string]bool) string {
+	words := strings.Fields(text)
+	first := -1
+	marked := make([]string, len(words))
+	for i, word := range words {
+		marked[i] = html.EscapeString(word)
+		core := strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if core == "" || !terms[analyzeTerm(core)] {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		prefix, suffix, _ := strings.Cut(word, core)
+		marked[i] = html.EscapeString(prefix) + markOpen + html.EscapeString(core) + markClose + html.EscapeString(suffix)
+	}
+	if first < 0 {
+		return ""
+	}
+
+	start := max(first-snippetContext, 0)
+	end := min(first+snippetContext+1, len(words))
+	snippet := strings.Join(marked[start:end], " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(words) {
+		snippet += "…"
+	}
+	return snippet
+}
@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SQLite keeps times as text, in the zone of the time.Time written, and
+// compares them as text. Two instants only compare in order when written in
+// the same zone, so every time is written and compared in UTC, whatever the
+// zone of the server or of the value.
+
+// sqliteUTCDriver is the name of the SQLite driver binding times in UTC
+const sqliteUTCDriver = "sqlite3_utc"
+
+func init() {
+	sql.Register(sqliteUTCDriver, &utcDriver{})
+}
+
+type utcDriver struct {
+	sqlite3.SQLiteDriver
+}
+
+func (d *utcDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &utcConn{conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+// utcConn is a SQLite connection converting the times it binds to UTC
+type utcConn struct {
+	*sqlite3.SQLiteConn
+}
+
+// CheckNamedValue converts an argument as database/sql would by default,
+// then moves times to UTC
+func (c *utcConn) CheckNamedValue(nv *driver.NamedValue) error {
+	value, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC()
+	}
+	nv.Value = value
+	return nil
+}
+
+// utcTimestamps converts to UTC the times of the models written in another
+// zone, before times were bound in UTC
+func (r *GormRepository) utcTimestamps(models ...any) error {
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: r.db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DataType != schema.Time || field.DBName == "" {
+				continue
+			}
+			column := stmt.Quote(field.DBName)
+			result := r.db.Exec("UPDATE " + stmt.Quote(stmt.Schema.Table) + " SET " + column + " = strftime('%Y-%m-%d %H:%M:%f+00:00', " + column + ") " +
+				"WHERE " + column + " IS NOT NULL AND " + column + " NOT LIKE '%+00:00'")
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				slog.Info("Converted times to UTC", "table", stmt.Schema.Table, "column", field.DBName, "count", result.RowsAffected)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"time"
+	"topdoctors/internal/domain"
+)
+
+type VaccinationDB struct {
+	ID                 uint   `gorm:"primaryKey,autoIncrement"`
+	ULID               string `gorm:"column:ulid;unique"`
+	PatientULID        string `gorm:"column:patient_ulid;index"`
+	VaccineCode        string
+	DoseNumber         int
+	Lot                string
+	AdministeredAt     time.Time
+	AdministeredByULID string    `gorm:"column:administered_by_ulid"`
+	RecordedByULID     string    `gorm:"column:recorded_by_ulid"`
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+}
+
+func (VaccinationDB) TableName() string {
+	return "vaccinations"
+}
+
+// Vaccination Repository Implementation
+func (r *GormRepository) CreateVaccination(vaccination *domain.Vaccination) error {
+	return r.db.Create(toVaccinationDB(vaccination)).Error
+}
+
+func (r *GormRepository) GetVaccinationsByPatientID(patientID string) ([]domain.Vaccination, error) {
+	var vaccinations []VaccinationDB
+	if err := r.db.Where("patient_ulid = ?", patientID).Order("administered_at, id").Find(&vaccinations).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Vaccination, len(vaccinations))
+	for i, v := range vaccinations {
+		result[i] = *toVaccinationDomain(&v)
+	}
+	return result, nil
+}
+
+// Mappers
+func toVaccinationDB(v *domain.Vaccination) *VaccinationDB {
+	return &VaccinationDB{
+		ULID:               v.ID,
+		PatientULID:        v.PatientID,
+		VaccineCode:        v.VaccineCode,
+		DoseNumber:         v.DoseNumber,
+		Lot:                v.Lot,
+		AdministeredAt:     v.AdministeredAt,
+		AdministeredByULID: v.AdministeredBy,
+		RecordedByULID:     v.RecordedBy,
+		CreatedAt:          v.CreatedAt,
+	}
+}
+
+func toVaccinationDomain(v *VaccinationDB) *domain.Vaccination {
+	return &domain.Vaccination{
+		ID:             v.ULID,
+		PatientID:      v.PatientULID,
+		VaccineCode:    v.VaccineCode,
+		DoseNumber:     v.DoseNumber,
+		Lot:            v.Lot,
+		AdministeredAt: v.AdministeredAt,
+		AdministeredBy: v.AdministeredByULID,
+		RecordedBy:     v.RecordedByULID,
+		CreatedAt:      v.CreatedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+)
+
+func TestVaccinations(t *testing.T) {
+	repo := newTestRepository(t)
+
+	patient := &domain.Patient{ID: "01HZY0000000000000000000P1", GivenName: "Lucía", FirstSurname: "Ruiz", DNI: "12345678Z"}
+	if err := repo.CreatePatient(patient, nil); err != nil {
+		t.Fatalf("CreatePatient() error = %v", err)
+	}
+
+	given := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	for i, v := range []domain.Vaccination{
+		{ID: "01HZY0000000000000000000V2", VaccineCode: "HEXA", DoseNumber: 2, AdministeredAt: given},
+		{ID: "01HZY0000000000000000000V1", VaccineCode: "HEXA", DoseNumber: 1, AdministeredAt: given.AddDate(0, -2, 0)},
+	} {
+		v.PatientID, v.Lot, v.AdministeredBy, v.RecordedBy, v.CreatedAt = patient.ID, "A12B3", "nurse", "doctor", time.Now()
+		if err := repo.CreateVaccination(&v); err != nil {
+			t.Fatalf("CreateVaccination() #%d error = %v", i, err)
+		}
+	}
+
+	got, err := repo.GetVaccinationsByPatientID(patient.ID)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("GetVaccinationsByPatientID() = %+v, %v", got, err)
+	}
+	if got[0].DoseNumber != 1 || got[1].DoseNumber != 2 {
+		t.Errorf("expected the doses in the order they were given, got %+v", got)
+	}
+	if got[0].AdministeredBy != "nurse" || got[0].RecordedBy != "doctor" || got[0].Lot != "A12B3" {
+		t.Errorf("GetVaccinationsByPatientID() = %+v", got[0])
+	}
+}
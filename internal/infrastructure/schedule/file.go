@@ -0,0 +1,66 @@
+package schedule
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"topdoctors/internal/domain"
+
+	"github.com/spf13/viper"
+)
+
+// scheduleFile is the YAML layout of a vaccination schedule, see
+// configs/vaccination_schedule.es.yml
+type scheduleFile struct {
+	Name  string `mapstructure:"name"`
+	Doses []struct {
+		Vaccine      string `mapstructure:"vaccine"`
+		Name         string `mapstructure:"name"`
+		Dose         int    `mapstructure:"dose"`
+		AgeMonths    int    `mapstructure:"age_months"`
+		MaxAgeMonths int    `mapstructure:"max_age_months"`
+	} `mapstructure:"doses"`
+}
+
+// FileSchedule is a vaccination schedule read from a YAML file at start up
+type FileSchedule struct {
+	schedule *domain.VaccinationSchedule
+}
+
+// LoadFileSchedule reads and validates the schedule, a broken file stops the
+// start up rather than producing wrong forecasts
+func LoadFileSchedule(path string) (*FileSchedule, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	var file scheduleFile
+	if err := v.Unmarshal(&file); err != nil {
+		return nil, err
+	}
+
+	schedule := &domain.VaccinationSchedule{Name: file.Name}
+	for _, d := range file.Doses {
+		schedule.Doses = append(schedule.Doses, domain.ScheduledDose{
+			VaccineCode:  strings.ToUpper(strings.TrimSpace(d.Vaccine)),
+			VaccineName:  d.Name,
+			DoseNumber:   d.Dose,
+			AgeMonths:    d.AgeMonths,
+			MaxAgeMonths: d.MaxAgeMonths,
+		})
+	}
+	if len(schedule.Doses) == 0 {
+		return nil, fmt.Errorf("%s: %w", path, domain.ErrInvalidVaccinationSchedule)
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	slog.Info("Vaccination schedule loaded", "name", schedule.Name, "doses", len(schedule.Doses))
+	return &FileSchedule{schedule: schedule}, nil
+}
+
+func (s *FileSchedule) GetVaccinationSchedule() (*domain.VaccinationSchedule, error) {
+	return s.schedule, nil
+}
@@ -0,0 +1,38 @@
+package schedule
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"topdoctors/internal/domain"
+)
+
+func TestLoadFileSchedule(t *testing.T) {
+	t.Run("Loads the Spanish childhood calendar", func(t *testing.T) {
+		source, err := LoadFileSchedule(filepath.Join("..", "..", "..", "configs", "vaccination_schedule.es.yml"))
+		if err != nil {
+			t.Fatalf("LoadFileSchedule() error = %v", err)
+		}
+		schedule, _ := source.GetVaccinationSchedule()
+		if schedule.Name == "" || len(schedule.Doses) == 0 {
+			t.Fatalf("GetVaccinationSchedule() = %+v", schedule)
+		}
+		first := schedule.Doses[0]
+		if first.VaccineCode != "HEXA" || first.DoseNumber != 1 || first.AgeMonths != 2 {
+			t.Errorf("expected the first hexavalent dose at 2 months, got %+v", first)
+		}
+	})
+
+	t.Run("Rejects a schedule with a repeated dose", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "schedule.yml")
+		os.WriteFile(path, []byte(`name: "Broken"
+doses:
+  - { vaccine: "tv", dose: 1, age_months: 12 }
+  - { vaccine: "TV", dose: 1, age_months: 36 }
+`), 0o600)
+		if _, err := LoadFileSchedule(path); !errors.Is(err, domain.ErrInvalidVaccinationSchedule) {
+			t.Errorf("LoadFileSchedule() = %v, want %v", err, domain.ErrInvalidVaccinationSchedule)
+		}
+	})
+}
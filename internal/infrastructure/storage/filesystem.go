@@ -0,0 +1,374 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"topdoctors/internal/domain"
+)
+
+const blobKeySize = 32
+
+const (
+	// blobSegmentSize is the plaintext sealed in each segment of a blob
+	blobSegmentSize = 64 << 10
+	// blobNoncePrefixSize leaves room in the nonce for the segment index and
+	// the flag marking the last segment
+	blobNoncePrefixSize = 7
+)
+
+// blobFormat starts the blobs sealed in segments. Blobs without it were
+// sealed whole, as a single nonce and ciphertext.
+var blobFormat = []byte("TDB1")
+
+// FileStorage keeps blobs on the local filesystem, each encrypted with
+// AES-256-GCM under its own key in a file named after its ID. Files are
+// fanned out in directories by the last two characters of the ID, the random
+// end of a ULID, to keep them small.
+//
+// Blobs are not content-addressed: identical content uploaded twice is stored
+// twice, under different keys, so a file reveals nothing about other
+// patients holding the same document and erasing one never affects another.
+//
+// The content is sealed in segments of blobSegmentSize, after a header with
+// the format and a random nonce prefix. The nonce of each segment is the
+// prefix, the segment index and whether it is the last one, so segments do
+// not decrypt reordered and a truncated blob does not decrypt at all. Content
+// is encrypted and decrypted a segment at a time and never held in memory
+// whole.
+type FileStorage struct {
+	root string
+}
+
+func NewFileStorage(root string) (*FileStorage, error) {
+	// Uploads are written to a scratch directory on the same filesystem and
+	// renamed into place, so a blob is never visible half written
+	if err := os.MkdirAll(filepath.Join(root, "tmp"), 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStorage{root: root}, nil
+}
+
+func (s *FileStorage) Put(id string, content io.Reader, expectedChecksum string) (*domain.StoredBlob, error) {
+	if !validBlobID(id) {
+		return nil, domain.ErrBlobNotFound
+	}
+	key := make([]byte, blobKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Join(s.root, "tmp"), "upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	checksum, size, err := writeSegments(tmp, key, id, content)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if errClose := tmp.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return nil, err
+	}
+	if expectedChecksum != "" && expectedChecksum != checksum {
+		return nil, domain.ErrAttachmentChecksumMismatch
+	}
+
+	path := s.path(id)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	return &domain.StoredBlob{Key: key, Checksum: checksum, Size: size}, nil
+}
+
+// Open returns the decrypted blob as an io.ReadSeeker, so it can be served
+// with range requests. The blob is read through once to verify it before it
+// is returned, a blob altered on disk fails authentication and is never
+// served.
+func (s *FileStorage) Open(id string, key []byte, checksum string) (io.ReadCloser, error) {
+	if !validBlobID(id) {
+		return nil, domain.ErrBlobNotFound
+	}
+	file, err := os.Open(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var content io.ReadSeekCloser
+	content, err = openSegments(file, key, id)
+	if errors.Is(err, errWholeBlob) {
+		content, err = openWhole(file, key, id)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	hash := sha256.New()
+	_, err = io.Copy(hash, content)
+	if err == nil && hex.EncodeToString(hash.Sum(nil)) != checksum {
+		err = domain.ErrBlobCorrupted
+	}
+	if err == nil {
+		_, err = content.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		content.Close()
+		return nil, err
+	}
+	return content, nil
+}
+
+func (s *FileStorage) Delete(id string) error {
+	if !validBlobID(id) {
+		return domain.ErrBlobNotFound
+	}
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStorage) path(id string) string {
+	return filepath.Join(s.root, strings.ToLower(id[len(id)-2:]), id)
+}
+
+// validBlobID rejects IDs that would escape the storage root
+func validBlobID(id string) bool {
+	return len(id) >= 2 && filepath.Base(id) == id && !strings.HasPrefix(id, ".")
+}
+
+// writeSegments encrypts the content into the file a segment at a time,
+// returning its checksum and size
+func writeSegments(file io.Writer, key []byte, id string, content io.Reader) (string, int64, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", 0, err
+	}
+	prefix := make([]byte, blobNoncePrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", 0, err
+	}
+	if _, err := file.Write(append(slices.Clip(blobFormat), prefix...)); err != nil {
+		return "", 0, err
+	}
+
+	hash := sha256.New()
+	var size int64
+	reader := bufio.NewReaderSize(content, blobSegmentSize)
+	segment := make([]byte, blobSegmentSize)
+	var sealed []byte
+	for index := uint32(0); ; index++ {
+		n, err := io.ReadFull(reader, segment)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return "", 0, err
+		}
+		// A full segment is the last one when nothing follows it
+		last := err != nil
+		if !last {
+			if _, err := reader.Peek(1); errors.Is(err, io.EOF) {
+				last = true
+			} else if err != nil {
+				return "", 0, err
+			}
+		}
+		hash.Write(segment[:n])
+		size += int64(n)
+		sealed = gcm.Seal(sealed[:0], segmentNonce(prefix, int64(index), last), segment[:n], []byte(id))
+		if _, err := file.Write(sealed); err != nil {
+			return "", 0, err
+		}
+		if last {
+			return hex.EncodeToString(hash.Sum(nil)), size, nil
+		}
+	}
+}
+
+func segmentNonce(prefix []byte, index int64, last bool) []byte {
+	nonce := make([]byte, blobNoncePrefixSize, blobNoncePrefixSize+5)
+	copy(nonce, prefix)
+	nonce = binary.BigEndian.AppendUint32(nonce, uint32(index))
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// errWholeBlob reports a blob sealed whole, without the segment format
+var errWholeBlob = errors.New("blob not sealed in segments")
+
+// openSegments returns a reader decrypting the segments of the file as they
+// are reached
+func openSegments(file *os.File, key []byte, id string) (*segmentReader, error) {
+	header := make([]byte, len(blobFormat)+blobNoncePrefixSize)
+	if _, err := io.ReadFull(file, header); err != nil || !bytes.Equal(header[:len(blobFormat)], blobFormat) {
+		return nil, errWholeBlob
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every segment is full but the last one, which holds the rest and is
+	// sealed even when empty
+	sealedSize := int64(blobSegmentSize + gcm.Overhead())
+	body := info.Size() - int64(len(header))
+	segments := (body + sealedSize - 1) / sealedSize
+	if segments == 0 || body-(segments-1)*sealedSize < int64(gcm.Overhead()) {
+		return nil, domain.ErrBlobCorrupted
+	}
+	return &segmentReader{
+		file:     file,
+		gcm:      gcm,
+		aad:      []byte(id),
+		prefix:   header[len(blobFormat):],
+		start:    int64(len(header)),
+		body:     body,
+		size:     body - segments*int64(gcm.Overhead()),
+		segments: segments,
+		current:  -1,
+	}, nil
+}
+
+// segmentReader decrypts a blob sealed in segments, holding a single segment
+// in memory
+type segmentReader struct {
+	file     *os.File
+	gcm      cipher.AEAD
+	aad      []byte
+	prefix   []byte
+	start    int64 // Offset of the first segment in the file
+	body     int64 // Size of the sealed segments
+	size     int64 // Size of the content
+	segments int64
+	offset   int64 // Position in the content
+
+	current   int64 // Index of the segment decrypted, -1 if none
+	plaintext []byte
+	sealed    []byte
+}
+
+func (r *segmentReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	index := r.offset / blobSegmentSize
+	if index != r.current {
+		if err := r.load(index); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plaintext[r.offset-index*blobSegmentSize:])
+	r.offset += int64(n)
+	return n, nil
+}
+
+// load decrypts a segment, failing with domain.ErrBlobCorrupted when it was
+// altered
+func (r *segmentReader) load(index int64) error {
+	sealedSize := int64(blobSegmentSize + r.gcm.Overhead())
+	length := min(sealedSize, r.body-index*sealedSize)
+	if cap(r.sealed) < int(sealedSize) {
+		r.sealed = make([]byte, sealedSize)
+	}
+	sealed := r.sealed[:length]
+	if _, err := r.file.ReadAt(sealed, r.start+index*sealedSize); err != nil {
+		return err
+	}
+	plaintext, err := r.gcm.Open(r.plaintext[:0], segmentNonce(r.prefix, index, index == r.segments-1), sealed, r.aad)
+	if err != nil {
+		r.current = -1
+		return domain.ErrBlobCorrupted
+	}
+	r.plaintext, r.current = plaintext, index
+	return nil
+}
+
+func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("storage: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("storage: negative position")
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *segmentReader) Close() error {
+	return r.file.Close()
+}
+
+// openWhole decrypts a blob sealed whole, as they were before being sealed in
+// segments. Those blobs are bounded by domain.MaxAttachmentSize.
+func openWhole(file *os.File, key []byte, id string) (*wholeReader, error) {
+	sealed, err := io.ReadAll(io.NewSectionReader(file, 0, domain.MaxAttachmentSize+blobKeySize))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := unseal(key, id, sealed)
+	if err != nil {
+		return nil, domain.ErrBlobCorrupted
+	}
+	file.Close()
+	return &wholeReader{bytes.NewReader(plaintext)}, nil
+}
+
+func unseal(key []byte, id string, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, domain.ErrBlobCorrupted
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, []byte(id))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// wholeReader serves a blob sealed whole from memory
+type wholeReader struct {
+	*bytes.Reader
+}
+
+func (wholeReader) Close() error {
+	return nil
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"topdoctors/internal/domain"
+)
+
+func TestFileStorage(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewFileStorage(root)
+	if err != nil {
+		t.Fatalf("NewFileStorage() error = %v", err)
+	}
+
+	const id = "01HZY0000000000000000000A1"
+	content := "%PDF-1.7 informe de resonancia"
+	sum := sha256.Sum256([]byte(content))
+	want := hex.EncodeToString(sum[:])
+
+	stored, err := store.Put(id, strings.NewReader(content), want)
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if stored.Checksum != want || stored.Size != int64(len(content)) || len(stored.Key) != blobKeySize {
+		t.Fatalf("Put() = %s, %d, want %s, %d", stored.Checksum, stored.Size, want, len(content))
+	}
+
+	t.Run("Encrypts the content", func(t *testing.T) {
+		onDisk, _ := os.ReadFile(store.path(id))
+		if len(onDisk) == 0 || bytes.Contains(onDisk, []byte("resonancia")) {
+			t.Errorf("expected the stored file to be encrypted, got %q", onDisk)
+		}
+	})
+
+	t.Run("Stores identical content apart with its own key", func(t *testing.T) {
+		other, err := store.Put("01HZY0000000000000000000A2", strings.NewReader(content), "")
+		if err != nil || other.Checksum != want {
+			t.Fatalf("Put() = %+v, %v", other, err)
+		}
+		if bytes.Equal(other.Key, stored.Key) {
+			t.Error("expected a key per blob")
+		}
+		if _, err := store.Open("01HZY0000000000000000000A2", stored.Key, want); err != domain.ErrBlobCorrupted {
+			t.Errorf("Open() with another blob's key = %v, want %v", err, domain.ErrBlobCorrupted)
+		}
+	})
+
+	t.Run("Rejects content not matching the expected checksum", func(t *testing.T) {
+		_, err := store.Put("01HZY0000000000000000000A3", strings.NewReader("other content"), want)
+		if err != domain.ErrAttachmentChecksumMismatch {
+			t.Errorf("Put() = %v, want %v", err, domain.ErrAttachmentChecksumMismatch)
+		}
+		if _, err := os.Stat(store.path("01HZY0000000000000000000A3")); !os.IsNotExist(err) {
+			t.Errorf("expected the rejected upload not to be stored, got %v", err)
+		}
+	})
+
+	t.Run("Reads the content back", func(t *testing.T) {
+		file, err := store.Open(id, stored.Key, want)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer file.Close()
+		if _, ok := file.(io.ReadSeeker); !ok {
+			t.Error("expected the content to be seekable")
+		}
+		got, _ := io.ReadAll(file)
+		if string(got) != content {
+			t.Errorf("Open() content = %q", got)
+		}
+	})
+
+	t.Run("Refuses altered content", func(t *testing.T) {
+		onDisk, _ := os.ReadFile(store.path(id))
+		onDisk[len(onDisk)-1] ^= 0xff
+		os.WriteFile(store.path(id), onDisk, 0o600)
+		if _, err := store.Open(id, stored.Key, want); err != domain.ErrBlobCorrupted {
+			t.Errorf("Open() = %v, want %v", err, domain.ErrBlobCorrupted)
+		}
+	})
+
+	t.Run("Deletes and ignores unknown IDs", func(t *testing.T) {
+		if err := store.Delete(id); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := store.Open(id, stored.Key, want); err != domain.ErrBlobNotFound {
+			t.Errorf("Open() after Delete() = %v, want %v", err, domain.ErrBlobNotFound)
+		}
+		if _, err := store.Open("../../etc/passwd", stored.Key, want); err != domain.ErrBlobNotFound {
+			t.Errorf("Open() with a path = %v, want %v", err, domain.ErrBlobNotFound)
+		}
+	})
+}
+
+func TestFileStorageSegments(t *testing.T) {
+	store, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStorage() error = %v", err)
+	}
+
+	const id = "01HZY0000000000000000000A1"
+	content := make([]byte, 3*blobSegmentSize+100)
+	rand.Read(content)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	stored, err := store.Put(id, bytes.NewReader(content), checksum)
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	onDisk, _ := os.ReadFile(store.path(id))
+	sealedSize := blobSegmentSize + 16
+	header := len(blobFormat) + blobNoncePrefixSize
+
+	t.Run("Reads and seeks across segments", func(t *testing.T) {
+		file, err := store.Open(id, stored.Key, checksum)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer file.Close()
+		seeker := file.(io.ReadSeeker)
+		for _, offset := range []int64{blobSegmentSize - 10, 2*blobSegmentSize + 5, 0, int64(len(content)) - 50} {
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				t.Fatalf("Seek(%d) error = %v", offset, err)
+			}
+			got := make([]byte, 50)
+			if _, err := io.ReadFull(seeker, got); err != nil || !bytes.Equal(got, content[offset:offset+50]) {
+				t.Errorf("read at %d = %v, want the content at that offset", offset, err)
+			}
+		}
+		if end, _ := seeker.Seek(0, io.SeekEnd); end != int64(len(content)) {
+			t.Errorf("Seek() to the end = %d, want %d", end, len(content))
+		}
+	})
+
+	t.Run("Stores content filling its last segment", func(t *testing.T) {
+		full := content[:2*blobSegmentSize]
+		sum := sha256.Sum256(full)
+		other, err := store.Put("01HZY0000000000000000000A2", bytes.NewReader(full), "")
+		if err != nil || other.Checksum != hex.EncodeToString(sum[:]) || other.Size != int64(len(full)) {
+			t.Fatalf("Put() = %+v, %v", other, err)
+		}
+		file, err := store.Open("01HZY0000000000000000000A2", other.Key, other.Checksum)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer file.Close()
+		if got, _ := io.ReadAll(file); !bytes.Equal(got, full) {
+			t.Errorf("Open() content differs, got %d bytes", len(got))
+		}
+	})
+
+	tampered := map[string][]byte{
+		"altered segment":   slices.Concat(onDisk[:header+sealedSize+10], []byte{onDisk[header+sealedSize+10] ^ 0xff}, onDisk[header+sealedSize+11:]),
+		"swapped segments":  slices.Concat(onDisk[:header], onDisk[header+sealedSize:header+2*sealedSize], onDisk[header:header+sealedSize], onDisk[header+2*sealedSize:]),
+		"truncated segment": onDisk[:len(onDisk)-10],
+		"dropped segment":   onDisk[:header+3*sealedSize],
+	}
+	for name, blob := range tampered {
+		t.Run("Refuses a blob with a "+name, func(t *testing.T) {
+			os.WriteFile(store.path(id), blob, 0o600)
+			if _, err := store.Open(id, stored.Key, checksum); err != domain.ErrBlobCorrupted {
+				t.Errorf("Open() = %v, want %v", err, domain.ErrBlobCorrupted)
+			}
+		})
+	}
+
+	t.Run("Reads blobs sealed whole", func(t *testing.T) {
+		const id = "01HZY0000000000000000000A3"
+		key := bytes.Repeat([]byte{7}, blobKeySize)
+		gcm, _ := newGCM(key)
+		nonce := make([]byte, gcm.NonceSize())
+		rand.Read(nonce)
+		os.MkdirAll(filepath.Dir(store.path(id)), 0o700)
+		os.WriteFile(store.path(id), gcm.Seal(nonce, nonce, content, []byte(id)), 0o600)
+
+		file, err := store.Open(id, key, checksum)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer file.Close()
+		if got, _ := io.ReadAll(file); !bytes.Equal(got, content) {
+			t.Errorf("Open() content differs, got %d bytes", len(got))
+		}
+	})
+}
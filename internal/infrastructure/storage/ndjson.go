@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/infrastructure/fhir"
+)
+
+// NDJSONStorage keeps the files of bulk exports on the local filesystem, as
+// FHIR resources one per line, in a directory per job.
+//
+// Files are encrypted with AES-256-GCM under a key of the job. Each appended
+// batch is sealed on its own and written as a record: its length as a 4 byte
+// big endian integer, followed by the sealed lines. Records are bound to the
+// job and resource type, so they do not decrypt in another file.
+type NDJSONStorage struct {
+	root string
+}
+
+func NewNDJSONStorage(root string) (*NDJSONStorage, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, err
+	}
+	return &NDJSONStorage{root: root}, nil
+}
+
+func (s *NDJSONStorage) Create(jobID string) ([]byte, error) {
+	dir, err := s.dir(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	key := make([]byte, blobKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (s *NDJSONStorage) AppendPatients(jobID string, key []byte, patients []domain.Patient) error {
+	resources := make([]any, len(patients))
+	for i, p := range patients {
+		resources[i] = fhir.FromPatient(p)
+	}
+	return s.append(jobID, key, domain.BulkExportTypePatient, resources)
+}
+
+func (s *NDJSONStorage) AppendConditions(jobID string, key []byte, diagnoses []domain.Diagnosis) error {
+	resources := make([]any, len(diagnoses))
+	for i, d := range diagnoses {
+		resources[i] = fhir.FromDiagnosis(d)
+	}
+	return s.append(jobID, key, domain.BulkExportTypeCondition, resources)
+}
+
+// Open returns the file decrypting it as it is read, a record at a time. A
+// record altered on disk fails the read with domain.ErrBulkExportFileCorrupted.
+func (s *NDJSONStorage) Open(jobID string, key []byte, resourceType string) (io.ReadCloser, error) {
+	path, err := s.path(jobID, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrBulkExportFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &recordReader{file: file, records: bufio.NewReader(file), gcm: gcm, aad: recordAAD(jobID, resourceType)}, nil
+}
+
+func (s *NDJSONStorage) Delete(jobID string) error {
+	dir, err := s.dir(jobID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// append seals the resources as a record at the end of the file. The batch
+// is encoded in memory, bulk exports write batches of bounded size.
+func (s *NDJSONStorage) append(jobID string, key []byte, resourceType string, resources []any) error {
+	path, err := s.path(jobID, resourceType)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+
+	// json.Encoder ends every value with a newline, as NDJSON requires
+	var lines bytes.Buffer
+	encoder := json.NewEncoder(&lines)
+	for _, resource := range resources {
+		if err := encoder.Encode(resource); err != nil {
+			return err
+		}
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	record := make([]byte, 4, 4+len(nonce)+lines.Len()+gcm.Overhead())
+	record = gcm.Seal(append(record, nonce...), nonce, lines.Bytes(), recordAAD(jobID, resourceType))
+	binary.BigEndian.PutUint32(record, uint32(len(record)-4))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(record)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
+
+// dir returns the directory of a job. Job IDs name directories, so they must
+// not reach outside the root.
+func (s *NDJSONStorage) dir(jobID string) (string, error) {
+	if jobID == "" || jobID != filepath.Base(jobID) || jobID == "." || jobID == ".." {
+		return "", domain.ErrBulkExportFileNotFound
+	}
+	return filepath.Join(s.root, jobID), nil
+}
+
+func (s *NDJSONStorage) path(jobID, resourceType string) (string, error) {
+	if !slices.Contains(domain.BulkExportTypes, resourceType) {
+		return "", domain.ErrBulkExportFileNotFound
+	}
+	dir, err := s.dir(jobID)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, resourceType+".ndjson"), nil
+}
+
+// maxRecordSize bounds the memory a record read from disk may claim, well
+// above what a batch of a bulk export takes
+const maxRecordSize = 64 << 20
+
+func recordAAD(jobID, resourceType string) []byte {
+	return []byte(jobID + "/" + resourceType)
+}
+
+// recordReader reads the NDJSON lines of a file, unsealing its records as
+// they are reached
+type recordReader struct {
+	file    *os.File
+	records *bufio.Reader
+	gcm     cipher.AEAD
+	aad     []byte
+	lines   []byte // Unsealed lines of the current record not read yet
+}
+
+func (r *recordReader) Read(p []byte) (int, error) {
+	for len(r.lines) == 0 {
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.lines)
+	r.lines = r.lines[n:]
+	return n, nil
+}
+
+// next unseals the following record, io.EOF once the file is over
+func (r *recordReader) next() error {
+	var header [4]byte
+	if _, err := io.ReadFull(r.records, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return domain.ErrBulkExportFileCorrupted
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxRecordSize {
+		return domain.ErrBulkExportFileCorrupted
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(r.records, sealed); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return domain.ErrBulkExportFileCorrupted
+		}
+		return err
+	}
+	if len(sealed) < r.gcm.NonceSize() {
+		return domain.ErrBulkExportFileCorrupted
+	}
+	nonce, ciphertext := sealed[:r.gcm.NonceSize()], sealed[r.gcm.NonceSize():]
+	lines, err := r.gcm.Open(ciphertext[:0], nonce, ciphertext, r.aad)
+	if err != nil {
+		return domain.ErrBulkExportFileCorrupted
+	}
+	r.lines = lines
+	return nil
+}
+
+func (r *recordReader) Close() error {
+	return r.file.Close()
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"topdoctors/internal/domain"
+	"topdoctors/internal/infrastructure/fhir"
+)
+
+func TestNDJSONStorage(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewNDJSONStorage(root)
+	if err != nil {
+		t.Fatalf("NewNDJSONStorage() error = %v", err)
+	}
+	const jobID = "01HZY0000000000000000000J1"
+	key, err := store.Create(jobID)
+	if err != nil || len(key) != blobKeySize {
+		t.Fatalf("Create() = %x, %v", key, err)
+	}
+
+	// Batches are appended to the same file
+	for _, batch := range [][]domain.Patient{
+		{{ID: "p1", GivenName: "Ana", FirstSurname: "García", DNI: "12345678Z"}},
+		{{ID: "p2", GivenName: "Luis", FirstSurname: "Pérez", DNI: "11111111H"}},
+	} {
+		if err := store.AppendPatients(jobID, key, batch); err != nil {
+			t.Fatalf("AppendPatients() error = %v", err)
+		}
+	}
+	err = store.AppendConditions(jobID, key, []domain.Diagnosis{{ID: "d1", PatientID: "p1", Diagnosis: "Faringitis", Date: time.Now()}})
+	if err != nil {
+		t.Fatalf("AppendConditions() error = %v", err)
+	}
+
+	t.Run("Writes a resource per line", func(t *testing.T) {
+		file, err := store.Open(jobID, key, domain.BulkExportTypePatient)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer file.Close()
+
+		var ids []string
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var patient fhir.Patient
+			if err := json.Unmarshal(scanner.Bytes(), &patient); err != nil {
+				t.Fatalf("line %q is not a resource: %v", scanner.Text(), err)
+			}
+			if patient.ResourceType != fhir.ResourcePatient {
+				t.Errorf("resourceType = %q", patient.ResourceType)
+			}
+			ids = append(ids, patient.ID)
+		}
+		if len(ids) != 2 || ids[0] != "p1" || ids[1] != "p2" {
+			t.Errorf("exported patients = %v", ids)
+		}
+	})
+
+	t.Run("Encrypts the files", func(t *testing.T) {
+		onDisk, _ := os.ReadFile(filepath.Join(root, jobID, "Patient.ndjson"))
+		if len(onDisk) == 0 || bytes.Contains(onDisk, []byte("12345678Z")) || bytes.Contains(onDisk, []byte("Ana")) {
+			t.Errorf("expected the file to be encrypted, got %q", onDisk)
+		}
+	})
+
+	t.Run("Refuses altered files", func(t *testing.T) {
+		path := filepath.Join(root, jobID, "Condition.ndjson")
+		onDisk, _ := os.ReadFile(path)
+		altered := bytes.Clone(onDisk)
+		altered[len(altered)-1] ^= 0xff
+		os.WriteFile(path, altered, 0o600)
+		defer os.WriteFile(path, onDisk, 0o600)
+
+		file, err := store.Open(jobID, key, domain.BulkExportTypeCondition)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer file.Close()
+		if _, err := io.ReadAll(file); err != domain.ErrBulkExportFileCorrupted {
+			t.Errorf("ReadAll() error = %v, want %v", err, domain.ErrBulkExportFileCorrupted)
+		}
+	})
+
+	t.Run("Refuses another job's key", func(t *testing.T) {
+		other, _ := store.Create("01HZY0000000000000000000J2")
+		file, err := store.Open(jobID, other, domain.BulkExportTypePatient)
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer file.Close()
+		if _, err := io.ReadAll(file); err != domain.ErrBulkExportFileCorrupted {
+			t.Errorf("ReadAll() error = %v, want %v", err, domain.ErrBulkExportFileCorrupted)
+		}
+	})
+
+	t.Run("Rejects paths outside the root", func(t *testing.T) {
+		if _, err := store.Open("../"+filepath.Base(root), key, domain.BulkExportTypePatient); err != domain.ErrBulkExportFileNotFound {
+			t.Errorf("Open() error = %v, want %v", err, domain.ErrBulkExportFileNotFound)
+		}
+		if _, err := store.Open(jobID, key, "../Patient"); err != domain.ErrBulkExportFileNotFound {
+			t.Errorf("Open() error = %v, want %v", err, domain.ErrBulkExportFileNotFound)
+		}
+	})
+
+	t.Run("Deletes every file of the job", func(t *testing.T) {
+		if err := store.Delete(jobID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(root, jobID)); !os.IsNotExist(err) {
+			t.Errorf("expected the job directory removed, got %v", err)
+		}
+		if _, err := store.Open(jobID, key, domain.BulkExportTypeCondition); err != domain.ErrBulkExportFileNotFound {
+			t.Errorf("Open() error = %v, want %v", err, domain.ErrBulkExportFileNotFound)
+		}
+	})
+}
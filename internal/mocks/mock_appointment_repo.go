@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\appointment_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\appointment_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_appointment_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAppointmentRepository is a mock of AppointmentRepository interface.
+type MockAppointmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppointmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAppointmentRepositoryMockRecorder is the mock recorder for MockAppointmentRepository.
+type MockAppointmentRepositoryMockRecorder struct {
+	mock *MockAppointmentRepository
+}
+
+// NewMockAppointmentRepository creates a new mock instance.
+func NewMockAppointmentRepository(ctrl *gomock.Controller) *MockAppointmentRepository {
+	mock := &MockAppointmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAppointmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAppointmentRepository) EXPECT() *MockAppointmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAppointment mocks base method.
+func (m *MockAppointmentRepository) CreateAppointment(appointment *domain.Appointment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAppointment", appointment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAppointment indicates an expected call of CreateAppointment.
+func (mr *MockAppointmentRepositoryMockRecorder) CreateAppointment(appointment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppointment", reflect.TypeOf((*MockAppointmentRepository)(nil).CreateAppointment), appointment)
+}
+
+// GetAgenda mocks base method.
+func (m *MockAppointmentRepository) GetAgenda(practitionerID string, from, to time.Time) ([]domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgenda", practitionerID, from, to)
+	ret0, _ := ret[0].([]domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgenda indicates an expected call of GetAgenda.
+func (mr *MockAppointmentRepositoryMockRecorder) GetAgenda(practitionerID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgenda", reflect.TypeOf((*MockAppointmentRepository)(nil).GetAgenda), practitionerID, from, to)
+}
+
+// GetAppointmentByID mocks base method.
+func (m *MockAppointmentRepository) GetAppointmentByID(id string) (*domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppointmentByID", id)
+	ret0, _ := ret[0].(*domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppointmentByID indicates an expected call of GetAppointmentByID.
+func (mr *MockAppointmentRepositoryMockRecorder) GetAppointmentByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppointmentByID", reflect.TypeOf((*MockAppointmentRepository)(nil).GetAppointmentByID), id)
+}
+
+// GetAppointmentsByPatientID mocks base method.
+func (m *MockAppointmentRepository) GetAppointmentsByPatientID(patientID string) ([]domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppointmentsByPatientID", patientID)
+	ret0, _ := ret[0].([]domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppointmentsByPatientID indicates an expected call of GetAppointmentsByPatientID.
+func (mr *MockAppointmentRepositoryMockRecorder) GetAppointmentsByPatientID(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppointmentsByPatientID", reflect.TypeOf((*MockAppointmentRepository)(nil).GetAppointmentsByPatientID), patientID)
+}
+
+// UpdateAppointment mocks base method.
+func (m *MockAppointmentRepository) UpdateAppointment(appointment *domain.Appointment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAppointment", appointment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAppointment indicates an expected call of UpdateAppointment.
+func (mr *MockAppointmentRepositoryMockRecorder) UpdateAppointment(appointment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppointment", reflect.TypeOf((*MockAppointmentRepository)(nil).UpdateAppointment), appointment)
+}
+
+// MockAppointmentService is a mock of AppointmentService interface.
+type MockAppointmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppointmentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAppointmentServiceMockRecorder is the mock recorder for MockAppointmentService.
+type MockAppointmentServiceMockRecorder struct {
+	mock *MockAppointmentService
+}
+
+// NewMockAppointmentService creates a new mock instance.
+func NewMockAppointmentService(ctrl *gomock.Controller) *MockAppointmentService {
+	mock := &MockAppointmentService{ctrl: ctrl}
+	mock.recorder = &MockAppointmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAppointmentService) EXPECT() *MockAppointmentServiceMockRecorder {
+	return m.recorder
+}
+
+// BookAppointment mocks base method.
+func (m *MockAppointmentService) BookAppointment(caller domain.Caller, appointment *domain.Appointment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookAppointment", caller, appointment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BookAppointment indicates an expected call of BookAppointment.
+func (mr *MockAppointmentServiceMockRecorder) BookAppointment(caller, appointment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookAppointment", reflect.TypeOf((*MockAppointmentService)(nil).BookAppointment), caller, appointment)
+}
+
+// CancelAppointment mocks base method.
+func (m *MockAppointmentService) CancelAppointment(caller domain.Caller, id string) (*domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAppointment", caller, id)
+	ret0, _ := ret[0].(*domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelAppointment indicates an expected call of CancelAppointment.
+func (mr *MockAppointmentServiceMockRecorder) CancelAppointment(caller, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAppointment", reflect.TypeOf((*MockAppointmentService)(nil).CancelAppointment), caller, id)
+}
+
+// GetAgenda mocks base method.
+func (m *MockAppointmentService) GetAgenda(caller domain.Caller, practitionerID string, date time.Time, view string) ([]domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgenda", caller, practitionerID, date, view)
+	ret0, _ := ret[0].([]domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgenda indicates an expected call of GetAgenda.
+func (mr *MockAppointmentServiceMockRecorder) GetAgenda(caller, practitionerID, date, view any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgenda", reflect.TypeOf((*MockAppointmentService)(nil).GetAgenda), caller, practitionerID, date, view)
+}
+
+// GetAppointment mocks base method.
+func (m *MockAppointmentService) GetAppointment(caller domain.Caller, id string) (*domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppointment", caller, id)
+	ret0, _ := ret[0].(*domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppointment indicates an expected call of GetAppointment.
+func (mr *MockAppointmentServiceMockRecorder) GetAppointment(caller, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppointment", reflect.TypeOf((*MockAppointmentService)(nil).GetAppointment), caller, id)
+}
+
+// GetPatientAppointments mocks base method.
+func (m *MockAppointmentService) GetPatientAppointments(caller domain.Caller, patientID string) ([]domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPatientAppointments", caller, patientID)
+	ret0, _ := ret[0].([]domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPatientAppointments indicates an expected call of GetPatientAppointments.
+func (mr *MockAppointmentServiceMockRecorder) GetPatientAppointments(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientAppointments", reflect.TypeOf((*MockAppointmentService)(nil).GetPatientAppointments), caller, patientID)
+}
+
+// RescheduleAppointment mocks base method.
+func (m *MockAppointmentService) RescheduleAppointment(caller domain.Caller, id string, start, end time.Time) (*domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleAppointment", caller, id, start, end)
+	ret0, _ := ret[0].(*domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleAppointment indicates an expected call of RescheduleAppointment.
+func (mr *MockAppointmentServiceMockRecorder) RescheduleAppointment(caller, id, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleAppointment", reflect.TypeOf((*MockAppointmentService)(nil).RescheduleAppointment), caller, id, start, end)
+}
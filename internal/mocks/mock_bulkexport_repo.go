@@ -0,0 +1,397 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\bulkexport_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\bulkexport_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_bulkexport_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBulkExportRepository is a mock of BulkExportRepository interface.
+type MockBulkExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkExportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBulkExportRepositoryMockRecorder is the mock recorder for MockBulkExportRepository.
+type MockBulkExportRepositoryMockRecorder struct {
+	mock *MockBulkExportRepository
+}
+
+// NewMockBulkExportRepository creates a new mock instance.
+func NewMockBulkExportRepository(ctrl *gomock.Controller) *MockBulkExportRepository {
+	mock := &MockBulkExportRepository{ctrl: ctrl}
+	mock.recorder = &MockBulkExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkExportRepository) EXPECT() *MockBulkExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddBulkExportPatients mocks base method.
+func (m *MockBulkExportRepository) AddBulkExportPatients(jobID string, patientIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBulkExportPatients", jobID, patientIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBulkExportPatients indicates an expected call of AddBulkExportPatients.
+func (mr *MockBulkExportRepositoryMockRecorder) AddBulkExportPatients(jobID, patientIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBulkExportPatients", reflect.TypeOf((*MockBulkExportRepository)(nil).AddBulkExportPatients), jobID, patientIDs)
+}
+
+// CreateBulkExportJob mocks base method.
+func (m *MockBulkExportRepository) CreateBulkExportJob(job *domain.BulkExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBulkExportJob", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBulkExportJob indicates an expected call of CreateBulkExportJob.
+func (mr *MockBulkExportRepositoryMockRecorder) CreateBulkExportJob(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBulkExportJob", reflect.TypeOf((*MockBulkExportRepository)(nil).CreateBulkExportJob), job)
+}
+
+// DeleteBulkExportJob mocks base method.
+func (m *MockBulkExportRepository) DeleteBulkExportJob(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBulkExportJob", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBulkExportJob indicates an expected call of DeleteBulkExportJob.
+func (mr *MockBulkExportRepositoryMockRecorder) DeleteBulkExportJob(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBulkExportJob", reflect.TypeOf((*MockBulkExportRepository)(nil).DeleteBulkExportJob), id)
+}
+
+// ExportDiagnoses mocks base method.
+func (m *MockBulkExportRepository) ExportDiagnoses(caller domain.Caller, since *time.Time, fn func([]domain.Diagnosis) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportDiagnoses", caller, since, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportDiagnoses indicates an expected call of ExportDiagnoses.
+func (mr *MockBulkExportRepositoryMockRecorder) ExportDiagnoses(caller, since, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportDiagnoses", reflect.TypeOf((*MockBulkExportRepository)(nil).ExportDiagnoses), caller, since, fn)
+}
+
+// ExportPatients mocks base method.
+func (m *MockBulkExportRepository) ExportPatients(caller domain.Caller, since *time.Time, fn func([]domain.Patient) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPatients", caller, since, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportPatients indicates an expected call of ExportPatients.
+func (mr *MockBulkExportRepositoryMockRecorder) ExportPatients(caller, since, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPatients", reflect.TypeOf((*MockBulkExportRepository)(nil).ExportPatients), caller, since, fn)
+}
+
+// GetBulkExportJobByID mocks base method.
+func (m *MockBulkExportRepository) GetBulkExportJobByID(id string) (*domain.BulkExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkExportJobByID", id)
+	ret0, _ := ret[0].(*domain.BulkExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkExportJobByID indicates an expected call of GetBulkExportJobByID.
+func (mr *MockBulkExportRepositoryMockRecorder) GetBulkExportJobByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkExportJobByID", reflect.TypeOf((*MockBulkExportRepository)(nil).GetBulkExportJobByID), id)
+}
+
+// GetBulkExportJobsByStatus mocks base method.
+func (m *MockBulkExportRepository) GetBulkExportJobsByStatus(status string) ([]domain.BulkExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkExportJobsByStatus", status)
+	ret0, _ := ret[0].([]domain.BulkExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkExportJobsByStatus indicates an expected call of GetBulkExportJobsByStatus.
+func (mr *MockBulkExportRepositoryMockRecorder) GetBulkExportJobsByStatus(status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkExportJobsByStatus", reflect.TypeOf((*MockBulkExportRepository)(nil).GetBulkExportJobsByStatus), status)
+}
+
+// GetBulkExportJobsWithPatient mocks base method.
+func (m *MockBulkExportRepository) GetBulkExportJobsWithPatient(patientID, status string) ([]domain.BulkExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkExportJobsWithPatient", patientID, status)
+	ret0, _ := ret[0].([]domain.BulkExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkExportJobsWithPatient indicates an expected call of GetBulkExportJobsWithPatient.
+func (mr *MockBulkExportRepositoryMockRecorder) GetBulkExportJobsWithPatient(patientID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkExportJobsWithPatient", reflect.TypeOf((*MockBulkExportRepository)(nil).GetBulkExportJobsWithPatient), patientID, status)
+}
+
+// GetExpiredBulkExportJobs mocks base method.
+func (m *MockBulkExportRepository) GetExpiredBulkExportJobs(at time.Time) ([]domain.BulkExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredBulkExportJobs", at)
+	ret0, _ := ret[0].([]domain.BulkExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredBulkExportJobs indicates an expected call of GetExpiredBulkExportJobs.
+func (mr *MockBulkExportRepositoryMockRecorder) GetExpiredBulkExportJobs(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredBulkExportJobs", reflect.TypeOf((*MockBulkExportRepository)(nil).GetExpiredBulkExportJobs), at)
+}
+
+// HasErasedBulkExportPatients mocks base method.
+func (m *MockBulkExportRepository) HasErasedBulkExportPatients(jobID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasErasedBulkExportPatients", jobID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasErasedBulkExportPatients indicates an expected call of HasErasedBulkExportPatients.
+func (mr *MockBulkExportRepositoryMockRecorder) HasErasedBulkExportPatients(jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasErasedBulkExportPatients", reflect.TypeOf((*MockBulkExportRepository)(nil).HasErasedBulkExportPatients), jobID)
+}
+
+// UpdateBulkExportJob mocks base method.
+func (m *MockBulkExportRepository) UpdateBulkExportJob(job *domain.BulkExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBulkExportJob", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBulkExportJob indicates an expected call of UpdateBulkExportJob.
+func (mr *MockBulkExportRepositoryMockRecorder) UpdateBulkExportJob(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBulkExportJob", reflect.TypeOf((*MockBulkExportRepository)(nil).UpdateBulkExportJob), job)
+}
+
+// MockBulkExportStorage is a mock of BulkExportStorage interface.
+type MockBulkExportStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkExportStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockBulkExportStorageMockRecorder is the mock recorder for MockBulkExportStorage.
+type MockBulkExportStorageMockRecorder struct {
+	mock *MockBulkExportStorage
+}
+
+// NewMockBulkExportStorage creates a new mock instance.
+func NewMockBulkExportStorage(ctrl *gomock.Controller) *MockBulkExportStorage {
+	mock := &MockBulkExportStorage{ctrl: ctrl}
+	mock.recorder = &MockBulkExportStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkExportStorage) EXPECT() *MockBulkExportStorageMockRecorder {
+	return m.recorder
+}
+
+// AppendConditions mocks base method.
+func (m *MockBulkExportStorage) AppendConditions(jobID string, key []byte, diagnoses []domain.Diagnosis) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendConditions", jobID, key, diagnoses)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendConditions indicates an expected call of AppendConditions.
+func (mr *MockBulkExportStorageMockRecorder) AppendConditions(jobID, key, diagnoses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendConditions", reflect.TypeOf((*MockBulkExportStorage)(nil).AppendConditions), jobID, key, diagnoses)
+}
+
+// AppendPatients mocks base method.
+func (m *MockBulkExportStorage) AppendPatients(jobID string, key []byte, patients []domain.Patient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendPatients", jobID, key, patients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendPatients indicates an expected call of AppendPatients.
+func (mr *MockBulkExportStorageMockRecorder) AppendPatients(jobID, key, patients any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendPatients", reflect.TypeOf((*MockBulkExportStorage)(nil).AppendPatients), jobID, key, patients)
+}
+
+// Create mocks base method.
+func (m *MockBulkExportStorage) Create(jobID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", jobID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBulkExportStorageMockRecorder) Create(jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBulkExportStorage)(nil).Create), jobID)
+}
+
+// Delete mocks base method.
+func (m *MockBulkExportStorage) Delete(jobID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", jobID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBulkExportStorageMockRecorder) Delete(jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBulkExportStorage)(nil).Delete), jobID)
+}
+
+// Open mocks base method.
+func (m *MockBulkExportStorage) Open(jobID string, key []byte, resourceType string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", jobID, key, resourceType)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockBulkExportStorageMockRecorder) Open(jobID, key, resourceType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockBulkExportStorage)(nil).Open), jobID, key, resourceType)
+}
+
+// MockBulkExportService is a mock of BulkExportService interface.
+type MockBulkExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkExportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBulkExportServiceMockRecorder is the mock recorder for MockBulkExportService.
+type MockBulkExportServiceMockRecorder struct {
+	mock *MockBulkExportService
+}
+
+// NewMockBulkExportService creates a new mock instance.
+func NewMockBulkExportService(ctrl *gomock.Controller) *MockBulkExportService {
+	mock := &MockBulkExportService{ctrl: ctrl}
+	mock.recorder = &MockBulkExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkExportService) EXPECT() *MockBulkExportServiceMockRecorder {
+	return m.recorder
+}
+
+// FailInterruptedExports mocks base method.
+func (m *MockBulkExportService) FailInterruptedExports() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailInterruptedExports")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailInterruptedExports indicates an expected call of FailInterruptedExports.
+func (mr *MockBulkExportServiceMockRecorder) FailInterruptedExports() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailInterruptedExports", reflect.TypeOf((*MockBulkExportService)(nil).FailInterruptedExports))
+}
+
+// GetExportJob mocks base method.
+func (m *MockBulkExportService) GetExportJob(caller domain.Caller, id string) (*domain.BulkExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportJob", caller, id)
+	ret0, _ := ret[0].(*domain.BulkExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportJob indicates an expected call of GetExportJob.
+func (mr *MockBulkExportServiceMockRecorder) GetExportJob(caller, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportJob", reflect.TypeOf((*MockBulkExportService)(nil).GetExportJob), caller, id)
+}
+
+// OpenExportFile mocks base method.
+func (m *MockBulkExportService) OpenExportFile(caller domain.Caller, id, resourceType string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenExportFile", caller, id, resourceType)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenExportFile indicates an expected call of OpenExportFile.
+func (mr *MockBulkExportServiceMockRecorder) OpenExportFile(caller, id, resourceType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenExportFile", reflect.TypeOf((*MockBulkExportService)(nil).OpenExportFile), caller, id, resourceType)
+}
+
+// PurgeExpiredExports mocks base method.
+func (m *MockBulkExportService) PurgeExpiredExports(at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredExports", at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredExports indicates an expected call of PurgeExpiredExports.
+func (mr *MockBulkExportServiceMockRecorder) PurgeExpiredExports(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredExports", reflect.TypeOf((*MockBulkExportService)(nil).PurgeExpiredExports), at)
+}
+
+// StartExport mocks base method.
+func (m *MockBulkExportService) StartExport(caller domain.Caller, types []string, since *time.Time) (*domain.BulkExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartExport", caller, types, since)
+	ret0, _ := ret[0].(*domain.BulkExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartExport indicates an expected call of StartExport.
+func (mr *MockBulkExportServiceMockRecorder) StartExport(caller, types, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExport", reflect.TypeOf((*MockBulkExportService)(nil).StartExport), caller, types, since)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\calendar_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\calendar_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_calendar_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCalendarFeedRepository is a mock of CalendarFeedRepository interface.
+type MockCalendarFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarFeedRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarFeedRepositoryMockRecorder is the mock recorder for MockCalendarFeedRepository.
+type MockCalendarFeedRepositoryMockRecorder struct {
+	mock *MockCalendarFeedRepository
+}
+
+// NewMockCalendarFeedRepository creates a new mock instance.
+func NewMockCalendarFeedRepository(ctrl *gomock.Controller) *MockCalendarFeedRepository {
+	mock := &MockCalendarFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarFeedRepository) EXPECT() *MockCalendarFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteCalendarFeed mocks base method.
+func (m *MockCalendarFeedRepository) DeleteCalendarFeed(practitionerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarFeed", practitionerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendarFeed indicates an expected call of DeleteCalendarFeed.
+func (mr *MockCalendarFeedRepositoryMockRecorder) DeleteCalendarFeed(practitionerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarFeed", reflect.TypeOf((*MockCalendarFeedRepository)(nil).DeleteCalendarFeed), practitionerID)
+}
+
+// GetCalendarFeed mocks base method.
+func (m *MockCalendarFeedRepository) GetCalendarFeed(practitionerID string) (*domain.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeed", practitionerID)
+	ret0, _ := ret[0].(*domain.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeed indicates an expected call of GetCalendarFeed.
+func (mr *MockCalendarFeedRepositoryMockRecorder) GetCalendarFeed(practitionerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeed", reflect.TypeOf((*MockCalendarFeedRepository)(nil).GetCalendarFeed), practitionerID)
+}
+
+// SaveCalendarFeed mocks base method.
+func (m *MockCalendarFeedRepository) SaveCalendarFeed(feed *domain.CalendarFeed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCalendarFeed", feed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCalendarFeed indicates an expected call of SaveCalendarFeed.
+func (mr *MockCalendarFeedRepositoryMockRecorder) SaveCalendarFeed(feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCalendarFeed", reflect.TypeOf((*MockCalendarFeedRepository)(nil).SaveCalendarFeed), feed)
+}
+
+// MockCalendarService is a mock of CalendarService interface.
+type MockCalendarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarServiceMockRecorder is the mock recorder for MockCalendarService.
+type MockCalendarServiceMockRecorder struct {
+	mock *MockCalendarService
+}
+
+// NewMockCalendarService creates a new mock instance.
+func NewMockCalendarService(ctrl *gomock.Controller) *MockCalendarService {
+	mock := &MockCalendarService{ctrl: ctrl}
+	mock.recorder = &MockCalendarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarService) EXPECT() *MockCalendarServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateFeed mocks base method.
+func (m *MockCalendarService) CreateFeed(caller domain.Caller, practitionerID string) (string, *domain.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeed", caller, practitionerID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*domain.CalendarFeed)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateFeed indicates an expected call of CreateFeed.
+func (mr *MockCalendarServiceMockRecorder) CreateFeed(caller, practitionerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeed", reflect.TypeOf((*MockCalendarService)(nil).CreateFeed), caller, practitionerID)
+}
+
+// GetFeedAppointments mocks base method.
+func (m *MockCalendarService) GetFeedAppointments(practitionerID, token string) ([]domain.Appointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedAppointments", practitionerID, token)
+	ret0, _ := ret[0].([]domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedAppointments indicates an expected call of GetFeedAppointments.
+func (mr *MockCalendarServiceMockRecorder) GetFeedAppointments(practitionerID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedAppointments", reflect.TypeOf((*MockCalendarService)(nil).GetFeedAppointments), practitionerID, token)
+}
+
+// RevokeFeed mocks base method.
+func (m *MockCalendarService) RevokeFeed(caller domain.Caller, practitionerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFeed", caller, practitionerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFeed indicates an expected call of RevokeFeed.
+func (mr *MockCalendarServiceMockRecorder) RevokeFeed(caller, practitionerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFeed", reflect.TypeOf((*MockCalendarService)(nil).RevokeFeed), caller, practitionerID)
+}
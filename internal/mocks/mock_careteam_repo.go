@@ -0,0 +1,240 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\careteam_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\careteam_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_careteam_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCareTeamRepository is a mock of CareTeamRepository interface.
+type MockCareTeamRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCareTeamRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCareTeamRepositoryMockRecorder is the mock recorder for MockCareTeamRepository.
+type MockCareTeamRepositoryMockRecorder struct {
+	mock *MockCareTeamRepository
+}
+
+// NewMockCareTeamRepository creates a new mock instance.
+func NewMockCareTeamRepository(ctrl *gomock.Controller) *MockCareTeamRepository {
+	mock := &MockCareTeamRepository{ctrl: ctrl}
+	mock.recorder = &MockCareTeamRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCareTeamRepository) EXPECT() *MockCareTeamRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCareTeamMember mocks base method.
+func (m *MockCareTeamRepository) AddCareTeamMember(member *domain.CareTeamMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCareTeamMember", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCareTeamMember indicates an expected call of AddCareTeamMember.
+func (mr *MockCareTeamRepositoryMockRecorder) AddCareTeamMember(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCareTeamMember", reflect.TypeOf((*MockCareTeamRepository)(nil).AddCareTeamMember), member)
+}
+
+// CreateAccessLogEntry mocks base method.
+func (m *MockCareTeamRepository) CreateAccessLogEntry(entry *domain.AccessLogEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessLogEntry", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccessLogEntry indicates an expected call of CreateAccessLogEntry.
+func (mr *MockCareTeamRepositoryMockRecorder) CreateAccessLogEntry(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessLogEntry", reflect.TypeOf((*MockCareTeamRepository)(nil).CreateAccessLogEntry), entry)
+}
+
+// CreateBreakGlassAccess mocks base method.
+func (m *MockCareTeamRepository) CreateBreakGlassAccess(access *domain.BreakGlassAccess) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBreakGlassAccess", access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBreakGlassAccess indicates an expected call of CreateBreakGlassAccess.
+func (mr *MockCareTeamRepositoryMockRecorder) CreateBreakGlassAccess(access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBreakGlassAccess", reflect.TypeOf((*MockCareTeamRepository)(nil).CreateBreakGlassAccess), access)
+}
+
+// GetAccessLogByPatientID mocks base method.
+func (m *MockCareTeamRepository) GetAccessLogByPatientID(patientID string) ([]domain.AccessLogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessLogByPatientID", patientID)
+	ret0, _ := ret[0].([]domain.AccessLogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessLogByPatientID indicates an expected call of GetAccessLogByPatientID.
+func (mr *MockCareTeamRepositoryMockRecorder) GetAccessLogByPatientID(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessLogByPatientID", reflect.TypeOf((*MockCareTeamRepository)(nil).GetAccessLogByPatientID), patientID)
+}
+
+// GetActiveBreakGlassAccess mocks base method.
+func (m *MockCareTeamRepository) GetActiveBreakGlassAccess(patientID, userID string, at time.Time) (*domain.BreakGlassAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBreakGlassAccess", patientID, userID, at)
+	ret0, _ := ret[0].(*domain.BreakGlassAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBreakGlassAccess indicates an expected call of GetActiveBreakGlassAccess.
+func (mr *MockCareTeamRepositoryMockRecorder) GetActiveBreakGlassAccess(patientID, userID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBreakGlassAccess", reflect.TypeOf((*MockCareTeamRepository)(nil).GetActiveBreakGlassAccess), patientID, userID, at)
+}
+
+// GetCareTeam mocks base method.
+func (m *MockCareTeamRepository) GetCareTeam(patientID string) ([]domain.CareTeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCareTeam", patientID)
+	ret0, _ := ret[0].([]domain.CareTeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCareTeam indicates an expected call of GetCareTeam.
+func (mr *MockCareTeamRepositoryMockRecorder) GetCareTeam(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCareTeam", reflect.TypeOf((*MockCareTeamRepository)(nil).GetCareTeam), patientID)
+}
+
+// IsCareTeamMember mocks base method.
+func (m *MockCareTeamRepository) IsCareTeamMember(patientID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCareTeamMember", patientID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCareTeamMember indicates an expected call of IsCareTeamMember.
+func (mr *MockCareTeamRepositoryMockRecorder) IsCareTeamMember(patientID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCareTeamMember", reflect.TypeOf((*MockCareTeamRepository)(nil).IsCareTeamMember), patientID, userID)
+}
+
+// RemoveCareTeamMember mocks base method.
+func (m *MockCareTeamRepository) RemoveCareTeamMember(patientID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCareTeamMember", patientID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCareTeamMember indicates an expected call of RemoveCareTeamMember.
+func (mr *MockCareTeamRepositoryMockRecorder) RemoveCareTeamMember(patientID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCareTeamMember", reflect.TypeOf((*MockCareTeamRepository)(nil).RemoveCareTeamMember), patientID, userID)
+}
+
+// MockCareTeamService is a mock of CareTeamService interface.
+type MockCareTeamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCareTeamServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCareTeamServiceMockRecorder is the mock recorder for MockCareTeamService.
+type MockCareTeamServiceMockRecorder struct {
+	mock *MockCareTeamService
+}
+
+// NewMockCareTeamService creates a new mock instance.
+func NewMockCareTeamService(ctrl *gomock.Controller) *MockCareTeamService {
+	mock := &MockCareTeamService{ctrl: ctrl}
+	mock.recorder = &MockCareTeamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCareTeamService) EXPECT() *MockCareTeamServiceMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockCareTeamService) AddMember(caller domain.Caller, patientID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", caller, patientID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockCareTeamServiceMockRecorder) AddMember(caller, patientID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockCareTeamService)(nil).AddMember), caller, patientID, userID)
+}
+
+// BreakGlass mocks base method.
+func (m *MockCareTeamService) BreakGlass(caller domain.Caller, patientID, justification string) (*domain.BreakGlassAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BreakGlass", caller, patientID, justification)
+	ret0, _ := ret[0].(*domain.BreakGlassAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BreakGlass indicates an expected call of BreakGlass.
+func (mr *MockCareTeamServiceMockRecorder) BreakGlass(caller, patientID, justification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BreakGlass", reflect.TypeOf((*MockCareTeamService)(nil).BreakGlass), caller, patientID, justification)
+}
+
+// GetCareTeam mocks base method.
+func (m *MockCareTeamService) GetCareTeam(caller domain.Caller, patientID string) ([]domain.CareTeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCareTeam", caller, patientID)
+	ret0, _ := ret[0].([]domain.CareTeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCareTeam indicates an expected call of GetCareTeam.
+func (mr *MockCareTeamServiceMockRecorder) GetCareTeam(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCareTeam", reflect.TypeOf((*MockCareTeamService)(nil).GetCareTeam), caller, patientID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockCareTeamService) RemoveMember(caller domain.Caller, patientID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", caller, patientID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockCareTeamServiceMockRecorder) RemoveMember(caller, patientID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockCareTeamService)(nil).RemoveMember), caller, patientID, userID)
+}
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\consent_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\consent_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_consent_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConsentRepository is a mock of ConsentRepository interface.
+type MockConsentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentRepositoryMockRecorder is the mock recorder for MockConsentRepository.
+type MockConsentRepositoryMockRecorder struct {
+	mock *MockConsentRepository
+}
+
+// NewMockConsentRepository creates a new mock instance.
+func NewMockConsentRepository(ctrl *gomock.Controller) *MockConsentRepository {
+	mock := &MockConsentRepository{ctrl: ctrl}
+	mock.recorder = &MockConsentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentRepository) EXPECT() *MockConsentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateConsent mocks base method.
+func (m *MockConsentRepository) CreateConsent(consent *domain.Consent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConsent", consent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateConsent indicates an expected call of CreateConsent.
+func (mr *MockConsentRepositoryMockRecorder) CreateConsent(consent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConsent", reflect.TypeOf((*MockConsentRepository)(nil).CreateConsent), consent)
+}
+
+// GetConsentByID mocks base method.
+func (m *MockConsentRepository) GetConsentByID(id string) (*domain.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsentByID", id)
+	ret0, _ := ret[0].(*domain.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsentByID indicates an expected call of GetConsentByID.
+func (mr *MockConsentRepositoryMockRecorder) GetConsentByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsentByID", reflect.TypeOf((*MockConsentRepository)(nil).GetConsentByID), id)
+}
+
+// GetConsentsByPatientID mocks base method.
+func (m *MockConsentRepository) GetConsentsByPatientID(patientID string) ([]domain.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsentsByPatientID", patientID)
+	ret0, _ := ret[0].([]domain.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsentsByPatientID indicates an expected call of GetConsentsByPatientID.
+func (mr *MockConsentRepositoryMockRecorder) GetConsentsByPatientID(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsentsByPatientID", reflect.TypeOf((*MockConsentRepository)(nil).GetConsentsByPatientID), patientID)
+}
+
+// RevokeConsent mocks base method.
+func (m *MockConsentRepository) RevokeConsent(id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeConsent", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeConsent indicates an expected call of RevokeConsent.
+func (mr *MockConsentRepositoryMockRecorder) RevokeConsent(id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeConsent", reflect.TypeOf((*MockConsentRepository)(nil).RevokeConsent), id, at)
+}
+
+// MockConsentService is a mock of ConsentService interface.
+type MockConsentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConsentServiceMockRecorder is the mock recorder for MockConsentService.
+type MockConsentServiceMockRecorder struct {
+	mock *MockConsentService
+}
+
+// NewMockConsentService creates a new mock instance.
+func NewMockConsentService(ctrl *gomock.Controller) *MockConsentService {
+	mock := &MockConsentService{ctrl: ctrl}
+	mock.recorder = &MockConsentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsentService) EXPECT() *MockConsentServiceMockRecorder {
+	return m.recorder
+}
+
+// GetConsents mocks base method.
+func (m *MockConsentService) GetConsents(caller domain.Caller, patientID string) ([]domain.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsents", caller, patientID)
+	ret0, _ := ret[0].([]domain.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsents indicates an expected call of GetConsents.
+func (mr *MockConsentServiceMockRecorder) GetConsents(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsents", reflect.TypeOf((*MockConsentService)(nil).GetConsents), caller, patientID)
+}
+
+// GrantConsent mocks base method.
+func (m *MockConsentService) GrantConsent(caller domain.Caller, consent *domain.Consent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantConsent", caller, consent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantConsent indicates an expected call of GrantConsent.
+func (mr *MockConsentServiceMockRecorder) GrantConsent(caller, consent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantConsent", reflect.TypeOf((*MockConsentService)(nil).GrantConsent), caller, consent)
+}
+
+// RevokeConsent mocks base method.
+func (m *MockConsentService) RevokeConsent(caller domain.Caller, patientID, consentID string) (*domain.Consent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeConsent", caller, patientID, consentID)
+	ret0, _ := ret[0].(*domain.Consent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeConsent indicates an expected call of RevokeConsent.
+func (mr *MockConsentServiceMockRecorder) RevokeConsent(caller, patientID, consentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeConsent", reflect.TypeOf((*MockConsentService)(nil).RevokeConsent), caller, patientID, consentID)
+}
@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\contact_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\contact_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_contact_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockContactRepository is a mock of ContactRepository interface.
+type MockContactRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockContactRepositoryMockRecorder is the mock recorder for MockContactRepository.
+type MockContactRepositoryMockRecorder struct {
+	mock *MockContactRepository
+}
+
+// NewMockContactRepository creates a new mock instance.
+func NewMockContactRepository(ctrl *gomock.Controller) *MockContactRepository {
+	mock := &MockContactRepository{ctrl: ctrl}
+	mock.recorder = &MockContactRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactRepository) EXPECT() *MockContactRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateContact mocks base method.
+func (m *MockContactRepository) CreateContact(contact *domain.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContact", contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateContact indicates an expected call of CreateContact.
+func (mr *MockContactRepositoryMockRecorder) CreateContact(contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContact", reflect.TypeOf((*MockContactRepository)(nil).CreateContact), contact)
+}
+
+// DeleteContact mocks base method.
+func (m *MockContactRepository) DeleteContact(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContact", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContact indicates an expected call of DeleteContact.
+func (mr *MockContactRepositoryMockRecorder) DeleteContact(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactRepository)(nil).DeleteContact), id)
+}
+
+// GetContactByID mocks base method.
+func (m *MockContactRepository) GetContactByID(id string) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactByID", id)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactByID indicates an expected call of GetContactByID.
+func (mr *MockContactRepositoryMockRecorder) GetContactByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactByID", reflect.TypeOf((*MockContactRepository)(nil).GetContactByID), id)
+}
+
+// GetContactsByPatientID mocks base method.
+func (m *MockContactRepository) GetContactsByPatientID(patientID string) ([]domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsByPatientID", patientID)
+	ret0, _ := ret[0].([]domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsByPatientID indicates an expected call of GetContactsByPatientID.
+func (mr *MockContactRepositoryMockRecorder) GetContactsByPatientID(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsByPatientID", reflect.TypeOf((*MockContactRepository)(nil).GetContactsByPatientID), patientID)
+}
+
+// UpdateContact mocks base method.
+func (m *MockContactRepository) UpdateContact(contact *domain.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContact", contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContact indicates an expected call of UpdateContact.
+func (mr *MockContactRepositoryMockRecorder) UpdateContact(contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockContactRepository)(nil).UpdateContact), contact)
+}
+
+// MockContactService is a mock of ContactService interface.
+type MockContactService struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockContactServiceMockRecorder is the mock recorder for MockContactService.
+type MockContactServiceMockRecorder struct {
+	mock *MockContactService
+}
+
+// NewMockContactService creates a new mock instance.
+func NewMockContactService(ctrl *gomock.Controller) *MockContactService {
+	mock := &MockContactService{ctrl: ctrl}
+	mock.recorder = &MockContactServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactService) EXPECT() *MockContactServiceMockRecorder {
+	return m.recorder
+}
+
+// AddContact mocks base method.
+func (m *MockContactService) AddContact(caller domain.Caller, contact *domain.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContact", caller, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddContact indicates an expected call of AddContact.
+func (mr *MockContactServiceMockRecorder) AddContact(caller, contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContact", reflect.TypeOf((*MockContactService)(nil).AddContact), caller, contact)
+}
+
+// DeleteContact mocks base method.
+func (m *MockContactService) DeleteContact(caller domain.Caller, patientID, contactID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContact", caller, patientID, contactID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContact indicates an expected call of DeleteContact.
+func (mr *MockContactServiceMockRecorder) DeleteContact(caller, patientID, contactID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactService)(nil).DeleteContact), caller, patientID, contactID)
+}
+
+// GetContacts mocks base method.
+func (m *MockContactService) GetContacts(caller domain.Caller, patientID string) ([]domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", caller, patientID)
+	ret0, _ := ret[0].([]domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockContactServiceMockRecorder) GetContacts(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContactService)(nil).GetContacts), caller, patientID)
+}
+
+// UpdateContact mocks base method.
+func (m *MockContactService) UpdateContact(caller domain.Caller, contact *domain.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContact", caller, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContact indicates an expected call of UpdateContact.
+func (mr *MockContactServiceMockRecorder) UpdateContact(caller, contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockContactService)(nil).UpdateContact), caller, contact)
+}
@@ -0,0 +1,212 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\encounter_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\encounter_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_encounter_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEncounterRepository is a mock of EncounterRepository interface.
+type MockEncounterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncounterRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEncounterRepositoryMockRecorder is the mock recorder for MockEncounterRepository.
+type MockEncounterRepositoryMockRecorder struct {
+	mock *MockEncounterRepository
+}
+
+// NewMockEncounterRepository creates a new mock instance.
+func NewMockEncounterRepository(ctrl *gomock.Controller) *MockEncounterRepository {
+	mock := &MockEncounterRepository{ctrl: ctrl}
+	mock.recorder = &MockEncounterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncounterRepository) EXPECT() *MockEncounterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEncounter mocks base method.
+func (m *MockEncounterRepository) CreateEncounter(encounter *domain.Encounter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEncounter", encounter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEncounter indicates an expected call of CreateEncounter.
+func (mr *MockEncounterRepositoryMockRecorder) CreateEncounter(encounter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEncounter", reflect.TypeOf((*MockEncounterRepository)(nil).CreateEncounter), encounter)
+}
+
+// GetDiagnosesByEncounterID mocks base method.
+func (m *MockEncounterRepository) GetDiagnosesByEncounterID(encounterID string) ([]domain.Diagnosis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiagnosesByEncounterID", encounterID)
+	ret0, _ := ret[0].([]domain.Diagnosis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiagnosesByEncounterID indicates an expected call of GetDiagnosesByEncounterID.
+func (mr *MockEncounterRepositoryMockRecorder) GetDiagnosesByEncounterID(encounterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnosesByEncounterID", reflect.TypeOf((*MockEncounterRepository)(nil).GetDiagnosesByEncounterID), encounterID)
+}
+
+// GetEncounterByID mocks base method.
+func (m *MockEncounterRepository) GetEncounterByID(id string) (*domain.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEncounterByID", id)
+	ret0, _ := ret[0].(*domain.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEncounterByID indicates an expected call of GetEncounterByID.
+func (mr *MockEncounterRepositoryMockRecorder) GetEncounterByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncounterByID", reflect.TypeOf((*MockEncounterRepository)(nil).GetEncounterByID), id)
+}
+
+// GetEncountersByPatientID mocks base method.
+func (m *MockEncounterRepository) GetEncountersByPatientID(patientID string) ([]domain.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEncountersByPatientID", patientID)
+	ret0, _ := ret[0].([]domain.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEncountersByPatientID indicates an expected call of GetEncountersByPatientID.
+func (mr *MockEncounterRepositoryMockRecorder) GetEncountersByPatientID(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncountersByPatientID", reflect.TypeOf((*MockEncounterRepository)(nil).GetEncountersByPatientID), patientID)
+}
+
+// UpdateEncounter mocks base method.
+func (m *MockEncounterRepository) UpdateEncounter(encounter *domain.Encounter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEncounter", encounter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEncounter indicates an expected call of UpdateEncounter.
+func (mr *MockEncounterRepositoryMockRecorder) UpdateEncounter(encounter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEncounter", reflect.TypeOf((*MockEncounterRepository)(nil).UpdateEncounter), encounter)
+}
+
+// MockEncounterService is a mock of EncounterService interface.
+type MockEncounterService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncounterServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEncounterServiceMockRecorder is the mock recorder for MockEncounterService.
+type MockEncounterServiceMockRecorder struct {
+	mock *MockEncounterService
+}
+
+// NewMockEncounterService creates a new mock instance.
+func NewMockEncounterService(ctrl *gomock.Controller) *MockEncounterService {
+	mock := &MockEncounterService{ctrl: ctrl}
+	mock.recorder = &MockEncounterServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncounterService) EXPECT() *MockEncounterServiceMockRecorder {
+	return m.recorder
+}
+
+// CloseEncounter mocks base method.
+func (m *MockEncounterService) CloseEncounter(caller domain.Caller, id string, note *domain.SOAPNote) (*domain.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseEncounter", caller, id, note)
+	ret0, _ := ret[0].(*domain.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseEncounter indicates an expected call of CloseEncounter.
+func (mr *MockEncounterServiceMockRecorder) CloseEncounter(caller, id, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseEncounter", reflect.TypeOf((*MockEncounterService)(nil).CloseEncounter), caller, id, note)
+}
+
+// GetEncounter mocks base method.
+func (m *MockEncounterService) GetEncounter(caller domain.Caller, id string) (*domain.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEncounter", caller, id)
+	ret0, _ := ret[0].(*domain.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEncounter indicates an expected call of GetEncounter.
+func (mr *MockEncounterServiceMockRecorder) GetEncounter(caller, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncounter", reflect.TypeOf((*MockEncounterService)(nil).GetEncounter), caller, id)
+}
+
+// GetPatientEncounters mocks base method.
+func (m *MockEncounterService) GetPatientEncounters(caller domain.Caller, patientID string) ([]domain.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPatientEncounters", caller, patientID)
+	ret0, _ := ret[0].([]domain.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPatientEncounters indicates an expected call of GetPatientEncounters.
+func (mr *MockEncounterServiceMockRecorder) GetPatientEncounters(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientEncounters", reflect.TypeOf((*MockEncounterService)(nil).GetPatientEncounters), caller, patientID)
+}
+
+// OpenEncounter mocks base method.
+func (m *MockEncounterService) OpenEncounter(caller domain.Caller, encounter *domain.Encounter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenEncounter", caller, encounter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenEncounter indicates an expected call of OpenEncounter.
+func (mr *MockEncounterServiceMockRecorder) OpenEncounter(caller, encounter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenEncounter", reflect.TypeOf((*MockEncounterService)(nil).OpenEncounter), caller, encounter)
+}
+
+// UpdateEncounterNote mocks base method.
+func (m *MockEncounterService) UpdateEncounterNote(caller domain.Caller, id string, note domain.SOAPNote) (*domain.Encounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEncounterNote", caller, id, note)
+	ret0, _ := ret[0].(*domain.Encounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEncounterNote indicates an expected call of UpdateEncounterNote.
+func (mr *MockEncounterServiceMockRecorder) UpdateEncounterNote(caller, id, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEncounterNote", reflect.TypeOf((*MockEncounterService)(nil).UpdateEncounterNote), caller, id, note)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\erasure_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\erasure_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_erasure_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockErasureRepository is a mock of ErasureRepository interface.
+type MockErasureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockErasureRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockErasureRepositoryMockRecorder is the mock recorder for MockErasureRepository.
+type MockErasureRepositoryMockRecorder struct {
+	mock *MockErasureRepository
+}
+
+// NewMockErasureRepository creates a new mock instance.
+func NewMockErasureRepository(ctrl *gomock.Controller) *MockErasureRepository {
+	mock := &MockErasureRepository{ctrl: ctrl}
+	mock.recorder = &MockErasureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockErasureRepository) EXPECT() *MockErasureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ErasePatient mocks base method.
+func (m *MockErasureRepository) ErasePatient(patient *domain.Patient, erasure *domain.Erasure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ErasePatient", patient, erasure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ErasePatient indicates an expected call of ErasePatient.
+func (mr *MockErasureRepositoryMockRecorder) ErasePatient(patient, erasure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErasePatient", reflect.TypeOf((*MockErasureRepository)(nil).ErasePatient), patient, erasure)
+}
+
+// GetErasuresDueForPurge mocks base method.
+func (m *MockErasureRepository) GetErasuresDueForPurge(at time.Time) ([]domain.Erasure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErasuresDueForPurge", at)
+	ret0, _ := ret[0].([]domain.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErasuresDueForPurge indicates an expected call of GetErasuresDueForPurge.
+func (mr *MockErasureRepositoryMockRecorder) GetErasuresDueForPurge(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErasuresDueForPurge", reflect.TypeOf((*MockErasureRepository)(nil).GetErasuresDueForPurge), at)
+}
+
+// PurgeClinicalRecords mocks base method.
+func (m *MockErasureRepository) PurgeClinicalRecords(erasure *domain.Erasure, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeClinicalRecords", erasure, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeClinicalRecords indicates an expected call of PurgeClinicalRecords.
+func (mr *MockErasureRepositoryMockRecorder) PurgeClinicalRecords(erasure, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeClinicalRecords", reflect.TypeOf((*MockErasureRepository)(nil).PurgeClinicalRecords), erasure, at)
+}
+
+// MockErasureService is a mock of ErasureService interface.
+type MockErasureService struct {
+	ctrl     *gomock.Controller
+	recorder *MockErasureServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockErasureServiceMockRecorder is the mock recorder for MockErasureService.
+type MockErasureServiceMockRecorder struct {
+	mock *MockErasureService
+}
+
+// NewMockErasureService creates a new mock instance.
+func NewMockErasureService(ctrl *gomock.Controller) *MockErasureService {
+	mock := &MockErasureService{ctrl: ctrl}
+	mock.recorder = &MockErasureServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockErasureService) EXPECT() *MockErasureServiceMockRecorder {
+	return m.recorder
+}
+
+// ErasePatient mocks base method.
+func (m *MockErasureService) ErasePatient(caller domain.Caller, patientID, reason string) (*domain.Erasure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ErasePatient", caller, patientID, reason)
+	ret0, _ := ret[0].(*domain.Erasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ErasePatient indicates an expected call of ErasePatient.
+func (mr *MockErasureServiceMockRecorder) ErasePatient(caller, patientID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ErasePatient", reflect.TypeOf((*MockErasureService)(nil).ErasePatient), caller, patientID, reason)
+}
+
+// PurgeExpiredRecords mocks base method.
+func (m *MockErasureService) PurgeExpiredRecords(at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredRecords", at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredRecords indicates an expected call of PurgeExpiredRecords.
+func (mr *MockErasureServiceMockRecorder) PurgeExpiredRecords(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredRecords", reflect.TypeOf((*MockErasureService)(nil).PurgeExpiredRecords), at)
+}
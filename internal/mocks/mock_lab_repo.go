@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\lab_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\lab_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_lab_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLabResultRepository is a mock of LabResultRepository interface.
+type MockLabResultRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabResultRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLabResultRepositoryMockRecorder is the mock recorder for MockLabResultRepository.
+type MockLabResultRepositoryMockRecorder struct {
+	mock *MockLabResultRepository
+}
+
+// NewMockLabResultRepository creates a new mock instance.
+func NewMockLabResultRepository(ctrl *gomock.Controller) *MockLabResultRepository {
+	mock := &MockLabResultRepository{ctrl: ctrl}
+	mock.recorder = &MockLabResultRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabResultRepository) EXPECT() *MockLabResultRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLabResults mocks base method.
+func (m *MockLabResultRepository) CreateLabResults(results []domain.LabResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabResults", results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLabResults indicates an expected call of CreateLabResults.
+func (mr *MockLabResultRepositoryMockRecorder) CreateLabResults(results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabResults", reflect.TypeOf((*MockLabResultRepository)(nil).CreateLabResults), results)
+}
+
+// GetAbnormalLabResults mocks base method.
+func (m *MockLabResultRepository) GetAbnormalLabResults(caller domain.Caller, from, to time.Time) ([]domain.LabResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbnormalLabResults", caller, from, to)
+	ret0, _ := ret[0].([]domain.LabResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbnormalLabResults indicates an expected call of GetAbnormalLabResults.
+func (mr *MockLabResultRepositoryMockRecorder) GetAbnormalLabResults(caller, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbnormalLabResults", reflect.TypeOf((*MockLabResultRepository)(nil).GetAbnormalLabResults), caller, from, to)
+}
+
+// GetLabResultsByPatientID mocks base method.
+func (m *MockLabResultRepository) GetLabResultsByPatientID(patientID string, filter domain.LabResultFilter) ([]domain.LabResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabResultsByPatientID", patientID, filter)
+	ret0, _ := ret[0].([]domain.LabResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabResultsByPatientID indicates an expected call of GetLabResultsByPatientID.
+func (mr *MockLabResultRepositoryMockRecorder) GetLabResultsByPatientID(patientID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabResultsByPatientID", reflect.TypeOf((*MockLabResultRepository)(nil).GetLabResultsByPatientID), patientID, filter)
+}
+
+// MockLabResultService is a mock of LabResultService interface.
+type MockLabResultService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabResultServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLabResultServiceMockRecorder is the mock recorder for MockLabResultService.
+type MockLabResultServiceMockRecorder struct {
+	mock *MockLabResultService
+}
+
+// NewMockLabResultService creates a new mock instance.
+func NewMockLabResultService(ctrl *gomock.Controller) *MockLabResultService {
+	mock := &MockLabResultService{ctrl: ctrl}
+	mock.recorder = &MockLabResultServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabResultService) EXPECT() *MockLabResultServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAbnormalLabResults mocks base method.
+func (m *MockLabResultService) GetAbnormalLabResults(caller domain.Caller, from, to time.Time) ([]domain.LabResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbnormalLabResults", caller, from, to)
+	ret0, _ := ret[0].([]domain.LabResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbnormalLabResults indicates an expected call of GetAbnormalLabResults.
+func (mr *MockLabResultServiceMockRecorder) GetAbnormalLabResults(caller, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbnormalLabResults", reflect.TypeOf((*MockLabResultService)(nil).GetAbnormalLabResults), caller, from, to)
+}
+
+// GetLabResults mocks base method.
+func (m *MockLabResultService) GetLabResults(caller domain.Caller, patientID string, filter domain.LabResultFilter) ([]domain.LabResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabResults", caller, patientID, filter)
+	ret0, _ := ret[0].([]domain.LabResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabResults indicates an expected call of GetLabResults.
+func (mr *MockLabResultServiceMockRecorder) GetLabResults(caller, patientID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabResults", reflect.TypeOf((*MockLabResultService)(nil).GetLabResults), caller, patientID, filter)
+}
+
+// IngestLabResults mocks base method.
+func (m *MockLabResultService) IngestLabResults(caller domain.Caller, patientID string, results []domain.LabResult) ([]domain.LabResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngestLabResults", caller, patientID, results)
+	ret0, _ := ret[0].([]domain.LabResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IngestLabResults indicates an expected call of IngestLabResults.
+func (mr *MockLabResultServiceMockRecorder) IngestLabResults(caller, patientID, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestLabResults", reflect.TypeOf((*MockLabResultService)(nil).IngestLabResults), caller, patientID, results)
+}
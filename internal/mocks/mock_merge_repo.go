@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\merge_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\merge_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_merge_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMergeRepository is a mock of MergeRepository interface.
+type MockMergeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMergeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMergeRepositoryMockRecorder is the mock recorder for MockMergeRepository.
+type MockMergeRepositoryMockRecorder struct {
+	mock *MockMergeRepository
+}
+
+// NewMockMergeRepository creates a new mock instance.
+func NewMockMergeRepository(ctrl *gomock.Controller) *MockMergeRepository {
+	mock := &MockMergeRepository{ctrl: ctrl}
+	mock.recorder = &MockMergeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMergeRepository) EXPECT() *MockMergeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindDuplicateCandidates mocks base method.
+func (m *MockMergeRepository) FindDuplicateCandidates(caller domain.Caller, patient *domain.Patient) ([]domain.DuplicateCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicateCandidates", caller, patient)
+	ret0, _ := ret[0].([]domain.DuplicateCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicateCandidates indicates an expected call of FindDuplicateCandidates.
+func (mr *MockMergeRepositoryMockRecorder) FindDuplicateCandidates(caller, patient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicateCandidates", reflect.TypeOf((*MockMergeRepository)(nil).FindDuplicateCandidates), caller, patient)
+}
+
+// MergePatients mocks base method.
+func (m *MockMergeRepository) MergePatients(merge *domain.PatientMerge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePatients", merge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePatients indicates an expected call of MergePatients.
+func (mr *MockMergeRepositoryMockRecorder) MergePatients(merge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePatients", reflect.TypeOf((*MockMergeRepository)(nil).MergePatients), merge)
+}
+
+// MockMergeService is a mock of MergeService interface.
+type MockMergeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMergeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMergeServiceMockRecorder is the mock recorder for MockMergeService.
+type MockMergeServiceMockRecorder struct {
+	mock *MockMergeService
+}
+
+// NewMockMergeService creates a new mock instance.
+func NewMockMergeService(ctrl *gomock.Controller) *MockMergeService {
+	mock := &MockMergeService{ctrl: ctrl}
+	mock.recorder = &MockMergeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMergeService) EXPECT() *MockMergeServiceMockRecorder {
+	return m.recorder
+}
+
+// FindDuplicates mocks base method.
+func (m *MockMergeService) FindDuplicates(caller domain.Caller, patientID string) ([]domain.DuplicateCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicates", caller, patientID)
+	ret0, _ := ret[0].([]domain.DuplicateCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicates indicates an expected call of FindDuplicates.
+func (mr *MockMergeServiceMockRecorder) FindDuplicates(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicates", reflect.TypeOf((*MockMergeService)(nil).FindDuplicates), caller, patientID)
+}
+
+// MergePatients mocks base method.
+func (m *MockMergeService) MergePatients(caller domain.Caller, survivorID, duplicateID, reason string) (*domain.PatientMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePatients", caller, survivorID, duplicateID, reason)
+	ret0, _ := ret[0].(*domain.PatientMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergePatients indicates an expected call of MergePatients.
+func (mr *MockMergeServiceMockRecorder) MergePatients(caller, survivorID, duplicateID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePatients", reflect.TypeOf((*MockMergeService)(nil).MergePatients), caller, survivorID, duplicateID, reason)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\observation_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\observation_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_observation_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockObservationRepository is a mock of ObservationRepository interface.
+type MockObservationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockObservationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockObservationRepositoryMockRecorder is the mock recorder for MockObservationRepository.
+type MockObservationRepositoryMockRecorder struct {
+	mock *MockObservationRepository
+}
+
+// NewMockObservationRepository creates a new mock instance.
+func NewMockObservationRepository(ctrl *gomock.Controller) *MockObservationRepository {
+	mock := &MockObservationRepository{ctrl: ctrl}
+	mock.recorder = &MockObservationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObservationRepository) EXPECT() *MockObservationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateObservation mocks base method.
+func (m *MockObservationRepository) CreateObservation(observation *domain.Observation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateObservation", observation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateObservation indicates an expected call of CreateObservation.
+func (mr *MockObservationRepositoryMockRecorder) CreateObservation(observation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateObservation", reflect.TypeOf((*MockObservationRepository)(nil).CreateObservation), observation)
+}
+
+// GetObservationsByPatientID mocks base method.
+func (m *MockObservationRepository) GetObservationsByPatientID(patientID string, filter domain.ObservationFilter) ([]domain.Observation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObservationsByPatientID", patientID, filter)
+	ret0, _ := ret[0].([]domain.Observation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObservationsByPatientID indicates an expected call of GetObservationsByPatientID.
+func (mr *MockObservationRepositoryMockRecorder) GetObservationsByPatientID(patientID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObservationsByPatientID", reflect.TypeOf((*MockObservationRepository)(nil).GetObservationsByPatientID), patientID, filter)
+}
+
+// MockObservationService is a mock of ObservationService interface.
+type MockObservationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockObservationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockObservationServiceMockRecorder is the mock recorder for MockObservationService.
+type MockObservationServiceMockRecorder struct {
+	mock *MockObservationService
+}
+
+// NewMockObservationService creates a new mock instance.
+func NewMockObservationService(ctrl *gomock.Controller) *MockObservationService {
+	mock := &MockObservationService{ctrl: ctrl}
+	mock.recorder = &MockObservationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObservationService) EXPECT() *MockObservationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetObservationSeries mocks base method.
+func (m *MockObservationService) GetObservationSeries(caller domain.Caller, patientID, code string, from, to *time.Time) (*domain.ObservationSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObservationSeries", caller, patientID, code, from, to)
+	ret0, _ := ret[0].(*domain.ObservationSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObservationSeries indicates an expected call of GetObservationSeries.
+func (mr *MockObservationServiceMockRecorder) GetObservationSeries(caller, patientID, code, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObservationSeries", reflect.TypeOf((*MockObservationService)(nil).GetObservationSeries), caller, patientID, code, from, to)
+}
+
+// GetObservations mocks base method.
+func (m *MockObservationService) GetObservations(caller domain.Caller, patientID string, filter domain.ObservationFilter) ([]domain.Observation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObservations", caller, patientID, filter)
+	ret0, _ := ret[0].([]domain.Observation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObservations indicates an expected call of GetObservations.
+func (mr *MockObservationServiceMockRecorder) GetObservations(caller, patientID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObservations", reflect.TypeOf((*MockObservationService)(nil).GetObservations), caller, patientID, filter)
+}
+
+// RecordObservation mocks base method.
+func (m *MockObservationService) RecordObservation(caller domain.Caller, observation *domain.Observation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordObservation", caller, observation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordObservation indicates an expected call of RecordObservation.
+func (mr *MockObservationServiceMockRecorder) RecordObservation(caller, observation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordObservation", reflect.TypeOf((*MockObservationService)(nil).RecordObservation), caller, observation)
+}
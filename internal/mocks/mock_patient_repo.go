@@ -42,31 +42,31 @@ func (m *MockPatientRepository) EXPECT() *MockPatientRepositoryMockRecorder {
 }
 
 // CreateDiagnosis mocks base method.
-func (m *MockPatientRepository) CreateDiagnosis(diagnosis *domain.Diagnosis) error {
+func (m *MockPatientRepository) CreateDiagnosis(diagnosis *domain.Diagnosis, followUp *domain.Appointment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDiagnosis", diagnosis)
+	ret := m.ctrl.Call(m, "CreateDiagnosis", diagnosis, followUp)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateDiagnosis indicates an expected call of CreateDiagnosis.
-func (mr *MockPatientRepositoryMockRecorder) CreateDiagnosis(diagnosis any) *gomock.Call {
+func (mr *MockPatientRepositoryMockRecorder) CreateDiagnosis(diagnosis, followUp any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiagnosis", reflect.TypeOf((*MockPatientRepository)(nil).CreateDiagnosis), diagnosis)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiagnosis", reflect.TypeOf((*MockPatientRepository)(nil).CreateDiagnosis), diagnosis, followUp)
 }
 
 // CreatePatient mocks base method.
-func (m *MockPatientRepository) CreatePatient(patient *domain.Patient) error {
+func (m *MockPatientRepository) CreatePatient(patient *domain.Patient, creator *domain.CareTeamMember) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePatient", patient)
+	ret := m.ctrl.Call(m, "CreatePatient", patient, creator)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePatient indicates an expected call of CreatePatient.
-func (mr *MockPatientRepositoryMockRecorder) CreatePatient(patient any) *gomock.Call {
+func (mr *MockPatientRepositoryMockRecorder) CreatePatient(patient, creator any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatient", reflect.TypeOf((*MockPatientRepository)(nil).CreatePatient), patient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatient", reflect.TypeOf((*MockPatientRepository)(nil).CreatePatient), patient, creator)
 }
 
 // GetByDiagnosisDateRange mocks base method.
@@ -84,6 +84,21 @@ func (mr *MockPatientRepositoryMockRecorder) GetByDiagnosisDateRange(startDate,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDiagnosisDateRange", reflect.TypeOf((*MockPatientRepository)(nil).GetByDiagnosisDateRange), startDate, endDate)
 }
 
+// GetDiagnosisByID mocks base method.
+func (m *MockPatientRepository) GetDiagnosisByID(id string) (*domain.Diagnosis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiagnosisByID", id)
+	ret0, _ := ret[0].(*domain.Diagnosis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiagnosisByID indicates an expected call of GetDiagnosisByID.
+func (mr *MockPatientRepositoryMockRecorder) GetDiagnosisByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnosisByID", reflect.TypeOf((*MockPatientRepository)(nil).GetDiagnosisByID), id)
+}
+
 // GetDiagnosisByPatientID mocks base method.
 func (m *MockPatientRepository) GetDiagnosisByPatientID(patientID string) ([]domain.Diagnosis, error) {
 	m.ctrl.T.Helper()
@@ -145,18 +160,33 @@ func (mr *MockPatientRepositoryMockRecorder) GetPatientByID(id any) *gomock.Call
 }
 
 // SearchDiagnosis mocks base method.
-func (m *MockPatientRepository) SearchDiagnosis(patientName *string, dateStart, dateEnd *time.Time) ([]domain.Diagnosis, error) {
+func (m *MockPatientRepository) SearchDiagnosis(caller domain.Caller, filter domain.DiagnosisFilter) (*domain.DiagnosisPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchDiagnosis", patientName, dateStart, dateEnd)
-	ret0, _ := ret[0].([]domain.Diagnosis)
+	ret := m.ctrl.Call(m, "SearchDiagnosis", caller, filter)
+	ret0, _ := ret[0].(*domain.DiagnosisPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchDiagnosis indicates an expected call of SearchDiagnosis.
-func (mr *MockPatientRepositoryMockRecorder) SearchDiagnosis(patientName, dateStart, dateEnd any) *gomock.Call {
+func (mr *MockPatientRepositoryMockRecorder) SearchDiagnosis(caller, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDiagnosis", reflect.TypeOf((*MockPatientRepository)(nil).SearchDiagnosis), patientName, dateStart, dateEnd)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchDiagnosis", reflect.TypeOf((*MockPatientRepository)(nil).SearchDiagnosis), caller, filter)
+}
+
+// SearchPatients mocks base method.
+func (m *MockPatientRepository) SearchPatients(caller domain.Caller, filter domain.PatientFilter) (*domain.PatientPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPatients", caller, filter)
+	ret0, _ := ret[0].(*domain.PatientPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPatients indicates an expected call of SearchPatients.
+func (mr *MockPatientRepositoryMockRecorder) SearchPatients(caller, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPatients", reflect.TypeOf((*MockPatientRepository)(nil).SearchPatients), caller, filter)
 }
 
 // MockPatientService is a mock of PatientService interface.
@@ -184,59 +214,90 @@ func (m *MockPatientService) EXPECT() *MockPatientServiceMockRecorder {
 }
 
 // CreateDiagnosis mocks base method.
-func (m *MockPatientService) CreateDiagnosis(diagnosis *domain.Diagnosis) error {
+func (m *MockPatientService) CreateDiagnosis(caller domain.Caller, diagnosis *domain.Diagnosis, followUpDays *int) (*domain.Appointment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDiagnosis", diagnosis)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateDiagnosis", caller, diagnosis, followUpDays)
+	ret0, _ := ret[0].(*domain.Appointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CreateDiagnosis indicates an expected call of CreateDiagnosis.
-func (mr *MockPatientServiceMockRecorder) CreateDiagnosis(diagnosis any) *gomock.Call {
+func (mr *MockPatientServiceMockRecorder) CreateDiagnosis(caller, diagnosis, followUpDays any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiagnosis", reflect.TypeOf((*MockPatientService)(nil).CreateDiagnosis), diagnosis)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiagnosis", reflect.TypeOf((*MockPatientService)(nil).CreateDiagnosis), caller, diagnosis, followUpDays)
 }
 
 // CreatePatient mocks base method.
-func (m *MockPatientService) CreatePatient(patient *domain.Patient) error {
+func (m *MockPatientService) CreatePatient(caller domain.Caller, patient *domain.Patient) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePatient", patient)
+	ret := m.ctrl.Call(m, "CreatePatient", caller, patient)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePatient indicates an expected call of CreatePatient.
-func (mr *MockPatientServiceMockRecorder) CreatePatient(patient any) *gomock.Call {
+func (mr *MockPatientServiceMockRecorder) CreatePatient(caller, patient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatient", reflect.TypeOf((*MockPatientService)(nil).CreatePatient), caller, patient)
+}
+
+// GetDiagnosis mocks base method.
+func (m *MockPatientService) GetDiagnosis(caller domain.Caller, id string) (*domain.Diagnosis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiagnosis", caller, id)
+	ret0, _ := ret[0].(*domain.Diagnosis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiagnosis indicates an expected call of GetDiagnosis.
+func (mr *MockPatientServiceMockRecorder) GetDiagnosis(caller, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatient", reflect.TypeOf((*MockPatientService)(nil).CreatePatient), patient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnosis", reflect.TypeOf((*MockPatientService)(nil).GetDiagnosis), caller, id)
 }
 
 // GetDiagnostics mocks base method.
-func (m *MockPatientService) GetDiagnostics(patientName *string, dateStart, dateEnd *time.Time) ([]domain.Diagnosis, error) {
+func (m *MockPatientService) GetDiagnostics(caller domain.Caller, filter domain.DiagnosisFilter) (*domain.DiagnosisPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDiagnostics", patientName, dateStart, dateEnd)
-	ret0, _ := ret[0].([]domain.Diagnosis)
+	ret := m.ctrl.Call(m, "GetDiagnostics", caller, filter)
+	ret0, _ := ret[0].(*domain.DiagnosisPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDiagnostics indicates an expected call of GetDiagnostics.
-func (mr *MockPatientServiceMockRecorder) GetDiagnostics(patientName, dateStart, dateEnd any) *gomock.Call {
+func (mr *MockPatientServiceMockRecorder) GetDiagnostics(caller, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnostics", reflect.TypeOf((*MockPatientService)(nil).GetDiagnostics), patientName, dateStart, dateEnd)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnostics", reflect.TypeOf((*MockPatientService)(nil).GetDiagnostics), caller, filter)
 }
 
 // GetPatient mocks base method.
-func (m *MockPatientService) GetPatient(dni string) (*domain.Patient, error) {
+func (m *MockPatientService) GetPatient(caller domain.Caller, id string) (*domain.Patient, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatient", dni)
+	ret := m.ctrl.Call(m, "GetPatient", caller, id)
 	ret0, _ := ret[0].(*domain.Patient)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPatient indicates an expected call of GetPatient.
-func (mr *MockPatientServiceMockRecorder) GetPatient(dni any) *gomock.Call {
+func (mr *MockPatientServiceMockRecorder) GetPatient(caller, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatient", reflect.TypeOf((*MockPatientService)(nil).GetPatient), caller, id)
+}
+
+// ListPatients mocks base method.
+func (m *MockPatientService) ListPatients(caller domain.Caller, filter domain.PatientFilter) (*domain.PatientPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPatients", caller, filter)
+	ret0, _ := ret[0].(*domain.PatientPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPatients indicates an expected call of ListPatients.
+func (mr *MockPatientServiceMockRecorder) ListPatients(caller, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatient", reflect.TypeOf((*MockPatientService)(nil).GetPatient), dni)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPatients", reflect.TypeOf((*MockPatientService)(nil).ListPatients), caller, filter)
 }
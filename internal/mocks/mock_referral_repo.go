@@ -0,0 +1,241 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\referral_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\referral_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_referral_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReferralRepository is a mock of ReferralRepository interface.
+type MockReferralRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferralRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReferralRepositoryMockRecorder is the mock recorder for MockReferralRepository.
+type MockReferralRepositoryMockRecorder struct {
+	mock *MockReferralRepository
+}
+
+// NewMockReferralRepository creates a new mock instance.
+func NewMockReferralRepository(ctrl *gomock.Controller) *MockReferralRepository {
+	mock := &MockReferralRepository{ctrl: ctrl}
+	mock.recorder = &MockReferralRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferralRepository) EXPECT() *MockReferralRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptReferral mocks base method.
+func (m *MockReferralRepository) AcceptReferral(referral *domain.Referral, member *domain.CareTeamMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptReferral", referral, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptReferral indicates an expected call of AcceptReferral.
+func (mr *MockReferralRepositoryMockRecorder) AcceptReferral(referral, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptReferral", reflect.TypeOf((*MockReferralRepository)(nil).AcceptReferral), referral, member)
+}
+
+// CreateReferral mocks base method.
+func (m *MockReferralRepository) CreateReferral(referral *domain.Referral) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReferral", referral)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReferral indicates an expected call of CreateReferral.
+func (mr *MockReferralRepositoryMockRecorder) CreateReferral(referral any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReferral", reflect.TypeOf((*MockReferralRepository)(nil).CreateReferral), referral)
+}
+
+// GetReferralByID mocks base method.
+func (m *MockReferralRepository) GetReferralByID(id string) (*domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferralByID", id)
+	ret0, _ := ret[0].(*domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferralByID indicates an expected call of GetReferralByID.
+func (mr *MockReferralRepositoryMockRecorder) GetReferralByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferralByID", reflect.TypeOf((*MockReferralRepository)(nil).GetReferralByID), id)
+}
+
+// GetReferralInbox mocks base method.
+func (m *MockReferralRepository) GetReferralInbox(userID, specialty string, filter domain.ReferralInboxFilter) ([]domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferralInbox", userID, specialty, filter)
+	ret0, _ := ret[0].([]domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferralInbox indicates an expected call of GetReferralInbox.
+func (mr *MockReferralRepositoryMockRecorder) GetReferralInbox(userID, specialty, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferralInbox", reflect.TypeOf((*MockReferralRepository)(nil).GetReferralInbox), userID, specialty, filter)
+}
+
+// GetReferralsByPatientID mocks base method.
+func (m *MockReferralRepository) GetReferralsByPatientID(patientID string) ([]domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferralsByPatientID", patientID)
+	ret0, _ := ret[0].([]domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferralsByPatientID indicates an expected call of GetReferralsByPatientID.
+func (mr *MockReferralRepositoryMockRecorder) GetReferralsByPatientID(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferralsByPatientID", reflect.TypeOf((*MockReferralRepository)(nil).GetReferralsByPatientID), patientID)
+}
+
+// UpdateReferral mocks base method.
+func (m *MockReferralRepository) UpdateReferral(referral *domain.Referral, fromStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReferral", referral, fromStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReferral indicates an expected call of UpdateReferral.
+func (mr *MockReferralRepositoryMockRecorder) UpdateReferral(referral, fromStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReferral", reflect.TypeOf((*MockReferralRepository)(nil).UpdateReferral), referral, fromStatus)
+}
+
+// MockReferralService is a mock of ReferralService interface.
+type MockReferralService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReferralServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReferralServiceMockRecorder is the mock recorder for MockReferralService.
+type MockReferralServiceMockRecorder struct {
+	mock *MockReferralService
+}
+
+// NewMockReferralService creates a new mock instance.
+func NewMockReferralService(ctrl *gomock.Controller) *MockReferralService {
+	mock := &MockReferralService{ctrl: ctrl}
+	mock.recorder = &MockReferralServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReferralService) EXPECT() *MockReferralServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptReferral mocks base method.
+func (m *MockReferralService) AcceptReferral(caller domain.Caller, id string) (*domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptReferral", caller, id)
+	ret0, _ := ret[0].(*domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptReferral indicates an expected call of AcceptReferral.
+func (mr *MockReferralServiceMockRecorder) AcceptReferral(caller, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptReferral", reflect.TypeOf((*MockReferralService)(nil).AcceptReferral), caller, id)
+}
+
+// CompleteReferral mocks base method.
+func (m *MockReferralService) CompleteReferral(caller domain.Caller, id, note string) (*domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteReferral", caller, id, note)
+	ret0, _ := ret[0].(*domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteReferral indicates an expected call of CompleteReferral.
+func (mr *MockReferralServiceMockRecorder) CompleteReferral(caller, id, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReferral", reflect.TypeOf((*MockReferralService)(nil).CompleteReferral), caller, id, note)
+}
+
+// CreateReferral mocks base method.
+func (m *MockReferralService) CreateReferral(caller domain.Caller, referral *domain.Referral) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReferral", caller, referral)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReferral indicates an expected call of CreateReferral.
+func (mr *MockReferralServiceMockRecorder) CreateReferral(caller, referral any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReferral", reflect.TypeOf((*MockReferralService)(nil).CreateReferral), caller, referral)
+}
+
+// GetInbox mocks base method.
+func (m *MockReferralService) GetInbox(caller domain.Caller, filter domain.ReferralInboxFilter) ([]domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", caller, filter)
+	ret0, _ := ret[0].([]domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox.
+func (mr *MockReferralServiceMockRecorder) GetInbox(caller, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockReferralService)(nil).GetInbox), caller, filter)
+}
+
+// GetPatientReferrals mocks base method.
+func (m *MockReferralService) GetPatientReferrals(caller domain.Caller, patientID string) ([]domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPatientReferrals", caller, patientID)
+	ret0, _ := ret[0].([]domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPatientReferrals indicates an expected call of GetPatientReferrals.
+func (mr *MockReferralServiceMockRecorder) GetPatientReferrals(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatientReferrals", reflect.TypeOf((*MockReferralService)(nil).GetPatientReferrals), caller, patientID)
+}
+
+// RejectReferral mocks base method.
+func (m *MockReferralService) RejectReferral(caller domain.Caller, id, reason string) (*domain.Referral, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReferral", caller, id, reason)
+	ret0, _ := ret[0].(*domain.Referral)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectReferral indicates an expected call of RejectReferral.
+func (mr *MockReferralServiceMockRecorder) RejectReferral(caller, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReferral", reflect.TypeOf((*MockReferralService)(nil).RejectReferral), caller, id, reason)
+}
@@ -54,6 +54,21 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), user)
 }
 
+// GetByID mocks base method.
+func (m *MockUserRepository) GetByID(id string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), id)
+}
+
 // GetByUsername mocks base method.
 func (m *MockUserRepository) GetByUsername(username string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +84,34 @@ func (mr *MockUserRepositoryMockRecorder) GetByUsername(username any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserRepository)(nil).GetByUsername), username)
 }
 
+// UpdateUserRole mocks base method.
+func (m *MockUserRepository) UpdateUserRole(id, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserRole(id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserRole), id, role)
+}
+
+// UpdateUserSpecialty mocks base method.
+func (m *MockUserRepository) UpdateUserSpecialty(id, specialty string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSpecialty", id, specialty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserSpecialty indicates an expected call of UpdateUserSpecialty.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserSpecialty(id, specialty any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSpecialty", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserSpecialty), id, specialty)
+}
+
 // MockUserService is a mock of UserService interface.
 type MockUserService struct {
 	ctrl     *gomock.Controller
@@ -93,6 +136,21 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// CurrentRole mocks base method.
+func (m *MockUserService) CurrentRole(userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentRole", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CurrentRole indicates an expected call of CurrentRole.
+func (mr *MockUserServiceMockRecorder) CurrentRole(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentRole", reflect.TypeOf((*MockUserService)(nil).CurrentRole), userID)
+}
+
 // Login mocks base method.
 func (m *MockUserService) Login(username, password string) (string, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +180,34 @@ func (mr *MockUserServiceMockRecorder) Register(username, password any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserService)(nil).Register), username, password)
 }
 
+// SetRole mocks base method.
+func (m *MockUserService) SetRole(username, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", username, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserServiceMockRecorder) SetRole(username, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserService)(nil).SetRole), username, role)
+}
+
+// SetSpecialty mocks base method.
+func (m *MockUserService) SetSpecialty(username, specialty string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSpecialty", username, specialty)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSpecialty indicates an expected call of SetSpecialty.
+func (mr *MockUserServiceMockRecorder) SetSpecialty(username, specialty any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSpecialty", reflect.TypeOf((*MockUserService)(nil).SetSpecialty), username, specialty)
+}
+
 // ValidateToken mocks base method.
 func (m *MockUserService) ValidateToken(token string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\vaccination_ports.go
+//
+// Generated by this command:
+//
+//	mockgen -source=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\domain\vaccination_ports.go -destination=w:\91_proyectos\Z_Trabajo Entrevistas\TopDoctors\internal\mocks\mock_vaccination_repo.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+	domain "topdoctors/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVaccinationRepository is a mock of VaccinationRepository interface.
+type MockVaccinationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVaccinationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVaccinationRepositoryMockRecorder is the mock recorder for MockVaccinationRepository.
+type MockVaccinationRepositoryMockRecorder struct {
+	mock *MockVaccinationRepository
+}
+
+// NewMockVaccinationRepository creates a new mock instance.
+func NewMockVaccinationRepository(ctrl *gomock.Controller) *MockVaccinationRepository {
+	mock := &MockVaccinationRepository{ctrl: ctrl}
+	mock.recorder = &MockVaccinationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVaccinationRepository) EXPECT() *MockVaccinationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateVaccination mocks base method.
+func (m *MockVaccinationRepository) CreateVaccination(vaccination *domain.Vaccination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVaccination", vaccination)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVaccination indicates an expected call of CreateVaccination.
+func (mr *MockVaccinationRepositoryMockRecorder) CreateVaccination(vaccination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVaccination", reflect.TypeOf((*MockVaccinationRepository)(nil).CreateVaccination), vaccination)
+}
+
+// GetVaccinationsByPatientID mocks base method.
+func (m *MockVaccinationRepository) GetVaccinationsByPatientID(patientID string) ([]domain.Vaccination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaccinationsByPatientID", patientID)
+	ret0, _ := ret[0].([]domain.Vaccination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaccinationsByPatientID indicates an expected call of GetVaccinationsByPatientID.
+func (mr *MockVaccinationRepositoryMockRecorder) GetVaccinationsByPatientID(patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaccinationsByPatientID", reflect.TypeOf((*MockVaccinationRepository)(nil).GetVaccinationsByPatientID), patientID)
+}
+
+// MockVaccinationScheduleSource is a mock of VaccinationScheduleSource interface.
+type MockVaccinationScheduleSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockVaccinationScheduleSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockVaccinationScheduleSourceMockRecorder is the mock recorder for MockVaccinationScheduleSource.
+type MockVaccinationScheduleSourceMockRecorder struct {
+	mock *MockVaccinationScheduleSource
+}
+
+// NewMockVaccinationScheduleSource creates a new mock instance.
+func NewMockVaccinationScheduleSource(ctrl *gomock.Controller) *MockVaccinationScheduleSource {
+	mock := &MockVaccinationScheduleSource{ctrl: ctrl}
+	mock.recorder = &MockVaccinationScheduleSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVaccinationScheduleSource) EXPECT() *MockVaccinationScheduleSourceMockRecorder {
+	return m.recorder
+}
+
+// GetVaccinationSchedule mocks base method.
+func (m *MockVaccinationScheduleSource) GetVaccinationSchedule() (*domain.VaccinationSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaccinationSchedule")
+	ret0, _ := ret[0].(*domain.VaccinationSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaccinationSchedule indicates an expected call of GetVaccinationSchedule.
+func (mr *MockVaccinationScheduleSourceMockRecorder) GetVaccinationSchedule() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaccinationSchedule", reflect.TypeOf((*MockVaccinationScheduleSource)(nil).GetVaccinationSchedule))
+}
+
+// MockVaccinationService is a mock of VaccinationService interface.
+type MockVaccinationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVaccinationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockVaccinationServiceMockRecorder is the mock recorder for MockVaccinationService.
+type MockVaccinationServiceMockRecorder struct {
+	mock *MockVaccinationService
+}
+
+// NewMockVaccinationService creates a new mock instance.
+func NewMockVaccinationService(ctrl *gomock.Controller) *MockVaccinationService {
+	mock := &MockVaccinationService{ctrl: ctrl}
+	mock.recorder = &MockVaccinationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVaccinationService) EXPECT() *MockVaccinationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetVaccinationForecast mocks base method.
+func (m *MockVaccinationService) GetVaccinationForecast(caller domain.Caller, patientID string, at time.Time, horizonDays int) (*domain.VaccinationForecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaccinationForecast", caller, patientID, at, horizonDays)
+	ret0, _ := ret[0].(*domain.VaccinationForecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaccinationForecast indicates an expected call of GetVaccinationForecast.
+func (mr *MockVaccinationServiceMockRecorder) GetVaccinationForecast(caller, patientID, at, horizonDays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaccinationForecast", reflect.TypeOf((*MockVaccinationService)(nil).GetVaccinationForecast), caller, patientID, at, horizonDays)
+}
+
+// GetVaccinations mocks base method.
+func (m *MockVaccinationService) GetVaccinations(caller domain.Caller, patientID string) ([]domain.Vaccination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaccinations", caller, patientID)
+	ret0, _ := ret[0].([]domain.Vaccination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaccinations indicates an expected call of GetVaccinations.
+func (mr *MockVaccinationServiceMockRecorder) GetVaccinations(caller, patientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaccinations", reflect.TypeOf((*MockVaccinationService)(nil).GetVaccinations), caller, patientID)
+}
+
+// RecordVaccination mocks base method.
+func (m *MockVaccinationService) RecordVaccination(caller domain.Caller, vaccination *domain.Vaccination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordVaccination", caller, vaccination)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordVaccination indicates an expected call of RecordVaccination.
+func (mr *MockVaccinationServiceMockRecorder) RecordVaccination(caller, vaccination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordVaccination", reflect.TypeOf((*MockVaccinationService)(nil).RecordVaccination), caller, vaccination)
+}
@@ -2,22 +2,39 @@ package integration
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 	"topdoctors/internal/application"
+	"topdoctors/internal/domain"
 	"topdoctors/internal/infrastructure/config"
+	"topdoctors/internal/infrastructure/fhir"
 	httpinfra "topdoctors/internal/infrastructure/http"
 	"topdoctors/internal/infrastructure/persistence"
+	"topdoctors/internal/infrastructure/schedule"
 	"topdoctors/internal/infrastructure/shared"
+	"topdoctors/internal/infrastructure/storage"
 	"topdoctors/pkg/logger"
 )
 
 func TestAPI_Flow(t *testing.T) {
+	// The config files hold no master key, the test database gets a throwaway one
+	masterKey, err := persistence.GenerateMasterKey()
+	if err != nil {
+		t.Fatalf("Failed to generate master key: %v", err)
+	}
+	t.Setenv("ENCRYPTION_MASTER_KEY", masterKey)
 
 	// Load Config
 	cfg, errLoadCfg := config.LoadConfig()
@@ -36,7 +53,7 @@ func TestAPI_Flow(t *testing.T) {
 
 	// Initialize Dependencies
 	repo, err := persistence.NewGormRepository(
-		persistence.Config{DSN: dbFile},
+		persistence.Config{DSN: dbFile, MasterKey: cfg.Encryption.MasterKey, MasterKeyFile: cfg.Encryption.MasterKeyFile},
 	)
 	if err != nil {
 		t.Fatalf("Failed to init repo: %v", err)
@@ -46,9 +63,29 @@ func TestAPI_Flow(t *testing.T) {
 		os.Remove(dbFile)
 	}()
 
+	// Keep uploaded files out of the working tree
+	blobs, err := storage.NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to init storage: %v", err)
+	}
+	exportFiles, err := storage.NewNDJSONStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to init bulk export storage: %v", err)
+	}
+
+	// Paths in the config are relative to the project root
+	vaccinationSchedule, err := schedule.LoadFileSchedule(filepath.Join("..", "..", cfg.Vaccination.Schedule))
+	if err != nil {
+		t.Fatalf("Failed to load vaccination schedule: %v", err)
+	}
+
 	support := shared.NewSupport()
 	// Initialize Application Services
-	app := application.NewApplication(repo, repo, support, cfg)
+	app := application.NewApplication(
+		application.Repositories{User: repo, Patient: repo, CareTeam: repo, Consent: repo, Erasure: repo, Merge: repo, Contact: repo, Appointment: repo, Calendar: repo, Observation: repo, Lab: repo, Attachment: repo, Blobs: blobs, Vaccination: repo, Schedule: vaccinationSchedule, Referral: repo, Encounter: repo, BulkExport: repo, ExportFiles: exportFiles},
+		support,
+		cfg,
+	)
 
 	h := httpinfra.NewHttpHandler(app, cfg)
 
@@ -98,7 +135,8 @@ func TestAPI_Flow(t *testing.T) {
 	}
 
 	// 3. Create Patient
-	patientPayload := `{"name": "Jane Doe", "dni": "11111111H", "email": "hane@example.com"}`
+	patientPayload := `{"name": "Jane Doe", "dni": "11111111H", "email": "hane@example.com", "phone": "0034-600-123-456",
+		"postal_address": {"street": "Calle Mayor", "number": "1", "postal_code": "28013", "city": "Madrid"}}`
 	req, _ := http.NewRequest("POST", baseURL+"/patients", bytes.NewBufferString(patientPayload))
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
@@ -114,9 +152,19 @@ func TestAPI_Flow(t *testing.T) {
 	if patientID == "" {
 		t.Fatal("Patient ID is empty")
 	}
+	// The legacy name field is split into the structured name
+	if patientResp.GivenName != "Jane" || patientResp.FirstSurname != "Doe" || patientResp.Name != "Jane Doe" {
+		t.Errorf("Expected structured name from legacy name, got %+v", patientResp)
+	}
+	if patientResp.Phone != "+34600123456" || patientResp.PhoneDisplay != "+34 600 12 34 56" {
+		t.Errorf("Expected phone normalized to E.164, got %q (%q)", patientResp.Phone, patientResp.PhoneDisplay)
+	}
+	if patientResp.PostalAddress == nil || patientResp.PostalAddress.Province != "28" || patientResp.Address != "Calle Mayor 1, 28013 Madrid" {
+		t.Errorf("Expected province filled from the postal code, got %+v (%q)", patientResp.PostalAddress, patientResp.Address)
+	}
 
 	// 4. Create Diagnosis
-	diagnosisPayload := `{"patient_id": "` + patientID + `", "diagnosis": "Fever", "date": "2023-11-01T10:00:00Z"}`
+	diagnosisPayload := `{"patient_id": "` + patientID + `", "diagnosis": "Fever", "date": "2023-11-01T10:00:00Z", "follow_up_in_days": 14}`
 	req, _ = http.NewRequest("POST", baseURL+"/diagnostics", bytes.NewBufferString(diagnosisPayload))
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
@@ -126,6 +174,674 @@ func TestAPI_Flow(t *testing.T) {
 		body, _ := io.ReadAll(resp.Body)
 		t.Errorf("Failed to create diagnosis: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
 	}
+	var diagnosisResp httpinfra.CreateDiagnosisResponse
+	json.NewDecoder(resp.Body).Decode(&diagnosisResp)
+	if diagnosisResp.FollowUp == nil || diagnosisResp.FollowUp.Status != "pending" || diagnosisResp.FollowUp.DueDate != "2023-11-15" {
+		t.Errorf("Expected a pending follow-up due two weeks later, got %+v", diagnosisResp.FollowUp)
+	}
+
+	// 4b. Book an appointment and read it from the practitioner's calendar feed
+	appointmentStart := time.Now().UTC().AddDate(0, 0, 7).Truncate(time.Hour)
+	appointmentPayload := `{"patient_id": "` + patientID + `", "start": "` + appointmentStart.Format(time.RFC3339) +
+		`", "end": "` + appointmentStart.Add(20*time.Minute).Format(time.RFC3339) + `", "reason": "Fever check"}`
+	req, _ = http.NewRequest("POST", baseURL+"/appointments", bytes.NewBufferString(appointmentPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to book appointment: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var appointmentResp httpinfra.AppointmentResponse
+	json.NewDecoder(resp.Body).Decode(&appointmentResp)
+
+	req, _ = http.NewRequest("POST", baseURL+"/appointments", bytes.NewBufferString(appointmentPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected 409 Conflict for overlapping appointment, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("POST", baseURL+"/practitioners/"+appointmentResp.PractitionerID+"/calendar-feed", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create calendar feed: %v, status: %d", err, resp.StatusCode)
+	}
+	var feedResp httpinfra.CalendarFeedResponse
+	json.NewDecoder(resp.Body).Decode(&feedResp)
+	feedURL, _ := url.Parse(feedResp.URL)
+
+	resp, err = client.Get(baseURL + feedURL.RequestURI())
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to get calendar feed: %v, status: %d", err, resp.StatusCode)
+	}
+	feed, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(feed), "UID:"+appointmentResp.ID+"@topdoctors\r\nDTSTAMP:") ||
+		!strings.Contains(string(feed), "DTSTART:"+appointmentStart.Format("20060102T150405Z")) || strings.Contains(string(feed), "Fever") {
+		t.Errorf("Expected the appointment without clinical details in the feed, got %s", feed)
+	}
+
+	resp, err = client.Get(baseURL + "/practitioners/" + appointmentResp.PractitionerID + "/calendar.ics?token=forged.token")
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 Unauthorized for a forged feed token, got %d", resp.StatusCode)
+	}
+
+	// 4c. Record a temperature in Fahrenheit and chart it in Celsius
+	observationPayload := `{"code": "8310-5", "value": 102.2, "unit": "[degF]", "diagnosis_id": "` + diagnosisResp.ID + `"}`
+	req, _ = http.NewRequest("POST", baseURL+"/patients/"+patientID+"/observations", bytes.NewBufferString(observationPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to record observation: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var observationResp httpinfra.ObservationResponse
+	json.NewDecoder(resp.Body).Decode(&observationResp)
+	if observationResp.Interpretation != "H" {
+		t.Errorf("Expected a fever to be flagged high, got %+v", observationResp)
+	}
+
+	req, _ = http.NewRequest("POST", baseURL+"/patients/"+patientID+"/observations", bytes.NewBufferString(`{"code": "8867-4", "value": 900, "unit": "/min"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request for an implausible heart rate, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/patients/"+patientID+"/observations/series?code=8310-5", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to get observation series: %v, status: %d", err, resp.StatusCode)
+	}
+	var seriesResp httpinfra.ObservationSeriesResponse
+	json.NewDecoder(resp.Body).Decode(&seriesResp)
+	if seriesResp.Unit != "Cel" || len(seriesResp.Points) != 1 || seriesResp.Points[0].Value < 38.99 || seriesResp.Points[0].Value > 39.01 {
+		t.Errorf("Expected one point of 39 Cel, got %+v", seriesResp)
+	}
+
+	// 4d. Ingest a lab report and find its abnormal values across the doctor's patients
+	labPayload := `{"collected_at": "2023-11-02T08:30:00Z", "diagnosis_id": "` + diagnosisResp.ID + `", "results": [
+		{"panel": "Complete blood count", "analyte": "Leukocytes", "value": 14.2, "unit": "10*3/uL", "reference_range": {"low": 4, "high": 11}},
+		{"panel": "Complete blood count", "analyte": "Hemoglobin", "value": 13.8, "unit": "g/dL", "reference_range": {"low": 12, "high": 16}}]}`
+	req, _ = http.NewRequest("POST", baseURL+"/patients/"+patientID+"/lab-results", bytes.NewBufferString(labPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to ingest lab results: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var labBatchResp httpinfra.LabResultBatchResponse
+	json.NewDecoder(resp.Body).Decode(&labBatchResp)
+	if labBatchResp.Abnormal != 1 || len(labBatchResp.Results) != 2 || labBatchResp.Results[0].Flag != "H" {
+		t.Errorf("Expected the leukocytes flagged high, got %+v", labBatchResp)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/lab-results/abnormal?from=2023-11-01&to=2023-11-30", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to get abnormal lab results: %v, status: %d", err, resp.StatusCode)
+	}
+	var abnormalResp []httpinfra.LabResultResponse
+	json.NewDecoder(resp.Body).Decode(&abnormalResp)
+	if len(abnormalResp) != 1 || abnormalResp[0].Analyte != "Leukocytes" || abnormalResp[0].PatientID != patientID {
+		t.Errorf("Expected only the leukocytes among abnormal results, got %+v", abnormalResp)
+	}
+
+	// 4e. Attach a scan to the diagnosis and download it back
+	scan := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0x42}, 600)...)
+	upload := func(content []byte, checksum string) *http.Response {
+		var form bytes.Buffer
+		writer := multipart.NewWriter(&form)
+		part, _ := writer.CreateFormFile("file", "rx-torax.png")
+		part.Write(content)
+		writer.Close()
+		req, _ := http.NewRequest("POST", baseURL+"/diagnostics/"+diagnosisResp.ID+"/attachments", &form)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		if checksum != "" {
+			req.Header.Set("X-Content-SHA256", checksum)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to upload attachment: %v", err)
+		}
+		return resp
+	}
+	scanSum := sha256.Sum256(scan)
+	resp = upload(scan, hex.EncodeToString(scanSum[:]))
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to upload attachment, status: %d, body: %s", resp.StatusCode, string(body))
+	}
+	var attachmentResp httpinfra.AttachmentResponse
+	json.NewDecoder(resp.Body).Decode(&attachmentResp)
+	if attachmentResp.ContentType != "image/png" || attachmentResp.Size != int64(len(scan)) || attachmentResp.SHA256 != hex.EncodeToString(scanSum[:]) {
+		t.Errorf("Expected a PNG attachment, got %+v", attachmentResp)
+	}
+
+	if resp = upload([]byte("<html><body>not a scan</body></html>"), ""); resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected 415 for an HTML upload, got %d", resp.StatusCode)
+	}
+	if resp = upload(scan, strings.Repeat("0", 64)); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a checksum mismatch, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+attachmentResp.URL, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to download attachment: %v, status: %d", err, resp.StatusCode)
+	}
+	downloaded, _ := io.ReadAll(resp.Body)
+	if !bytes.Equal(downloaded, scan) || resp.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("Expected the uploaded scan back, got %d bytes of %s", len(downloaded), resp.Header.Get("Content-Type"))
+	}
+
+	// 4f. Track the vaccinations of a five month old baby against the schedule
+	birthDate := time.Now().AddDate(0, -5, 0)
+	babyPayload := `{"given_name": "Leo", "first_surname": "Doe", "dni": "22222222J", "email": "leo.doe@example.com", "birth_date": "` + birthDate.Format("2006-01-02") + `"}`
+	req, _ = http.NewRequest("POST", baseURL+"/patients", bytes.NewBufferString(babyPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to create baby patient: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var babyResp httpinfra.PatientResponse
+	json.NewDecoder(resp.Body).Decode(&babyResp)
+
+	vaccinationPayload := `{"vaccine_code": "hexa", "dose_number": 1, "lot": "A12B345", "administered_at": "` + birthDate.AddDate(0, 2, 0).Format(time.RFC3339) + `"}`
+	for _, wantStatus := range []int{http.StatusCreated, http.StatusConflict} {
+		req, _ = http.NewRequest("POST", baseURL+"/patients/"+babyResp.ID+"/vaccinations", bytes.NewBufferString(vaccinationPayload))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err = client.Do(req)
+		if err != nil || resp.StatusCode != wantStatus {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("Expected %d recording the vaccination, got %d: %s", wantStatus, resp.StatusCode, string(body))
+		}
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/patients/"+babyResp.ID+"/vaccinations/forecast?days=365", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to get vaccination forecast: %v, status: %d", err, resp.StatusCode)
+	}
+	var forecastResp httpinfra.VaccinationForecastResponse
+	json.NewDecoder(resp.Body).Decode(&forecastResp)
+	// The 2 and 4 month doses except the hexavalent given and the first
+	// rotavirus, which is no longer given at five months
+	if len(forecastResp.Overdue) != 7 || len(forecastResp.Upcoming) == 0 {
+		t.Errorf("Expected 7 overdue doses and some upcoming, got %+v", forecastResp)
+	}
+	for _, d := range forecastResp.Overdue {
+		if (d.VaccineCode == "HEXA" || d.VaccineCode == "RV") && d.DoseNumber == 1 {
+			t.Errorf("Expected %s dose 1 not to be overdue", d.VaccineCode)
+		}
+	}
+
+	// 4g. Refer the patient to cardiology, a cardiologist accepts and completes the referral
+	cardioPayload := `{"username": "cardio", "password": "password"}`
+	resp, err = client.Post(baseURL+"/register", "application/json", bytes.NewBufferString(cardioPayload))
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to register cardiologist: %v", err)
+	}
+	if err := app.Auth().SetSpecialty("cardio", "cardiology"); err != nil {
+		t.Fatalf("Failed to set the cardiologist's specialty: %v", err)
+	}
+	resp, err = client.Post(baseURL+"/login", "application/json", bytes.NewBufferString(cardioPayload))
+	if err != nil {
+		t.Fatalf("Failed to login cardiologist: %v", err)
+	}
+	json.NewDecoder(resp.Body).Decode(&loginResp)
+	cardioToken := loginResp["token"]
+
+	referralPayload := `{"patient_id": "` + patientID + `", "diagnosis_id": "` + diagnosisResp.ID + `", "to_specialty": "cardiology", "reason": "Soplo sistólico", "urgency": "urgent"}`
+	req, _ = http.NewRequest("POST", baseURL+"/referrals", bytes.NewBufferString(referralPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to create referral: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var referralResp httpinfra.ReferralResponse
+	json.NewDecoder(resp.Body).Decode(&referralResp)
+
+	req, _ = http.NewRequest("GET", baseURL+"/referrals/inbox?status=pending", nil)
+	req.Header.Set("Authorization", "Bearer "+cardioToken)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to get referral inbox: %v, status: %d", err, resp.StatusCode)
+	}
+	var inboxResp []httpinfra.ReferralResponse
+	json.NewDecoder(resp.Body).Decode(&inboxResp)
+	if len(inboxResp) != 1 || inboxResp[0].ID != referralResp.ID || inboxResp[0].Reason != "Soplo sistólico" {
+		t.Errorf("Expected the referral in the cardiologist's inbox, got %+v", inboxResp)
+	}
+
+	// Only a recipient can answer the referral
+	req, _ = http.NewRequest("POST", baseURL+"/referrals/"+referralResp.ID+"/accept", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 Forbidden accepting a referral addressed to another specialty, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("POST", baseURL+"/referrals/"+referralResp.ID+"/accept", nil)
+	req.Header.Set("Authorization", "Bearer "+cardioToken)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to accept referral: %v, status: %d", err, resp.StatusCode)
+	}
+
+	// Accepting made the cardiologist part of the care team
+	req, _ = http.NewRequest("GET", baseURL+"/patients/"+patientID+"/referrals", nil)
+	req.Header.Set("Authorization", "Bearer "+cardioToken)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 OK reading the referred patient, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("POST", baseURL+"/referrals/"+referralResp.ID+"/complete", bytes.NewBufferString(`{"note": "Ecocardiograma normal"}`))
+	req.Header.Set("Authorization", "Bearer "+cardioToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to complete referral: %v, status: %d", err, resp.StatusCode)
+	}
+	referralResp = httpinfra.ReferralResponse{}
+	json.NewDecoder(resp.Body).Decode(&referralResp)
+	if referralResp.Status != "completed" || referralResp.CompletionNote != "Ecocardiograma normal" {
+		t.Errorf("Expected the referral completed with its note, got %+v", referralResp)
+	}
+
+	req, _ = http.NewRequest("POST", baseURL+"/referrals/"+referralResp.ID+"/reject", bytes.NewBufferString(`{"reason": "Too late"}`))
+	req.Header.Set("Authorization", "Bearer "+cardioToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected 409 Conflict rejecting a completed referral, got %d", resp.StatusCode)
+	}
+
+	// 4h. See the baby in a visit: its diagnosis is grouped with the note, and the closed visit takes no more
+	encounterPayload := `{"patient_id": "` + babyResp.ID + `", "note": {"subjective": "Tos y mucosidad desde hace 3 días"}}`
+	req, _ = http.NewRequest("POST", baseURL+"/encounters", bytes.NewBufferString(encounterPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to open encounter: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var encounterResp httpinfra.EncounterResponse
+	json.NewDecoder(resp.Body).Decode(&encounterResp)
+
+	encounterDiagnosisPayload := `{"patient_id": "` + babyResp.ID + `", "encounter_id": "` + encounterResp.ID + `", "diagnosis": "Bronquiolitis leve", "prescription": "Lavados nasales", "date": "` + time.Now().Format(time.RFC3339) + `"}`
+	req, _ = http.NewRequest("POST", baseURL+"/diagnostics", bytes.NewBufferString(encounterDiagnosisPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to create diagnosis in the encounter: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+
+	closePayload := `{"note": {"subjective": "Tos y mucosidad desde hace 3 días", "objective": "Sat O2 97%, sibilancias aisladas", "assessment": "Bronquiolitis leve", "plan": "Lavados nasales y control en 48 horas"}}`
+	req, _ = http.NewRequest("POST", baseURL+"/encounters/"+encounterResp.ID+"/close", bytes.NewBufferString(closePayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to close encounter: %v, status: %d", err, resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/encounters/"+encounterResp.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to get encounter: %v, status: %d", err, resp.StatusCode)
+	}
+	encounterResp = httpinfra.EncounterResponse{}
+	json.NewDecoder(resp.Body).Decode(&encounterResp)
+	if encounterResp.Status != "closed" || encounterResp.Note.Plan != "Lavados nasales y control en 48 horas" {
+		t.Errorf("Expected the encounter closed with its note, got %+v", encounterResp)
+	}
+	if len(encounterResp.Diagnoses) != 1 || encounterResp.Diagnoses[0].Diagnosis != "Bronquiolitis leve" {
+		t.Errorf("Expected the diagnosis grouped in the encounter, got %+v", encounterResp.Diagnoses)
+	}
+
+	req, _ = http.NewRequest("POST", baseURL+"/diagnostics", bytes.NewBufferString(encounterDiagnosisPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected 409 Conflict adding a diagnosis to a closed encounter, got %d", resp.StatusCode)
+	}
+
+	// 4i. A partner speaking FHIR registers a patient and a condition, then finds them
+	resp, err = client.Get(baseURL + "/fhir/r4/metadata")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to get FHIR capability statement: %v, status: %d", err, resp.StatusCode)
+	}
+	var capabilityResp fhir.CapabilityStatement
+	json.NewDecoder(resp.Body).Decode(&capabilityResp)
+	if capabilityResp.FHIRVersion != fhir.Version || len(capabilityResp.Rest) != 1 {
+		t.Errorf("Expected a FHIR R4 capability statement, got %+v", capabilityResp)
+	}
+
+	fhirPatientPayload := `{"resourceType": "Patient", "identifier": [{"system": "` + fhir.DNISystem + `", "value": "33333333P"}],
+		"name": [{"use": "official", "family": "Ruiz Sanz", "given": ["Lucía"]}], "gender": "female", "birthDate": "1990-04-02",
+		"telecom": [{"system": "email", "value": "lucia.ruiz@example.com"}]}`
+	req, _ = http.NewRequest("POST", baseURL+"/fhir/r4/Patient", bytes.NewBufferString(fhirPatientPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", fhir.ContentType)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to create FHIR Patient: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var fhirPatientResp fhir.Patient
+	json.NewDecoder(resp.Body).Decode(&fhirPatientResp)
+	if resp.Header.Get("Location") == "" || len(fhirPatientResp.Name) != 1 || fhirPatientResp.Name[0].Family != "Ruiz Sanz" {
+		t.Errorf("Expected the created FHIR Patient and its location, got %+v", fhirPatientResp)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/Patient?identifier="+url.QueryEscape(fhir.DNISystem+"|33333333p"), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to search FHIR Patient: %v, status: %d", err, resp.StatusCode)
+	}
+	var bundleResp fhir.Bundle
+	json.NewDecoder(resp.Body).Decode(&bundleResp)
+	if bundleResp.Type != "searchset" || bundleResp.Total == nil || *bundleResp.Total != 1 || !strings.HasSuffix(bundleResp.Entry[0].FullURL, "/Patient/"+fhirPatientResp.ID) {
+		t.Errorf("Expected the patient found by DNI, got %+v", bundleResp)
+	}
+
+	// Listings are paged, following the Link header
+	req, _ = http.NewRequest("GET", baseURL+"/patients?limit=1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to list patients: %v, status: %d", err, resp.StatusCode)
+	}
+	var firstPage []httpinfra.PatientSearchResponse
+	json.NewDecoder(resp.Body).Decode(&firstPage)
+	next := strings.TrimSuffix(strings.TrimPrefix(resp.Header.Get("Link"), "<"), `>; rel="next"`)
+	if len(firstPage) != 1 || !strings.HasPrefix(next, "/patients?") {
+		t.Fatalf("Expected one patient and a next page, got %v, Link: %q", firstPage, resp.Header.Get("Link"))
+	}
+	req, _ = http.NewRequest("GET", baseURL+next, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to list the next page of patients: %v, status: %d", err, resp.StatusCode)
+	}
+	var secondPage []httpinfra.PatientSearchResponse
+	json.NewDecoder(resp.Body).Decode(&secondPage)
+	if len(secondPage) != 1 || secondPage[0].ID == firstPage[0].ID {
+		t.Errorf("Expected another patient on the next page, got %v", secondPage)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/patients?limit=500", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a page over the limit, got %v, status: %d", err, resp.StatusCode)
+	}
+
+	fhirConditionPayload := `{"resourceType": "Condition", "code": {"text": "Migraña sin aura"},
+		"subject": {"reference": "Patient/` + fhirPatientResp.ID + `"}, "recordedDate": "2026-03-02T09:30:00Z"}`
+	req, _ = http.NewRequest("POST", baseURL+"/fhir/r4/Condition", bytes.NewBufferString(fhirConditionPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", fhir.ContentType)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Failed to create FHIR Condition: %v, status: %d, body: %s", err, resp.StatusCode, string(body))
+	}
+	var fhirConditionResp fhir.Condition
+	json.NewDecoder(resp.Body).Decode(&fhirConditionResp)
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/Condition?subject=Patient/"+fhirPatientResp.ID+"&recorded-date=ge2026-03-01&recorded-date=lt2026-03-03", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to search FHIR Condition: %v, status: %d", err, resp.StatusCode)
+	}
+	bundleResp = fhir.Bundle{}
+	json.NewDecoder(resp.Body).Decode(&bundleResp)
+	if bundleResp.Total == nil || *bundleResp.Total != 1 || !strings.HasSuffix(bundleResp.Entry[0].FullURL, "/Condition/"+fhirConditionResp.ID) {
+		t.Errorf("Expected the condition found by subject and recorded date, got %+v", bundleResp)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/Condition?subject=Patient/"+fhirPatientResp.ID+"&recorded-date=gt2026-03-02", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	bundleResp = fhir.Bundle{}
+	json.NewDecoder(resp.Body).Decode(&bundleResp)
+	if err != nil || bundleResp.Total == nil || *bundleResp.Total != 0 {
+		t.Errorf("Expected no condition recorded after the day, got %+v", bundleResp)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/Condition?subject=Patient/"+fhirPatientResp.ID+"&patient=01HZY0000000000000000000XX", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 Bad Request searching conflicting subject and patient, got %d", resp.StatusCode)
+	}
+	var conflictOutcome fhir.OperationOutcome
+	json.NewDecoder(resp.Body).Decode(&conflictOutcome)
+	if conflictOutcome.ResourceType != "OperationOutcome" || len(conflictOutcome.Issue) != 1 || conflictOutcome.Issue[0].Code != "invalid" {
+		t.Errorf("Expected an invalid OperationOutcome, got %+v", conflictOutcome)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/Condition/"+fhirConditionResp.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != fhir.ContentType {
+		t.Fatalf("Failed to read FHIR Condition: %v, status: %d", err, resp.StatusCode)
+	}
+	fhirConditionResp = fhir.Condition{}
+	json.NewDecoder(resp.Body).Decode(&fhirConditionResp)
+	if fhirConditionResp.Code == nil || fhirConditionResp.Code.Text != "Migraña sin aura" || fhirConditionResp.Subject.Reference != "Patient/"+fhirPatientResp.ID {
+		t.Errorf("Expected the condition read back, got %+v", fhirConditionResp)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/Condition/01HZY0000000000000000000XX", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 Not Found reading an unknown condition, got %d", resp.StatusCode)
+	}
+	var outcomeResp fhir.OperationOutcome
+	json.NewDecoder(resp.Body).Decode(&outcomeResp)
+	if outcomeResp.ResourceType != "OperationOutcome" || len(outcomeResp.Issue) != 1 || outcomeResp.Issue[0].Code != "not-found" {
+		t.Errorf("Expected a not-found OperationOutcome, got %+v", outcomeResp)
+	}
+
+	// 4j. The dispensing system polls the prescriptions issued since a day
+	prescriptionPayload := `{"patient_id": "` + fhirPatientResp.ID + `", "diagnosis": "Migraña con aura", "prescription": "Sumatriptán 50 mg", "date": "2026-03-05T11:00:00Z"}`
+	req, _ = http.NewRequest("POST", baseURL+"/diagnostics", bytes.NewBufferString(prescriptionPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to create diagnosis with a prescription: %v, status: %d", err, resp.StatusCode)
+	}
+	var prescribedResp httpinfra.CreateDiagnosisResponse
+	json.NewDecoder(resp.Body).Decode(&prescribedResp)
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/MedicationRequest?patient="+fhirPatientResp.ID+"&authoredon=ge2026-03-01", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to search FHIR MedicationRequest: %v, status: %d", err, resp.StatusCode)
+	}
+	bundleResp = fhir.Bundle{}
+	json.NewDecoder(resp.Body).Decode(&bundleResp)
+	if bundleResp.Total == nil || *bundleResp.Total != 1 || !strings.HasSuffix(bundleResp.Entry[0].FullURL, "/MedicationRequest/"+prescribedResp.ID) {
+		t.Errorf("Expected only the diagnosis with a prescription, got %+v", bundleResp)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/MedicationRequest/"+prescribedResp.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Failed to read FHIR MedicationRequest: %v, status: %d", err, resp.StatusCode)
+	}
+	var medicationRequestResp fhir.MedicationRequest
+	json.NewDecoder(resp.Body).Decode(&medicationRequestResp)
+	if medicationRequestResp.MedicationCodeableConcept == nil || medicationRequestResp.MedicationCodeableConcept.Text != "Sumatriptán 50 mg" ||
+		len(medicationRequestResp.ReasonReference) != 1 || medicationRequestResp.ReasonReference[0].Reference != "Condition/"+prescribedResp.ID {
+		t.Errorf("Expected the prescription linked to its condition, got %+v", medicationRequestResp)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/fhir/r4/MedicationRequest/"+fhirConditionResp.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 Not Found for a diagnosis without prescription, got %d", resp.StatusCode)
+	}
+
+	// 4k. An analytics partner exports what the patients consented to share
+	consentPayload := `{"purpose": "third_party_sharing", "scope": "all", "evidence": "Formulario firmado CI-2026-0107"}`
+	req, _ = http.NewRequest("POST", baseURL+"/patients/"+fhirPatientResp.ID+"/consents", bytes.NewBufferString(consentPayload))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to grant consent to share: %v, status: %d", err, resp.StatusCode)
+	}
+
+	startExport := func(bearer, query string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest("GET", baseURL+"/fhir/r4/$export"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		req.Header.Set("Accept", fhir.ContentType)
+		req.Header.Set("Prefer", fhir.PreferAsync)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to kick off bulk export: %v", err)
+		}
+		return resp
+	}
+	if resp = startExport(token, ""); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 Forbidden for a practitioner's bulk export, got %d", resp.StatusCode)
+	}
+
+	partnerPayload := `{"username": "analytics", "password": "password"}`
+	resp, err = client.Post(baseURL+"/register", "application/json", bytes.NewBufferString(partnerPayload))
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to register integration client: %v", err)
+	}
+	if err := app.Auth().SetRole("analytics", domain.RoleIntegration); err != nil {
+		t.Fatalf("Failed to make the user an integration client: %v", err)
+	}
+	resp, err = client.Post(baseURL+"/login", "application/json", bytes.NewBufferString(partnerPayload))
+	if err != nil {
+		t.Fatalf("Failed to login integration client: %v", err)
+	}
+	json.NewDecoder(resp.Body).Decode(&loginResp)
+	partnerToken := loginResp["token"]
+
+	// Integration clients are read only: they cannot create patients, which
+	// would make them care team members
+	req, _ = http.NewRequest("POST", baseURL+"/patients", bytes.NewBufferString(`{"given_name": "Eva", "first_surname": "Ruiz", "dni": "22222222J", "email": "eva@example.com"}`))
+	req.Header.Set("Authorization", "Bearer "+partnerToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to send create patient request: %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 Forbidden for an integration client creating a patient, got %d", resp.StatusCode)
+	}
+
+	waitForExport := func(statusURL string) fhir.ExportManifest {
+		t.Helper()
+		for range 100 {
+			req, _ := http.NewRequest("GET", statusURL, nil)
+			req.Header.Set("Authorization", "Bearer "+partnerToken)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to poll bulk export: %v", err)
+			}
+			if resp.StatusCode == http.StatusAccepted {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				t.Fatalf("Bulk export did not complete, status: %d, body: %s", resp.StatusCode, string(body))
+			}
+			var manifest fhir.ExportManifest
+			json.NewDecoder(resp.Body).Decode(&manifest)
+			return manifest
+		}
+		t.Fatal("Bulk export still running")
+		return fhir.ExportManifest{}
+	}
+
+	resp = startExport(partnerToken, "")
+	statusURL := resp.Header.Get("Content-Location")
+	if resp.StatusCode != http.StatusAccepted || statusURL == "" {
+		t.Fatalf("Expected 202 Accepted with a status URL, got %d %q", resp.StatusCode, statusURL)
+	}
+	manifest := waitForExport(statusURL)
+	counts := make(map[string]int)
+	for _, output := range manifest.Output {
+		counts[output.Type] = output.Count
+	}
+	// Only Lucía consented: her patient record and both migraines
+	if len(manifest.Output) != 2 || counts["Patient"] != 1 || counts["Condition"] != 2 || !manifest.RequiresAccessToken {
+		t.Fatalf("Expected the consented patient and conditions in the manifest, got %+v", manifest)
+	}
+
+	req, _ = http.NewRequest("GET", manifest.Output[0].URL, nil)
+	req.Header.Set("Authorization", "Bearer "+partnerToken)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != fhir.NDJSONContentType {
+		t.Fatalf("Failed to download bulk export file: %v, status: %d", err, resp.StatusCode)
+	}
+	var exportedPatient fhir.Patient
+	json.NewDecoder(resp.Body).Decode(&exportedPatient)
+	if exportedPatient.ID != fhirPatientResp.ID || len(exportedPatient.Identifier) != 1 || exportedPatient.Identifier[0].Value != "33333333P" {
+		t.Errorf("Expected the exported patient with their DNI, got %+v", exportedPatient)
+	}
+
+	req, _ = http.NewRequest("GET", statusURL, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 Not Found for another user's bulk export, got %d", resp.StatusCode)
+	}
+
+	resp = startExport(partnerToken, "?_type=Condition&_since="+url.QueryEscape(manifest.TransactionTime))
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("Expected 202 Accepted for an incremental export, got %d", resp.StatusCode)
+	}
+	if manifest = waitForExport(resp.Header.Get("Content-Location")); len(manifest.Output) != 0 {
+		t.Errorf("Expected nothing updated since the last export, got %+v", manifest.Output)
+	}
+
+	// Role changes apply to tokens already issued
+	if err := app.Auth().SetRole("analytics", domain.RolePractitioner); err != nil {
+		t.Fatalf("Failed to change the integration client role: %v", err)
+	}
+	if resp = startExport(partnerToken, ""); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 Forbidden once the client is no longer an integration, got %d", resp.StatusCode)
+	}
 
 	// 5. Get Diagnostics
 	req, _ = http.NewRequest("GET", baseURL+"/diagnostics?patient_name=Jane", nil)
@@ -135,4 +851,71 @@ func TestAPI_Flow(t *testing.T) {
 	if err != nil || resp.StatusCode != http.StatusOK {
 		t.Errorf("Failed to get diagnostics: %v, status: %d", err, resp.StatusCode)
 	}
+	var diagnosticsResp []httpinfra.DiagnosisResponse
+	json.NewDecoder(resp.Body).Decode(&diagnosticsResp)
+	if len(diagnosticsResp) != 1 {
+		t.Errorf("Expected 1 diagnosis for care team member, got %d", len(diagnosticsResp))
+	} else if len(diagnosticsResp[0].Attachments) != 1 || diagnosticsResp[0].Attachments[0].ID != attachmentResp.ID {
+		t.Errorf("Expected the scan listed with the diagnosis, got %+v", diagnosticsResp[0].Attachments)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/diagnostics?q=FEVER", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Failed to search diagnostics text: %v, status: %d", err, resp.StatusCode)
+	}
+	diagnosticsResp = nil
+	json.NewDecoder(resp.Body).Decode(&diagnosticsResp)
+	if len(diagnosticsResp) != 1 || diagnosticsResp[0].Match == nil || diagnosticsResp[0].Match.DiagnosisSnippet != "<mark>Fever</mark>" {
+		t.Errorf("Expected 1 highlighted full-text match, got %+v", diagnosticsResp)
+	}
+
+	// 6. A user outside the care team cannot read the patient
+	registerPayload = `{"username": "nurse", "password": "password"}`
+	resp, err = client.Post(baseURL+"/register", "application/json", bytes.NewBufferString(registerPayload))
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Failed to register second user: %v", err)
+	}
+	resp, err = client.Post(baseURL+"/login", "application/json", bytes.NewBufferString(registerPayload))
+	if err != nil {
+		t.Fatalf("Failed to login second user: %v", err)
+	}
+	json.NewDecoder(resp.Body).Decode(&loginResp)
+	otherToken := loginResp["token"]
+
+	req, _ = http.NewRequest("GET", baseURL+"/patients/"+patientID, nil)
+	req.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 Forbidden outside care team, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/diagnostics?patient_name=Jane", nil)
+	req.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Failed to get diagnostics: %v, status: %d", err, resp.StatusCode)
+	}
+	diagnosticsResp = nil
+	json.NewDecoder(resp.Body).Decode(&diagnosticsResp)
+	if len(diagnosticsResp) != 0 {
+		t.Errorf("Expected no diagnostics outside care team, got %d", len(diagnosticsResp))
+	}
+
+	// 7. Break-glass grants temporary access
+	req, _ = http.NewRequest("POST", baseURL+"/patients/"+patientID+"/break-glass", bytes.NewBufferString(`{"justification": "Emergency admission"}`))
+	req.Header.Set("Authorization", "Bearer "+otherToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Errorf("Expected 201 Created for break-glass, got %d", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest("GET", baseURL+"/patients/"+patientID, nil)
+	req.Header.Set("Authorization", "Bearer "+otherToken)
+	resp, err = client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 OK after break-glass, got %d", resp.StatusCode)
+	}
 }